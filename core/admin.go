@@ -99,12 +99,18 @@ func getAllHandlers(hoverfly *Hoverfly) []handlers.AdminHandler {
 		&v2.HoverflyHandler{Hoverfly: hoverfly},
 		&v2.HoverflyDestinationHandler{Hoverfly: hoverfly},
 		&v2.HoverflyModeHandler{Hoverfly: hoverfly},
+		&v2.HoverflyModeRoutesHandler{Hoverfly: hoverfly},
+		&v2.HoverflyTransformationsHandler{Hoverfly: hoverfly},
 		&v2.HoverflyMiddlewareHandler{Hoverfly: hoverfly},
 		&v2.HoverflyUsageHandler{Hoverfly: hoverfly},
 		&v2.HoverflyVersionHandler{Hoverfly: hoverfly},
 		&v2.HoverflyUpstreamProxyHandler{Hoverfly: hoverfly},
 		&v2.HoverflyPACHandler{Hoverfly: hoverfly},
 		&v2.SimulationHandler{Hoverfly: hoverfly},
+		&v2.SimulationsHandler{Hoverfly: hoverfly},
+		&v2.SimulationLintHandler{Hoverfly: hoverfly},
+		&v2.SimulationVerifyHandler{Hoverfly: hoverfly},
+		&v2.SimulationCoverageHandler{Hoverfly: hoverfly},
 		&v2.CacheHandler{Hoverfly: hoverfly},
 		&v2.LogsHandler{Hoverfly: hoverfly.StoreLogsHook},
 		&v2.JournalHandler{Hoverfly: hoverfly.Journal},
@@ -25,7 +25,9 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
 	log "github.com/Sirupsen/logrus"
@@ -35,9 +37,11 @@ import (
 	"github.com/SpectoLabs/hoverfly/core/cache"
 	hvc "github.com/SpectoLabs/hoverfly/core/certs"
 	"github.com/SpectoLabs/hoverfly/core/handlers"
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/core/matching"
 	mw "github.com/SpectoLabs/hoverfly/core/middleware"
 	"github.com/SpectoLabs/hoverfly/core/modes"
+	"github.com/boltdb/bolt"
 )
 
 type arrayFlags []string
@@ -53,6 +57,29 @@ func (i *arrayFlags) Set(value string) error {
 
 var importFlags arrayFlags
 var destinationFlags arrayFlags
+var redactFlags arrayFlags
+var redactAnyValueFlags arrayFlags
+
+func parseRedactionFlags(rules arrayFlags, anyValue bool) []*v2.RedactionRule {
+	redactions := []*v2.RedactionRule{}
+	for _, rule := range rules {
+		view, err := v2.NewRedactionRuleViewFromString(rule, anyValue)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"error": err.Error(),
+			}).Fatal("Invalid redaction rule")
+		}
+		redaction, err := v2.NewRedactionRule(view)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"error": err.Error(),
+			}).Fatal("Invalid redaction rule")
+		}
+		redactions = append(redactions, redaction)
+	}
+
+	return redactions
+}
 
 const boltBackend = "boltdb"
 const inmemoryBackend = "memory"
@@ -62,7 +89,7 @@ var (
 	verbose      = flag.Bool("v", false, "Should every proxy request be logged to stdout")
 	capture      = flag.Bool("capture", false, "Start Hoverfly in capture mode - transparently intercepts and saves requests/response")
 	synthesize   = flag.Bool("synthesize", false, "Start Hoverfly in synthesize mode (middleware is required)")
-	modify       = flag.Bool("modify", false, "Start Hoverfly in modify mode - applies middleware (required) to both outgoing and incoming HTTP traffic")
+	modify       = flag.Bool("modify", false, "Start Hoverfly in modify mode - applies middleware and transformation rules to both outgoing and incoming HTTP traffic")
 	spy          = flag.Bool("spy", false, "Start Hoverfly in spy mode, similar to simulate but calls real server when cache miss")
 	diff         = flag.Bool("diff", false, "Start Hoverfly in diff mode - calls real server and compares the actual response with the expected simulation config if present")
 	middleware   = flag.String("middleware", "", "Should proxy use middleware")
@@ -73,6 +100,7 @@ var (
 	dev          = flag.Bool("dev", false, "Enable CORS headers to allow frontend development")
 	destination  = flag.String("destination", ".", "Destination URI to catch")
 	webserver    = flag.Bool("webserver", false, "Start Hoverfly in webserver mode (simulate mode)")
+	importWatch  = flag.Bool("import-watch", false, "Reimport the files and directories given with -import whenever they change, keeping the previous simulation if a change is invalid")
 
 	addNew          = flag.Bool("add", false, "Add new user '-add -username hfadmin -password hfpass'")
 	addUser         = flag.String("username", "", "Username for new user")
@@ -170,8 +198,10 @@ func main() {
 	hoverfly := hv.NewHoverfly()
 
 	// log.SetFormatter(&log.JSONFormatter{})
-	flag.Var(&importFlags, "import", "Import from file or from URL (i.e. '-import my_service.json' or '-import http://mypage.com/service_x.json'")
+	flag.Var(&importFlags, "import", "Import from file, directory or URL as JSON or YAML (i.e. '-import my_service.json', '-import my_service.yaml', '-import simulations/' or '-import http://mypage.com/service_x.json'")
 	flag.Var(&destinationFlags, "dest", "Specify which hosts to process (i.e. '-dest fooservice.org -dest barservice.org -dest catservice.org') - other hosts will be ignored will passthrough'")
+	flag.Var(&redactFlags, "redact", "Redact values in captured and exported simulations, replacing them with REDACTED (i.e. '-redact header:Authorization -redact query:token -redact jsonpath:$.password -redact xpath://password -redact regex:[0-9]{16}')")
+	flag.Var(&redactAnyValueFlags, "redact-any-value", "Redact values in captured and exported simulations as -redact does, matching any value of them in request matchers")
 	flag.Parse()
 	if *logsFormat == "json" {
 		log.SetFormatter(&log.JSONFormatter{})
@@ -298,20 +328,25 @@ func main() {
 		cfg.Destination = *destination
 	}
 
+	cfg.Redactions = append(parseRedactionFlags(redactFlags, false), parseRedactionFlags(redactAnyValueFlags, true)...)
+
 	var requestCache cache.Cache
 	var tokenCache cache.Cache
 	var userCache cache.Cache
+	var simulationCache cache.Cache
 
 	if *databasePath != "" {
 		cfg.DatabasePath = *databasePath
 	}
 
+	var db *bolt.DB
 	if *database == boltBackend {
-		db := cache.GetDB(cfg.DatabasePath)
+		db = cache.GetDB(cfg.DatabasePath)
 		defer db.Close()
 		requestCache = cache.NewBoltDBCache(db, []byte("requestsBucket"))
 		tokenCache = cache.NewBoltDBCache(db, []byte(backends.TokenBucketName))
 		userCache = cache.NewBoltDBCache(db, []byte(backends.UserBucketName))
+		simulationCache = cache.NewBoltDBCache(db, []byte(hv.SimulationBucketName))
 
 		log.Info("Using boltdb backend")
 	} else if *database == inmemoryBackend {
@@ -347,8 +382,21 @@ func main() {
 		Webserver:    cfg.Webserver,
 	}
 	hoverfly.Authentication = authBackend
+	hoverfly.Persistence = simulationCache
 	hoverfly.HTTP = hv.GetDefaultHoverflyHTTPClient(hoverfly.Cfg.TLSVerification, hoverfly.Cfg.UpstreamProxy)
 
+	// writing the changes still pending before the database is closed when Hoverfly is stopped
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-stop
+		hoverfly.ClosePersistence()
+		if db != nil {
+			db.Close()
+		}
+		os.Exit(0)
+	}()
+
 	// if add new user supplied - adding it to database
 	if *addNew || *authEnabled {
 		var err error
@@ -389,34 +437,60 @@ func main() {
 		}
 	}
 
-	// importing records if environment variable is set
-	ev := os.Getenv(hv.HoverflyImportRecordsEV)
-	if ev != "" {
-		err := hoverfly.Import(ev)
+	// restoring the simulation, state and middleware persisted by a previous run
+	err = hoverfly.LoadPersistedSimulation()
+	if err != nil {
+		log.WithFields(log.Fields{
+			"error":    err.Error(),
+			"database": cfg.DatabasePath,
+		}).Fatal("Failed to load persisted simulation")
+	}
+
+	// importing the environment variable and the -import flags together, replacing any restored simulation
+	imports := []string{}
+	if ev := os.Getenv(hv.HoverflyImportRecordsEV); ev != "" {
+		imports = append(imports, ev)
+	}
+	for _, v := range importFlags {
+		if v != "" {
+			imports = append(imports, v)
+		}
+	}
+
+	if len(imports) > 0 {
+		log.WithFields(log.Fields{
+			"import": imports,
+		}).Debug("Importing given resources")
+		err := hoverfly.Import(imports...)
 		if err != nil {
 			log.WithFields(log.Fields{
 				"error":  err.Error(),
-				"import": ev,
-			}).Fatal("Environment variable for importing was set but failed to import this resource")
+				"import": imports,
+			}).Fatal("Failed to import given resources")
 		}
 	}
 
-	// importing stuff
-	if len(importFlags) > 0 {
+	if *importWatch {
+		watched := []string{}
 		for _, v := range importFlags {
 			if v != "" {
-				log.WithFields(log.Fields{
-					"import": v,
-				}).Debug("Importing given resource")
-				err := hoverfly.Import(v)
-				if err != nil {
-					log.WithFields(log.Fields{
-						"error":  err.Error(),
-						"import": v,
-					}).Fatal("Failed to import given resource")
-				}
+				watched = append(watched, v)
 			}
 		}
+		if len(watched) == 0 {
+			log.Fatal("-import-watch requires at least one file or directory to be given with -import")
+		}
+
+		_, err := hoverfly.WatchImports(watched)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"error":  err.Error(),
+				"import": watched,
+			}).Fatal("Failed to watch imported resources")
+		}
+		log.WithFields(log.Fields{
+			"import": watched,
+		}).Info("Watching imported resources for changes")
 	}
 
 	// start metrics registry flush
@@ -504,7 +578,7 @@ func getInitialMode(cfg *hv.Configuration) string {
 
 	} else if *modify {
 		if !cfg.Middleware.IsSet() {
-			log.Fatal("Modify mode chosen although middleware not supplied")
+			log.Warn("Modify mode chosen without middleware, requests and responses will only be changed by transformation rules")
 		}
 
 		if *capture == true || *synthesize == true || *spy == true || *diff == true {
@@ -0,0 +1,94 @@
+package hoverfly
+
+import (
+	"sync"
+	"time"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/models"
+)
+
+// coverage counts how often each pair and global delay has been used since it was last reset.
+// Pairs are counted by id, and delays by value as the first of several identical delays is used.
+type coverage struct {
+	since     time.Time
+	pairHits  map[string]int
+	delayHits map[models.ResponseDelay]int
+	mutex     sync.RWMutex
+}
+
+func (this *coverage) addPairHit(pairId string) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+
+	if this.pairHits == nil {
+		this.pairHits = map[string]int{}
+	}
+	this.pairHits[pairId]++
+}
+
+func (this *coverage) addDelayHit(delay models.ResponseDelay) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+
+	if this.delayHits == nil {
+		this.delayHits = map[models.ResponseDelay]int{}
+	}
+	this.delayHits[delay]++
+}
+
+func (this *coverage) reset() {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+
+	this.since = time.Now()
+	this.pairHits = map[string]int{}
+	this.delayHits = map[models.ResponseDelay]int{}
+}
+
+// GetCoverage returns how often each pair and global delay in the simulation has been used
+// since the coverage was last reset, in the order they appear in the simulation
+func (hf *Hoverfly) GetCoverage() v2.SimulationCoverageView {
+	hf.coverage.mutex.RLock()
+	defer hf.coverage.mutex.RUnlock()
+
+	coverageView := v2.SimulationCoverageView{
+		Since:  hf.coverage.since.Format(time.RFC3339),
+		Pairs:  []v2.PairCoverageView{},
+		Delays: []v2.DelayCoverageView{},
+	}
+
+	for _, pair := range hf.Simulation.GetMatchingPairs() {
+		coverageView.Pairs = append(coverageView.Pairs, v2.PairCoverageView{
+			Hits:                             hf.coverage.pairHits[pair.Id],
+			RequestMatcherResponsePairViewV6: pair.BuildView(),
+		})
+	}
+
+	counted := map[models.ResponseDelay]bool{}
+	for _, delay := range hf.Simulation.GetResponseDelays().ConvertToResponseDelayPayloadView().Data {
+		key := models.ResponseDelay{
+			UrlPattern: delay.UrlPattern,
+			HttpMethod: delay.HttpMethod,
+			Delay:      delay.Delay,
+		}
+
+		hits := 0
+		if !counted[key] {
+			hits = hf.coverage.delayHits[key]
+			counted[key] = true
+		}
+
+		coverageView.Delays = append(coverageView.Delays, v2.DelayCoverageView{
+			Hits:              hits,
+			ResponseDelayView: delay,
+		})
+	}
+
+	return coverageView
+}
+
+// ResetCoverage sets the hits of every pair and global delay back to zero
+func (hf *Hoverfly) ResetCoverage() {
+	hf.coverage.reset()
+}
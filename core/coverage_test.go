@@ -0,0 +1,108 @@
+package hoverfly
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/modes"
+	. "github.com/onsi/gomega"
+)
+
+func newCoverageTestHoverfly() *Hoverfly {
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.Cfg.SetMode(modes.Simulate)
+
+	simulationView, err := v2.NewSimulationViewFromResponseBody([]byte(`{
+		"data": {
+			"pairs": [
+				{"id": "first", "request": {"path": [{"matcher": "exact", "value": "/first"}]}, "response": {"status": 200}},
+				{"id": "second", "request": {"path": [{"matcher": "exact", "value": "/second"}]}, "response": {"status": 200}}
+			],
+			"globalActions": {
+				"delays": [
+					{"urlPattern": "first", "delay": 1},
+					{"urlPattern": "second", "delay": 1},
+					{"urlPattern": "first", "delay": 1}
+				]
+			}
+		},
+		"meta": {"schemaVersion": "v6"}
+	}`))
+	Expect(err).To(BeNil())
+	Expect(unit.PutSimulation(simulationView).GetError()).To(BeNil())
+
+	return unit
+}
+
+func Test_Hoverfly_GetCoverage_CountsHitsForEachPairAndDelay(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := newCoverageTestHoverfly()
+
+	for i := 0; i < 2; i++ {
+		request, _ := http.NewRequest("GET", "http://test.com/first", nil)
+		Expect(unit.processRequest(request).StatusCode).To(Equal(http.StatusOK))
+	}
+
+	request, _ := http.NewRequest("GET", "http://test.com/missing", nil)
+	Expect(unit.processRequest(request).StatusCode).To(Equal(http.StatusBadGateway))
+
+	coverageView := unit.GetCoverage()
+
+	Expect(coverageView.Since).ToNot(BeEmpty())
+
+	Expect(coverageView.Pairs).To(HaveLen(2))
+	Expect(coverageView.Pairs[0].Id).To(Equal("first"))
+	Expect(coverageView.Pairs[0].Hits).To(Equal(2))
+	Expect(coverageView.Pairs[1].Id).To(Equal("second"))
+	Expect(coverageView.Pairs[1].Hits).To(Equal(0))
+
+	Expect(coverageView.Delays).To(HaveLen(3))
+	Expect(coverageView.Delays[0].Hits).To(Equal(2))
+	Expect(coverageView.Delays[1].Hits).To(Equal(0))
+	Expect(coverageView.Delays[2].Hits).To(Equal(0))
+}
+
+func Test_Hoverfly_GetCoverage_CountsHitsFromTheCache(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := newCoverageTestHoverfly()
+
+	for i := 0; i < 3; i++ {
+		request, _ := http.NewRequest("GET", "http://test.com/second", nil)
+		Expect(unit.processRequest(request).StatusCode).To(Equal(http.StatusOK))
+	}
+
+	coverageView := unit.GetCoverage()
+
+	Expect(coverageView.Pairs[1].Hits).To(Equal(3))
+	Expect(coverageView.Delays[1].Hits).To(Equal(3))
+}
+
+func Test_Hoverfly_ResetCoverage_SetsHitsBackToZero(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := newCoverageTestHoverfly()
+
+	request, _ := http.NewRequest("GET", "http://test.com/first", nil)
+	unit.processRequest(request)
+
+	unit.ResetCoverage()
+
+	coverageView := unit.GetCoverage()
+
+	Expect(coverageView.Pairs[0].Hits).To(Equal(0))
+	Expect(coverageView.Delays[0].Hits).To(Equal(0))
+}
+
+func Test_Hoverfly_GetCoverage_ReturnsEmptyListsWithoutASimulation(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	coverageView := unit.GetCoverage()
+
+	Expect(coverageView.Pairs).To(BeEmpty())
+	Expect(coverageView.Delays).To(BeEmpty())
+}
@@ -26,23 +26,23 @@ func (this HoverflyCacheStub) GetCache() (CacheView, error) {
 	return CacheView{
 		Cache: []CachedResponseView{
 			CachedResponseView{
-				MatchingPair: &RequestMatcherResponsePairViewV5{
+				MatchingPair: &RequestMatcherResponsePairViewV6{
 					RequestMatcher: RequestMatcherViewV5{
 						Destination: []MatcherViewV5{
 							NewMatcherView(matchers.Exact, "one"),
 						},
 					},
-					Response: ResponseDetailsViewV5{},
+					Response: ResponseDetailsViewV6{},
 				},
 			},
 			CachedResponseView{
-				MatchingPair: &RequestMatcherResponsePairViewV5{
+				MatchingPair: &RequestMatcherResponsePairViewV6{
 					RequestMatcher: RequestMatcherViewV5{
 						Destination: []MatcherViewV5{
 							NewMatcherView(matchers.Exact, "two"),
 						},
 					},
-					Response: ResponseDetailsViewV5{},
+					Response: ResponseDetailsViewV6{},
 				},
 			},
 		},
@@ -0,0 +1,207 @@
+package v2
+
+import (
+	"fmt"
+	"mime"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// CaptureFilterView limits which requests capture mode saves. Requests which are filtered out
+// are still proxied and journaled. Statuses are given as 200, 2xx or 200-299, content types as
+// application/json or text/*, and destinations and paths as regexes.
+type CaptureFilterView struct {
+	Statuses           []string `json:"statuses,omitempty"`
+	ContentTypes       []string `json:"contentTypes,omitempty"`
+	Methods            []string `json:"methods,omitempty"`
+	IncludeDestination string   `json:"includeDestination,omitempty"`
+	ExcludeDestination string   `json:"excludeDestination,omitempty"`
+	IncludePath        string   `json:"includePath,omitempty"`
+	ExcludePath        string   `json:"excludePath,omitempty"`
+}
+
+const (
+	CaptureSkippedMethod      = "method"
+	CaptureSkippedDestination = "destination"
+	CaptureSkippedPath        = "path"
+	CaptureSkippedStatus      = "status"
+	CaptureSkippedContentType = "contentType"
+)
+
+// CaptureSkippedView counts the requests capture mode did not save, by the part of the filter
+// which skipped them
+type CaptureSkippedView struct {
+	Total       int `json:"total"`
+	Method      int `json:"method,omitempty"`
+	Destination int `json:"destination,omitempty"`
+	Path        int `json:"path,omitempty"`
+	Status      int `json:"status,omitempty"`
+	ContentType int `json:"contentType,omitempty"`
+}
+
+func (this *CaptureSkippedView) Add(reason string) {
+	this.Total++
+	switch reason {
+	case CaptureSkippedMethod:
+		this.Method++
+	case CaptureSkippedDestination:
+		this.Destination++
+	case CaptureSkippedPath:
+		this.Path++
+	case CaptureSkippedStatus:
+		this.Status++
+	case CaptureSkippedContentType:
+		this.ContentType++
+	}
+}
+
+func (this CaptureFilterView) Validate() error {
+	for _, status := range this.Statuses {
+		if _, _, err := parseStatusRange(status); err != nil {
+			return err
+		}
+	}
+
+	for _, contentType := range this.ContentTypes {
+		if !strings.Contains(contentType, "/") {
+			return fmt.Errorf("Content type %s is not valid, expected a type such as application/json or text/*", contentType)
+		}
+	}
+
+	for name, expression := range map[string]string{
+		"includeDestination": this.IncludeDestination,
+		"excludeDestination": this.ExcludeDestination,
+		"includePath":        this.IncludePath,
+		"excludePath":        this.ExcludePath,
+	} {
+		if _, err := regexp.Compile(expression); err != nil {
+			return fmt.Errorf("Capture filter %s is not a valid regex: %s", name, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// CaptureFilter is a capture filter with its regexes compiled, which is done once when the
+// filter is set rather than for every request
+type CaptureFilter struct {
+	CaptureFilterView
+
+	includeDestination *regexp.Regexp
+	excludeDestination *regexp.Regexp
+	includePath        *regexp.Regexp
+	excludePath        *regexp.Regexp
+}
+
+func NewCaptureFilter(view CaptureFilterView) (*CaptureFilter, error) {
+	if err := view.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &CaptureFilter{
+		CaptureFilterView:  view,
+		includeDestination: compileFilterRegex(view.IncludeDestination),
+		excludeDestination: compileFilterRegex(view.ExcludeDestination),
+		includePath:        compileFilterRegex(view.IncludePath),
+		excludePath:        compileFilterRegex(view.ExcludePath),
+	}, nil
+}
+
+// compileFilterRegex compiles a regex which has been validated, an empty regex is nil
+func compileFilterRegex(expression string) *regexp.Regexp {
+	if expression == "" {
+		return nil
+	}
+
+	return regexp.MustCompile(expression)
+}
+
+// SkipRequest returns why a request is not saved, which is known before it is proxied, or
+// an empty string when it may be saved
+func (this CaptureFilter) SkipRequest(method, destination, path string) string {
+	if len(this.Methods) > 0 {
+		allowed := false
+		for _, allowedMethod := range this.Methods {
+			allowed = allowed || strings.EqualFold(allowedMethod, method)
+		}
+		if !allowed {
+			return CaptureSkippedMethod
+		}
+	}
+
+	if !includes(this.includeDestination, this.excludeDestination, destination) {
+		return CaptureSkippedDestination
+	}
+
+	if !includes(this.includePath, this.excludePath, path) {
+		return CaptureSkippedPath
+	}
+
+	return ""
+}
+
+// SkipResponse returns why a response is not saved, or an empty string when it may be saved
+func (this CaptureFilterView) SkipResponse(status int, contentType string) string {
+	if len(this.Statuses) > 0 {
+		allowed := false
+		for _, statusRange := range this.Statuses {
+			from, to, _ := parseStatusRange(statusRange)
+			allowed = allowed || (status >= from && status <= to)
+		}
+		if !allowed {
+			return CaptureSkippedStatus
+		}
+	}
+
+	if len(this.ContentTypes) > 0 {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			mediaType = ""
+		}
+		allowed := false
+		for _, allowedType := range this.ContentTypes {
+			allowedType = strings.ToLower(allowedType)
+			if strings.HasSuffix(allowedType, "/*") {
+				allowed = allowed || strings.HasPrefix(mediaType, strings.TrimSuffix(allowedType, "*"))
+			} else {
+				allowed = allowed || mediaType == allowedType
+			}
+		}
+		if !allowed {
+			return CaptureSkippedContentType
+		}
+	}
+
+	return ""
+}
+
+func includes(include, exclude *regexp.Regexp, value string) bool {
+	if include != nil && !include.MatchString(value) {
+		return false
+	}
+
+	return exclude == nil || !exclude.MatchString(value)
+}
+
+var statusClassPattern = regexp.MustCompile(`^([1-5])[xX][xX]$`)
+
+// parseStatusRange parses 200, 2xx and 200-299 into the first and last status of the range
+func parseStatusRange(statusRange string) (int, int, error) {
+	if match := statusClassPattern.FindStringSubmatch(statusRange); match != nil {
+		class, _ := strconv.Atoi(match[1])
+		return class * 100, class*100 + 99, nil
+	}
+
+	bounds := strings.SplitN(statusRange, "-", 2)
+	from, err := strconv.Atoi(strings.TrimSpace(bounds[0]))
+	to := from
+	if err == nil && len(bounds) == 2 {
+		to, err = strconv.Atoi(strings.TrimSpace(bounds[1]))
+	}
+	if err != nil || from < 100 || to > 599 || from > to {
+		return 0, 0, fmt.Errorf("Status %s is not valid, expected a status such as 200, 2xx or 200-299", statusRange)
+	}
+
+	return from, to, nil
+}
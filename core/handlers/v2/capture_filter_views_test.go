@@ -0,0 +1,77 @@
+package v2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func Test_CaptureFilterView_Validate_AcceptsValidFilter(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(CaptureFilterView{
+		Statuses:           []string{"200", "2xx", "400-404"},
+		ContentTypes:       []string{"application/json", "text/*"},
+		Methods:            []string{"GET"},
+		IncludeDestination: `api\.test\.com`,
+		ExcludePath:        "^/health$",
+	}.Validate()).To(BeNil())
+}
+
+func Test_CaptureFilterView_Validate_RejectsInvalidValues(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(CaptureFilterView{Statuses: []string{"2x"}}.Validate()).ToNot(BeNil())
+	Expect(CaptureFilterView{Statuses: []string{"404-400"}}.Validate()).ToNot(BeNil())
+	Expect(CaptureFilterView{Statuses: []string{"700"}}.Validate()).ToNot(BeNil())
+	Expect(CaptureFilterView{ContentTypes: []string{"json"}}.Validate()).ToNot(BeNil())
+
+	err := CaptureFilterView{IncludePath: "("}.Validate()
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("includePath"))
+}
+
+func Test_NewCaptureFilter_RejectsInvalidFilter(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := NewCaptureFilter(CaptureFilterView{
+		IncludePath: "[",
+	})
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("includePath"))
+}
+
+func Test_CaptureFilter_SkipRequest(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit, err := NewCaptureFilter(CaptureFilterView{
+		Methods:            []string{"get", "POST"},
+		IncludeDestination: `\.test\.com$`,
+		ExcludeDestination: `^internal\.`,
+		ExcludePath:        "^/health",
+	})
+	Expect(err).To(BeNil())
+
+	Expect(unit.SkipRequest("GET", "api.test.com", "/users")).To(Equal(""))
+	Expect(unit.SkipRequest("DELETE", "api.test.com", "/users")).To(Equal(CaptureSkippedMethod))
+	Expect(unit.SkipRequest("GET", "api.other.com", "/users")).To(Equal(CaptureSkippedDestination))
+	Expect(unit.SkipRequest("GET", "internal.test.com", "/users")).To(Equal(CaptureSkippedDestination))
+	Expect(unit.SkipRequest("GET", "api.test.com", "/health/live")).To(Equal(CaptureSkippedPath))
+}
+
+func Test_CaptureFilterView_SkipResponse(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := CaptureFilterView{
+		Statuses:     []string{"2xx", "404"},
+		ContentTypes: []string{"application/json", "text/*"},
+	}
+
+	Expect(unit.SkipResponse(201, "application/json; charset=utf-8")).To(Equal(""))
+	Expect(unit.SkipResponse(404, "text/html")).To(Equal(""))
+	Expect(unit.SkipResponse(500, "application/json")).To(Equal(CaptureSkippedStatus))
+	Expect(unit.SkipResponse(200, "application/xml")).To(Equal(CaptureSkippedContentType))
+	Expect(unit.SkipResponse(200, "")).To(Equal(CaptureSkippedContentType))
+
+	Expect(CaptureFilterView{}.SkipResponse(500, "")).To(Equal(""))
+}
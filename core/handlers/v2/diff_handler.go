@@ -2,6 +2,7 @@ package v2
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"github.com/SpectoLabs/hoverfly/core/handlers"
@@ -9,8 +10,14 @@ import (
 	"github.com/go-zoo/bone"
 )
 
+type HoverflyDiff interface {
+	GetDiff() map[SimpleRequestDefinitionView][]DiffReport
+	ClearDiff()
+	GetSimulation() (SimulationViewV6, error)
+}
+
 type DiffHandler struct {
-	Hoverfly Hoverfly
+	Hoverfly HoverflyDiff
 }
 
 func (this *DiffHandler) RegisterRoutes(mux *bone.Mux, am *handlers.AuthHandler) {
@@ -37,9 +44,29 @@ func (this *DiffHandler) Get(w http.ResponseWriter, req *http.Request, next http
 		})
 	}
 
-	marshal, err := json.Marshal(DiffView{
-		Diff: diffsToReturn,
-	})
+	var marshal []byte
+	var err error
+	switch format := req.URL.Query().Get("format"); format {
+	case "", "json":
+		marshal, err = json.Marshal(DiffView{
+			Diff: diffsToReturn,
+		})
+	case "junit", "markdown":
+		var simulation SimulationViewV6
+		simulation, err = this.Hoverfly.GetSimulation()
+		if err != nil {
+			break
+		}
+
+		if format == "junit" {
+			marshal, err = NewJUnitDiffReport(diffsToReturn, simulation)
+		} else {
+			marshal = NewMarkdownDiffReport(diffsToReturn, simulation)
+		}
+	default:
+		handlers.WriteErrorResponse(w, fmt.Sprintf("Unknown format %s, expected json, junit or markdown", format), http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		handlers.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -23,6 +23,29 @@ func (this *DiffHOverflyStub) ClearDiff() {
 	diffView = make(map[SimpleRequestDefinitionView][]DiffReport)
 }
 
+func (this *DiffHOverflyStub) GetSimulation() (SimulationViewV6, error) {
+	return SimulationViewV6{
+		DataViewV6: DataViewV6{
+			RequestResponsePairs: []RequestMatcherResponsePairViewV6{
+				{
+					RequestMatcher: RequestMatcherViewV5{
+						Method:      []MatcherViewV5{NewMatcherView("exact", "testMethod")},
+						Destination: []MatcherViewV5{NewMatcherView("exact", "testHost")},
+						Path:        []MatcherViewV5{NewMatcherView("exact", "testPath")},
+					},
+				},
+				{
+					RequestMatcher: RequestMatcherViewV5{
+						Method:      []MatcherViewV5{NewMatcherView("exact", "GET")},
+						Destination: []MatcherViewV5{NewMatcherView("exact", "testHost")},
+						Path:        []MatcherViewV5{NewMatcherView("glob", "/health*")},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
 var diffView map[SimpleRequestDefinitionView][]DiffReport
 
 func TestDiffHandlerGetReturnsTheCorrectDiff(t *testing.T) {
@@ -55,6 +78,64 @@ func TestDiffHandlerGetReturnsTheCorrectDiff(t *testing.T) {
 	Expect(report[1].DiffEntries).To(ConsistOf(DiffReportEntry{"second", "expected2", "actual2"}))
 }
 
+func TestDiffHandlerGetReturnsJUnitReport(t *testing.T) {
+	RegisterTestingT(t)
+
+	// given
+	initializeDiff()
+	unit, request, err := createRequest("GET")
+	request.URL.RawQuery = "format=junit"
+
+	// when
+	response := makeRequestOnHandler(unit.Get, request)
+
+	// then
+	Expect(err).To(BeNil())
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	body := response.Body.String()
+	Expect(body).To(HavePrefix(`<?xml version="1.0" encoding="UTF-8"?>`))
+	Expect(body).To(ContainSubstring(`<testsuites tests="2" failures="1">`))
+	Expect(body).To(ContainSubstring(`<testcase name="testMethod testHosttestPath" classname="hoverfly.diff">`))
+	Expect(body).To(ContainSubstring(`<failure message="2 differences from the simulation" type="diff">`))
+	Expect(body).To(ContainSubstring(`<testcase name="GET testHost/health*" classname="hoverfly.diff"></testcase>`))
+}
+
+func TestDiffHandlerGetReturnsMarkdownReport(t *testing.T) {
+	RegisterTestingT(t)
+
+	// given
+	initializeDiff()
+	unit, request, err := createRequest("GET")
+	request.URL.RawQuery = "format=markdown"
+
+	// when
+	response := makeRequestOnHandler(unit.Get, request)
+
+	// then
+	Expect(err).To(BeNil())
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(response.Body.String()).To(ContainSubstring("1 of 2 endpoints differ from the simulation."))
+	Expect(response.Body.String()).To(ContainSubstring("| first | expected1 | actual1 |"))
+}
+
+func TestDiffHandlerGetRejectsUnknownFormat(t *testing.T) {
+	RegisterTestingT(t)
+
+	// given
+	initializeDiff()
+	unit, request, err := createRequest("GET")
+	request.URL.RawQuery = "format=html"
+
+	// when
+	response := makeRequestOnHandler(unit.Get, request)
+
+	// then
+	Expect(err).To(BeNil())
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+	Expect(response.Body.String()).To(ContainSubstring("Unknown format html, expected json, junit or markdown"))
+}
+
 func TestDiffHandlerDeleteCleansAllStoredDiffs(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -0,0 +1,255 @@
+package v2
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+)
+
+// diffTestCase is a simulated endpoint in a diff report, which fails when any request to it was found
+// to differ from the simulation
+type diffTestCase struct {
+	name     string
+	matchers []RequestMatcherViewV5
+	diffs    []ResponseDiffForRequestView
+}
+
+func (this diffTestCase) differences() int {
+	differences := 0
+	for _, diff := range this.diffs {
+		for _, report := range diff.DiffReport {
+			differences += len(report.DiffEntries)
+		}
+	}
+
+	return differences
+}
+
+// newDiffTestCases returns a test case for each endpoint of the simulation, named after the method,
+// destination and path of its request matchers, followed by a test case for each request which did
+// not match any endpoint
+func newDiffTestCases(diffs []ResponseDiffForRequestView, simulation SimulationViewV6) []*diffTestCase {
+	testCases := []*diffTestCase{}
+	testCasesByName := map[string]*diffTestCase{}
+
+	for _, pair := range simulation.RequestResponsePairs {
+		name := endpointName(pair.RequestMatcher)
+		if testCase, ok := testCasesByName[name]; ok {
+			testCase.matchers = append(testCase.matchers, pair.RequestMatcher)
+			continue
+		}
+
+		testCase := &diffTestCase{name: name, matchers: []RequestMatcherViewV5{pair.RequestMatcher}}
+		testCases = append(testCases, testCase)
+		testCasesByName[name] = testCase
+	}
+
+	sortedDiffs := append([]ResponseDiffForRequestView{}, diffs...)
+	sort.SliceStable(sortedDiffs, func(i, j int) bool {
+		return requestName(sortedDiffs[i].Request) < requestName(sortedDiffs[j].Request)
+	})
+
+	for _, diff := range sortedDiffs {
+		testCase := findDiffTestCase(testCases, diff.Request)
+		if testCase == nil {
+			name := requestName(diff.Request)
+			if testCase = testCasesByName[name]; testCase == nil {
+				testCase = &diffTestCase{name: name}
+				testCases = append(testCases, testCase)
+				testCasesByName[name] = testCase
+			}
+		}
+		testCase.diffs = append(testCase.diffs, diff)
+	}
+
+	return testCases
+}
+
+func findDiffTestCase(testCases []*diffTestCase, request SimpleRequestDefinitionView) *diffTestCase {
+	for _, testCase := range testCases {
+		for _, requestMatcher := range testCase.matchers {
+			if fieldMatches(requestMatcher.Method, request.Method) &&
+				fieldMatches(requestMatcher.Destination, request.Host) &&
+				fieldMatches(requestMatcher.Path, request.Path) {
+				return testCase
+			}
+		}
+	}
+
+	return nil
+}
+
+func fieldMatches(fieldMatchers []MatcherViewV5, value string) bool {
+	for _, fieldMatcher := range fieldMatchers {
+		matcherFunc, ok := matchers.Matchers[fieldMatcher.Matcher]
+		if !ok || !matcherFunc(fieldMatcher.Value, value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func endpointName(requestMatcher RequestMatcherViewV5) string {
+	return fmt.Sprintf("%s %s%s",
+		fieldMatcherValues(requestMatcher.Method, "ANY"),
+		fieldMatcherValues(requestMatcher.Destination, "*"),
+		fieldMatcherValues(requestMatcher.Path, "/*"))
+}
+
+func fieldMatcherValues(fieldMatchers []MatcherViewV5, defaultValue string) string {
+	values := []string{}
+	for _, fieldMatcher := range fieldMatchers {
+		values = append(values, fmt.Sprint(fieldMatcher.Value))
+	}
+	if len(values) == 0 {
+		return defaultValue
+	}
+
+	return strings.Join(values, " & ")
+}
+
+func requestName(request SimpleRequestDefinitionView) string {
+	name := fmt.Sprintf("%s %s%s", request.Method, request.Host, request.Path)
+	if request.Query != "" {
+		name += "?" + request.Query
+	}
+
+	return name
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",cdata"`
+}
+
+// NewJUnitDiffReport writes the diffs as a JUnit XML report with a test case for each endpoint of the
+// simulation, which fails with the differences found in the requests to it
+func NewJUnitDiffReport(diffs []ResponseDiffForRequestView, simulation SimulationViewV6) ([]byte, error) {
+	suite := junitTestSuite{Name: "Hoverfly diff"}
+
+	for _, testCase := range newDiffTestCases(diffs, simulation) {
+		junitCase := junitTestCase{Name: testCase.name, ClassName: "hoverfly.diff"}
+
+		if differences := testCase.differences(); differences > 0 {
+			var text bytes.Buffer
+			for _, diff := range testCase.diffs {
+				for _, report := range diff.DiffReport {
+					fmt.Fprintf(&text, "%s at %s\n", requestName(diff.Request), report.Timestamp)
+					for _, entry := range report.DiffEntries {
+						fmt.Fprintf(&text, "  %s: expected %s, actual %s\n", entry.Field, entry.Expected, entry.Actual)
+					}
+				}
+			}
+
+			junitCase.Failure = &junitFailure{
+				Message: pluralise(differences, "difference") + " from the simulation",
+				Type:    "diff",
+				Text:    text.String(),
+			}
+			suite.Failures++
+		}
+
+		suite.TestCases = append(suite.TestCases, junitCase)
+		suite.Tests++
+	}
+
+	report, err := xml.MarshalIndent(junitTestSuites{
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Suites:   []junitTestSuite{suite},
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), append(report, '\n')...), nil
+}
+
+// NewMarkdownDiffReport writes the diffs as a Markdown summary of the endpoints of the simulation,
+// followed by a table of the differences found in the requests to each failing endpoint
+func NewMarkdownDiffReport(diffs []ResponseDiffForRequestView, simulation SimulationViewV6) []byte {
+	testCases := newDiffTestCases(diffs, simulation)
+
+	failures := 0
+	for _, testCase := range testCases {
+		if testCase.differences() > 0 {
+			failures++
+		}
+	}
+
+	var markdown bytes.Buffer
+	markdown.WriteString("# Hoverfly diff report\n\n")
+	fmt.Fprintf(&markdown, "%d of %s differ from the simulation.\n", failures, pluralise(len(testCases), "endpoint"))
+
+	if len(testCases) == 0 {
+		return markdown.Bytes()
+	}
+
+	markdown.WriteString("\n| Endpoint | Result |\n| --- | --- |\n")
+	for _, testCase := range testCases {
+		result := "Passed"
+		if differences := testCase.differences(); differences > 0 {
+			result = "Failed with " + pluralise(differences, "difference")
+		}
+		fmt.Fprintf(&markdown, "| %s | %s |\n", markdownCell(testCase.name), result)
+	}
+
+	for _, testCase := range testCases {
+		if testCase.differences() == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&markdown, "\n## %s\n", testCase.name)
+		for _, diff := range testCase.diffs {
+			for _, report := range diff.DiffReport {
+				fmt.Fprintf(&markdown, "\n`%s` at %s\n\n", requestName(diff.Request), report.Timestamp)
+				markdown.WriteString("| Field | Expected | Actual |\n| --- | --- | --- |\n")
+				for _, entry := range report.DiffEntries {
+					fmt.Fprintf(&markdown, "| %s | %s | %s |\n", markdownCell(entry.Field), markdownCell(entry.Expected), markdownCell(entry.Actual))
+				}
+			}
+		}
+	}
+
+	return markdown.Bytes()
+}
+
+var markdownCellReplacer = strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>")
+
+func markdownCell(value string) string {
+	return markdownCellReplacer.Replace(value)
+}
+
+func pluralise(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, noun)
+	}
+
+	return fmt.Sprintf("%d %ss", count, noun)
+}
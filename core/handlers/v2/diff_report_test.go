@@ -0,0 +1,136 @@
+package v2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+var diffReportSimulation = SimulationViewV6{
+	DataViewV6: DataViewV6{
+		RequestResponsePairs: []RequestMatcherResponsePairViewV6{
+			{
+				RequestMatcher: RequestMatcherViewV5{
+					Method:      []MatcherViewV5{NewMatcherView("exact", "GET")},
+					Destination: []MatcherViewV5{NewMatcherView("exact", "api.test.com")},
+					Path:        []MatcherViewV5{NewMatcherView("glob", "/items/*")},
+				},
+			},
+			{
+				RequestMatcher: RequestMatcherViewV5{
+					Method:      []MatcherViewV5{NewMatcherView("exact", "GET")},
+					Destination: []MatcherViewV5{NewMatcherView("exact", "api.test.com")},
+					Path:        []MatcherViewV5{NewMatcherView("glob", "/items/*")},
+					Body:        []MatcherViewV5{NewMatcherView("exact", "other")},
+				},
+			},
+			{
+				RequestMatcher: RequestMatcherViewV5{
+					Destination: []MatcherViewV5{NewMatcherView("exact", "api.test.com")},
+					Path:        []MatcherViewV5{NewMatcherView("exact", "/health")},
+				},
+			},
+		},
+	},
+}
+
+var diffReportDiffs = []ResponseDiffForRequestView{
+	{
+		Request: SimpleRequestDefinitionView{Method: "GET", Host: "unknown.test.com", Path: "/"},
+		DiffReport: []DiffReport{
+			{Timestamp: "2018-03-16T17:45:40Z", DiffEntries: []DiffReportEntry{{"status", "200", "404"}}},
+		},
+	},
+	{
+		Request: SimpleRequestDefinitionView{Method: "GET", Host: "api.test.com", Path: "/items/1", Query: "page=2"},
+		DiffReport: []DiffReport{
+			{Timestamp: "2018-03-16T17:45:40Z", DiffEntries: []DiffReportEntry{{"body/total", "20", "21"}, {"body/name", "a|b", "c"}}},
+		},
+	},
+}
+
+func Test_newDiffTestCases_GroupsDiffsByEndpointsOfSimulation(t *testing.T) {
+	RegisterTestingT(t)
+
+	testCases := newDiffTestCases(diffReportDiffs, diffReportSimulation)
+
+	Expect(testCases).To(HaveLen(3))
+
+	Expect(testCases[0].name).To(Equal("GET api.test.com/items/*"))
+	Expect(testCases[0].matchers).To(HaveLen(2))
+	Expect(testCases[0].diffs).To(Equal([]ResponseDiffForRequestView{diffReportDiffs[1]}))
+	Expect(testCases[0].differences()).To(Equal(2))
+
+	Expect(testCases[1].name).To(Equal("ANY api.test.com/health"))
+	Expect(testCases[1].differences()).To(Equal(0))
+
+	Expect(testCases[2].name).To(Equal("GET unknown.test.com/"))
+	Expect(testCases[2].diffs).To(Equal([]ResponseDiffForRequestView{diffReportDiffs[0]}))
+}
+
+func Test_NewJUnitDiffReport_FailsTestCasesOfEndpointsWithDiffs(t *testing.T) {
+	RegisterTestingT(t)
+
+	report, err := NewJUnitDiffReport(diffReportDiffs, diffReportSimulation)
+	Expect(err).To(BeNil())
+
+	Expect(string(report)).To(Equal(`<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="3" failures="2">
+  <testsuite name="Hoverfly diff" tests="3" failures="2">
+    <testcase name="GET api.test.com/items/*" classname="hoverfly.diff">
+      <failure message="2 differences from the simulation" type="diff"><![CDATA[GET api.test.com/items/1?page=2 at 2018-03-16T17:45:40Z
+  body/total: expected 20, actual 21
+  body/name: expected a|b, actual c
+]]></failure>
+    </testcase>
+    <testcase name="ANY api.test.com/health" classname="hoverfly.diff"></testcase>
+    <testcase name="GET unknown.test.com/" classname="hoverfly.diff">
+      <failure message="1 difference from the simulation" type="diff"><![CDATA[GET unknown.test.com/ at 2018-03-16T17:45:40Z
+  status: expected 200, actual 404
+]]></failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`))
+}
+
+func Test_NewMarkdownDiffReport_SummarisesEndpointsAndTabulatesDiffs(t *testing.T) {
+	RegisterTestingT(t)
+
+	report := NewMarkdownDiffReport(diffReportDiffs, diffReportSimulation)
+
+	Expect(string(report)).To(Equal("# Hoverfly diff report\n" +
+		"\n" +
+		"2 of 3 endpoints differ from the simulation.\n" +
+		"\n" +
+		"| Endpoint | Result |\n" +
+		"| --- | --- |\n" +
+		"| GET api.test.com/items/* | Failed with 2 differences |\n" +
+		"| ANY api.test.com/health | Passed |\n" +
+		"| GET unknown.test.com/ | Failed with 1 difference |\n" +
+		"\n" +
+		"## GET api.test.com/items/*\n" +
+		"\n" +
+		"`GET api.test.com/items/1?page=2` at 2018-03-16T17:45:40Z\n" +
+		"\n" +
+		"| Field | Expected | Actual |\n" +
+		"| --- | --- | --- |\n" +
+		"| body/total | 20 | 21 |\n" +
+		"| body/name | a\\|b | c |\n" +
+		"\n" +
+		"## GET unknown.test.com/\n" +
+		"\n" +
+		"`GET unknown.test.com/` at 2018-03-16T17:45:40Z\n" +
+		"\n" +
+		"| Field | Expected | Actual |\n" +
+		"| --- | --- | --- |\n" +
+		"| status | 200 | 404 |\n"))
+}
+
+func Test_NewMarkdownDiffReport_WithoutEndpoints(t *testing.T) {
+	RegisterTestingT(t)
+
+	report := NewMarkdownDiffReport(nil, SimulationViewV6{})
+
+	Expect(string(report)).To(Equal("# Hoverfly diff report\n\n0 of 0 endpoints differ from the simulation.\n"))
+}
@@ -0,0 +1,122 @@
+package v2
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+const diffIgnoredPlaceholder = "IGNORED"
+
+// DiffRulesView describes differences diff and shadow mode should not report. Headers are named
+// case insensitively, and values at the JSONPaths and XPaths, or matching the regexes, are left
+// out of bodies before they are compared. Numbers are the same when they differ by no more than
+// the tolerance, or by no more than the relative tolerance as a fraction of the expected number.
+type DiffRulesView struct {
+	IgnoreHeaders     []string `json:"ignoreHeaders,omitempty"`
+	IgnoreJsonPaths   []string `json:"ignoreJsonPaths,omitempty"`
+	IgnoreXPaths      []string `json:"ignoreXPaths,omitempty"`
+	IgnoreRegexes     []string `json:"ignoreRegexes,omitempty"`
+	Tolerance         float64  `json:"tolerance,omitempty"`
+	RelativeTolerance float64  `json:"relativeTolerance,omitempty"`
+}
+
+func (this DiffRulesView) Validate() error {
+	for _, path := range this.IgnoreJsonPaths {
+		if _, err := parseRedactionJsonPath(path); err != nil {
+			return fmt.Errorf("Diff rules have an invalid JSONPath: %s", err.Error())
+		}
+	}
+
+	for _, path := range this.IgnoreXPaths {
+		if _, err := parseRedactionXPath(path); err != nil {
+			return fmt.Errorf("Diff rules have an invalid XPath: %s", err.Error())
+		}
+	}
+
+	for _, expression := range this.IgnoreRegexes {
+		if _, err := regexp.Compile(expression); err != nil {
+			return fmt.Errorf("Diff rules have an invalid regex: %s", err.Error())
+		}
+	}
+
+	if this.Tolerance < 0 || this.RelativeTolerance < 0 {
+		return fmt.Errorf("Diff tolerances cannot be negative")
+	}
+
+	return nil
+}
+
+func (this DiffRulesView) IgnoresHeader(name string) bool {
+	for _, header := range this.IgnoreHeaders {
+		if strings.EqualFold(header, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DiffRules are diff rules with their JSONPaths, XPaths and regexes parsed, which is done once when
+// the rules are set rather than for every comparison
+type DiffRules struct {
+	DiffRulesView
+
+	jsonPaths [][]jsonPathStep
+	xPaths    []redactionXPath
+	regexes   []*regexp.Regexp
+}
+
+func NewDiffRules(view DiffRulesView) (*DiffRules, error) {
+	if err := view.Validate(); err != nil {
+		return nil, err
+	}
+
+	rules := &DiffRules{DiffRulesView: view}
+
+	for _, path := range view.IgnoreJsonPaths {
+		steps, _ := parseRedactionJsonPath(path)
+		rules.jsonPaths = append(rules.jsonPaths, steps)
+	}
+
+	for _, path := range view.IgnoreXPaths {
+		xpath, _ := parseRedactionXPath(path)
+		rules.xPaths = append(rules.xPaths, xpath)
+	}
+
+	for _, expression := range view.IgnoreRegexes {
+		rules.regexes = append(rules.regexes, regexp.MustCompile(expression))
+	}
+
+	return rules, nil
+}
+
+// IgnoreInBody replaces the ignored values of a body with the same placeholder, so they are equal
+// in any two bodies the rules are applied to
+func (this DiffRules) IgnoreInBody(body string) string {
+	for _, steps := range this.jsonPaths {
+		body, _ = redactJsonPath(body, steps, diffIgnoredPlaceholder)
+	}
+
+	for _, xpath := range this.xPaths {
+		body, _ = redactXPath(body, xpath, diffIgnoredPlaceholder)
+	}
+
+	return this.IgnoreInValue(body)
+}
+
+// IgnoreInValue replaces the parts of a value matching the regexes with the placeholder
+func (this DiffRules) IgnoreInValue(value string) string {
+	for _, expression := range this.regexes {
+		value = expression.ReplaceAllLiteralString(value, diffIgnoredPlaceholder)
+	}
+
+	return value
+}
+
+func (this DiffRulesView) NumbersEqual(expected, actual float64) bool {
+	difference := math.Abs(expected - actual)
+
+	return difference <= this.Tolerance || difference <= this.RelativeTolerance*math.Abs(expected)
+}
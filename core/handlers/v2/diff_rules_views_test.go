@@ -0,0 +1,76 @@
+package v2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func Test_DiffRulesView_Validate_AcceptsValidRules(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(DiffRulesView{
+		IgnoreHeaders:     []string{"Date"},
+		IgnoreJsonPaths:   []string{"$.id", "$..timestamp"},
+		IgnoreXPaths:      []string{"//order/@id"},
+		IgnoreRegexes:     []string{"[0-9a-f]{32}"},
+		Tolerance:         0.01,
+		RelativeTolerance: 0.05,
+	}.Validate()).To(BeNil())
+}
+
+func Test_DiffRulesView_Validate_RejectsInvalidRules(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(DiffRulesView{IgnoreJsonPaths: []string{"id"}}.Validate()).ToNot(BeNil())
+	Expect(DiffRulesView{IgnoreXPaths: []string{"order"}}.Validate()).ToNot(BeNil())
+	Expect(DiffRulesView{IgnoreRegexes: []string{"("}}.Validate()).ToNot(BeNil())
+	Expect(DiffRulesView{Tolerance: -1}.Validate()).ToNot(BeNil())
+	Expect(DiffRulesView{RelativeTolerance: -0.1}.Validate()).ToNot(BeNil())
+}
+
+func Test_DiffRulesView_IgnoresHeader_IgnoresCase(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := DiffRulesView{IgnoreHeaders: []string{"X-Request-Id"}}
+
+	Expect(unit.IgnoresHeader("x-request-id")).To(BeTrue())
+	Expect(unit.IgnoresHeader("Date")).To(BeFalse())
+}
+
+func Test_NewDiffRules_RejectsInvalidRules(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := NewDiffRules(DiffRulesView{
+		IgnoreJsonPaths: []string{"id"},
+	})
+	Expect(err).ToNot(BeNil())
+}
+
+func Test_DiffRules_IgnoreInBody_ReplacesIgnoredValuesWithTheSamePlaceholder(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit, err := NewDiffRules(DiffRulesView{
+		IgnoreJsonPaths: []string{"$.id"},
+		IgnoreXPaths:    []string{"//order/@id"},
+		IgnoreRegexes:   []string{`\d{4}-\d{2}-\d{2}`},
+	})
+	Expect(err).To(BeNil())
+
+	Expect(unit.IgnoreInBody(`{"id":"abc","name":"test"}`)).To(Equal(unit.IgnoreInBody(`{"id":"def","name":"test"}`)))
+	Expect(unit.IgnoreInBody(`<order id="1"><date>2018-01-01</date></order>`)).To(Equal(`<order id="IGNORED"><date>IGNORED</date></order>`))
+	Expect(unit.IgnoreInBody(`{"id":"abc","name":"test"}`)).ToNot(Equal(unit.IgnoreInBody(`{"id":"abc","name":"other"}`)))
+}
+
+func Test_DiffRulesView_NumbersEqual_UsesTolerances(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(DiffRulesView{}.NumbersEqual(1, 1)).To(BeTrue())
+	Expect(DiffRulesView{}.NumbersEqual(1, 1.001)).To(BeFalse())
+
+	Expect(DiffRulesView{Tolerance: 0.01}.NumbersEqual(1, 1.005)).To(BeTrue())
+	Expect(DiffRulesView{Tolerance: 0.01}.NumbersEqual(1, 1.02)).To(BeFalse())
+
+	Expect(DiffRulesView{RelativeTolerance: 0.1}.NumbersEqual(200, 215)).To(BeTrue())
+	Expect(DiffRulesView{RelativeTolerance: 0.1}.NumbersEqual(200, 225)).To(BeFalse())
+}
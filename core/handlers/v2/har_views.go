@@ -0,0 +1,368 @@
+package v2
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+)
+
+const HarVersion = "1.2"
+
+// HarView is a HTTP Archive as described by the HAR 1.2 specification,
+// http://www.softwareishard.com/blog/har-12-spec/
+type HarView struct {
+	Log HarLogView `json:"log"`
+}
+
+type HarLogView struct {
+	Version string         `json:"version"`
+	Creator HarCreatorView `json:"creator"`
+	Entries []HarEntryView `json:"entries"`
+}
+
+type HarCreatorView struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type HarEntryView struct {
+	StartedDateTime string          `json:"startedDateTime"`
+	Time            float64         `json:"time"`
+	Request         HarRequestView  `json:"request"`
+	Response        HarResponseView `json:"response"`
+	Cache           struct{}        `json:"cache"`
+	Timings         HarTimingsView  `json:"timings"`
+	Comment         string          `json:"comment,omitempty"`
+}
+
+type HarRequestView struct {
+	Method      string             `json:"method"`
+	Url         string             `json:"url"`
+	HttpVersion string             `json:"httpVersion"`
+	Cookies     []HarNameValueView `json:"cookies"`
+	Headers     []HarNameValueView `json:"headers"`
+	QueryString []HarNameValueView `json:"queryString"`
+	PostData    *HarPostDataView   `json:"postData,omitempty"`
+	HeadersSize int                `json:"headersSize"`
+	BodySize    int                `json:"bodySize"`
+}
+
+type HarResponseView struct {
+	Status      int                `json:"status"`
+	StatusText  string             `json:"statusText"`
+	HttpVersion string             `json:"httpVersion"`
+	Cookies     []HarNameValueView `json:"cookies"`
+	Headers     []HarNameValueView `json:"headers"`
+	Content     HarContentView     `json:"content"`
+	RedirectUrl string             `json:"redirectURL"`
+	HeadersSize int                `json:"headersSize"`
+	BodySize    int                `json:"bodySize"`
+}
+
+type HarNameValueView struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type HarPostDataView struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type HarContentView struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+type HarTimingsView struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+func NewHarViewFromResponseBody(body []byte) (HarView, error) {
+	var har HarView
+
+	err := json.Unmarshal(body, &har)
+	if err != nil {
+		return HarView{}, errors.New("Invalid JSON")
+	}
+
+	if har.Log.Version == "" {
+		return HarView{}, errors.New("Invalid HAR file, missing log.version")
+	}
+
+	if har.Log.Entries == nil {
+		return HarView{}, errors.New("Invalid HAR file, missing log.entries")
+	}
+
+	return har, nil
+}
+
+// NewHarViewFromSimulation builds a HAR file with an entry for each pair in the simulation. As a HAR file
+// can only hold concrete requests, only the values of exact matchers are used to build each request.
+func NewHarViewFromSimulation(simulation SimulationViewV6) HarView {
+	entries := []HarEntryView{}
+
+	for _, pair := range simulation.RequestResponsePairs {
+		request := NewExactRequestDetailsView(pair.RequestMatcher)
+
+		entry := newHarEntryView(request, ResponseDetailsView{
+			Status:      pair.Response.Status,
+			Body:        pair.Response.Body,
+			EncodedBody: pair.Response.EncodedBody,
+			Headers:     pair.Response.Headers,
+		})
+		entry.StartedDateTime = simulation.TimeExported
+		if !IsExactRequestMatcher(pair.RequestMatcher) {
+			entry.Comment = "Only the exact matchers of this pair are included in the request"
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return newHarView(entries, simulation.HoverflyVersion)
+}
+
+// NewHarViewFromJournal builds a HAR file with an entry for each of the given journal entries
+func NewHarViewFromJournal(journalEntries []JournalEntryView) HarView {
+	entries := []HarEntryView{}
+
+	for _, journalEntry := range journalEntries {
+		entry := newHarEntryView(journalEntry.Request, journalEntry.Response)
+		entry.StartedDateTime = journalEntry.TimeStarted
+		entry.Time = journalEntry.Latency
+		entry.Timings.Wait = journalEntry.Latency
+		entry.Comment = fmt.Sprintf("Served in %s mode", journalEntry.Mode)
+
+		entries = append(entries, entry)
+	}
+
+	return newHarView(entries, "")
+}
+
+func newHarView(entries []HarEntryView, version string) HarView {
+	return HarView{
+		Log: HarLogView{
+			Version: HarVersion,
+			Creator: HarCreatorView{
+				Name:    "Hoverfly",
+				Version: version,
+			},
+			Entries: entries,
+		},
+	}
+}
+
+func newHarEntryView(request RequestDetailsView, response ResponseDetailsView) HarEntryView {
+	requestUrl := newRequestUrl(request)
+
+	queryString := []HarNameValueView{}
+	query, _ := url.ParseQuery(requestUrl.RawQuery)
+	for _, key := range sortedKeys(query) {
+		for _, value := range query[key] {
+			queryString = append(queryString, HarNameValueView{Name: key, Value: value})
+		}
+	}
+
+	harRequest := HarRequestView{
+		Method:      stringOrDefault(request.Method, http.MethodGet),
+		Url:         requestUrl.String(),
+		HttpVersion: "HTTP/1.1",
+		Cookies:     []HarNameValueView{},
+		Headers:     newHarNameValueViews(request.Headers),
+		QueryString: queryString,
+		HeadersSize: -1,
+		BodySize:    -1,
+	}
+
+	if body := stringOrDefault(request.Body, ""); body != "" {
+		harRequest.PostData = &HarPostDataView{
+			MimeType: firstHeaderValue(request.Headers, "Content-Type"),
+			Text:     body,
+		}
+		harRequest.BodySize = len(body)
+	}
+
+	content := HarContentView{
+		Size:     len(response.Body),
+		MimeType: firstHeaderValue(response.Headers, "Content-Type"),
+		Text:     response.Body,
+	}
+	if response.EncodedBody {
+		content.Encoding = "base64"
+	}
+
+	return HarEntryView{
+		Request: harRequest,
+		Response: HarResponseView{
+			Status:      response.Status,
+			StatusText:  http.StatusText(response.Status),
+			HttpVersion: "HTTP/1.1",
+			Cookies:     []HarNameValueView{},
+			Headers:     newHarNameValueViews(response.Headers),
+			Content:     content,
+			RedirectUrl: firstHeaderValue(response.Headers, "Location"),
+			HeadersSize: -1,
+			BodySize:    -1,
+		},
+		Timings: HarTimingsView{
+			Send:    0,
+			Wait:    0,
+			Receive: 0,
+		},
+	}
+}
+
+// NewHeadersFromHarNameValueViews collects HAR headers into a header map. HTTP/2 pseudo headers such
+// as :authority are left out, and names are canonicalized as HTTP/2 HAR files use lower case names.
+func NewHeadersFromHarNameValueViews(views []HarNameValueView) map[string][]string {
+	headers := map[string][]string{}
+
+	for _, view := range views {
+		if strings.HasPrefix(view.Name, ":") {
+			continue
+		}
+		name := http.CanonicalHeaderKey(view.Name)
+		headers[name] = append(headers[name], view.Value)
+	}
+
+	return headers
+}
+
+func newHarNameValueViews(values map[string][]string) []HarNameValueView {
+	views := []HarNameValueView{}
+
+	for _, name := range sortedKeys(values) {
+		for _, value := range values[name] {
+			views = append(views, HarNameValueView{Name: name, Value: value})
+		}
+	}
+
+	return views
+}
+
+// NewExactRequestDetailsView builds the request a request matcher would match from the values of its
+// exact matchers. Fields without an exact matcher are left empty.
+func NewExactRequestDetailsView(requestMatcher RequestMatcherViewV5) RequestDetailsView {
+	request := RequestDetailsView{
+		Method:      exactMatcherValue(requestMatcher.Method),
+		Scheme:      exactMatcherValue(requestMatcher.Scheme),
+		Destination: exactMatcherValue(requestMatcher.Destination),
+		Path:        exactMatcherValue(requestMatcher.Path),
+		Body:        exactMatcherValue(requestMatcher.Body),
+		Headers:     map[string][]string{},
+	}
+
+	query := url.Values{}
+	if requestMatcher.Query != nil {
+		for key, queryMatchers := range *requestMatcher.Query {
+			if value := exactMatcherValue(queryMatchers); value != nil {
+				query[key] = strings.Split(*value, "&")
+			}
+		}
+	}
+	if value := exactMatcherValue(requestMatcher.DeprecatedQuery); value != nil && len(query) == 0 {
+		query, _ = url.ParseQuery(*value)
+	}
+	encodedQuery := query.Encode()
+	request.Query = &encodedQuery
+
+	for key, headerMatchers := range requestMatcher.Headers {
+		if value := exactMatcherValue(headerMatchers); value != nil {
+			request.Headers[key] = strings.Split(*value, ";")
+		}
+	}
+
+	return request
+}
+
+func newRequestUrl(request RequestDetailsView) url.URL {
+	return url.URL{
+		Scheme:   stringOrDefault(request.Scheme, "http"),
+		Host:     stringOrDefault(request.Destination, ""),
+		Path:     stringOrDefault(request.Path, "/"),
+		RawQuery: stringOrDefault(request.Query, ""),
+	}
+}
+
+func exactMatcherValue(fieldMatchers []MatcherViewV5) *string {
+	for _, matcher := range fieldMatchers {
+		if matcher.Matcher != matchers.Exact {
+			continue
+		}
+		if value, ok := matcher.Value.(string); ok {
+			return &value
+		}
+	}
+
+	return nil
+}
+
+// IsExactRequestMatcher returns whether every matcher of a request matcher is exact
+func IsExactRequestMatcher(requestMatcher RequestMatcherViewV5) bool {
+	fields := [][]MatcherViewV5{
+		requestMatcher.Method,
+		requestMatcher.Scheme,
+		requestMatcher.Destination,
+		requestMatcher.Path,
+		requestMatcher.Body,
+		requestMatcher.DeprecatedQuery,
+	}
+	if requestMatcher.Query != nil {
+		for _, queryMatchers := range *requestMatcher.Query {
+			fields = append(fields, queryMatchers)
+		}
+	}
+	for _, headerMatchers := range requestMatcher.Headers {
+		fields = append(fields, headerMatchers)
+	}
+
+	for _, fieldMatchers := range fields {
+		for _, matcher := range fieldMatchers {
+			if matcher.Matcher != matchers.Exact {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func firstHeaderValue(headers map[string][]string, name string) string {
+	values := http.Header(headers)[http.CanonicalHeaderKey(name)]
+	if len(values) == 0 {
+		values = headers[name]
+	}
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+func stringOrDefault(value *string, defaultValue string) string {
+	if value == nil || *value == "" {
+		return defaultValue
+	}
+
+	return *value
+}
+
+func sortedKeys(values map[string][]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,225 @@
+package v2
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	. "github.com/onsi/gomega"
+)
+
+func Test_NewHarViewFromResponseBody_ParsesHar(t *testing.T) {
+	RegisterTestingT(t)
+
+	har, err := NewHarViewFromResponseBody([]byte(`{
+		"log": {
+			"version": "1.2",
+			"creator": {"name": "Browser", "version": "1"},
+			"entries": [
+				{
+					"startedDateTime": "2017-01-01T00:00:00.000Z",
+					"request": {"method": "GET", "url": "http://test.com/path"},
+					"response": {"status": 200, "content": {"text": "body"}}
+				}
+			]
+		}
+	}`))
+
+	Expect(err).To(BeNil())
+	Expect(har.Log.Entries).To(HaveLen(1))
+	Expect(har.Log.Entries[0].Request.Url).To(Equal("http://test.com/path"))
+	Expect(har.Log.Entries[0].Response.Content.Text).To(Equal("body"))
+}
+
+func Test_NewHarViewFromResponseBody_ErrorsOnInvalidJson(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := NewHarViewFromResponseBody([]byte(`{{`))
+
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Invalid JSON"))
+}
+
+func Test_NewHarViewFromResponseBody_ErrorsWhenNotAHarFile(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := NewHarViewFromResponseBody([]byte(`{"data": {"pairs": []}}`))
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Invalid HAR file, missing log.version"))
+
+	_, err = NewHarViewFromResponseBody([]byte(`{"log": {"version": "1.2"}}`))
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Invalid HAR file, missing log.entries"))
+}
+
+func Test_NewHarViewFromSimulation_BuildsRequestsFromExactMatchers(t *testing.T) {
+	RegisterTestingT(t)
+
+	har := NewHarViewFromSimulation(SimulationViewV6{
+		DataViewV6{
+			RequestResponsePairs: []RequestMatcherResponsePairViewV6{
+				{
+					RequestMatcher: RequestMatcherViewV5{
+						Method:      []MatcherViewV5{NewMatcherView(matchers.Exact, "POST")},
+						Scheme:      []MatcherViewV5{NewMatcherView(matchers.Exact, "https")},
+						Destination: []MatcherViewV5{NewMatcherView(matchers.Exact, "test.com")},
+						Path:        []MatcherViewV5{NewMatcherView(matchers.Exact, "/path")},
+						Body:        []MatcherViewV5{NewMatcherView(matchers.Exact, "request-body")},
+						Query: &QueryMatcherViewV5{
+							"q": []MatcherViewV5{NewMatcherView(matchers.Exact, "one")},
+						},
+						Headers: map[string][]MatcherViewV5{
+							"Content-Type": []MatcherViewV5{NewMatcherView(matchers.Exact, "text/plain")},
+						},
+					},
+					Response: ResponseDetailsViewV6{
+						Status:  201,
+						Body:    "response-body",
+						Headers: map[string][]string{"Content-Type": []string{"application/json"}},
+					},
+				},
+			},
+		},
+		MetaView{
+			HoverflyVersion: "v0.17.0",
+			TimeExported:    "2017-01-01T00:00:00Z",
+		},
+	})
+
+	Expect(har.Log.Version).To(Equal("1.2"))
+	Expect(har.Log.Creator).To(Equal(HarCreatorView{Name: "Hoverfly", Version: "v0.17.0"}))
+	Expect(har.Log.Entries).To(HaveLen(1))
+
+	entry := har.Log.Entries[0]
+	Expect(entry.StartedDateTime).To(Equal("2017-01-01T00:00:00Z"))
+	Expect(entry.Comment).To(BeEmpty())
+
+	Expect(entry.Request.Method).To(Equal("POST"))
+	Expect(entry.Request.Url).To(Equal("https://test.com/path?q=one"))
+	Expect(entry.Request.QueryString).To(Equal([]HarNameValueView{{Name: "q", Value: "one"}}))
+	Expect(entry.Request.Headers).To(Equal([]HarNameValueView{{Name: "Content-Type", Value: "text/plain"}}))
+	Expect(entry.Request.PostData).To(Equal(&HarPostDataView{MimeType: "text/plain", Text: "request-body"}))
+
+	Expect(entry.Response.Status).To(Equal(201))
+	Expect(entry.Response.StatusText).To(Equal("Created"))
+	Expect(entry.Response.Headers).To(Equal([]HarNameValueView{{Name: "Content-Type", Value: "application/json"}}))
+	Expect(entry.Response.Content).To(Equal(HarContentView{
+		Size:     13,
+		MimeType: "application/json",
+		Text:     "response-body",
+	}))
+}
+
+func Test_NewHarViewFromSimulation_CommentsOnPairsWithMatchersWhichAreNotExact(t *testing.T) {
+	RegisterTestingT(t)
+
+	har := NewHarViewFromSimulation(SimulationViewV6{
+		DataViewV6{
+			RequestResponsePairs: []RequestMatcherResponsePairViewV6{
+				{
+					RequestMatcher: RequestMatcherViewV5{
+						Destination: []MatcherViewV5{NewMatcherView(matchers.Exact, "test.com")},
+						Path:        []MatcherViewV5{NewMatcherView(matchers.Glob, "/api/*")},
+					},
+					Response: ResponseDetailsViewV6{
+						Status: 200,
+					},
+				},
+			},
+		},
+		MetaView{},
+	})
+
+	entry := har.Log.Entries[0]
+	Expect(entry.Request.Method).To(Equal("GET"))
+	Expect(entry.Request.Url).To(Equal("http://test.com/"))
+	Expect(entry.Comment).To(Equal("Only the exact matchers of this pair are included in the request"))
+}
+
+func Test_NewHarViewFromSimulation_KeepsEncodedBodiesAsBase64(t *testing.T) {
+	RegisterTestingT(t)
+
+	har := NewHarViewFromSimulation(SimulationViewV6{
+		DataViewV6{
+			RequestResponsePairs: []RequestMatcherResponsePairViewV6{
+				{
+					Response: ResponseDetailsViewV6{
+						Status:      200,
+						Body:        "aGVsbG8=",
+						EncodedBody: true,
+					},
+				},
+			},
+		},
+		MetaView{},
+	})
+
+	Expect(har.Log.Entries[0].Response.Content.Text).To(Equal("aGVsbG8="))
+	Expect(har.Log.Entries[0].Response.Content.Encoding).To(Equal("base64"))
+}
+
+func Test_NewHarViewFromJournal_BuildsAnEntryForEachJournalEntry(t *testing.T) {
+	RegisterTestingT(t)
+
+	method := "GET"
+	scheme := "http"
+	destination := "test.com"
+	path := "/path"
+	query := "b=2&a=1"
+	body := ""
+
+	har := NewHarViewFromJournal([]JournalEntryView{
+		{
+			Request: RequestDetailsView{
+				Method:      &method,
+				Scheme:      &scheme,
+				Destination: &destination,
+				Path:        &path,
+				Query:       &query,
+				Body:        &body,
+				Headers:     map[string][]string{"Accept": []string{"text/plain"}},
+			},
+			Response: ResponseDetailsView{
+				Status: 200,
+				Body:   "hello",
+			},
+			Mode:        "simulate",
+			TimeStarted: "2017-01-01T00:00:00.000Z",
+			Latency:     1.5,
+		},
+	})
+
+	Expect(har.Log.Entries).To(HaveLen(1))
+
+	entry := har.Log.Entries[0]
+	Expect(entry.StartedDateTime).To(Equal("2017-01-01T00:00:00.000Z"))
+	Expect(entry.Time).To(Equal(1.5))
+	Expect(entry.Timings.Wait).To(Equal(1.5))
+	Expect(entry.Comment).To(Equal("Served in simulate mode"))
+
+	Expect(entry.Request.Url).To(Equal("http://test.com/path?b=2&a=1"))
+	Expect(entry.Request.QueryString).To(Equal([]HarNameValueView{
+		{Name: "a", Value: "1"},
+		{Name: "b", Value: "2"},
+	}))
+	Expect(entry.Request.Headers).To(Equal([]HarNameValueView{{Name: "Accept", Value: "text/plain"}}))
+	Expect(entry.Request.PostData).To(BeNil())
+
+	Expect(entry.Response.Status).To(Equal(200))
+	Expect(entry.Response.Content.Text).To(Equal("hello"))
+}
+
+func Test_NewHeadersFromHarNameValueViews_CanonicalizesNamesAndSkipsPseudoHeaders(t *testing.T) {
+	RegisterTestingT(t)
+
+	headers := NewHeadersFromHarNameValueViews([]HarNameValueView{
+		{Name: ":authority", Value: "test.com"},
+		{Name: "content-type", Value: "text/plain"},
+		{Name: "Accept", Value: "text/html"},
+		{Name: "accept", Value: "application/json"},
+	})
+
+	Expect(headers).To(Equal(map[string][]string{
+		"Content-Type": []string{"text/plain"},
+		"Accept":       []string{"text/html", "application/json"},
+	}))
+}
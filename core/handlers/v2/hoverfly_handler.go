@@ -20,6 +20,8 @@ type Hoverfly interface {
 	SetState(map[string]string)
 	PatchState(map[string]string)
 	ClearState()
+	GetScheduledStateTransitions() map[string]ScheduledStateTransitionView
+	ScheduleStateTransitions(map[string]ScheduledStateTransitionView)
 	GetUpstreamProxy() string
 	IsWebServer() bool
 	GetDiff() map[SimpleRequestDefinitionView][]DiffReport
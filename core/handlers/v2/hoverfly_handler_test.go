@@ -61,6 +61,13 @@ func (this *HoverflyStub) PatchState(state map[string]string) {
 func (this *HoverflyStub) ClearState() {
 }
 
+func (this *HoverflyStub) GetScheduledStateTransitions() map[string]ScheduledStateTransitionView {
+	return nil
+}
+
+func (this *HoverflyStub) ScheduleStateTransitions(transitions map[string]ScheduledStateTransitionView) {
+}
+
 func (this *HoverflyStub) IsWebServer() bool {
 	return false
 }
@@ -0,0 +1,96 @@
+package v2
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers"
+	"github.com/codegangsta/negroni"
+	"github.com/go-zoo/bone"
+)
+
+type HoverflyModeRoutes interface {
+	GetModeRoutes() ModeRoutesView
+	SetModeRoutes(ModeRoutesView) error
+	AddModeRoute(ModeRouteView) error
+	DeleteModeRoutes()
+}
+
+type HoverflyModeRoutesHandler struct {
+	Hoverfly HoverflyModeRoutes
+}
+
+func (this *HoverflyModeRoutesHandler) RegisterRoutes(mux *bone.Mux, am *handlers.AuthHandler) {
+	mux.Get("/api/v2/hoverfly/mode/routes", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Get),
+	))
+	mux.Put("/api/v2/hoverfly/mode/routes", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Put),
+	))
+	mux.Post("/api/v2/hoverfly/mode/routes", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Post),
+	))
+	mux.Delete("/api/v2/hoverfly/mode/routes", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Delete),
+	))
+	mux.Options("/api/v2/hoverfly/mode/routes", negroni.New(
+		negroni.HandlerFunc(this.Options),
+	))
+}
+
+func (this *HoverflyModeRoutesHandler) Get(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	bytes, _ := json.Marshal(this.Hoverfly.GetModeRoutes())
+
+	handlers.WriteResponse(w, bytes)
+}
+
+// Put replaces every route with the ones given
+func (this *HoverflyModeRoutesHandler) Put(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	var routesView ModeRoutesView
+	err := handlers.ReadFromRequest(req, &routesView)
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = this.Hoverfly.SetModeRoutes(routesView)
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	this.Get(w, req, next)
+}
+
+// Post adds a route after the existing ones
+func (this *HoverflyModeRoutesHandler) Post(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	var routeView ModeRouteView
+	err := handlers.ReadFromRequest(req, &routeView)
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = this.Hoverfly.AddModeRoute(routeView)
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	this.Get(w, req, next)
+}
+
+func (this *HoverflyModeRoutesHandler) Delete(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	this.Hoverfly.DeleteModeRoutes()
+
+	this.Get(w, req, next)
+}
+
+func (this *HoverflyModeRoutesHandler) Options(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	w.Header().Add("Allow", "OPTIONS, GET, PUT, POST, DELETE")
+	handlers.WriteResponse(w, []byte(""))
+}
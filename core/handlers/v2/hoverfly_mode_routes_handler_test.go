@@ -0,0 +1,162 @@
+package v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+type HoverflyModeRoutesStub struct {
+	Routes ModeRoutesView
+}
+
+func (this HoverflyModeRoutesStub) GetModeRoutes() ModeRoutesView {
+	return this.Routes
+}
+
+func (this *HoverflyModeRoutesStub) SetModeRoutes(routesView ModeRoutesView) error {
+	for _, route := range routesView.Routes {
+		if route.Mode == "error" {
+			return fmt.Errorf("This is an error")
+		}
+	}
+
+	this.Routes = routesView
+	return nil
+}
+
+func (this *HoverflyModeRoutesStub) AddModeRoute(routeView ModeRouteView) error {
+	if routeView.Mode == "error" {
+		return fmt.Errorf("This is an error")
+	}
+
+	this.Routes.Routes = append(this.Routes.Routes, routeView)
+	return nil
+}
+
+func (this *HoverflyModeRoutesStub) DeleteModeRoutes() {
+	this.Routes = ModeRoutesView{Routes: []ModeRouteView{}}
+}
+
+func unmarshalModeRoutesView(buffer *bytes.Buffer) (ModeRoutesView, error) {
+	var routesView ModeRoutesView
+
+	err := json.Unmarshal(buffer.Bytes(), &routesView)
+
+	return routesView, err
+}
+
+func Test_HoverflyModeRoutesHandler_Get_ReturnsRoutes(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := HoverflyModeRoutesHandler{Hoverfly: &HoverflyModeRoutesStub{ModeRoutesView{
+		Routes: []ModeRouteView{{Destination: "payments.com", ModeView: ModeView{Mode: "simulate"}}},
+	}}}
+
+	request, err := http.NewRequest("GET", "/api/v2/hoverfly/mode/routes", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Get, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(response.Body.String()).To(Equal(`{"routes":[{"destination":"payments.com","mode":"simulate","arguments":{}}]}`))
+}
+
+func Test_HoverflyModeRoutesHandler_Post_AddsRoute(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyModeRoutesStub{}
+	unit := HoverflyModeRoutesHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("POST", "/api/v2/hoverfly/mode/routes", ioutil.NopCloser(bytes.NewBufferString(
+		`{"destination": "catalog.com", "path": "^/items", "mode": "capture", "arguments": {"stateful": true}}`)))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Post, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	routesView, err := unmarshalModeRoutesView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(routesView.Routes).To(Equal([]ModeRouteView{{
+		Destination: "catalog.com",
+		Path:        "^/items",
+		ModeView: ModeView{
+			Mode:      "capture",
+			Arguments: ModeArgumentsView{Stateful: true},
+		},
+	}}))
+}
+
+func Test_HoverflyModeRoutesHandler_Post_ReturnsErrorForInvalidRoute(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := HoverflyModeRoutesHandler{Hoverfly: &HoverflyModeRoutesStub{}}
+
+	request, err := http.NewRequest("POST", "/api/v2/hoverfly/mode/routes", ioutil.NopCloser(bytes.NewBufferString(`{"mode": "error"}`)))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Post, request)
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+
+	errorView, err := unmarshalErrorView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(errorView.Error).To(Equal("This is an error"))
+}
+
+func Test_HoverflyModeRoutesHandler_Put_ReplacesRoutes(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyModeRoutesStub{ModeRoutesView{
+		Routes: []ModeRouteView{{Destination: "payments.com", ModeView: ModeView{Mode: "simulate"}}},
+	}}
+	unit := HoverflyModeRoutesHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("PUT", "/api/v2/hoverfly/mode/routes", ioutil.NopCloser(bytes.NewBufferString(
+		`{"routes": [{"path": "^/health", "mode": "spy"}]}`)))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Put, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	Expect(stubHoverfly.Routes.Routes).To(Equal([]ModeRouteView{{Path: "^/health", ModeView: ModeView{Mode: "spy"}}}))
+}
+
+func Test_HoverflyModeRoutesHandler_Put_ReturnsErrorForMalformedJson(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := HoverflyModeRoutesHandler{Hoverfly: &HoverflyModeRoutesStub{}}
+
+	request, err := http.NewRequest("PUT", "/api/v2/hoverfly/mode/routes", ioutil.NopCloser(bytes.NewBufferString(`{"routes": [`)))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Put, request)
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+}
+
+func Test_HoverflyModeRoutesHandler_Delete_DeletesRoutes(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyModeRoutesStub{ModeRoutesView{
+		Routes: []ModeRouteView{{Destination: "payments.com", ModeView: ModeView{Mode: "simulate"}}},
+	}}
+	unit := HoverflyModeRoutesHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("DELETE", "/api/v2/hoverfly/mode/routes", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Delete, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(response.Body.String()).To(Equal(`{"routes":[]}`))
+}
+
+func Test_ModeRouteView_Validate_RejectsInvalidRegexes(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(ModeRouteView{Destination: `payments\.com`, Path: "^/v1"}.Validate()).To(BeNil())
+	Expect(ModeRouteView{Destination: "payments(com"}.Validate()).ToNot(BeNil())
+	Expect(ModeRouteView{Path: "[v1"}.Validate()).ToNot(BeNil())
+}
@@ -0,0 +1,96 @@
+package v2
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers"
+	"github.com/codegangsta/negroni"
+	"github.com/go-zoo/bone"
+)
+
+type HoverflyTransformations interface {
+	GetTransformationRules() TransformationRulesView
+	SetTransformationRules(TransformationRulesView) error
+	AddTransformationRule(TransformationRuleView) error
+	DeleteTransformationRules()
+}
+
+type HoverflyTransformationsHandler struct {
+	Hoverfly HoverflyTransformations
+}
+
+func (this *HoverflyTransformationsHandler) RegisterRoutes(mux *bone.Mux, am *handlers.AuthHandler) {
+	mux.Get("/api/v2/hoverfly/transformations", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Get),
+	))
+	mux.Put("/api/v2/hoverfly/transformations", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Put),
+	))
+	mux.Post("/api/v2/hoverfly/transformations", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Post),
+	))
+	mux.Delete("/api/v2/hoverfly/transformations", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Delete),
+	))
+	mux.Options("/api/v2/hoverfly/transformations", negroni.New(
+		negroni.HandlerFunc(this.Options),
+	))
+}
+
+func (this *HoverflyTransformationsHandler) Get(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	bytes, _ := json.Marshal(this.Hoverfly.GetTransformationRules())
+
+	handlers.WriteResponse(w, bytes)
+}
+
+// Put replaces every rule with the ones given
+func (this *HoverflyTransformationsHandler) Put(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	var rulesView TransformationRulesView
+	err := handlers.ReadFromRequest(req, &rulesView)
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = this.Hoverfly.SetTransformationRules(rulesView)
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	this.Get(w, req, next)
+}
+
+// Post adds a rule after the existing ones
+func (this *HoverflyTransformationsHandler) Post(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	var ruleView TransformationRuleView
+	err := handlers.ReadFromRequest(req, &ruleView)
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = this.Hoverfly.AddTransformationRule(ruleView)
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	this.Get(w, req, next)
+}
+
+func (this *HoverflyTransformationsHandler) Delete(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	this.Hoverfly.DeleteTransformationRules()
+
+	this.Get(w, req, next)
+}
+
+func (this *HoverflyTransformationsHandler) Options(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	w.Header().Add("Allow", "OPTIONS, GET, PUT, POST, DELETE")
+	handlers.WriteResponse(w, []byte(""))
+}
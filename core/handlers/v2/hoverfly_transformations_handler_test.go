@@ -0,0 +1,159 @@
+package v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+type HoverflyTransformationsStub struct {
+	Rules TransformationRulesView
+}
+
+func (this HoverflyTransformationsStub) GetTransformationRules() TransformationRulesView {
+	return this.Rules
+}
+
+func (this *HoverflyTransformationsStub) SetTransformationRules(rulesView TransformationRulesView) error {
+	for _, rule := range rulesView.Rules {
+		if rule.Destination == "error" {
+			return fmt.Errorf("This is an error")
+		}
+	}
+
+	this.Rules = rulesView
+	return nil
+}
+
+func (this *HoverflyTransformationsStub) AddTransformationRule(ruleView TransformationRuleView) error {
+	if ruleView.Destination == "error" {
+		return fmt.Errorf("This is an error")
+	}
+
+	this.Rules.Rules = append(this.Rules.Rules, ruleView)
+	return nil
+}
+
+func (this *HoverflyTransformationsStub) DeleteTransformationRules() {
+	this.Rules = TransformationRulesView{Rules: []TransformationRuleView{}}
+}
+
+func unmarshalTransformationRulesView(buffer *bytes.Buffer) (TransformationRulesView, error) {
+	var rulesView TransformationRulesView
+
+	err := json.Unmarshal(buffer.Bytes(), &rulesView)
+
+	return rulesView, err
+}
+
+func Test_HoverflyTransformationsHandler_Get_ReturnsRules(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := HoverflyTransformationsHandler{Hoverfly: &HoverflyTransformationsStub{TransformationRulesView{
+		Rules: []TransformationRuleView{{Destination: "payments.com", Response: &ResponseTransformationView{Status: 503}}},
+	}}}
+
+	request, err := http.NewRequest("GET", "/api/v2/hoverfly/transformations", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Get, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(response.Body.String()).To(Equal(`{"rules":[{"destination":"payments.com","response":{"status":503}}]}`))
+}
+
+func Test_HoverflyTransformationsHandler_Post_AddsRule(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyTransformationsStub{}
+	unit := HoverflyTransformationsHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("POST", "/api/v2/hoverfly/transformations", ioutil.NopCloser(bytes.NewBufferString(
+		`{"path": "^/items", "request": {"destination": "catalog.internal", "setHeaders": {"X-Api-Key": ["key"]}}}`)))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Post, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	rulesView, err := unmarshalTransformationRulesView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(rulesView.Rules).To(Equal([]TransformationRuleView{{
+		Path: "^/items",
+		Request: &RequestTransformationView{
+			Destination: "catalog.internal",
+			MessageTransformationView: MessageTransformationView{
+				SetHeaders: map[string][]string{"X-Api-Key": {"key"}},
+			},
+		},
+	}}))
+}
+
+func Test_HoverflyTransformationsHandler_Post_ReturnsErrorForInvalidRule(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := HoverflyTransformationsHandler{Hoverfly: &HoverflyTransformationsStub{}}
+
+	request, err := http.NewRequest("POST", "/api/v2/hoverfly/transformations", ioutil.NopCloser(bytes.NewBufferString(`{"destination": "error"}`)))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Post, request)
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+
+	errorView, err := unmarshalErrorView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(errorView.Error).To(Equal("This is an error"))
+}
+
+func Test_HoverflyTransformationsHandler_Put_ReplacesRules(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyTransformationsStub{TransformationRulesView{
+		Rules: []TransformationRuleView{{Destination: "payments.com", Response: &ResponseTransformationView{Status: 503}}},
+	}}
+	unit := HoverflyTransformationsHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("PUT", "/api/v2/hoverfly/transformations", ioutil.NopCloser(bytes.NewBufferString(
+		`{"rules": [{"response": {"removeHeaders": ["Server"]}}]}`)))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Put, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	Expect(stubHoverfly.Rules.Rules).To(Equal([]TransformationRuleView{{
+		Response: &ResponseTransformationView{
+			MessageTransformationView: MessageTransformationView{RemoveHeaders: []string{"Server"}},
+		},
+	}}))
+}
+
+func Test_HoverflyTransformationsHandler_Put_ReturnsErrorForMalformedJson(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := HoverflyTransformationsHandler{Hoverfly: &HoverflyTransformationsStub{}}
+
+	request, err := http.NewRequest("PUT", "/api/v2/hoverfly/transformations", ioutil.NopCloser(bytes.NewBufferString(`{"rules": [`)))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Put, request)
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+}
+
+func Test_HoverflyTransformationsHandler_Delete_DeletesRules(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyTransformationsStub{TransformationRulesView{
+		Rules: []TransformationRuleView{{Destination: "payments.com", Response: &ResponseTransformationView{Status: 503}}},
+	}}
+	unit := HoverflyTransformationsHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("DELETE", "/api/v2/hoverfly/transformations", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Delete, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(response.Body.String()).To(Equal(`{"rules":[]}`))
+}
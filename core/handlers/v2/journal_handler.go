@@ -2,6 +2,7 @@ package v2
 
 import (
 	"encoding/json"
+	"math"
 	"net/http"
 
 	"github.com/SpectoLabs/hoverfly/core/handlers"
@@ -50,9 +51,15 @@ func (this *JournalHandler) Get(response http.ResponseWriter, request *http.Requ
 	fromTime := util.GetUnixTimeQueryParam(request, "from")
 	toTime := util.GetUnixTimeQueryParam(request, "to")
 	sort := queryParams.Get("sort")
+	harFormat := isHarFormat(request)
 
 	if limit == 0 {
-		limit = DefaultJournalLimit
+		// A HAR file is an export of the journal, so it is not paged unless asked to be
+		if harFormat {
+			limit = math.MaxInt32
+		} else {
+			limit = DefaultJournalLimit
+		}
 	}
 
 	journalView, err := this.Hoverfly.GetEntries(offset, limit, fromTime, toTime, sort)
@@ -61,6 +68,12 @@ func (this *JournalHandler) Get(response http.ResponseWriter, request *http.Requ
 		return
 	}
 
+	if harFormat {
+		bytes, _ := util.JSONMarshal(NewHarViewFromJournal(journalView.Journal))
+		handlers.WriteResponse(response, bytes)
+		return
+	}
+
 	bytes, _ := json.Marshal(journalView)
 	handlers.WriteResponse(response, bytes)
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"io/ioutil"
+	"math"
 	"net/http"
 	"testing"
 
@@ -113,6 +114,28 @@ func Test_JournalHandler_Get_SetDefaultPagingQueryIfNotSpecified(t *testing.T) {
 	Expect(stubHoverfly.offset).To(Equal(0))
 }
 
+func Test_JournalHandler_Get_WithHarFormatReturnsWholeJournalAsHar(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflyJournalStub{}
+
+	unit := JournalHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("GET", "/api/v2/journal?format=har", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Get, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(stubHoverfly.offset).To(Equal(0))
+	Expect(stubHoverfly.limit).To(Equal(math.MaxInt32))
+
+	har, err := NewHarViewFromResponseBody(response.Body.Bytes())
+	Expect(err).To(BeNil())
+	Expect(har.Log.Entries).To(HaveLen(1))
+	Expect(har.Log.Entries[0].Comment).To(Equal("Served in test mode"))
+}
+
 func Test_JournalHandler_Get_WithPagingQuery(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -0,0 +1,193 @@
+package v2
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+)
+
+const (
+	PathSegmentUuid   = "uuid"
+	PathSegmentNumber = "number"
+)
+
+var pathSegmentPatterns = map[string]*regexp.Regexp{
+	PathSegmentUuid:   regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`),
+	PathSegmentNumber: regexp.MustCompile(`^[0-9]+$`),
+}
+
+// MatcherPolicyView describes the request matchers capture mode generates instead of exact matchers.
+// Ignored fields and query keys are not matched at all, path segments which are a uuid, a number or
+// match a regex become a * in a glob matcher, and with bodyJsonPaths the body only has to have the
+// values at those locations.
+type MatcherPolicyView struct {
+	IgnoreFields     []string `json:"ignoreFields,omitempty"`
+	IgnoreQuery      []string `json:"ignoreQuery,omitempty"`
+	GlobPathSegments []string `json:"globPathSegments,omitempty"`
+	BodyJsonPaths    []string `json:"bodyJsonPaths,omitempty"`
+}
+
+func (this MatcherPolicyView) Validate() error {
+	for _, field := range this.IgnoreFields {
+		switch field {
+		case "method", "scheme", "destination", "path", "query", "body":
+		default:
+			return fmt.Errorf("Cannot ignore %s, only method, scheme, destination, path, query and body can be ignored", field)
+		}
+	}
+
+	for _, segment := range this.GlobPathSegments {
+		if _, err := this.pathSegmentPattern(segment); err != nil {
+			return fmt.Errorf("Path segment %s is neither uuid, number nor a valid regex: %s", segment, err.Error())
+		}
+	}
+
+	for _, path := range this.BodyJsonPaths {
+		if _, err := parseRedactionJsonPath(path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (this MatcherPolicyView) pathSegmentPattern(segment string) (*regexp.Regexp, error) {
+	if pattern, ok := pathSegmentPatterns[segment]; ok {
+		return pattern, nil
+	}
+
+	return regexp.Compile("^(?:" + segment + ")$")
+}
+
+func (this MatcherPolicyView) ignores(field string) bool {
+	for _, ignored := range this.IgnoreFields {
+		if ignored == field {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MatcherPolicy is a matcher policy with its path segment regexes compiled and its body JSONPaths
+// parsed, which is done once when the policy is set rather than for every captured pair
+type MatcherPolicy struct {
+	MatcherPolicyView
+
+	pathSegmentPatterns []*regexp.Regexp
+	bodyJsonPaths       [][]jsonPathStep
+}
+
+func NewMatcherPolicy(view MatcherPolicyView) (*MatcherPolicy, error) {
+	if err := view.Validate(); err != nil {
+		return nil, err
+	}
+
+	policy := &MatcherPolicy{MatcherPolicyView: view}
+	for _, segment := range view.GlobPathSegments {
+		pattern, _ := view.pathSegmentPattern(segment)
+		policy.pathSegmentPatterns = append(policy.pathSegmentPatterns, pattern)
+	}
+	for _, path := range view.BodyJsonPaths {
+		steps, _ := parseRedactionJsonPath(path)
+		policy.bodyJsonPaths = append(policy.bodyJsonPaths, steps)
+	}
+
+	return policy, nil
+}
+
+// ApplyMatcherPolicy replaces the exact matchers of a captured pair as the policy describes
+func ApplyMatcherPolicy(pair RequestMatcherResponsePairViewV6, policy *MatcherPolicy) RequestMatcherResponsePairViewV6 {
+	requestMatcher := copyRequestMatcherView(pair.RequestMatcher)
+
+	if len(policy.GlobPathSegments) > 0 {
+		requestMatcher.Path = globPathSegments(requestMatcher.Path, policy.pathSegmentPatterns)
+	}
+
+	if len(policy.BodyJsonPaths) > 0 {
+		requestMatcher.Body = selectBodyJsonPaths(requestMatcher.Body, policy.bodyJsonPaths)
+	}
+
+	if requestMatcher.Query != nil && len(policy.IgnoreQuery) > 0 {
+		for _, key := range policy.IgnoreQuery {
+			delete(*requestMatcher.Query, key)
+		}
+		// An empty query matcher only matches requests without a query
+		if len(*requestMatcher.Query) == 0 {
+			requestMatcher.Query = nil
+		}
+	}
+
+	if policy.ignores("method") {
+		requestMatcher.Method = nil
+	}
+	if policy.ignores("scheme") {
+		requestMatcher.Scheme = nil
+	}
+	if policy.ignores("destination") {
+		requestMatcher.Destination = nil
+	}
+	if policy.ignores("path") {
+		requestMatcher.Path = nil
+	}
+	if policy.ignores("query") {
+		requestMatcher.Query = nil
+		requestMatcher.DeprecatedQuery = nil
+	}
+	if policy.ignores("body") {
+		requestMatcher.Body = nil
+	}
+
+	pair.RequestMatcher = requestMatcher
+
+	return pair
+}
+
+func globPathSegments(pathMatchers []MatcherViewV5, patterns []*regexp.Regexp) []MatcherViewV5 {
+	globbed := []MatcherViewV5{}
+	for _, matcher := range pathMatchers {
+		path, ok := matcher.Value.(string)
+		if ok && matcher.Matcher == matchers.Exact {
+			segments := strings.Split(path, "/")
+			replaced := false
+			for i, segment := range segments {
+				for _, pattern := range patterns {
+					if segment != "" && pattern.MatchString(segment) {
+						segments[i] = "*"
+						replaced = true
+						break
+					}
+				}
+			}
+			if replaced {
+				matcher = NewMatcherView(matchers.Glob, strings.Join(segments, "/"))
+			}
+		}
+		globbed = append(globbed, matcher)
+	}
+
+	return globbed
+}
+
+// selectBodyJsonPaths replaces json and exact body matchers with jsonpartial matchers holding only
+// the values at the locations. Bodies which are not JSON, or have none of them, are matched as before.
+func selectBodyJsonPaths(bodyMatchers []MatcherViewV5, paths [][]jsonPathStep) []MatcherViewV5 {
+	if bodyMatchers == nil {
+		return nil
+	}
+
+	selected := []MatcherViewV5{}
+	for _, matcher := range bodyMatchers {
+		body, ok := matcher.Value.(string)
+		if ok && (matcher.Matcher == matchers.Json || matcher.Matcher == matchers.Exact) {
+			if partial, found := selectJsonPaths(body, paths); found {
+				matcher = NewMatcherView(matchers.JsonPartial, partial)
+			}
+		}
+		selected = append(selected, matcher)
+	}
+
+	return selected
+}
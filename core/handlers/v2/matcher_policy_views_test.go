@@ -0,0 +1,159 @@
+package v2
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	. "github.com/onsi/gomega"
+)
+
+func newCapturedPairView() RequestMatcherResponsePairViewV6 {
+	return RequestMatcherResponsePairViewV6{
+		RequestMatcher: RequestMatcherViewV5{
+			Method:      []MatcherViewV5{NewMatcherView(matchers.Exact, "POST")},
+			Scheme:      []MatcherViewV5{NewMatcherView(matchers.Exact, "https")},
+			Destination: []MatcherViewV5{NewMatcherView(matchers.Exact, "test.com")},
+			Path:        []MatcherViewV5{NewMatcherView(matchers.Exact, "/users/42/orders/3f2b8c1e-9d4a-4e6b-8f1c-2a7d5e9b0c31/v2")},
+			Query: &QueryMatcherViewV5{
+				"page":      []MatcherViewV5{NewMatcherView(matchers.Exact, "1")},
+				"timestamp": []MatcherViewV5{NewMatcherView(matchers.Exact, "1500000000")},
+			},
+			Body: []MatcherViewV5{NewMatcherView(matchers.Json, `{"user": {"id": 42, "name": "Ben"}, "requestedAt": "now", "items": [{"sku": "a", "n": 1}, {"sku": "b", "n": 2}]}`)},
+		},
+		Response: ResponseDetailsViewV6{
+			Status: 200,
+		},
+	}
+}
+
+func mustMatcherPolicy(view MatcherPolicyView) *MatcherPolicy {
+	policy, err := NewMatcherPolicy(view)
+	Expect(err).To(BeNil())
+
+	return policy
+}
+
+func Test_MatcherPolicyView_Validate_RejectsUnknownFields(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(MatcherPolicyView{IgnoreFields: []string{"scheme", "body"}}.Validate()).To(BeNil())
+
+	err := MatcherPolicyView{IgnoreFields: []string{"headers"}}.Validate()
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("Cannot ignore headers"))
+}
+
+func Test_MatcherPolicyView_Validate_RejectsInvalidPathSegmentsAndJsonPaths(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(MatcherPolicyView{GlobPathSegments: []string{"uuid", "number", "v[0-9]+"}}.Validate()).To(BeNil())
+	Expect(MatcherPolicyView{GlobPathSegments: []string{"v[0-9"}}.Validate()).ToNot(BeNil())
+	Expect(MatcherPolicyView{BodyJsonPaths: []string{"user.id"}}.Validate()).ToNot(BeNil())
+}
+
+func Test_ApplyMatcherPolicy_GlobsPathSegments(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := ApplyMatcherPolicy(newCapturedPairView(), mustMatcherPolicy(MatcherPolicyView{
+		GlobPathSegments: []string{"uuid", "number"},
+	}))
+
+	Expect(unit.RequestMatcher.Path).To(Equal([]MatcherViewV5{NewMatcherView(matchers.Glob, "/users/*/orders/*/v2")}))
+
+	unit = ApplyMatcherPolicy(newCapturedPairView(), mustMatcherPolicy(MatcherPolicyView{
+		GlobPathSegments: []string{"v[0-9]+"},
+	}))
+
+	Expect(unit.RequestMatcher.Path).To(Equal([]MatcherViewV5{NewMatcherView(matchers.Glob, "/users/42/orders/3f2b8c1e-9d4a-4e6b-8f1c-2a7d5e9b0c31/*")}))
+}
+
+func Test_ApplyMatcherPolicy_KeepsExactPathWithoutMatchingSegments(t *testing.T) {
+	RegisterTestingT(t)
+
+	pair := newCapturedPairView()
+	pair.RequestMatcher.Path = []MatcherViewV5{NewMatcherView(matchers.Exact, "/users")}
+
+	unit := ApplyMatcherPolicy(pair, mustMatcherPolicy(MatcherPolicyView{
+		GlobPathSegments: []string{"uuid", "number"},
+	}))
+
+	Expect(unit.RequestMatcher.Path).To(Equal([]MatcherViewV5{NewMatcherView(matchers.Exact, "/users")}))
+}
+
+func Test_ApplyMatcherPolicy_RemovesIgnoredQueryKeys(t *testing.T) {
+	RegisterTestingT(t)
+
+	pair := newCapturedPairView()
+	unit := ApplyMatcherPolicy(pair, mustMatcherPolicy(MatcherPolicyView{
+		IgnoreQuery: []string{"timestamp"},
+	}))
+
+	Expect(*unit.RequestMatcher.Query).To(HaveLen(1))
+	Expect((*unit.RequestMatcher.Query)["page"]).To(Equal([]MatcherViewV5{NewMatcherView(matchers.Exact, "1")}))
+	Expect(*pair.RequestMatcher.Query).To(HaveLen(2))
+
+	unit = ApplyMatcherPolicy(pair, mustMatcherPolicy(MatcherPolicyView{
+		IgnoreQuery: []string{"timestamp", "page"},
+	}))
+
+	Expect(unit.RequestMatcher.Query).To(BeNil())
+}
+
+func Test_ApplyMatcherPolicy_RemovesIgnoredFields(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := ApplyMatcherPolicy(newCapturedPairView(), mustMatcherPolicy(MatcherPolicyView{
+		IgnoreFields: []string{"method", "scheme", "destination", "path", "query", "body"},
+	}))
+
+	Expect(unit.RequestMatcher.Method).To(BeNil())
+	Expect(unit.RequestMatcher.Scheme).To(BeNil())
+	Expect(unit.RequestMatcher.Destination).To(BeNil())
+	Expect(unit.RequestMatcher.Path).To(BeNil())
+	Expect(unit.RequestMatcher.Query).To(BeNil())
+	Expect(unit.RequestMatcher.Body).To(BeNil())
+}
+
+func Test_ApplyMatcherPolicy_SelectsBodyJsonPaths(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := ApplyMatcherPolicy(newCapturedPairView(), mustMatcherPolicy(MatcherPolicyView{
+		BodyJsonPaths: []string{"$.user.id", "$.items[*].sku"},
+	}))
+
+	Expect(unit.RequestMatcher.Body).To(Equal([]MatcherViewV5{
+		NewMatcherView(matchers.JsonPartial, `{"items":[{"sku":"a"},{"sku":"b"}],"user":{"id":42}}`),
+	}))
+}
+
+func Test_ApplyMatcherPolicy_KeepsBodiesWithoutSelectedValues(t *testing.T) {
+	RegisterTestingT(t)
+
+	pair := newCapturedPairView()
+	pair.RequestMatcher.Body = []MatcherViewV5{NewMatcherView(matchers.Exact, "name=Ben")}
+
+	unit := ApplyMatcherPolicy(pair, mustMatcherPolicy(MatcherPolicyView{
+		BodyJsonPaths: []string{"$.user.id"},
+	}))
+
+	Expect(unit.RequestMatcher.Body).To(Equal([]MatcherViewV5{NewMatcherView(matchers.Exact, "name=Ben")}))
+
+	pair.RequestMatcher.Body = []MatcherViewV5{NewMatcherView(matchers.Json, `{"name": "Ben"}`)}
+
+	unit = ApplyMatcherPolicy(pair, mustMatcherPolicy(MatcherPolicyView{
+		BodyJsonPaths: []string{"$.user.id"},
+	}))
+
+	Expect(unit.RequestMatcher.Body).To(Equal([]MatcherViewV5{NewMatcherView(matchers.Json, `{"name": "Ben"}`)}))
+}
+
+func Test_NewMatcherPolicy_ReturnsErrorForInvalidPolicy(t *testing.T) {
+	RegisterTestingT(t)
+
+	policy, err := NewMatcherPolicy(MatcherPolicyView{
+		IgnoreFields: []string{"headers", "method"},
+	})
+
+	Expect(err).ToNot(BeNil())
+	Expect(policy).To(BeNil())
+}
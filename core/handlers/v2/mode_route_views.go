@@ -0,0 +1,31 @@
+package v2
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// ModeRouteView sends the requests whose destination and path match its regexes to its own mode,
+// instead of the mode of Hoverfly. A route without a destination or a path matches any of them.
+type ModeRouteView struct {
+	Destination string `json:"destination,omitempty"`
+	Path        string `json:"path,omitempty"`
+	ModeView
+}
+
+// ModeRoutesView holds the routes in the order they are tried, the first route matching a request wins
+type ModeRoutesView struct {
+	Routes []ModeRouteView `json:"routes"`
+}
+
+func (this ModeRouteView) Validate() error {
+	if _, err := regexp.Compile(this.Destination); err != nil {
+		return fmt.Errorf("Route destination is not a valid regex: %s", err.Error())
+	}
+
+	if _, err := regexp.Compile(this.Path); err != nil {
+		return fmt.Errorf("Route path is not a valid regex: %s", err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,706 @@
+package v2
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v1"
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	"github.com/SpectoLabs/hoverfly/core/util"
+)
+
+const openApiWarningMessage = "WARNING: Could not model %s"
+
+// OpenApiView holds the parts of an OpenAPI 3 specification which are used to build a simulation.
+// Swagger 2 specifications are read into the same view, see NewOpenApiViewFromResponseBody.
+type OpenApiView struct {
+	OpenApi    string                         `json:"openapi"`
+	Swagger    string                         `json:"swagger,omitempty"`
+	Servers    []OpenApiServerView            `json:"servers,omitempty"`
+	Paths      map[string]OpenApiPathItemView `json:"paths"`
+	Components OpenApiComponentsView          `json:"components,omitempty"`
+
+	// Swagger 2 fields
+	Host        string                        `json:"host,omitempty"`
+	BasePath    string                        `json:"basePath,omitempty"`
+	Schemes     []string                      `json:"schemes,omitempty"`
+	Produces    []string                      `json:"produces,omitempty"`
+	Definitions map[string]*OpenApiSchemaView `json:"definitions,omitempty"`
+}
+
+type OpenApiServerView struct {
+	Url string `json:"url"`
+}
+
+type OpenApiComponentsView struct {
+	Schemas   map[string]*OpenApiSchemaView  `json:"schemas,omitempty"`
+	Responses map[string]OpenApiResponseView `json:"responses,omitempty"`
+	Examples  map[string]OpenApiExampleView  `json:"examples,omitempty"`
+}
+
+type OpenApiPathItemView struct {
+	Get     *OpenApiOperationView `json:"get,omitempty"`
+	Put     *OpenApiOperationView `json:"put,omitempty"`
+	Post    *OpenApiOperationView `json:"post,omitempty"`
+	Delete  *OpenApiOperationView `json:"delete,omitempty"`
+	Options *OpenApiOperationView `json:"options,omitempty"`
+	Head    *OpenApiOperationView `json:"head,omitempty"`
+	Patch   *OpenApiOperationView `json:"patch,omitempty"`
+}
+
+type OpenApiOperationView struct {
+	OperationId string                         `json:"operationId,omitempty"`
+	Produces    []string                       `json:"produces,omitempty"`
+	Responses   map[string]OpenApiResponseView `json:"responses"`
+}
+
+type OpenApiResponseView struct {
+	Ref     string                          `json:"$ref,omitempty"`
+	Headers map[string]OpenApiHeaderView    `json:"headers,omitempty"`
+	Content map[string]OpenApiMediaTypeView `json:"content,omitempty"`
+
+	// Swagger 2 fields
+	Schema   *OpenApiSchemaView     `json:"schema,omitempty"`
+	Examples map[string]interface{} `json:"examples,omitempty"`
+}
+
+type OpenApiHeaderView struct {
+	Schema  *OpenApiSchemaView `json:"schema,omitempty"`
+	Example interface{}        `json:"example,omitempty"`
+
+	// Swagger 2 fields
+	Type string `json:"type,omitempty"`
+}
+
+type OpenApiMediaTypeView struct {
+	Schema   *OpenApiSchemaView            `json:"schema,omitempty"`
+	Example  interface{}                   `json:"example,omitempty"`
+	Examples map[string]OpenApiExampleView `json:"examples,omitempty"`
+}
+
+type OpenApiExampleView struct {
+	Ref   string      `json:"$ref,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+type OpenApiSchemaView struct {
+	Ref        string                        `json:"$ref,omitempty"`
+	Type       string                        `json:"type,omitempty"`
+	Format     string                        `json:"format,omitempty"`
+	Properties map[string]*OpenApiSchemaView `json:"properties,omitempty"`
+	Items      *OpenApiSchemaView            `json:"items,omitempty"`
+	AllOf      []*OpenApiSchemaView          `json:"allOf,omitempty"`
+	OneOf      []*OpenApiSchemaView          `json:"oneOf,omitempty"`
+	AnyOf      []*OpenApiSchemaView          `json:"anyOf,omitempty"`
+	Enum       []interface{}                 `json:"enum,omitempty"`
+	Example    interface{}                   `json:"example,omitempty"`
+	Default    interface{}                   `json:"default,omitempty"`
+}
+
+// NewOpenApiViewFromResponseBody reads an OpenAPI 3 or Swagger 2 specification in either JSON or YAML.
+// Swagger 2 specifications are converted so the rest of the import only deals with OpenAPI 3.
+func NewOpenApiViewFromResponseBody(body []byte) (OpenApiView, error) {
+	var spec OpenApiView
+
+	if err := json.Unmarshal(body, &spec); err != nil {
+		spec = OpenApiView{}
+
+		jsonSpec, err := util.YAMLToJSON(body)
+		if err != nil {
+			return OpenApiView{}, errors.New("Invalid OpenAPI specification, expected JSON or YAML")
+		}
+		if err := json.Unmarshal(jsonSpec, &spec); err != nil {
+			return OpenApiView{}, errors.New("Invalid OpenAPI specification, expected JSON or YAML")
+		}
+	}
+
+	if spec.Swagger != "" {
+		upgradeSwaggerView(&spec)
+	}
+
+	if spec.OpenApi == "" {
+		return OpenApiView{}, errors.New("Invalid OpenAPI specification, missing openapi version")
+	}
+
+	if len(spec.Paths) == 0 {
+		return OpenApiView{}, errors.New("Invalid OpenAPI specification, missing paths")
+	}
+
+	return spec, nil
+}
+
+func upgradeSwaggerView(spec *OpenApiView) {
+	spec.OpenApi = spec.Swagger
+
+	if spec.Host != "" {
+		scheme := "http"
+		if len(spec.Schemes) > 0 {
+			scheme = spec.Schemes[0]
+		}
+		spec.Servers = []OpenApiServerView{{Url: scheme + "://" + spec.Host + spec.BasePath}}
+	} else if spec.BasePath != "" {
+		spec.Servers = []OpenApiServerView{{Url: spec.BasePath}}
+	}
+
+	if spec.Components.Schemas == nil {
+		spec.Components.Schemas = map[string]*OpenApiSchemaView{}
+	}
+	for name, schema := range spec.Definitions {
+		spec.Components.Schemas[name] = schema
+	}
+
+	for _, pathItem := range spec.Paths {
+		for _, operation := range pathItem.operations() {
+			produces := operation.view.Produces
+			if len(produces) == 0 {
+				produces = spec.Produces
+			}
+			mediaType := "application/json"
+			if len(produces) > 0 {
+				mediaType = produces[0]
+			}
+
+			for code, response := range operation.view.Responses {
+				if response.Schema == nil && len(response.Examples) == 0 {
+					continue
+				}
+
+				content := map[string]OpenApiMediaTypeView{}
+				for exampleMediaType, example := range response.Examples {
+					content[exampleMediaType] = OpenApiMediaTypeView{Schema: response.Schema, Example: example}
+				}
+				if len(content) == 0 {
+					content[mediaType] = OpenApiMediaTypeView{Schema: response.Schema}
+				}
+				response.Content = content
+				operation.view.Responses[code] = response
+			}
+		}
+	}
+}
+
+type openApiOperation struct {
+	method string
+	view   *OpenApiOperationView
+}
+
+func (this OpenApiPathItemView) operations() []openApiOperation {
+	operations := []openApiOperation{}
+
+	for _, operation := range []openApiOperation{
+		{http.MethodGet, this.Get},
+		{http.MethodPut, this.Put},
+		{http.MethodPost, this.Post},
+		{http.MethodDelete, this.Delete},
+		{http.MethodOptions, this.Options},
+		{http.MethodHead, this.Head},
+		{http.MethodPatch, this.Patch},
+	} {
+		if operation.view != nil {
+			operations = append(operations, operation)
+		}
+	}
+
+	return operations
+}
+
+var openApiPathParameter = regexp.MustCompile(`\{([^}/]+)\}`)
+
+// NewSimulationViewFromOpenApi builds a pair for each operation in the specification. Operations which
+// cannot be modelled are left out of the simulation and a warning is returned for each of them.
+func NewSimulationViewFromOpenApi(spec OpenApiView) (SimulationViewV6, []SimulationImportWarning) {
+	warnings := []SimulationImportWarning{}
+	pairs := []RequestMatcherResponsePairViewV6{}
+
+	destination, basePath, err := newDestinationFromOpenApiServers(spec.Servers)
+	if err != nil {
+		warnings = append(warnings, newOpenApiWarning(err.Error()))
+	}
+
+	for _, path := range sortedOpenApiPaths(spec.Paths) {
+		for _, operation := range spec.Paths[path].operations() {
+			name := fmt.Sprintf("%s %s", operation.method, path)
+
+			pair, skippedHeaders, err := newPairFromOpenApiOperation(spec, basePath+path, operation)
+			if err != nil {
+				warnings = append(warnings, newOpenApiWarning(fmt.Sprintf("%s, %s", name, err.Error())))
+				continue
+			}
+
+			for _, header := range skippedHeaders {
+				warnings = append(warnings, newOpenApiWarning(fmt.Sprintf("header %s of %s, it has no example or type", header, name)))
+			}
+
+			if destination != "" {
+				pair.RequestMatcher.Destination = []MatcherViewV5{NewMatcherView(matchers.Exact, destination)}
+			}
+
+			pairs = append(pairs, pair)
+		}
+	}
+
+	return BuildSimulationView(pairs, v1.ResponseDelayPayloadView{Data: []v1.ResponseDelayView{}}, ""), warnings
+}
+
+func newOpenApiWarning(message string) SimulationImportWarning {
+	return SimulationImportWarning{Message: fmt.Sprintf(openApiWarningMessage, message)}
+}
+
+func newDestinationFromOpenApiServers(servers []OpenApiServerView) (string, string, error) {
+	if len(servers) == 0 {
+		return "", "", nil
+	}
+
+	serverUrl := servers[0].Url
+	if strings.Contains(serverUrl, "{") {
+		return "", "", fmt.Errorf("servers[0].url %s as it has variables, any destination will be matched", serverUrl)
+	}
+
+	parsedUrl, err := url.Parse(serverUrl)
+	if err != nil {
+		return "", "", fmt.Errorf("servers[0].url %s as it is not a valid url, any destination will be matched", serverUrl)
+	}
+
+	return parsedUrl.Host, strings.TrimSuffix(parsedUrl.Path, "/"), nil
+}
+
+// sortedOpenApiPaths puts paths without parameters first so that they
+// are matched before any templated path which would also match them
+func sortedOpenApiPaths(paths map[string]OpenApiPathItemView) []string {
+	sorted := []string{}
+	for path := range paths {
+		sorted = append(sorted, path)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		iParameters := len(openApiPathParameter.FindAllString(sorted[i], -1))
+		jParameters := len(openApiPathParameter.FindAllString(sorted[j], -1))
+		if iParameters != jParameters {
+			return iParameters < jParameters
+		}
+		return sorted[i] < sorted[j]
+	})
+
+	return sorted
+}
+
+// sortedOpenApiHeaders orders the headers by name, so they are warned about in the same order every time
+func sortedOpenApiHeaders(headers map[string]OpenApiHeaderView) []string {
+	sorted := []string{}
+	for name := range headers {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	return sorted
+}
+
+// newPairFromOpenApiOperation builds the pair of an operation, leaving out the response headers
+// which have no example or type, which are returned so they can be warned about
+func newPairFromOpenApiOperation(spec OpenApiView, path string, operation openApiOperation) (RequestMatcherResponsePairViewV6, []string, error) {
+	status, response, err := chooseOpenApiResponse(spec, operation.view.Responses)
+	if err != nil {
+		return RequestMatcherResponsePairViewV6{}, nil, err
+	}
+
+	pathMatcher, pathParameters := newOpenApiPathMatcher(path)
+
+	responseView := ResponseDetailsViewV6{
+		Status:  status,
+		Headers: map[string][]string{},
+	}
+
+	skippedHeaders := []string{}
+	for _, name := range sortedOpenApiHeaders(response.Headers) {
+		value, ok, err := exampleFromOpenApiHeader(spec, response.Headers[name])
+		if err != nil {
+			return RequestMatcherResponsePairViewV6{}, nil, err
+		}
+		if !ok {
+			skippedHeaders = append(skippedHeaders, name)
+			continue
+		}
+		responseView.Headers[http.CanonicalHeaderKey(name)] = []string{value}
+	}
+
+	if mediaType, content, ok := chooseOpenApiMediaType(response.Content); ok {
+		responseView.Headers["Content-Type"] = []string{mediaType}
+
+		body, templated, err := newOpenApiResponseBody(spec, mediaType, content, pathParameters)
+		if err != nil {
+			return RequestMatcherResponsePairViewV6{}, nil, err
+		}
+		responseView.Body = body
+		responseView.Templated = templated
+	}
+
+	return RequestMatcherResponsePairViewV6{
+		RequestMatcher: RequestMatcherViewV5{
+			Method: []MatcherViewV5{NewMatcherView(matchers.Exact, operation.method)},
+			Path:   []MatcherViewV5{pathMatcher},
+		},
+		Response: responseView,
+	}, skippedHeaders, nil
+}
+
+// newOpenApiPathMatcher turns a path template such as /pets/{petId} into a regex matcher. It also returns the
+// parameters which make up a whole path segment along with their index, for use in Request.Path templates.
+func newOpenApiPathMatcher(path string) (MatcherViewV5, map[string]int) {
+	parameters := map[string]int{}
+
+	if !openApiPathParameter.MatchString(path) {
+		return NewMatcherView(matchers.Exact, path), parameters
+	}
+
+	for i, segment := range strings.Split(strings.TrimPrefix(path, "/"), "/") {
+		if match := openApiPathParameter.FindStringSubmatch(segment); match != nil && match[0] == segment {
+			parameters[match[1]] = i
+		}
+	}
+
+	expression := "^"
+	lastIndex := 0
+	for _, location := range openApiPathParameter.FindAllStringIndex(path, -1) {
+		expression += regexp.QuoteMeta(path[lastIndex:location[0]]) + "[^/]+"
+		lastIndex = location[1]
+	}
+	expression += regexp.QuoteMeta(path[lastIndex:]) + "$"
+
+	return NewMatcherView(matchers.Regex, expression), parameters
+}
+
+// chooseOpenApiResponse picks the lowest successful response, falling back to the default
+// response and then to the lowest status code documented
+func chooseOpenApiResponse(spec OpenApiView, responses map[string]OpenApiResponseView) (int, OpenApiResponseView, error) {
+	if len(responses) == 0 {
+		return 0, OpenApiResponseView{}, errors.New("it has no responses")
+	}
+
+	codes := []string{}
+	for code := range responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	chosen := codes[0]
+	if _, ok := responses["default"]; ok {
+		chosen = "default"
+	}
+	for _, code := range codes {
+		if strings.HasPrefix(code, "2") {
+			chosen = code
+			break
+		}
+	}
+
+	status := http.StatusOK
+	if chosen != "default" {
+		parsed, err := strconv.Atoi(strings.Replace(strings.ToUpper(chosen), "XX", "00", 1))
+		if err != nil {
+			return 0, OpenApiResponseView{}, fmt.Errorf("%s is not a valid response status", chosen)
+		}
+		status = parsed
+	}
+
+	response := responses[chosen]
+	if response.Ref != "" {
+		resolved, ok := spec.Components.Responses[strings.TrimPrefix(response.Ref, "#/components/responses/")]
+		if !ok {
+			return 0, OpenApiResponseView{}, fmt.Errorf("could not resolve $ref %s", response.Ref)
+		}
+		response = resolved
+	}
+
+	return status, response, nil
+}
+
+func chooseOpenApiMediaType(content map[string]OpenApiMediaTypeView) (string, OpenApiMediaTypeView, bool) {
+	if len(content) == 0 {
+		return "", OpenApiMediaTypeView{}, false
+	}
+
+	mediaTypes := []string{}
+	for mediaType := range content {
+		if isJsonMediaType(mediaType) {
+			return mediaType, content[mediaType], true
+		}
+		mediaTypes = append(mediaTypes, mediaType)
+	}
+	sort.Strings(mediaTypes)
+
+	return mediaTypes[0], content[mediaTypes[0]], true
+}
+
+func isJsonMediaType(mediaType string) bool {
+	return strings.HasPrefix(mediaType, "application/json") || strings.HasSuffix(strings.Split(mediaType, ";")[0], "+json")
+}
+
+// newOpenApiResponseBody uses an example from the specification if there is one. Otherwise a JSON
+// body is generated from the schema, templating any property named after a path parameter.
+func newOpenApiResponseBody(spec OpenApiView, mediaType string, content OpenApiMediaTypeView, pathParameters map[string]int) (string, bool, error) {
+	example := content.Example
+	if example == nil && len(content.Examples) > 0 {
+		names := []string{}
+		for name := range content.Examples {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		namedExample := content.Examples[names[0]]
+		if namedExample.Ref != "" {
+			resolved, ok := spec.Components.Examples[strings.TrimPrefix(namedExample.Ref, "#/components/examples/")]
+			if !ok {
+				return "", false, fmt.Errorf("could not resolve $ref %s", namedExample.Ref)
+			}
+			namedExample = resolved
+		}
+		example = namedExample.Value
+	}
+
+	if example != nil {
+		if body, ok := example.(string); ok {
+			return body, false, nil
+		}
+		body, err := json.Marshal(example)
+		return string(body), false, err
+	}
+
+	if content.Schema == nil {
+		return "", false, nil
+	}
+
+	if !isJsonMediaType(mediaType) {
+		return "", false, fmt.Errorf("a body can only be generated from the schema for JSON, not %s", mediaType)
+	}
+
+	schema, err := resolveOpenApiSchema(spec, content.Schema)
+	if err != nil {
+		return "", false, err
+	}
+
+	generated, err := exampleFromOpenApiSchema(spec, schema, 0)
+	if err != nil {
+		return "", false, err
+	}
+
+	templates := map[string]string{}
+	if object, ok := generated.(map[string]interface{}); ok {
+		properties, _ := schemaProperties(spec, schema, 0)
+
+		for name, index := range pathParameters {
+			if _, ok := object[name]; !ok {
+				continue
+			}
+
+			placeholder := fmt.Sprintf("hoverfly-path-parameter-%v", index)
+			template := fmt.Sprintf("{{ Request.Path.[%v] }}", index)
+
+			propertySchema, _ := resolveOpenApiSchema(spec, properties[name])
+			if propertySchema == nil || propertySchema.Type == "string" || propertySchema.Type == "" {
+				template = `"` + template + `"`
+			}
+
+			object[name] = placeholder
+			templates[`"`+placeholder+`"`] = template
+		}
+	}
+
+	body, err := json.Marshal(generated)
+	if err != nil {
+		return "", false, err
+	}
+
+	templatedBody := string(body)
+	for placeholder, template := range templates {
+		templatedBody = strings.Replace(templatedBody, placeholder, template, 1)
+	}
+
+	return templatedBody, len(templates) > 0, nil
+}
+
+// exampleFromOpenApiHeader returns the value of a header, or false when it has no example or type
+func exampleFromOpenApiHeader(spec OpenApiView, header OpenApiHeaderView) (string, bool, error) {
+	if header.Example != nil {
+		return openApiHeaderValue(header.Example), true, nil
+	}
+
+	schema := header.Schema
+	if schema == nil {
+		schema = &OpenApiSchemaView{Type: header.Type}
+	}
+
+	resolved, err := resolveOpenApiSchema(spec, schema)
+	if err != nil {
+		return "", false, err
+	}
+
+	value, err := exampleFromOpenApiSchema(spec, resolved, 0)
+	if err != nil {
+		return "", false, err
+	}
+	if value == nil {
+		return "", false, nil
+	}
+
+	return openApiHeaderValue(value), true, nil
+}
+
+// openApiHeaderValue writes scalars as they are, and objects and arrays as JSON
+func openApiHeaderValue(value interface{}) string {
+	switch value := value.(type) {
+	case string:
+		return value
+	case map[string]interface{}, []interface{}:
+		encoded, err := json.Marshal(value)
+		if err == nil {
+			return string(encoded)
+		}
+	}
+
+	return fmt.Sprint(value)
+}
+
+func resolveOpenApiSchema(spec OpenApiView, schema *OpenApiSchemaView) (*OpenApiSchemaView, error) {
+	visited := map[string]bool{}
+
+	for schema != nil && schema.Ref != "" {
+		if visited[schema.Ref] {
+			return nil, fmt.Errorf("$ref %s refers back to itself", schema.Ref)
+		}
+		visited[schema.Ref] = true
+
+		name := strings.TrimPrefix(strings.TrimPrefix(schema.Ref, "#/components/schemas/"), "#/definitions/")
+
+		resolved, ok := spec.Components.Schemas[name]
+		if !ok || name == schema.Ref {
+			return nil, fmt.Errorf("could not resolve $ref %s", schema.Ref)
+		}
+		schema = resolved
+	}
+
+	return schema, nil
+}
+
+// Schemas can refer to themselves, so generation stops at this depth
+const maxOpenApiSchemaDepth = 10
+
+func schemaProperties(spec OpenApiView, schema *OpenApiSchemaView, depth int) (map[string]*OpenApiSchemaView, error) {
+	if depth > maxOpenApiSchemaDepth {
+		return nil, fmt.Errorf("allOf is nested more than %d deep", maxOpenApiSchemaDepth)
+	}
+
+	properties := map[string]*OpenApiSchemaView{}
+	for name, property := range schema.Properties {
+		properties[name] = property
+	}
+
+	for _, child := range schema.AllOf {
+		resolved, err := resolveOpenApiSchema(spec, child)
+		if err != nil || resolved == nil {
+			continue
+		}
+
+		childProperties, err := schemaProperties(spec, resolved, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		for name, property := range childProperties {
+			properties[name] = property
+		}
+	}
+
+	return properties, nil
+}
+
+func exampleFromOpenApiSchema(spec OpenApiView, schema *OpenApiSchemaView, depth int) (interface{}, error) {
+	if schema == nil || depth > maxOpenApiSchemaDepth {
+		return nil, nil
+	}
+
+	switch {
+	case schema.Example != nil:
+		return schema.Example, nil
+	case schema.Default != nil:
+		return schema.Default, nil
+	case len(schema.Enum) > 0:
+		return schema.Enum[0], nil
+	case len(schema.OneOf) > 0:
+		return exampleFromResolvedOpenApiSchema(spec, schema.OneOf[0], depth)
+	case len(schema.AnyOf) > 0:
+		return exampleFromResolvedOpenApiSchema(spec, schema.AnyOf[0], depth)
+	}
+
+	switch schema.Type {
+	case "array":
+		item, err := exampleFromResolvedOpenApiSchema(spec, schema.Items, depth)
+		if err != nil {
+			return nil, err
+		}
+		if item == nil {
+			return []interface{}{}, nil
+		}
+		return []interface{}{item}, nil
+	case "integer":
+		return 0, nil
+	case "number":
+		return 0.0, nil
+	case "boolean":
+		return true, nil
+	case "string":
+		return exampleFromOpenApiStringFormat(schema.Format), nil
+	}
+
+	properties, err := schemaProperties(spec, schema, 0)
+	if err != nil {
+		return nil, err
+	}
+	if schema.Type != "object" && len(properties) == 0 {
+		return nil, nil
+	}
+
+	object := map[string]interface{}{}
+	for name, property := range properties {
+		value, err := exampleFromResolvedOpenApiSchema(spec, property, depth)
+		if err != nil {
+			return nil, err
+		}
+		if value != nil {
+			object[name] = value
+		}
+	}
+
+	return object, nil
+}
+
+func exampleFromResolvedOpenApiSchema(spec OpenApiView, schema *OpenApiSchemaView, depth int) (interface{}, error) {
+	resolved, err := resolveOpenApiSchema(spec, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return exampleFromOpenApiSchema(spec, resolved, depth+1)
+}
+
+func exampleFromOpenApiStringFormat(format string) string {
+	switch format {
+	case "date-time":
+		return "2017-01-01T00:00:00Z"
+	case "date":
+		return "2017-01-01"
+	case "uuid":
+		return "00000000-0000-0000-0000-000000000000"
+	case "email":
+		return "user@example.com"
+	case "uri", "url":
+		return "http://example.com"
+	}
+
+	return "string"
+}
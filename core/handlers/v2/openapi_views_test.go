@@ -0,0 +1,363 @@
+package v2
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	. "github.com/onsi/gomega"
+)
+
+const petStoreOpenApi = `
+openapi: 3.0.0
+servers:
+  - url: http://petstore.example.com/v1
+paths:
+  /pets/{petId}:
+    get:
+      responses:
+        200:
+          headers:
+            X-Rate-Limit:
+              schema:
+                type: integer
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+        404:
+          content:
+            application/json:
+              example: {"error": "not found"}
+  /pets:
+    get:
+      responses:
+        default:
+          content:
+            application/json:
+              examples:
+                dogs:
+                  value: [{"id": 1, "name": "Rex"}]
+    post:
+      responses:
+        201:
+          description: created
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        petId:
+          type: integer
+        name:
+          type: string
+          example: Rex
+        born:
+          type: string
+          format: date
+        tags:
+          type: array
+          items:
+            type: string
+`
+
+func Test_NewOpenApiViewFromResponseBody_ParsesYaml(t *testing.T) {
+	RegisterTestingT(t)
+
+	spec, err := NewOpenApiViewFromResponseBody([]byte(petStoreOpenApi))
+	Expect(err).To(BeNil())
+
+	Expect(spec.OpenApi).To(Equal("3.0.0"))
+	Expect(spec.Servers).To(Equal([]OpenApiServerView{{Url: "http://petstore.example.com/v1"}}))
+	Expect(spec.Paths).To(HaveLen(2))
+	Expect(spec.Paths["/pets/{petId}"].Get.Responses).To(HaveKey("200"))
+	Expect(spec.Components.Schemas["Pet"].Properties).To(HaveLen(4))
+}
+
+func Test_NewOpenApiViewFromResponseBody_ParsesJson(t *testing.T) {
+	RegisterTestingT(t)
+
+	spec, err := NewOpenApiViewFromResponseBody([]byte(`{
+		"openapi": "3.0.0",
+		"paths": {
+			"/pets": {"get": {"responses": {"200": {"description": "ok"}}}}
+		}
+	}`))
+	Expect(err).To(BeNil())
+
+	Expect(spec.Paths["/pets"].Get.Responses).To(HaveKey("200"))
+}
+
+func Test_NewOpenApiViewFromResponseBody_ErrorsWhenNotASpecification(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := NewOpenApiViewFromResponseBody([]byte("{{"))
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Invalid OpenAPI specification, expected JSON or YAML"))
+
+	_, err = NewOpenApiViewFromResponseBody([]byte(`{"data": {}}`))
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Invalid OpenAPI specification, missing openapi version"))
+
+	_, err = NewOpenApiViewFromResponseBody([]byte(`openapi: 3.0.0`))
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Invalid OpenAPI specification, missing paths"))
+}
+
+func Test_NewOpenApiViewFromResponseBody_UpgradesSwagger(t *testing.T) {
+	RegisterTestingT(t)
+
+	spec, err := NewOpenApiViewFromResponseBody([]byte(`
+swagger: "2.0"
+host: petstore.example.com
+basePath: /v1
+schemes: [https]
+produces: [application/json]
+paths:
+  /pets:
+    get:
+      responses:
+        200:
+          schema:
+            $ref: '#/definitions/Pet'
+definitions:
+  Pet:
+    type: object
+`))
+	Expect(err).To(BeNil())
+
+	Expect(spec.OpenApi).To(Equal("2.0"))
+	Expect(spec.Servers).To(Equal([]OpenApiServerView{{Url: "https://petstore.example.com/v1"}}))
+	Expect(spec.Components.Schemas).To(HaveKey("Pet"))
+	Expect(spec.Paths["/pets"].Get.Responses["200"].Content).To(Equal(map[string]OpenApiMediaTypeView{
+		"application/json": {Schema: &OpenApiSchemaView{Ref: "#/definitions/Pet"}},
+	}))
+}
+
+func Test_NewSimulationViewFromOpenApi_BuildsPairForEachOperation(t *testing.T) {
+	RegisterTestingT(t)
+
+	spec, err := NewOpenApiViewFromResponseBody([]byte(petStoreOpenApi))
+	Expect(err).To(BeNil())
+
+	simulation, warnings := NewSimulationViewFromOpenApi(spec)
+	Expect(warnings).To(BeEmpty())
+
+	pairs := simulation.RequestResponsePairs
+	Expect(pairs).To(HaveLen(3))
+
+	Expect(pairs[0].RequestMatcher).To(Equal(RequestMatcherViewV5{
+		Method:      []MatcherViewV5{NewMatcherView(matchers.Exact, "GET")},
+		Path:        []MatcherViewV5{NewMatcherView(matchers.Exact, "/v1/pets")},
+		Destination: []MatcherViewV5{NewMatcherView(matchers.Exact, "petstore.example.com")},
+	}))
+	Expect(pairs[0].Response).To(Equal(ResponseDetailsViewV6{
+		Status:  200,
+		Body:    `[{"id":1,"name":"Rex"}]`,
+		Headers: map[string][]string{"Content-Type": {"application/json"}},
+	}))
+
+	Expect(pairs[1].RequestMatcher.Method).To(Equal([]MatcherViewV5{NewMatcherView(matchers.Exact, "POST")}))
+	Expect(pairs[1].Response).To(Equal(ResponseDetailsViewV6{
+		Status:  201,
+		Headers: map[string][]string{},
+	}))
+
+	Expect(pairs[2].RequestMatcher.Path).To(Equal([]MatcherViewV5{NewMatcherView(matchers.Regex, `^/v1/pets/[^/]+$`)}))
+}
+
+func Test_NewSimulationViewFromOpenApi_GeneratesTemplatedBodyFromSchema(t *testing.T) {
+	RegisterTestingT(t)
+
+	spec, err := NewOpenApiViewFromResponseBody([]byte(petStoreOpenApi))
+	Expect(err).To(BeNil())
+
+	simulation, _ := NewSimulationViewFromOpenApi(spec)
+
+	response := simulation.RequestResponsePairs[2].Response
+	Expect(response.Status).To(Equal(200))
+	Expect(response.Templated).To(BeTrue())
+	Expect(response.Body).To(Equal(`{"born":"2017-01-01","name":"Rex","petId":{{ Request.Path.[2] }},"tags":["string"]}`))
+	Expect(response.Headers).To(Equal(map[string][]string{
+		"Content-Type": {"application/json"},
+		"X-Rate-Limit": {"0"},
+	}))
+}
+
+func Test_NewSimulationViewFromOpenApi_SkipsHeadersWithoutExampleOrTypeAndEncodesObjects(t *testing.T) {
+	RegisterTestingT(t)
+
+	spec, err := NewOpenApiViewFromResponseBody([]byte(`
+openapi: 3.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        200:
+          headers:
+            X-Untyped:
+              description: Has neither an example nor a type
+            X-Object:
+              example:
+                limit: 1
+            X-List:
+              schema:
+                type: array
+                items:
+                  type: string
+`))
+	Expect(err).To(BeNil())
+
+	simulation, warnings := NewSimulationViewFromOpenApi(spec)
+	Expect(warnings).To(Equal([]SimulationImportWarning{
+		{Message: "WARNING: Could not model header X-Untyped of GET /pets, it has no example or type"},
+	}))
+
+	Expect(simulation.RequestResponsePairs).To(HaveLen(1))
+	Expect(simulation.RequestResponsePairs[0].Response.Headers).To(Equal(map[string][]string{
+		"X-Object": {`{"limit":1}`},
+		"X-List":   {`["string"]`},
+	}))
+}
+
+func Test_NewSimulationViewFromOpenApi_WarnsAboutOperationsWhichCannotBeModelled(t *testing.T) {
+	RegisterTestingT(t)
+
+	spec, err := NewOpenApiViewFromResponseBody([]byte(`
+openapi: 3.0.0
+servers:
+  - url: http://{environment}.example.com
+paths:
+  /pets:
+    get:
+      responses:
+        200:
+          content:
+            application/json:
+              schema:
+                $ref: 'pet.yaml#/Pet'
+    delete:
+      responses: {}
+  /owners:
+    get:
+      responses:
+        200:
+          content:
+            application/xml:
+              schema:
+                type: object
+`))
+	Expect(err).To(BeNil())
+
+	simulation, warnings := NewSimulationViewFromOpenApi(spec)
+
+	Expect(simulation.RequestResponsePairs).To(BeEmpty())
+	Expect(warnings).To(Equal([]SimulationImportWarning{
+		{Message: "WARNING: Could not model servers[0].url http://{environment}.example.com as it has variables, any destination will be matched"},
+		{Message: "WARNING: Could not model GET /owners, a body can only be generated from the schema for JSON, not application/xml"},
+		{Message: "WARNING: Could not model GET /pets, could not resolve $ref pet.yaml#/Pet"},
+		{Message: "WARNING: Could not model DELETE /pets, it has no responses"},
+	}))
+}
+
+func Test_NewSimulationViewFromOpenApi_StopsGeneratingRecursiveSchemas(t *testing.T) {
+	RegisterTestingT(t)
+
+	spec, err := NewOpenApiViewFromResponseBody([]byte(`
+openapi: 3.0.0
+paths:
+  /nodes:
+    get:
+      responses:
+        200:
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Node'
+components:
+  schemas:
+    Node:
+      type: object
+      properties:
+        child:
+          $ref: '#/components/schemas/Node'
+`))
+	Expect(err).To(BeNil())
+
+	simulation, warnings := NewSimulationViewFromOpenApi(spec)
+
+	Expect(warnings).To(BeEmpty())
+	Expect(simulation.RequestResponsePairs[0].Response.Body).To(ContainSubstring(`{"child":{"child":`))
+}
+
+func Test_NewSimulationViewFromOpenApi_WarnsAboutCircularRefs(t *testing.T) {
+	RegisterTestingT(t)
+
+	spec, err := NewOpenApiViewFromResponseBody([]byte(`
+openapi: 3.0.0
+paths:
+  /self:
+    get:
+      responses:
+        200:
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Self'
+  /cycle:
+    get:
+      responses:
+        200:
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/A'
+components:
+  schemas:
+    Self:
+      $ref: '#/components/schemas/Self'
+    A:
+      $ref: '#/components/schemas/B'
+    B:
+      $ref: '#/components/schemas/A'
+`))
+	Expect(err).To(BeNil())
+
+	simulation, warnings := NewSimulationViewFromOpenApi(spec)
+
+	Expect(simulation.RequestResponsePairs).To(BeEmpty())
+	Expect(warnings).To(Equal([]SimulationImportWarning{
+		{Message: "WARNING: Could not model GET /cycle, $ref #/components/schemas/A refers back to itself"},
+		{Message: "WARNING: Could not model GET /self, $ref #/components/schemas/Self refers back to itself"},
+	}))
+}
+
+func Test_NewSimulationViewFromOpenApi_WarnsAboutRecursiveAllOf(t *testing.T) {
+	RegisterTestingT(t)
+
+	spec, err := NewOpenApiViewFromResponseBody([]byte(`
+openapi: 3.0.0
+paths:
+  /nodes:
+    get:
+      responses:
+        200:
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Node'
+components:
+  schemas:
+    Node:
+      type: object
+      allOf:
+        - $ref: '#/components/schemas/Node'
+`))
+	Expect(err).To(BeNil())
+
+	simulation, warnings := NewSimulationViewFromOpenApi(spec)
+
+	Expect(simulation.RequestResponsePairs).To(BeEmpty())
+	Expect(warnings).To(Equal([]SimulationImportWarning{
+		{Message: "WARNING: Could not model GET /nodes, allOf is nested more than 10 deep"},
+	}))
+}
@@ -0,0 +1,431 @@
+package v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// bodyRedactor replaces the values at a location in a body with the placeholder, returning
+// whether anything was replaced. Bodies which cannot be parsed are returned unchanged.
+type bodyRedactor func(body, placeholder string) (string, bool)
+
+func newBodyRedactor(rule RedactionRuleView) bodyRedactor {
+	if rule.JsonPath != "" {
+		steps, _ := parseRedactionJsonPath(rule.JsonPath)
+		return func(body, placeholder string) (string, bool) {
+			return redactJsonPath(body, steps, placeholder)
+		}
+	}
+
+	path, _ := parseRedactionXPath(rule.XPath)
+	return func(body, placeholder string) (string, bool) {
+		return redactXPath(body, path, placeholder)
+	}
+}
+
+// jsonPathStep is one step of the JSONPath subset used by redaction rules: a key, an index or
+// a wildcard, which with recursive set also applies to every descendant
+type jsonPathStep struct {
+	key       string
+	index     int
+	wildcard  bool
+	recursive bool
+}
+
+var jsonPathStepPattern = regexp.MustCompile(`^(\.\.|\.)(\*|[^.\[\]]+)|^\[(\*|[0-9]+|'[^']*')\]`)
+
+// parseRedactionJsonPath parses paths such as $.user.password, $.cards[*].number and $..token
+func parseRedactionJsonPath(path string) ([]jsonPathStep, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("JSONPath %s must start with $", path)
+	}
+
+	steps := []jsonPathStep{}
+	rest := path[1:]
+	for rest != "" {
+		match := jsonPathStepPattern.FindStringSubmatch(rest)
+		if match == nil {
+			return nil, fmt.Errorf("JSONPath %s is not supported, only keys, indexes, * and .. can be used", path)
+		}
+		rest = rest[len(match[0]):]
+
+		step := jsonPathStep{index: -1}
+		selector := match[2]
+		if match[1] == "" {
+			selector = match[3]
+		}
+		step.recursive = match[1] == ".."
+
+		switch {
+		case selector == "*":
+			step.wildcard = true
+		case strings.HasPrefix(selector, "'"):
+			step.key = strings.Trim(selector, "'")
+		case match[1] == "":
+			step.index, _ = strconv.Atoi(selector)
+		default:
+			step.key = selector
+		}
+
+		steps = append(steps, step)
+	}
+
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("JSONPath %s does not select a value", path)
+	}
+
+	return steps, nil
+}
+
+func redactJsonPath(body string, steps []jsonPathStep, placeholder string) (string, bool) {
+	return setJsonPathValues(body, steps, placeholder)
+}
+
+// setJsonPathValues replaces each value the steps select in a JSON body with the value given,
+// returning whether anything was replaced
+func setJsonPathValues(body string, steps []jsonPathStep, newValue interface{}) (string, bool) {
+	document, ok := decodeJsonBody(body)
+	if !ok {
+		return body, false
+	}
+
+	root := []interface{}{document}
+	found := false
+	for _, path := range findJsonPaths(document, steps) {
+		if setJsonPath(root, append([]interface{}{0}, path...), newValue) {
+			found = true
+		}
+	}
+	if !found {
+		return body, false
+	}
+
+	return encodeJsonBody(root[0], body)
+}
+
+// selectJsonPaths returns a document with only the values the paths select, keeping the objects and
+// arrays around them, and whether any value was selected
+func selectJsonPaths(body string, paths [][]jsonPathStep) (string, bool) {
+	document, ok := decodeJsonBody(body)
+	if !ok {
+		return body, false
+	}
+
+	selected := [][]interface{}{}
+	for _, steps := range paths {
+		selected = append(selected, findJsonPaths(document, steps)...)
+	}
+	if len(selected) == 0 {
+		return body, false
+	}
+
+	return encodeJsonBody(pruneJson(document, selected), body)
+}
+
+func decodeJsonBody(body string) (interface{}, bool) {
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.UseNumber()
+
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return nil, false
+	}
+
+	return document, true
+}
+
+func encodeJsonBody(document interface{}, body string) (string, bool) {
+	var encoded bytes.Buffer
+	encoder := json.NewEncoder(&encoded)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(document); err != nil {
+		return body, false
+	}
+
+	return strings.TrimSuffix(encoded.String(), "\n"), true
+}
+
+// findJsonPaths returns the keys and indexes leading to each value the steps select in the document,
+// in the order the values appear in it
+func findJsonPaths(node interface{}, steps []jsonPathStep) [][]interface{} {
+	step := steps[0]
+	paths := [][]interface{}{}
+
+	visit := func(key interface{}, child interface{}) {
+		if len(steps) == 1 {
+			paths = append(paths, []interface{}{key})
+			return
+		}
+		for _, path := range findJsonPaths(child, steps[1:]) {
+			paths = append(paths, append([]interface{}{key}, path...))
+		}
+	}
+	descend := func(key interface{}, child interface{}) {
+		for _, path := range findJsonPaths(child, steps) {
+			paths = append(paths, append([]interface{}{key}, path...))
+		}
+	}
+
+	switch value := node.(type) {
+	case map[string]interface{}:
+		for _, key := range sortedJsonKeys(value) {
+			if step.wildcard || (step.index < 0 && key == step.key) {
+				visit(key, value[key])
+			}
+			if step.recursive {
+				descend(key, value[key])
+			}
+		}
+	case []interface{}:
+		for i := range value {
+			if step.wildcard || i == step.index {
+				visit(i, value[i])
+			}
+			if step.recursive {
+				descend(i, value[i])
+			}
+		}
+	}
+
+	return paths
+}
+
+// setJsonPath replaces the value at the end of the path below the parent, returning false when a value
+// along the path has already been replaced
+func setJsonPath(parent interface{}, path []interface{}, childValue interface{}) bool {
+	for _, key := range path[:len(path)-1] {
+		parent = getJsonChild(parent, key)
+	}
+
+	switch value := parent.(type) {
+	case map[string]interface{}:
+		value[path[len(path)-1].(string)] = childValue
+		return true
+	case []interface{}:
+		value[path[len(path)-1].(int)] = childValue
+		return true
+	}
+
+	return false
+}
+
+func getJsonChild(parent interface{}, child interface{}) interface{} {
+	switch value := parent.(type) {
+	case map[string]interface{}:
+		return value[child.(string)]
+	case []interface{}:
+		return value[child.(int)]
+	}
+
+	return nil
+}
+
+// pruneJson returns a copy of the node with only the values at the ends of the paths
+func pruneJson(node interface{}, paths [][]interface{}) interface{} {
+	children := map[interface{}][][]interface{}{}
+	for _, path := range paths {
+		if len(path) == 0 {
+			return node
+		}
+		children[path[0]] = append(children[path[0]], path[1:])
+	}
+
+	switch value := node.(type) {
+	case map[string]interface{}:
+		pruned := map[string]interface{}{}
+		for key, childPaths := range children {
+			pruned[key.(string)] = pruneJson(value[key.(string)], childPaths)
+		}
+		return pruned
+	case []interface{}:
+		pruned := []interface{}{}
+		for i := range value {
+			if childPaths, ok := children[i]; ok {
+				pruned = append(pruned, pruneJson(value[i], childPaths))
+			}
+		}
+		return pruned
+	}
+
+	return node
+}
+
+func sortedJsonKeys(values map[string]interface{}) []string {
+	keys := []string{}
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// xpathStep is one element of the XPath subset used by redaction rules. With descendant set the
+// element can be anywhere below the previous step, as with //.
+type xpathStep struct {
+	name       string
+	descendant bool
+}
+
+type redactionXPath struct {
+	steps     []xpathStep
+	attribute string
+}
+
+var xpathStepPattern = regexp.MustCompile(`^(//|/)(@?)(\*|[A-Za-z_][\w.\-]*(?::[A-Za-z_][\w.\-]*)?)`)
+
+// parseRedactionXPath parses paths such as /user/password, //token and //card/@number
+func parseRedactionXPath(path string) (redactionXPath, error) {
+	xpath := redactionXPath{}
+
+	rest := path
+	for rest != "" {
+		match := xpathStepPattern.FindStringSubmatch(rest)
+		if match == nil || xpath.attribute != "" {
+			return xpath, fmt.Errorf("XPath %s is not supported, only element names, * and a final attribute can be used", path)
+		}
+		rest = rest[len(match[0]):]
+
+		if match[2] == "@" {
+			if match[1] == "//" || len(xpath.steps) == 0 {
+				return xpath, fmt.Errorf("XPath %s must select an attribute of an element", path)
+			}
+			xpath.attribute = localXmlName(match[3])
+			continue
+		}
+
+		xpath.steps = append(xpath.steps, xpathStep{
+			name:       localXmlName(match[3]),
+			descendant: match[1] == "//",
+		})
+	}
+
+	if len(xpath.steps) == 0 {
+		return xpath, fmt.Errorf("XPath %s does not select an element", path)
+	}
+
+	return xpath, nil
+}
+
+func (this redactionXPath) matches(elements []string) bool {
+	return xpathStepsMatch(this.steps, elements)
+}
+
+func xpathStepsMatch(steps []xpathStep, elements []string) bool {
+	if len(steps) == 0 {
+		return len(elements) == 0
+	}
+
+	for i := range elements {
+		if i > 0 && !steps[0].descendant {
+			break
+		}
+		if (steps[0].name == "*" || steps[0].name == elements[i]) && xpathStepsMatch(steps[1:], elements[i+1:]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type xmlReplacement struct {
+	start, end int
+}
+
+// redactXPath replaces the text of the selected elements, or the value of the selected attribute,
+// in place, so the rest of the document is kept exactly as it was
+func redactXPath(body string, xpath redactionXPath, placeholder string) (string, bool) {
+	decoder := xml.NewDecoder(strings.NewReader(body))
+
+	replacements := []xmlReplacement{}
+	elements := []string{}
+	contentStarts := []int{}
+
+	for {
+		tokenStart := int(decoder.InputOffset())
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return body, false
+		}
+		tokenEnd := int(decoder.InputOffset())
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			elements = append(elements, element.Name.Local)
+			contentStarts = append(contentStarts, tokenEnd)
+
+			if xpath.attribute != "" && xpath.matches(elements) {
+				if start, end, ok := findXmlAttributeValue(body[tokenStart:tokenEnd], xpath.attribute); ok {
+					replacements = append(replacements, xmlReplacement{tokenStart + start, tokenStart + end})
+				}
+			}
+		case xml.EndElement:
+			if len(elements) == 0 {
+				return body, false
+			}
+			contentStart := contentStarts[len(contentStarts)-1]
+			if xpath.attribute == "" && xpath.matches(elements) && contentStart < tokenStart {
+				replacements = append(replacements, xmlReplacement{contentStart, tokenStart})
+			}
+			elements = elements[:len(elements)-1]
+			contentStarts = contentStarts[:len(contentStarts)-1]
+		}
+	}
+
+	if len(replacements) == 0 {
+		return body, false
+	}
+
+	sort.Slice(replacements, func(i, j int) bool {
+		return replacements[i].start < replacements[j].start
+	})
+
+	var escapedPlaceholder bytes.Buffer
+	xml.EscapeText(&escapedPlaceholder, []byte(placeholder))
+
+	var redacted bytes.Buffer
+	position := 0
+	for _, replacement := range replacements {
+		// The text of an element inside an element which has already been replaced is gone
+		if replacement.start < position {
+			continue
+		}
+		redacted.WriteString(body[position:replacement.start])
+		redacted.Write(escapedPlaceholder.Bytes())
+		position = replacement.end
+	}
+	redacted.WriteString(body[position:])
+
+	return redacted.String(), true
+}
+
+var xmlAttributePattern = regexp.MustCompile(`([\w.\-:]+)\s*=\s*("[^"]*"|'[^']*')`)
+
+// findXmlAttributeValue returns where the value of the attribute is, between its quotes, in a start tag
+func findXmlAttributeValue(startTag, attribute string) (int, int, bool) {
+	for _, match := range xmlAttributePattern.FindAllStringSubmatchIndex(startTag, -1) {
+		if localXmlName(startTag[match[2]:match[3]]) == attribute {
+			return match[4] + 1, match[5] - 1, true
+		}
+	}
+
+	return 0, 0, false
+}
+
+func localXmlName(name string) string {
+	if i := strings.LastIndex(name, ":"); i >= 0 {
+		return name[i+1:]
+	}
+
+	return name
+}
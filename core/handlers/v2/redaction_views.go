@@ -0,0 +1,379 @@
+package v2
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+)
+
+const DefaultRedactionPlaceholder = "REDACTED"
+
+// RedactionRuleView describes values which should not be kept in a simulation. Exactly one of
+// header, query, jsonPath, xpath and regex is given. Redacted values are replaced with the
+// placeholder, or with a matcher accepting any value in request matchers when anyValue is set.
+type RedactionRuleView struct {
+	Header      string `json:"header,omitempty"`
+	Query       string `json:"query,omitempty"`
+	JsonPath    string `json:"jsonPath,omitempty"`
+	XPath       string `json:"xpath,omitempty"`
+	Regex       string `json:"regex,omitempty"`
+	Placeholder string `json:"placeholder,omitempty"`
+	AnyValue    bool   `json:"anyValue,omitempty"`
+}
+
+// NewRedactionRuleViewFromString parses rules given on the command line, such as
+// header:Authorization, query:token, jsonpath:$.password, xpath://password or regex:[0-9]{16}
+func NewRedactionRuleViewFromString(rule string, anyValue bool) (RedactionRuleView, error) {
+	ruleView := RedactionRuleView{AnyValue: anyValue}
+
+	parts := strings.SplitN(rule, ":", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return ruleView, fmt.Errorf("Redaction rule %s must be given as <type>:<location>", rule)
+	}
+
+	switch strings.ToLower(parts[0]) {
+	case "header":
+		ruleView.Header = parts[1]
+	case "query":
+		ruleView.Query = parts[1]
+	case "jsonpath":
+		ruleView.JsonPath = parts[1]
+	case "xpath":
+		ruleView.XPath = parts[1]
+	case "regex":
+		ruleView.Regex = parts[1]
+	default:
+		return ruleView, fmt.Errorf("Redaction rule %s has an unknown type, expected header, query, jsonpath, xpath or regex", rule)
+	}
+
+	return ruleView, ruleView.Validate()
+}
+
+func (this RedactionRuleView) Validate() error {
+	locations := 0
+	for _, location := range []string{this.Header, this.Query, this.JsonPath, this.XPath, this.Regex} {
+		if location != "" {
+			locations++
+		}
+	}
+	if locations != 1 {
+		return fmt.Errorf("A redaction rule must have exactly one of header, query, jsonPath, xpath or regex")
+	}
+
+	var err error
+	switch {
+	case this.JsonPath != "":
+		_, err = parseRedactionJsonPath(this.JsonPath)
+	case this.XPath != "":
+		_, err = parseRedactionXPath(this.XPath)
+	case this.Regex != "":
+		_, err = regexp.Compile(this.Regex)
+	}
+	if err != nil {
+		return fmt.Errorf("Redaction rule has an invalid location: %s", err.Error())
+	}
+
+	return nil
+}
+
+func (this RedactionRuleView) placeholder() string {
+	if this.Placeholder == "" {
+		return DefaultRedactionPlaceholder
+	}
+
+	return this.Placeholder
+}
+
+// RedactionRule is a redaction rule with its location parsed or compiled, which is done once
+// when the rule is set rather than for every pair it is applied to
+type RedactionRule struct {
+	RedactionRuleView
+
+	regex      *regexp.Regexp
+	redactBody bodyRedactor
+}
+
+func NewRedactionRule(view RedactionRuleView) (*RedactionRule, error) {
+	if err := view.Validate(); err != nil {
+		return nil, err
+	}
+
+	rule := &RedactionRule{RedactionRuleView: view}
+	switch {
+	case view.Regex != "":
+		rule.regex = regexp.MustCompile(view.Regex)
+	case view.JsonPath != "" || view.XPath != "":
+		rule.redactBody = newBodyRedactor(view)
+	}
+
+	return rule, nil
+}
+
+// NewRedactionRules compiles each of the rules, failing on the first which is not valid
+func NewRedactionRules(views []RedactionRuleView) ([]*RedactionRule, error) {
+	rules := []*RedactionRule{}
+	for _, view := range views {
+		rule, err := NewRedactionRule(view)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// RedactionRuleViews returns the views of the rules
+func RedactionRuleViews(rules []*RedactionRule) []RedactionRuleView {
+	if rules == nil {
+		return nil
+	}
+
+	views := []RedactionRuleView{}
+	for _, rule := range rules {
+		views = append(views, rule.RedactionRuleView)
+	}
+
+	return views
+}
+
+// RedactSimulationView applies the redaction rules to every pair of a simulation
+func RedactSimulationView(simulation SimulationViewV6, rules []*RedactionRule) SimulationViewV6 {
+	if len(rules) == 0 {
+		return simulation
+	}
+
+	pairs := []RequestMatcherResponsePairViewV6{}
+	for _, pair := range simulation.RequestResponsePairs {
+		pairs = append(pairs, RedactPairView(pair, rules))
+	}
+	simulation.RequestResponsePairs = pairs
+
+	return simulation
+}
+
+// RedactPairView applies the redaction rules to the request matcher and response of a pair. Only
+// exact, json, jsonpartial and xml matchers hold the values of a request, so matchers of other
+// types are kept as they are. Encoded response bodies are not redacted.
+func RedactPairView(pair RequestMatcherResponsePairViewV6, rules []*RedactionRule) RequestMatcherResponsePairViewV6 {
+	pair.RequestMatcher = copyRequestMatcherView(pair.RequestMatcher)
+	pair.Response.Headers = copyHeaders(pair.Response.Headers)
+	body := pair.Response.Body
+
+	for _, rule := range rules {
+		switch {
+		case rule.Header != "":
+			redactHeaderMatchers(pair.RequestMatcher.Headers, rule.RedactionRuleView)
+			for name := range pair.Response.Headers {
+				if strings.EqualFold(name, rule.Header) {
+					pair.Response.Headers[name] = redactedValues(pair.Response.Headers[name], rule.placeholder())
+				}
+			}
+		case rule.Query != "":
+			redactQueryMatchers(&pair.RequestMatcher, rule.RedactionRuleView)
+		case rule.Regex != "":
+			redactRegex(&pair, rule)
+		default:
+			pair.RequestMatcher.Body = redactBodyMatchers(pair.RequestMatcher.Body, rule.RedactionRuleView, rule.redactBody)
+			if !pair.Response.EncodedBody {
+				pair.Response.Body, _ = rule.redactBody(pair.Response.Body, rule.placeholder())
+			}
+		}
+	}
+
+	if pair.Response.Body != body {
+		// the length of the body is worked out again when it is written
+		deleteHeader(pair.Response.Headers, "Content-Length")
+	}
+
+	return pair
+}
+
+func redactHeaderMatchers(headers map[string][]MatcherViewV5, rule RedactionRuleView) {
+	for name, headerMatchers := range headers {
+		if strings.EqualFold(name, rule.Header) {
+			headers[name] = redactFieldMatchers(headerMatchers, rule)
+		}
+	}
+}
+
+func redactQueryMatchers(requestMatcher *RequestMatcherViewV5, rule RedactionRuleView) {
+	if requestMatcher.Query != nil {
+		for key, queryMatchers := range *requestMatcher.Query {
+			if key == rule.Query {
+				(*requestMatcher.Query)[key] = redactFieldMatchers(queryMatchers, rule)
+			}
+		}
+	}
+
+	// The deprecated query holds the whole query string, so the value is always replaced with the placeholder
+	for i, matcher := range requestMatcher.DeprecatedQuery {
+		value, ok := matcher.Value.(string)
+		if !ok || matcher.Matcher != matchers.Exact {
+			continue
+		}
+		query, err := url.ParseQuery(value)
+		if err != nil || query[rule.Query] == nil {
+			continue
+		}
+		query[rule.Query] = redactedValues(query[rule.Query], rule.placeholder())
+		requestMatcher.DeprecatedQuery[i].Value = query.Encode()
+	}
+}
+
+// redactFieldMatchers replaces the values of the exact matchers of a header or query key
+func redactFieldMatchers(fieldMatchers []MatcherViewV5, rule RedactionRuleView) []MatcherViewV5 {
+	if rule.AnyValue {
+		return []MatcherViewV5{NewMatcherView(matchers.Glob, "*")}
+	}
+
+	redacted := []MatcherViewV5{}
+	for _, matcher := range fieldMatchers {
+		if matcher.Matcher == matchers.Exact {
+			matcher.Value = rule.placeholder()
+		}
+		redacted = append(redacted, matcher)
+	}
+
+	return redacted
+}
+
+func redactBodyMatchers(bodyMatchers []MatcherViewV5, rule RedactionRuleView, redactBody bodyRedactor) []MatcherViewV5 {
+	if bodyMatchers == nil {
+		return nil
+	}
+
+	redacted := []MatcherViewV5{}
+	for _, matcher := range bodyMatchers {
+		value, ok := matcher.Value.(string)
+		if ok && isValueMatcher(matcher) {
+			if redactedValue, changed := redactBody(value, rule.placeholder()); changed {
+				if rule.AnyValue {
+					return []MatcherViewV5{NewMatcherView(matchers.Glob, "*")}
+				}
+				matcher.Value = redactedValue
+			}
+		}
+		redacted = append(redacted, matcher)
+	}
+
+	return redacted
+}
+
+func redactRegex(pair *RequestMatcherResponsePairViewV6, rule *RedactionRule) {
+	expression := rule.regex
+
+	redactMatchers := func(fieldMatchers []MatcherViewV5) []MatcherViewV5 {
+		if fieldMatchers == nil {
+			return nil
+		}
+
+		redacted := []MatcherViewV5{}
+		for _, matcher := range fieldMatchers {
+			value, ok := matcher.Value.(string)
+			if ok && isValueMatcher(matcher) && expression.MatchString(value) {
+				switch {
+				case !rule.AnyValue:
+					matcher.Value = expression.ReplaceAllLiteralString(value, rule.placeholder())
+				case matcher.Matcher == matchers.Exact:
+					matcher = NewMatcherView(matchers.Glob, expression.ReplaceAllLiteralString(value, "*"))
+				default:
+					matcher = NewMatcherView(matchers.Glob, "*")
+				}
+			}
+			redacted = append(redacted, matcher)
+		}
+
+		return redacted
+	}
+
+	requestMatcher := &pair.RequestMatcher
+	requestMatcher.Path = redactMatchers(requestMatcher.Path)
+	requestMatcher.Destination = redactMatchers(requestMatcher.Destination)
+	requestMatcher.Body = redactMatchers(requestMatcher.Body)
+	requestMatcher.DeprecatedQuery = redactMatchers(requestMatcher.DeprecatedQuery)
+	for name, headerMatchers := range requestMatcher.Headers {
+		requestMatcher.Headers[name] = redactMatchers(headerMatchers)
+	}
+	if requestMatcher.Query != nil {
+		for key, queryMatchers := range *requestMatcher.Query {
+			(*requestMatcher.Query)[key] = redactMatchers(queryMatchers)
+		}
+	}
+
+	for name, values := range pair.Response.Headers {
+		redacted := []string{}
+		for _, value := range values {
+			redacted = append(redacted, expression.ReplaceAllLiteralString(value, rule.placeholder()))
+		}
+		pair.Response.Headers[name] = redacted
+	}
+	if !pair.Response.EncodedBody {
+		pair.Response.Body = expression.ReplaceAllLiteralString(pair.Response.Body, rule.placeholder())
+	}
+}
+
+func isValueMatcher(matcher MatcherViewV5) bool {
+	return matcher.Matcher == matchers.Exact || matcher.Matcher == matchers.Json || matcher.Matcher == matchers.JsonPartial || matcher.Matcher == matchers.Xml
+}
+
+func redactedValues(values []string, placeholder string) []string {
+	redacted := []string{}
+	for range values {
+		redacted = append(redacted, placeholder)
+	}
+
+	return redacted
+}
+
+// copyRequestMatcherView copies the maps and slices of a request matcher, so a redacted pair does
+// not share them with the pair it was redacted from
+func copyRequestMatcherView(requestMatcher RequestMatcherViewV5) RequestMatcherViewV5 {
+	copyMatchers := func(fieldMatchers []MatcherViewV5) []MatcherViewV5 {
+		if fieldMatchers == nil {
+			return nil
+		}
+		return append([]MatcherViewV5{}, fieldMatchers...)
+	}
+
+	requestMatcher.Path = copyMatchers(requestMatcher.Path)
+	requestMatcher.Method = copyMatchers(requestMatcher.Method)
+	requestMatcher.Destination = copyMatchers(requestMatcher.Destination)
+	requestMatcher.Scheme = copyMatchers(requestMatcher.Scheme)
+	requestMatcher.Body = copyMatchers(requestMatcher.Body)
+	requestMatcher.DeprecatedQuery = copyMatchers(requestMatcher.DeprecatedQuery)
+
+	if requestMatcher.Headers != nil {
+		headers := map[string][]MatcherViewV5{}
+		for name, headerMatchers := range requestMatcher.Headers {
+			headers[name] = copyMatchers(headerMatchers)
+		}
+		requestMatcher.Headers = headers
+	}
+
+	if requestMatcher.Query != nil {
+		query := QueryMatcherViewV5{}
+		for key, queryMatchers := range *requestMatcher.Query {
+			query[key] = copyMatchers(queryMatchers)
+		}
+		requestMatcher.Query = &query
+	}
+
+	return requestMatcher
+}
+
+func copyHeaders(headers map[string][]string) map[string][]string {
+	if headers == nil {
+		return nil
+	}
+
+	copied := map[string][]string{}
+	for name, values := range headers {
+		copied[name] = append([]string{}, values...)
+	}
+
+	return copied
+}
@@ -0,0 +1,344 @@
+package v2
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	. "github.com/onsi/gomega"
+)
+
+func mustRedactionRules(views []RedactionRuleView) []*RedactionRule {
+	rules, err := NewRedactionRules(views)
+	Expect(err).To(BeNil())
+
+	return rules
+}
+
+func Test_NewRedactionRuleViewFromString_ParsesEachType(t *testing.T) {
+	RegisterTestingT(t)
+
+	rule, err := NewRedactionRuleViewFromString("header:Authorization", false)
+	Expect(err).To(BeNil())
+	Expect(rule).To(Equal(RedactionRuleView{Header: "Authorization"}))
+
+	rule, err = NewRedactionRuleViewFromString("query:token", true)
+	Expect(err).To(BeNil())
+	Expect(rule).To(Equal(RedactionRuleView{Query: "token", AnyValue: true}))
+
+	rule, err = NewRedactionRuleViewFromString("jsonpath:$.user.password", false)
+	Expect(err).To(BeNil())
+	Expect(rule).To(Equal(RedactionRuleView{JsonPath: "$.user.password"}))
+
+	rule, err = NewRedactionRuleViewFromString("xpath://card/@number", false)
+	Expect(err).To(BeNil())
+	Expect(rule).To(Equal(RedactionRuleView{XPath: "//card/@number"}))
+
+	rule, err = NewRedactionRuleViewFromString("regex:[0-9]{4}:[0-9]{4}", false)
+	Expect(err).To(BeNil())
+	Expect(rule).To(Equal(RedactionRuleView{Regex: "[0-9]{4}:[0-9]{4}"}))
+}
+
+func Test_NewRedactionRuleViewFromString_ErrorsOnInvalidRules(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := NewRedactionRuleViewFromString("Authorization", false)
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Redaction rule Authorization must be given as <type>:<location>"))
+
+	_, err = NewRedactionRuleViewFromString("cookie:session", false)
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Redaction rule cookie:session has an unknown type, expected header, query, jsonpath, xpath or regex"))
+
+	_, err = NewRedactionRuleViewFromString("regex:[0-9", false)
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(HavePrefix("Redaction rule has an invalid location: "))
+
+	_, err = NewRedactionRuleViewFromString("jsonpath:$.cards[?(@.number)]", false)
+	Expect(err).ToNot(BeNil())
+
+	_, err = NewRedactionRuleViewFromString("xpath:/user[1]", false)
+	Expect(err).ToNot(BeNil())
+}
+
+func Test_RedactionRuleView_Validate_RequiresExactlyOneLocation(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(RedactionRuleView{}.Validate()).ToNot(BeNil())
+	Expect(RedactionRuleView{Header: "Authorization", Query: "token"}.Validate()).ToNot(BeNil())
+	Expect(RedactionRuleView{Header: "Authorization"}.Validate()).To(BeNil())
+}
+
+func Test_NewRedactionRules_ReturnsErrorForInvalidRule(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := NewRedactionRules([]RedactionRuleView{{Header: "Authorization"}, {Regex: "["}})
+
+	Expect(err).ToNot(BeNil())
+}
+
+func Test_RedactPairView_ReplacesHeaderValuesWithPlaceholder(t *testing.T) {
+	RegisterTestingT(t)
+
+	pair := RequestMatcherResponsePairViewV6{
+		RequestMatcher: RequestMatcherViewV5{
+			Headers: map[string][]MatcherViewV5{
+				"authorization": []MatcherViewV5{NewMatcherView(matchers.Exact, "Bearer secret")},
+				"Accept":        []MatcherViewV5{NewMatcherView(matchers.Exact, "application/json")},
+			},
+		},
+		Response: ResponseDetailsViewV6{
+			Headers: map[string][]string{
+				"Authorization": []string{"Bearer secret"},
+			},
+		},
+	}
+
+	redacted := RedactPairView(pair, mustRedactionRules([]RedactionRuleView{{Header: "Authorization"}}))
+
+	Expect(redacted.RequestMatcher.Headers["authorization"]).To(Equal([]MatcherViewV5{NewMatcherView(matchers.Exact, "REDACTED")}))
+	Expect(redacted.RequestMatcher.Headers["Accept"]).To(Equal([]MatcherViewV5{NewMatcherView(matchers.Exact, "application/json")}))
+	Expect(redacted.Response.Headers["Authorization"]).To(Equal([]string{"REDACTED"}))
+
+	Expect(pair.RequestMatcher.Headers["authorization"][0].Value).To(Equal("Bearer secret"))
+	Expect(pair.Response.Headers["Authorization"]).To(Equal([]string{"Bearer secret"}))
+}
+
+func Test_RedactPairView_ReplacesQueryMatcherWithGlobWhenAnyValue(t *testing.T) {
+	RegisterTestingT(t)
+
+	pair := RequestMatcherResponsePairViewV6{
+		RequestMatcher: RequestMatcherViewV5{
+			Query: &QueryMatcherViewV5{
+				"token": []MatcherViewV5{NewMatcherView(matchers.Exact, "secret")},
+				"page":  []MatcherViewV5{NewMatcherView(matchers.Exact, "1")},
+			},
+			DeprecatedQuery: []MatcherViewV5{NewMatcherView(matchers.Exact, "page=1&token=secret")},
+		},
+	}
+
+	redacted := RedactPairView(pair, mustRedactionRules([]RedactionRuleView{{Query: "token", AnyValue: true}}))
+
+	Expect((*redacted.RequestMatcher.Query)["token"]).To(Equal([]MatcherViewV5{NewMatcherView(matchers.Glob, "*")}))
+	Expect((*redacted.RequestMatcher.Query)["page"]).To(Equal([]MatcherViewV5{NewMatcherView(matchers.Exact, "1")}))
+	Expect(redacted.RequestMatcher.DeprecatedQuery[0].Value).To(Equal("page=1&token=REDACTED"))
+}
+
+func Test_RedactPairView_ReplacesJsonPathInBodies(t *testing.T) {
+	RegisterTestingT(t)
+
+	pair := RequestMatcherResponsePairViewV6{
+		RequestMatcher: RequestMatcherViewV5{
+			Body: []MatcherViewV5{NewMatcherView(matchers.Json, `{"user": {"name": "Ben", "password": "secret"}}`)},
+		},
+		Response: ResponseDetailsViewV6{
+			Body: `{"cards": [{"number": 4111, "type": "visa"}, {"number": 5500, "type": "<mastercard>"}]}`,
+		},
+	}
+
+	redacted := RedactPairView(pair, mustRedactionRules([]RedactionRuleView{
+		{JsonPath: "$.user.password"},
+		{JsonPath: "$.cards[*].number", Placeholder: "XXXX"},
+	}))
+
+	Expect(redacted.RequestMatcher.Body[0].Matcher).To(Equal(matchers.Json))
+	Expect(redacted.RequestMatcher.Body[0].Value).To(Equal(`{"user":{"name":"Ben","password":"REDACTED"}}`))
+	Expect(redacted.Response.Body).To(Equal(`{"cards":[{"number":"XXXX","type":"visa"},{"number":"XXXX","type":"<mastercard>"}]}`))
+}
+
+func Test_RedactPairView_ReplacesRecursiveJsonPath(t *testing.T) {
+	RegisterTestingT(t)
+
+	pair := RequestMatcherResponsePairViewV6{
+		Response: ResponseDetailsViewV6{
+			Body: `{"token": "a", "session": {"token": "b", "items": [{"token": "c"}]}}`,
+		},
+	}
+
+	redacted := RedactPairView(pair, mustRedactionRules([]RedactionRuleView{{JsonPath: "$..token"}}))
+
+	Expect(redacted.Response.Body).To(Equal(`{"session":{"items":[{"token":"REDACTED"}],"token":"REDACTED"},"token":"REDACTED"}`))
+}
+
+func Test_RedactPairView_LeavesBodyUnchangedWhenJsonPathIsNotFound(t *testing.T) {
+	RegisterTestingT(t)
+
+	pair := RequestMatcherResponsePairViewV6{
+		RequestMatcher: RequestMatcherViewV5{
+			Body: []MatcherViewV5{NewMatcherView(matchers.Exact, "not json")},
+		},
+		Response: ResponseDetailsViewV6{
+			Body: `{ "name": "Ben" }`,
+		},
+	}
+
+	redacted := RedactPairView(pair, mustRedactionRules([]RedactionRuleView{{JsonPath: "$.password", AnyValue: true}}))
+
+	Expect(redacted.RequestMatcher.Body).To(Equal([]MatcherViewV5{NewMatcherView(matchers.Exact, "not json")}))
+	Expect(redacted.Response.Body).To(Equal(`{ "name": "Ben" }`))
+}
+
+func Test_RedactPairView_RemovesContentLengthWhenBodyChanges(t *testing.T) {
+	RegisterTestingT(t)
+
+	pair := RequestMatcherResponsePairViewV6{
+		Response: ResponseDetailsViewV6{
+			Body: `{"password": "hunter2", "user": "bob"}`,
+			Headers: map[string][]string{
+				"Content-Length": {"38"},
+				"Content-Type":   {"application/json"},
+			},
+		},
+	}
+
+	redacted := RedactPairView(pair, mustRedactionRules([]RedactionRuleView{{JsonPath: "$.password"}}))
+
+	Expect(redacted.Response.Body).To(Equal(`{"password":"REDACTED","user":"bob"}`))
+	Expect(redacted.Response.Headers).To(Equal(map[string][]string{
+		"Content-Type": {"application/json"},
+	}))
+	Expect(pair.Response.Headers).To(HaveKey("Content-Length"))
+}
+
+func Test_RedactPairView_KeepsContentLengthWhenBodyIsUnchanged(t *testing.T) {
+	RegisterTestingT(t)
+
+	pair := RequestMatcherResponsePairViewV6{
+		Response: ResponseDetailsViewV6{
+			Body: `{"user": "bob"}`,
+			Headers: map[string][]string{
+				"Content-Length": {"15"},
+			},
+		},
+	}
+
+	redacted := RedactPairView(pair, mustRedactionRules([]RedactionRuleView{{JsonPath: "$.password"}}))
+
+	Expect(redacted.Response.Headers).To(HaveKeyWithValue("Content-Length", []string{"15"}))
+}
+
+func Test_RedactPairView_ReplacesBodyMatcherWithGlobWhenAnyValue(t *testing.T) {
+	RegisterTestingT(t)
+
+	pair := RequestMatcherResponsePairViewV6{
+		RequestMatcher: RequestMatcherViewV5{
+			Body: []MatcherViewV5{NewMatcherView(matchers.Json, `{"password": "secret"}`)},
+		},
+		Response: ResponseDetailsViewV6{
+			Body: `{"password": "secret"}`,
+		},
+	}
+
+	redacted := RedactPairView(pair, mustRedactionRules([]RedactionRuleView{{JsonPath: "$.password", AnyValue: true}}))
+
+	Expect(redacted.RequestMatcher.Body).To(Equal([]MatcherViewV5{NewMatcherView(matchers.Glob, "*")}))
+	Expect(redacted.Response.Body).To(Equal(`{"password":"REDACTED"}`))
+}
+
+func Test_RedactPairView_ReplacesXPathInBodiesInPlace(t *testing.T) {
+	RegisterTestingT(t)
+
+	pair := RequestMatcherResponsePairViewV6{
+		RequestMatcher: RequestMatcherViewV5{
+			Body: []MatcherViewV5{NewMatcherView(matchers.Xml, "<user>\n  <name>Ben</name>\n  <password>secret</password>\n</user>")},
+		},
+		Response: ResponseDetailsViewV6{
+			Body: `<cards><card number="4111" type='visa'/><card number="5500"><owner>Ben</owner></card></cards>`,
+		},
+	}
+
+	redacted := RedactPairView(pair, mustRedactionRules([]RedactionRuleView{
+		{XPath: "/user/password"},
+		{XPath: "//card/@number", Placeholder: "<hidden>"},
+	}))
+
+	Expect(redacted.RequestMatcher.Body[0].Value).To(Equal("<user>\n  <name>Ben</name>\n  <password>REDACTED</password>\n</user>"))
+	Expect(redacted.Response.Body).To(Equal(`<cards><card number="&lt;hidden&gt;" type='visa'/><card number="&lt;hidden&gt;"><owner>Ben</owner></card></cards>`))
+}
+
+func Test_RedactPairView_ReplacesTextOfDescendantElements(t *testing.T) {
+	RegisterTestingT(t)
+
+	pair := RequestMatcherResponsePairViewV6{
+		Response: ResponseDetailsViewV6{
+			Body: `<a><token>one</token><b><token>two</token></b><token/></a>`,
+		},
+	}
+
+	redacted := RedactPairView(pair, mustRedactionRules([]RedactionRuleView{{XPath: "//token"}}))
+
+	Expect(redacted.Response.Body).To(Equal(`<a><token>REDACTED</token><b><token>REDACTED</token></b><token/></a>`))
+}
+
+func Test_RedactPairView_ReplacesRegexMatches(t *testing.T) {
+	RegisterTestingT(t)
+
+	pair := RequestMatcherResponsePairViewV6{
+		RequestMatcher: RequestMatcherViewV5{
+			Path: []MatcherViewV5{NewMatcherView(matchers.Exact, "/cards/4111111111111111")},
+			Body: []MatcherViewV5{NewMatcherView(matchers.Regex, "[0-9]{16}")},
+		},
+		Response: ResponseDetailsViewV6{
+			Body: `{"number": "4111111111111111"}`,
+			Headers: map[string][]string{
+				"Location": []string{"/cards/4111111111111111"},
+			},
+		},
+	}
+
+	redacted := RedactPairView(pair, mustRedactionRules([]RedactionRuleView{{Regex: "[0-9]{16}"}}))
+
+	Expect(redacted.RequestMatcher.Path).To(Equal([]MatcherViewV5{NewMatcherView(matchers.Exact, "/cards/REDACTED")}))
+	Expect(redacted.RequestMatcher.Body).To(Equal([]MatcherViewV5{NewMatcherView(matchers.Regex, "[0-9]{16}")}))
+	Expect(redacted.Response.Body).To(Equal(`{"number": "REDACTED"}`))
+	Expect(redacted.Response.Headers["Location"]).To(Equal([]string{"/cards/REDACTED"}))
+}
+
+func Test_RedactPairView_ReplacesRegexMatchesWithGlobWhenAnyValue(t *testing.T) {
+	RegisterTestingT(t)
+
+	pair := RequestMatcherResponsePairViewV6{
+		RequestMatcher: RequestMatcherViewV5{
+			Path: []MatcherViewV5{NewMatcherView(matchers.Exact, "/cards/4111111111111111")},
+			Body: []MatcherViewV5{NewMatcherView(matchers.Json, `{"number": "4111111111111111"}`)},
+		},
+	}
+
+	redacted := RedactPairView(pair, mustRedactionRules([]RedactionRuleView{{Regex: "[0-9]{16}", AnyValue: true}}))
+
+	Expect(redacted.RequestMatcher.Path).To(Equal([]MatcherViewV5{NewMatcherView(matchers.Glob, "/cards/*")}))
+	Expect(redacted.RequestMatcher.Body).To(Equal([]MatcherViewV5{NewMatcherView(matchers.Glob, "*")}))
+}
+
+func Test_RedactPairView_DoesNotRedactEncodedBodies(t *testing.T) {
+	RegisterTestingT(t)
+
+	pair := RequestMatcherResponsePairViewV6{
+		Response: ResponseDetailsViewV6{
+			Body:        "MTIzNA==",
+			EncodedBody: true,
+		},
+	}
+
+	redacted := RedactPairView(pair, mustRedactionRules([]RedactionRuleView{{Regex: "M"}}))
+
+	Expect(redacted.Response.Body).To(Equal("MTIzNA=="))
+}
+
+func Test_RedactSimulationView_RedactsEveryPair(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation := SimulationViewV6{
+		DataViewV6: DataViewV6{
+			RequestResponsePairs: []RequestMatcherResponsePairViewV6{
+				{Response: ResponseDetailsViewV6{Body: "secret one"}},
+				{Response: ResponseDetailsViewV6{Body: "secret two"}},
+			},
+		},
+	}
+
+	redacted := RedactSimulationView(simulation, mustRedactionRules([]RedactionRuleView{{Regex: "secret"}}))
+
+	Expect(redacted.RequestResponsePairs[0].Response.Body).To(Equal("REDACTED one"))
+	Expect(redacted.RequestResponsePairs[1].Response.Body).To(Equal("REDACTED two"))
+	Expect(simulation.RequestResponsePairs[0].Response.Body).To(Equal("secret one"))
+}
@@ -0,0 +1,55 @@
+package v2
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers"
+	"github.com/codegangsta/negroni"
+	"github.com/go-zoo/bone"
+)
+
+type HoverflySimulationCoverage interface {
+	GetCoverage() SimulationCoverageView
+	ResetCoverage()
+}
+
+type SimulationCoverageHandler struct {
+	Hoverfly HoverflySimulationCoverage
+}
+
+func (this *SimulationCoverageHandler) RegisterRoutes(mux *bone.Mux, am *handlers.AuthHandler) {
+	mux.Get("/api/v2/simulation/coverage", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Get),
+	))
+	mux.Delete("/api/v2/simulation/coverage", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Delete),
+	))
+	mux.Options("/api/v2/simulation/coverage", negroni.New(
+		negroni.HandlerFunc(this.Options),
+	))
+}
+
+func (this *SimulationCoverageHandler) Get(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	bytes, err := json.Marshal(this.Hoverfly.GetCoverage())
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	handlers.WriteResponse(w, bytes)
+}
+
+// Delete resets the hits of every pair and delay to zero
+func (this *SimulationCoverageHandler) Delete(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	this.Hoverfly.ResetCoverage()
+
+	this.Get(w, req, next)
+}
+
+func (this *SimulationCoverageHandler) Options(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	w.Header().Add("Allow", "OPTIONS, GET, DELETE")
+	handlers.WriteResponse(w, []byte(""))
+}
@@ -0,0 +1,139 @@
+package v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v1"
+	. "github.com/onsi/gomega"
+)
+
+type HoverflySimulationCoverageStub struct {
+	Reset bool
+}
+
+func (this *HoverflySimulationCoverageStub) GetCoverage() SimulationCoverageView {
+	hits := 3
+	if this.Reset {
+		hits = 0
+	}
+
+	return SimulationCoverageView{
+		Since: "2017-07-17T10:41:59Z",
+		Pairs: []PairCoverageView{
+			{
+				Hits: hits,
+				RequestMatcherResponsePairViewV6: RequestMatcherResponsePairViewV6{
+					Id: "get-payment",
+					Response: ResponseDetailsViewV6{
+						Body: "settled",
+					},
+				},
+			},
+		},
+		Delays: []DelayCoverageView{
+			{
+				Hits: hits,
+				ResponseDelayView: v1.ResponseDelayView{
+					UrlPattern: "test.com",
+					Delay:      100,
+				},
+			},
+		},
+	}
+}
+
+func (this *HoverflySimulationCoverageStub) ResetCoverage() {
+	this.Reset = true
+}
+
+func Test_SimulationCoverageHandler_Get_ReturnsCoverage(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := SimulationCoverageHandler{Hoverfly: &HoverflySimulationCoverageStub{}}
+
+	request, err := http.NewRequest("GET", "/api/v2/simulation/coverage", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Get, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	coverageView, err := unmarshalSimulationCoverageView(response.Body)
+	Expect(err).To(BeNil())
+
+	Expect(coverageView.Since).To(Equal("2017-07-17T10:41:59Z"))
+	Expect(coverageView.Pairs).To(HaveLen(1))
+	Expect(coverageView.Pairs[0].Hits).To(Equal(3))
+	Expect(coverageView.Pairs[0].Id).To(Equal("get-payment"))
+	Expect(coverageView.Pairs[0].Response.Body).To(Equal("settled"))
+	Expect(coverageView.Delays).To(HaveLen(1))
+	Expect(coverageView.Delays[0].Hits).To(Equal(3))
+	Expect(coverageView.Delays[0].UrlPattern).To(Equal("test.com"))
+}
+
+func Test_SimulationCoverageHandler_Get_FlattensPairsAndDelaysInJson(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := SimulationCoverageHandler{Hoverfly: &HoverflySimulationCoverageStub{}}
+
+	request, err := http.NewRequest("GET", "/api/v2/simulation/coverage", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Get, request)
+
+	Expect(response.Body.String()).To(ContainSubstring(`{"hits":3,"id":"get-payment",`))
+	Expect(response.Body.String()).To(ContainSubstring(`{"hits":3,"urlPattern":"test.com",`))
+}
+
+func Test_SimulationCoverageHandler_Delete_ResetsCoverage(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationCoverageStub{}
+	unit := SimulationCoverageHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("DELETE", "/api/v2/simulation/coverage", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Delete, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(stubHoverfly.Reset).To(BeTrue())
+
+	coverageView, err := unmarshalSimulationCoverageView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(coverageView.Pairs[0].Hits).To(Equal(0))
+}
+
+func Test_SimulationCoverageHandler_Options_GetsOptions(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := SimulationCoverageHandler{Hoverfly: &HoverflySimulationCoverageStub{}}
+
+	request, err := http.NewRequest("OPTIONS", "/api/v2/simulation/coverage", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Options, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(response.Header().Get("Allow")).To(Equal("OPTIONS, GET, DELETE"))
+}
+
+func unmarshalSimulationCoverageView(buffer *bytes.Buffer) (SimulationCoverageView, error) {
+	body, err := ioutil.ReadAll(buffer)
+	if err != nil {
+		return SimulationCoverageView{}, err
+	}
+
+	var coverageView SimulationCoverageView
+
+	err = json.Unmarshal(body, &coverageView)
+	if err != nil {
+		return SimulationCoverageView{}, err
+	}
+
+	return coverageView, nil
+}
@@ -0,0 +1,80 @@
+package v2
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const DefaultCurlProxy = "http://localhost:8500"
+
+// NewCurlScriptFromSimulation writes a shell script with a curl command for each pair whose request
+// matcher only uses exact matchers, sending the request the pair matches through the given Hoverfly
+// proxy. The proxy can be changed when the script is run with the HOVERFLY_PROXY environment variable.
+func NewCurlScriptFromSimulation(simulation SimulationViewV6, proxy string) []byte {
+	if proxy == "" {
+		proxy = DefaultCurlProxy
+	}
+
+	var script bytes.Buffer
+	script.WriteString("#!/bin/sh\n")
+	script.WriteString("# Requests for the pairs of a Hoverfly simulation, sent through the Hoverfly proxy\n")
+	fmt.Fprintf(&script, "HOVERFLY_PROXY=${HOVERFLY_PROXY:-%s}\n", shellQuote(proxy))
+
+	for i, pair := range simulation.RequestResponsePairs {
+		fmt.Fprintf(&script, "\n# %s\n", pairSummary(i, pair))
+
+		if !IsExactRequestMatcher(pair.RequestMatcher) {
+			script.WriteString("# Left out as its request matcher uses matchers other than exact\n")
+			continue
+		}
+
+		request := NewExactRequestDetailsView(pair.RequestMatcher)
+		if stringOrDefault(request.Destination, "") == "" {
+			script.WriteString("# Left out as its request matcher has no destination\n")
+			continue
+		}
+
+		requestUrl := newRequestUrl(request)
+
+		arguments := []string{"curl", "--silent", "--show-error", "--proxy", `"$HOVERFLY_PROXY"`}
+		if requestUrl.Scheme == "https" {
+			// Hoverfly signs the certificates of the requests it proxies with its own CA
+			arguments = append(arguments, "--insecure")
+		}
+		arguments = append(arguments, "-X", shellQuote(stringOrDefault(request.Method, "GET")), shellQuote(requestUrl.String()))
+
+		lines := []string{strings.Join(arguments, " ")}
+		for _, name := range sortedKeys(request.Headers) {
+			for _, value := range request.Headers[name] {
+				lines = append(lines, "-H "+shellQuote(name+": "+value))
+			}
+		}
+		if body := stringOrDefault(request.Body, ""); body != "" {
+			lines = append(lines, "--data-raw "+shellQuote(body))
+		}
+
+		script.WriteString(strings.Join(lines, " \\\n  "))
+		script.WriteString("\n")
+	}
+
+	return script.Bytes()
+}
+
+// pairSummary names a pair by its index and id, followed by its description
+func pairSummary(index int, pair RequestMatcherResponsePairViewV6) string {
+	summary := fmt.Sprintf("data.pairs[%v]", index)
+	if pair.Id != "" {
+		summary += " " + pair.Id
+	}
+	if pair.Description != "" {
+		summary += ": " + strings.Replace(pair.Description, "\n", " ", -1)
+	}
+
+	return summary
+}
+
+// shellQuote quotes a value so the shell passes it on unchanged
+func shellQuote(value string) string {
+	return "'" + strings.Replace(value, "'", `'\''`, -1) + "'"
+}
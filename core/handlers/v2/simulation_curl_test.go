@@ -0,0 +1,76 @@
+package v2
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	. "github.com/onsi/gomega"
+)
+
+func Test_NewCurlScriptFromSimulation_WritesCurlCommandForExactPair(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation := SimulationViewV6{
+		DataViewV6: DataViewV6{
+			RequestResponsePairs: []RequestMatcherResponsePairViewV6{
+				{
+					Id:          "create-payment",
+					Description: "Creates a payment",
+					RequestMatcher: RequestMatcherViewV5{
+						Method:      []MatcherViewV5{NewMatcherView(matchers.Exact, "POST")},
+						Scheme:      []MatcherViewV5{NewMatcherView(matchers.Exact, "https")},
+						Destination: []MatcherViewV5{NewMatcherView(matchers.Exact, "test.com")},
+						Path:        []MatcherViewV5{NewMatcherView(matchers.Exact, "/payments")},
+						Query: &QueryMatcherViewV5{
+							"dry": []MatcherViewV5{NewMatcherView(matchers.Exact, "true")},
+						},
+						Headers: map[string][]MatcherViewV5{
+							"Content-Type": []MatcherViewV5{NewMatcherView(matchers.Exact, "application/json")},
+						},
+						Body: []MatcherViewV5{NewMatcherView(matchers.Exact, `{"name": "it's"}`)},
+					},
+				},
+			},
+		},
+	}
+
+	script := string(NewCurlScriptFromSimulation(simulation, ""))
+
+	Expect(script).To(Equal(`#!/bin/sh
+# Requests for the pairs of a Hoverfly simulation, sent through the Hoverfly proxy
+HOVERFLY_PROXY=${HOVERFLY_PROXY:-'http://localhost:8500'}
+
+# data.pairs[0] create-payment: Creates a payment
+curl --silent --show-error --proxy "$HOVERFLY_PROXY" --insecure -X 'POST' 'https://test.com/payments?dry=true' \
+  -H 'Content-Type: application/json' \
+  --data-raw '{"name": "it'\''s"}'
+`))
+}
+
+func Test_NewCurlScriptFromSimulation_LeavesOutPairsWhichCannotBeSent(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation := SimulationViewV6{
+		DataViewV6: DataViewV6{
+			RequestResponsePairs: []RequestMatcherResponsePairViewV6{
+				{
+					RequestMatcher: RequestMatcherViewV5{
+						Destination: []MatcherViewV5{NewMatcherView(matchers.Glob, "*.com")},
+					},
+				},
+				{
+					RequestMatcher: RequestMatcherViewV5{
+						Path: []MatcherViewV5{NewMatcherView(matchers.Exact, "/payments")},
+					},
+				},
+			},
+		},
+	}
+
+	script := string(NewCurlScriptFromSimulation(simulation, "http://hoverfly:8500"))
+
+	Expect(script).To(ContainSubstring("HOVERFLY_PROXY=${HOVERFLY_PROXY:-'http://hoverfly:8500'}"))
+	Expect(script).To(ContainSubstring("# data.pairs[0]\n# Left out as its request matcher uses matchers other than exact\n"))
+	Expect(script).To(ContainSubstring("# data.pairs[1]\n# Left out as its request matcher has no destination\n"))
+	Expect(script).ToNot(ContainSubstring("curl "))
+}
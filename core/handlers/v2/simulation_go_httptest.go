@@ -0,0 +1,162 @@
+package v2
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"go/format"
+	"go/token"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const DefaultGoHttpTestPackage = "simulation"
+
+// NewGoHttpTestFromSimulation writes a Go file with a function starting an httptest.Server which
+// responds as the simple pairs of the simulation do. A pair is simple when its request matcher only
+// uses exact matchers and does not require a state, and its response is not templated. The server
+// is the destination of every request, so the destination and scheme matchers are not used.
+func NewGoHttpTestFromSimulation(simulation SimulationViewV6, packageName string) ([]byte, error) {
+	if packageName == "" {
+		packageName = DefaultGoHttpTestPackage
+	}
+	if !token.IsIdentifier(packageName) {
+		return nil, fmt.Errorf("%s is not a valid Go package name", packageName)
+	}
+
+	imports := map[string]bool{
+		"net/http":          true,
+		"net/http/httptest": true,
+	}
+
+	var cases, leftOut bytes.Buffer
+	readsBody := false
+
+	for i, pair := range simulation.RequestResponsePairs {
+		reason := goHttpTestLeftOutReason(pair)
+		if reason != "" {
+			fmt.Fprintf(&leftOut, "// data.pairs[%v] is left out as %s\n", i, reason)
+			continue
+		}
+
+		request := NewExactRequestDetailsView(pair.RequestMatcher)
+
+		conditions := []string{}
+		if method := stringOrDefault(request.Method, ""); method != "" {
+			conditions = append(conditions, "r.Method == "+strconv.Quote(method))
+		}
+		if path := stringOrDefault(request.Path, ""); path != "" {
+			conditions = append(conditions, "r.URL.Path == "+strconv.Quote(path))
+		}
+		if pair.RequestMatcher.Query != nil || len(pair.RequestMatcher.DeprecatedQuery) > 0 {
+			query, _ := url.ParseQuery(stringOrDefault(request.Query, ""))
+			imports["net/url"] = true
+			imports["reflect"] = true
+			conditions = append(conditions, "reflect.DeepEqual(r.URL.Query(), "+goUrlValues(query)+")")
+		}
+		for _, name := range sortedKeys(request.Headers) {
+			imports["strings"] = true
+			conditions = append(conditions, fmt.Sprintf(`strings.Join(r.Header[%s], ";") == %s`,
+				strconv.Quote(http.CanonicalHeaderKey(name)), strconv.Quote(strings.Join(request.Headers[name], ";"))))
+		}
+		if body := stringOrDefault(request.Body, ""); body != "" {
+			readsBody = true
+			conditions = append(conditions, "string(body) == "+strconv.Quote(body))
+		}
+		if len(conditions) == 0 {
+			conditions = append(conditions, "true")
+		}
+
+		body := pair.Response.Body
+		if pair.Response.EncodedBody {
+			decoded, err := base64.StdEncoding.DecodeString(pair.Response.Body)
+			if err != nil {
+				return nil, fmt.Errorf("data.pairs[%v].response.body is not valid base64", i)
+			}
+			body = string(decoded)
+		}
+
+		fmt.Fprintf(&cases, "// %s\ncase %s:\n", pairSummary(i, pair), strings.Join(conditions, " && "))
+		for _, name := range sortedKeys(pair.Response.Headers) {
+			fmt.Fprintf(&cases, "w.Header()[%s] = %#v\n", strconv.Quote(name), pair.Response.Headers[name])
+		}
+		fmt.Fprintf(&cases, "w.WriteHeader(%v)\n", pair.Response.Status)
+		if body != "" {
+			fmt.Fprintf(&cases, "w.Write([]byte(%s))\n", strconv.Quote(body))
+		}
+	}
+
+	if readsBody {
+		imports["io/ioutil"] = true
+	}
+
+	importNames := []string{}
+	for name := range imports {
+		importNames = append(importNames, name)
+	}
+	sort.Strings(importNames)
+
+	var source bytes.Buffer
+	source.WriteString("// Code generated by Hoverfly from a simulation. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&source, "package %s\n\n", packageName)
+	source.WriteString("import (\n")
+	for _, name := range importNames {
+		fmt.Fprintf(&source, "%s\n", strconv.Quote(name))
+	}
+	source.WriteString(")\n\n")
+	if leftOut.Len() > 0 {
+		source.Write(leftOut.Bytes())
+		source.WriteString("\n")
+	}
+	source.WriteString("// NewSimulationServer starts a server which responds as the simple pairs of the simulation do.\n")
+	source.WriteString("// Requests which match none of them get a 502, as they would from Hoverfly.\n")
+	source.WriteString("func NewSimulationServer() *httptest.Server {\n")
+	source.WriteString("return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {\n")
+	if readsBody {
+		source.WriteString("body, _ := ioutil.ReadAll(r.Body)\n\n")
+	}
+	source.WriteString("switch {\n")
+	source.Write(cases.Bytes())
+	source.WriteString("default:\n")
+	source.WriteString("http.Error(w, \"Hoverfly could not find a matching pair\", http.StatusBadGateway)\n")
+	source.WriteString("}\n")
+	source.WriteString("}))\n")
+	source.WriteString("}\n")
+
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("Could not generate Go source: %s", err.Error())
+	}
+
+	return formatted, nil
+}
+
+// goUrlValues writes the values as a url.Values literal, with the keys in order
+func goUrlValues(values url.Values) string {
+	entries := []string{}
+	for _, key := range sortedKeys(values) {
+		quoted := []string{}
+		for _, value := range values[key] {
+			quoted = append(quoted, strconv.Quote(value))
+		}
+		entries = append(entries, fmt.Sprintf("%s: {%s}", strconv.Quote(key), strings.Join(quoted, ", ")))
+	}
+
+	return "url.Values{" + strings.Join(entries, ", ") + "}"
+}
+
+func goHttpTestLeftOutReason(pair RequestMatcherResponsePairViewV6) string {
+	switch {
+	case !IsExactRequestMatcher(pair.RequestMatcher):
+		return "its request matcher uses matchers other than exact"
+	case len(pair.RequestMatcher.RequiresState) > 0:
+		return "it requires a state"
+	case pair.Response.Templated:
+		return "its response is templated"
+	}
+
+	return ""
+}
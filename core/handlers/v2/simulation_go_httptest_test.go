@@ -0,0 +1,152 @@
+package v2
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	. "github.com/onsi/gomega"
+)
+
+func Test_NewGoHttpTestFromSimulation_WritesCaseForSimplePair(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation := SimulationViewV6{
+		DataViewV6: DataViewV6{
+			RequestResponsePairs: []RequestMatcherResponsePairViewV6{
+				{
+					Id: "create-payment",
+					RequestMatcher: RequestMatcherViewV5{
+						Method:      []MatcherViewV5{NewMatcherView(matchers.Exact, "POST")},
+						Destination: []MatcherViewV5{NewMatcherView(matchers.Exact, "test.com")},
+						Path:        []MatcherViewV5{NewMatcherView(matchers.Exact, "/payments")},
+						Query: &QueryMatcherViewV5{
+							"dry":  []MatcherViewV5{NewMatcherView(matchers.Exact, "true")},
+							"note": []MatcherViewV5{NewMatcherView(matchers.Exact, "a b+c")},
+						},
+						Headers: map[string][]MatcherViewV5{
+							"content-type": []MatcherViewV5{NewMatcherView(matchers.Exact, "application/json")},
+						},
+						Body: []MatcherViewV5{NewMatcherView(matchers.Exact, `{"amount": 1}`)},
+					},
+					Response: ResponseDetailsViewV6{
+						Status: 201,
+						Body:   `{"id": 1}`,
+						Headers: map[string][]string{
+							"Content-Type": []string{"application/json"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	source, err := NewGoHttpTestFromSimulation(simulation, "")
+	Expect(err).To(BeNil())
+
+	Expect(string(source)).To(Equal(`// Code generated by Hoverfly from a simulation. DO NOT EDIT.
+
+package simulation
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// NewSimulationServer starts a server which responds as the simple pairs of the simulation do.
+// Requests which match none of them get a 502, as they would from Hoverfly.
+func NewSimulationServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+
+		switch {
+		// data.pairs[0] create-payment
+		case r.Method == "POST" && r.URL.Path == "/payments" && reflect.DeepEqual(r.URL.Query(), url.Values{"dry": {"true"}, "note": {"a b+c"}}) && strings.Join(r.Header["Content-Type"], ";") == "application/json" && string(body) == "{\"amount\": 1}":
+			w.Header()["Content-Type"] = []string{"application/json"}
+			w.WriteHeader(201)
+			w.Write([]byte("{\"id\": 1}"))
+		default:
+			http.Error(w, "Hoverfly could not find a matching pair", http.StatusBadGateway)
+		}
+	}))
+}
+`))
+}
+
+func Test_NewGoHttpTestFromSimulation_LeavesOutPairsWhichAreNotSimple(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation := SimulationViewV6{
+		DataViewV6: DataViewV6{
+			RequestResponsePairs: []RequestMatcherResponsePairViewV6{
+				{
+					RequestMatcher: RequestMatcherViewV5{
+						Path: []MatcherViewV5{NewMatcherView(matchers.Regex, "/payments/.*")},
+					},
+				},
+				{
+					RequestMatcher: RequestMatcherViewV5{
+						Path:          []MatcherViewV5{NewMatcherView(matchers.Exact, "/payments")},
+						RequiresState: map[string]string{"logged-in": "true"},
+					},
+				},
+				{
+					RequestMatcher: RequestMatcherViewV5{
+						Path: []MatcherViewV5{NewMatcherView(matchers.Exact, "/payments")},
+					},
+					Response: ResponseDetailsViewV6{
+						Templated: true,
+					},
+				},
+			},
+		},
+	}
+
+	source, err := NewGoHttpTestFromSimulation(simulation, "fixtures")
+	Expect(err).To(BeNil())
+
+	Expect(string(source)).To(ContainSubstring("package fixtures"))
+	Expect(string(source)).To(ContainSubstring("// data.pairs[0] is left out as its request matcher uses matchers other than exact\n"))
+	Expect(string(source)).To(ContainSubstring("// data.pairs[1] is left out as it requires a state\n"))
+	Expect(string(source)).To(ContainSubstring("// data.pairs[2] is left out as its response is templated\n\n// NewSimulationServer starts"))
+	Expect(string(source)).ToNot(ContainSubstring("case "))
+	Expect(string(source)).ToNot(ContainSubstring("ioutil"))
+}
+
+func Test_NewGoHttpTestFromSimulation_DecodesEncodedBodies(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation := SimulationViewV6{
+		DataViewV6: DataViewV6{
+			RequestResponsePairs: []RequestMatcherResponsePairViewV6{
+				{
+					RequestMatcher: RequestMatcherViewV5{
+						Path: []MatcherViewV5{NewMatcherView(matchers.Exact, "/image")},
+					},
+					Response: ResponseDetailsViewV6{
+						Status:      200,
+						Body:        "AAEC",
+						EncodedBody: true,
+					},
+				},
+			},
+		},
+	}
+
+	source, err := NewGoHttpTestFromSimulation(simulation, "")
+	Expect(err).To(BeNil())
+
+	Expect(string(source)).To(ContainSubstring(`w.Write([]byte("\x00\x01\x02"))`))
+}
+
+func Test_NewGoHttpTestFromSimulation_ErrorsOnInvalidPackageName(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := NewGoHttpTestFromSimulation(SimulationViewV6{}, "my-fixtures")
+
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("my-fixtures is not a valid Go package name"))
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"io/ioutil"
+	"strings"
 
 	"github.com/SpectoLabs/hoverfly/core/handlers"
 	"github.com/SpectoLabs/hoverfly/core/util"
@@ -15,10 +16,20 @@ import (
 )
 
 type HoverflySimulation interface {
-	GetSimulation() (SimulationViewV5, error)
-	GetFilteredSimulation(string) (SimulationViewV5, error)
-	PutSimulation(SimulationViewV5) SimulationImportResult
+	GetSimulation() (SimulationViewV6, error)
+	GetFilteredSimulation(string) (SimulationViewV6, error)
+	ReplaceSimulation(SimulationViewV6) SimulationImportResult
+	AppendSimulation(SimulationViewV6) SimulationImportResult
+	PutHar(HarView, ModeArgumentsView) SimulationImportResult
+	AppendHar(HarView, ModeArgumentsView) SimulationImportResult
+	PutWireMock(WireMockMappingsView) SimulationImportResult
+	AppendWireMock(WireMockMappingsView) SimulationImportResult
 	DeleteSimulation()
+	GetSimulationPairs() []SimulationPairView
+	GetSimulationPair(string) (SimulationPairView, error)
+	PutSimulationPair(string, RequestMatcherResponsePairViewV6) (SimulationPairView, error)
+	DeleteSimulationPair(string) error
+	GetRedactionRules() []*RedactionRule
 }
 
 type SimulationHandler struct {
@@ -34,6 +45,10 @@ func (this *SimulationHandler) RegisterRoutes(mux *bone.Mux, am *handlers.AuthHa
 		negroni.HandlerFunc(am.RequireTokenAuthentication),
 		negroni.HandlerFunc(this.Put),
 	))
+	mux.Post("/api/v2/simulation", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Post),
+	))
 	mux.Delete("/api/v2/simulation", negroni.New(
 		negroni.HandlerFunc(am.RequireTokenAuthentication),
 		negroni.HandlerFunc(this.Delete),
@@ -49,13 +64,37 @@ func (this *SimulationHandler) RegisterRoutes(mux *bone.Mux, am *handlers.AuthHa
 	mux.Options("/api/v2/simulation/schema", negroni.New(
 		negroni.HandlerFunc(this.Options),
 	))
+
+	mux.Get("/api/v2/simulation/pairs", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.GetPairs),
+	))
+	mux.Options("/api/v2/simulation/pairs", negroni.New(
+		negroni.HandlerFunc(this.OptionsPairs),
+	))
+
+	mux.Get("/api/v2/simulation/pairs/:id", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.GetPair),
+	))
+	mux.Put("/api/v2/simulation/pairs/:id", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.PutPair),
+	))
+	mux.Delete("/api/v2/simulation/pairs/:id", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.DeletePair),
+	))
+	mux.Options("/api/v2/simulation/pairs/:id", negroni.New(
+		negroni.HandlerFunc(this.OptionsPair),
+	))
 }
 
 func (this *SimulationHandler) Get(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
 	urlPattern := req.URL.Query().Get("urlPattern")
 
 	var err error
-	var simulationView SimulationViewV5
+	var simulationView SimulationViewV6
 	if urlPattern == "" {
 		simulationView, err = this.Hoverfly.GetSimulation()
 	} else {
@@ -66,7 +105,29 @@ func (this *SimulationHandler) Get(w http.ResponseWriter, req *http.Request, nex
 		return
 	}
 
-	bytes, _ := util.JSONMarshal(simulationView)
+	if labels := req.URL.Query()["label"]; len(labels) > 0 {
+		simulationView = FilterSimulationViewByLabels(simulationView, labels)
+	}
+
+	simulationView = RedactSimulationView(simulationView, this.Hoverfly.GetRedactionRules())
+
+	var bytes []byte
+	switch {
+	case isHarFormat(req):
+		bytes, _ = util.JSONMarshal(NewHarViewFromSimulation(simulationView))
+	case isWireMockFormat(req):
+		bytes, _ = util.JSONMarshal(NewWireMockViewFromSimulation(simulationView))
+	case isCurlFormat(req):
+		bytes = NewCurlScriptFromSimulation(simulationView, req.URL.Query().Get("proxy"))
+	case isGoHttpTestFormat(req):
+		bytes, err = NewGoHttpTestFromSimulation(simulationView, req.URL.Query().Get("package"))
+		if err != nil {
+			handlers.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		bytes, _ = util.JSONMarshal(simulationView)
+	}
 
 	handlers.WriteResponse(w, bytes)
 }
@@ -74,22 +135,181 @@ func (this *SimulationHandler) Get(w http.ResponseWriter, req *http.Request, nex
 func (this *SimulationHandler) Put(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
 	body, _ := ioutil.ReadAll(req.Body)
 
-	simulationView, err := NewSimulationViewFromResponseBody(body)
+	if isHarFormat(req) {
+		this.importHar(w, req, next, body, true)
+		return
+	}
+
+	if isOpenApiFormat(req) {
+		this.importOpenApi(w, req, next, body, true)
+		return
+	}
+
+	if isWireMockFormat(req) {
+		this.importWireMock(w, req, next, body, true)
+		return
+	}
+
+	simulationView, err := newSimulationViewFromRequest(req, body)
 	if err != nil {
 		handlers.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	this.Hoverfly.DeleteSimulation()
+	result := this.Hoverfly.ReplaceSimulation(simulationView)
+	if result.err != nil {
+
+		log.WithFields(log.Fields{
+			"body": string(body),
+		}).Debug(result.err.Error())
+
+		handlers.WriteErrorResponse(w, "An error occured: "+result.err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(result.WarningMessages) > 0 {
+		bytes, _ := util.JSONMarshal(result)
+
+		handlers.WriteResponse(w, bytes)
+		return
+	}
+
+	this.Get(w, req, next)
+}
+
+// Post merges the simulation in the body into the one already loaded
+func (this *SimulationHandler) Post(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	body, _ := ioutil.ReadAll(req.Body)
 
-	result := this.Hoverfly.PutSimulation(simulationView)
+	if isHarFormat(req) {
+		this.importHar(w, req, next, body, false)
+		return
+	}
+
+	if isOpenApiFormat(req) {
+		this.importOpenApi(w, req, next, body, false)
+		return
+	}
+
+	if isWireMockFormat(req) {
+		this.importWireMock(w, req, next, body, false)
+		return
+	}
+
+	simulationView, err := newSimulationViewFromRequest(req, body)
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result := this.Hoverfly.AppendSimulation(simulationView)
 	if result.err != nil {
 
 		log.WithFields(log.Fields{
 			"body": string(body),
-		}).Debug(err.Error())
+		}).Debug(result.err.Error())
+
+		handlers.WriteErrorResponse(w, "An error occured: "+result.err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(result.WarningMessages) > 0 {
+		bytes, _ := util.JSONMarshal(result)
+
+		handlers.WriteResponse(w, bytes)
+		return
+	}
+
+	this.Get(w, req, next)
+}
+
+// importHar converts the entries of a HAR file into pairs, building the matchers from the
+// headersWhitelist and stateful query parameters in the same way as capture mode does
+func (this *SimulationHandler) importHar(w http.ResponseWriter, req *http.Request, next http.HandlerFunc, body []byte, replace bool) {
+	har, err := NewHarViewFromResponseBody(body)
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	options := ModeArgumentsView{
+		Stateful: req.URL.Query().Get("stateful") == "true",
+	}
+	if headers := req.URL.Query().Get("headersWhitelist"); headers != "" {
+		options.Headers = strings.Split(headers, ",")
+	}
+
+	var result SimulationImportResult
+	if replace {
+		result = this.Hoverfly.PutHar(har, options)
+	} else {
+		result = this.Hoverfly.AppendHar(har, options)
+	}
+
+	if result.err != nil {
+		handlers.WriteErrorResponse(w, result.err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(result.WarningMessages) > 0 {
+		bytes, _ := util.JSONMarshal(result)
+
+		handlers.WriteResponse(w, bytes)
+		return
+	}
+
+	this.Get(w, req, next)
+}
+
+// importOpenApi builds a pair for each operation in an OpenAPI specification. Operations
+// which could not be modelled are reported as warnings rather than failing the import.
+func (this *SimulationHandler) importOpenApi(w http.ResponseWriter, req *http.Request, next http.HandlerFunc, body []byte, replace bool) {
+	spec, err := NewOpenApiViewFromResponseBody(body)
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	simulationView, warnings := NewSimulationViewFromOpenApi(spec)
+
+	var result SimulationImportResult
+	if replace {
+		result = this.Hoverfly.ReplaceSimulation(simulationView)
+	} else {
+		result = this.Hoverfly.AppendSimulation(simulationView)
+	}
+
+	if result.err != nil {
+		handlers.WriteErrorResponse(w, result.err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result.WarningMessages = append(warnings, result.WarningMessages...)
+	if len(result.WarningMessages) > 0 {
+		bytes, _ := util.JSONMarshal(result)
+
+		handlers.WriteResponse(w, bytes)
+		return
+	}
+
+	this.Get(w, req, next)
+}
 
-		handlers.WriteErrorResponse(w, "An error occured: "+err.Error(), http.StatusInternalServerError)
+// importWireMock converts WireMock stub mappings into pairs, with any unsupported
+// parts of the mappings reported as warnings
+func (this *SimulationHandler) importWireMock(w http.ResponseWriter, req *http.Request, next http.HandlerFunc, body []byte, replace bool) {
+	mappings, err := NewWireMockMappingsViewFromResponseBody(body)
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var result SimulationImportResult
+	if replace {
+		result = this.Hoverfly.PutWireMock(mappings)
+	} else {
+		result = this.Hoverfly.AppendWireMock(mappings)
+	}
+
+	if result.err != nil {
+		handlers.WriteErrorResponse(w, result.err.Error(), http.StatusBadRequest)
 		return
 	}
 	if len(result.WarningMessages) > 0 {
@@ -109,12 +329,12 @@ func (this *SimulationHandler) Delete(w http.ResponseWriter, req *http.Request,
 }
 
 func (this *SimulationHandler) Options(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
-	w.Header().Add("Allow", "OPTIONS, GET, PUT, DELETE")
+	w.Header().Add("Allow", "OPTIONS, GET, PUT, POST, DELETE")
 	handlers.WriteResponse(w, []byte(""))
 }
 
 func (this *SimulationHandler) GetSchema(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
-	bytes, _ := json.Marshal(SimulationViewV5Schema)
+	bytes, _ := json.Marshal(SimulationViewV6Schema)
 
 	handlers.WriteResponse(w, bytes)
 }
@@ -122,3 +342,108 @@ func (this *SimulationHandler) GetSchema(w http.ResponseWriter, req *http.Reques
 func (this *SimulationHandler) OptionsSchema(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
 	w.Header().Add("Allow", "OPTIONS, GET")
 }
+
+func (this *SimulationHandler) GetPairs(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	bytes, _ := util.JSONMarshal(SimulationPairsView{
+		Pairs: this.Hoverfly.GetSimulationPairs(),
+	})
+
+	handlers.WriteResponse(w, bytes)
+}
+
+func (this *SimulationHandler) GetPair(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	pairView, err := this.Hoverfly.GetSimulationPair(bone.GetValue(req, "id"))
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	bytes, _ := util.JSONMarshal(pairView)
+
+	handlers.WriteResponse(w, bytes)
+}
+
+func (this *SimulationHandler) PutPair(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	id := bone.GetValue(req, "id")
+
+	if _, err := this.Hoverfly.GetSimulationPair(id); err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	body, _ := ioutil.ReadAll(req.Body)
+
+	pairView, err := NewSimulationPairViewFromResponseBody(body)
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	updatedPairView, err := this.Hoverfly.PutSimulationPair(id, pairView)
+	switch err.(type) {
+	case nil:
+	case PairNotFoundError:
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusNotFound)
+		return
+	case DuplicatePairError:
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusConflict)
+		return
+	default:
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	bytes, _ := util.JSONMarshal(updatedPairView)
+
+	handlers.WriteResponse(w, bytes)
+}
+
+func (this *SimulationHandler) DeletePair(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	err := this.Hoverfly.DeleteSimulationPair(bone.GetValue(req, "id"))
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	this.GetPairs(w, req, next)
+}
+
+func (this *SimulationHandler) OptionsPairs(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	w.Header().Add("Allow", "OPTIONS, GET")
+	handlers.WriteResponse(w, []byte(""))
+}
+
+func (this *SimulationHandler) OptionsPair(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	w.Header().Add("Allow", "OPTIONS, GET, PUT, DELETE")
+	handlers.WriteResponse(w, []byte(""))
+}
+
+// newSimulationViewFromRequest reads the simulation in the body of the
+// request as YAML when the Content-Type says so, otherwise as JSON
+func newSimulationViewFromRequest(req *http.Request, body []byte) (SimulationViewV6, error) {
+	if IsYamlContentType(req.Header.Get("Content-Type")) {
+		return NewSimulationViewFromYaml(body)
+	}
+
+	return NewSimulationViewFromResponseBody(body)
+}
+
+func isHarFormat(req *http.Request) bool {
+	return req.URL.Query().Get("format") == "har"
+}
+
+func isOpenApiFormat(req *http.Request) bool {
+	return req.URL.Query().Get("format") == "openapi"
+}
+
+func isWireMockFormat(req *http.Request) bool {
+	return req.URL.Query().Get("format") == "wiremock"
+}
+
+func isCurlFormat(req *http.Request) bool {
+	return req.URL.Query().Get("format") == "curl"
+}
+
+func isGoHttpTestFormat(req *http.Request) bool {
+	return req.URL.Query().Get("format") == "go-httptest"
+}
@@ -5,24 +5,36 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"testing"
 
 	"fmt"
 
+	"github.com/SpectoLabs/hoverfly/core/handlers"
 	"github.com/SpectoLabs/hoverfly/core/handlers/v1"
 	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	"github.com/go-zoo/bone"
 	. "github.com/onsi/gomega"
 )
 
 type HoverflySimulationStub struct {
 	Deleted    bool
-	Simulation SimulationViewV5
+	Replaced   bool
+	Appended   bool
+	Simulation SimulationViewV6
 	UrlPattern string
 	Filtered   bool
+	Pairs      []SimulationPairView
+	Har        HarView
+	HarOptions ModeArgumentsView
+	WireMock   WireMockMappingsView
+	Redactions []*RedactionRule
 }
 
-func (this HoverflySimulationStub) GetSimulation() (SimulationViewV5, error) {
-	pairOne := RequestMatcherResponsePairViewV5{
+func (this HoverflySimulationStub) GetSimulation() (SimulationViewV6, error) {
+	pairOne := RequestMatcherResponsePairViewV6{
+		Labels: []string{"payments"},
 		RequestMatcher: RequestMatcherViewV5{
 			Destination: []MatcherViewV5{
 				NewMatcherView(matchers.Exact, "test.com"),
@@ -31,14 +43,14 @@ func (this HoverflySimulationStub) GetSimulation() (SimulationViewV5, error) {
 				NewMatcherView(matchers.Exact, "/testing"),
 			},
 		},
-		Response: ResponseDetailsViewV5{
+		Response: ResponseDetailsViewV6{
 			Body: "test-body",
 		},
 	}
 
-	return SimulationViewV5{
-		DataViewV5{
-			RequestResponsePairs: []RequestMatcherResponsePairViewV5{pairOne},
+	return SimulationViewV6{
+		DataViewV6{
+			RequestResponsePairs: []RequestMatcherResponsePairViewV6{pairOne},
 			GlobalActions: GlobalActionsView{
 				Delays: []v1.ResponseDelayView{
 					{
@@ -56,7 +68,7 @@ func (this HoverflySimulationStub) GetSimulation() (SimulationViewV5, error) {
 	}, nil
 }
 
-func (this *HoverflySimulationStub) GetFilteredSimulation(urlPattern string) (SimulationViewV5, error) {
+func (this *HoverflySimulationStub) GetFilteredSimulation(urlPattern string) (SimulationViewV6, error) {
 	this.Filtered = true
 	this.UrlPattern = urlPattern
 	return this.GetSimulation()
@@ -66,47 +78,219 @@ func (this *HoverflySimulationStub) DeleteSimulation() {
 	this.Deleted = true
 }
 
-func (this *HoverflySimulationStub) PutSimulation(simulation SimulationViewV5) SimulationImportResult {
+func (this *HoverflySimulationStub) ReplaceSimulation(simulation SimulationViewV6) SimulationImportResult {
+	this.Replaced = true
 	this.Simulation = simulation
 	return SimulationImportResult{}
 }
 
+func (this *HoverflySimulationStub) AppendSimulation(simulation SimulationViewV6) SimulationImportResult {
+	this.Appended = true
+	this.Simulation = simulation
+	return SimulationImportResult{}
+}
+
+func (this *HoverflySimulationStub) PutHar(har HarView, options ModeArgumentsView) SimulationImportResult {
+	this.Replaced = true
+	this.Har = har
+	this.HarOptions = options
+	return SimulationImportResult{}
+}
+
+func (this *HoverflySimulationStub) AppendHar(har HarView, options ModeArgumentsView) SimulationImportResult {
+	this.Appended = true
+	this.Har = har
+	this.HarOptions = options
+	return SimulationImportResult{}
+}
+
+func (this *HoverflySimulationStub) PutWireMock(mappings WireMockMappingsView) SimulationImportResult {
+	this.Replaced = true
+	this.WireMock = mappings
+	return SimulationImportResult{}
+}
+
+func (this *HoverflySimulationStub) AppendWireMock(mappings WireMockMappingsView) SimulationImportResult {
+	this.Appended = true
+	this.WireMock = mappings
+	return SimulationImportResult{}
+}
+
+func (this *HoverflySimulationStub) GetSimulationPairs() []SimulationPairView {
+	return this.Pairs
+}
+
+func (this *HoverflySimulationStub) GetSimulationPair(id string) (SimulationPairView, error) {
+	for _, pair := range this.Pairs {
+		if pair.Id == id {
+			return pair, nil
+		}
+	}
+	return SimulationPairView{}, PairNotFoundError{Id: id}
+}
+
+func (this *HoverflySimulationStub) PutSimulationPair(id string, pairView RequestMatcherResponsePairViewV6) (SimulationPairView, error) {
+	for i, pair := range this.Pairs {
+		if pair.Id != id && reflect.DeepEqual(pair.RequestMatcher, pairView.RequestMatcher) {
+			return SimulationPairView{}, DuplicatePairError{Id: pair.Id}
+		}
+		if pair.Id == id {
+			this.Pairs[i].RequestMatcherResponsePairViewV6 = pairView
+		}
+	}
+	return this.GetSimulationPair(id)
+}
+
+func (this HoverflySimulationStub) GetRedactionRules() []*RedactionRule {
+	return this.Redactions
+}
+
+func (this *HoverflySimulationStub) DeleteSimulationPair(id string) error {
+	for i, pair := range this.Pairs {
+		if pair.Id == id {
+			this.Pairs = append(this.Pairs[:i], this.Pairs[i+1:]...)
+			return nil
+		}
+	}
+	return PairNotFoundError{Id: id}
+}
+
 type HoverflySimulationErrorStub struct{}
 
-func (this HoverflySimulationErrorStub) GetSimulation() (SimulationViewV5, error) {
-	return SimulationViewV5{}, fmt.Errorf("error")
+func (this HoverflySimulationErrorStub) GetSimulation() (SimulationViewV6, error) {
+	return SimulationViewV6{}, fmt.Errorf("error")
 }
 
-func (this HoverflySimulationErrorStub) GetFilteredSimulation(urlPattern string) (SimulationViewV5, error) {
-	return SimulationViewV5{}, fmt.Errorf("error")
+func (this HoverflySimulationErrorStub) GetFilteredSimulation(urlPattern string) (SimulationViewV6, error) {
+	return SimulationViewV6{}, fmt.Errorf("error")
 }
 
 func (this *HoverflySimulationErrorStub) DeleteSimulation() {}
 
-func (this *HoverflySimulationErrorStub) PutSimulation(simulation SimulationViewV5) SimulationImportResult {
+func (this *HoverflySimulationErrorStub) ReplaceSimulation(simulation SimulationViewV6) SimulationImportResult {
+	return SimulationImportResult{
+		err: fmt.Errorf("error"),
+	}
+}
+
+func (this *HoverflySimulationErrorStub) AppendSimulation(simulation SimulationViewV6) SimulationImportResult {
+	return SimulationImportResult{
+		err: fmt.Errorf("error"),
+	}
+}
+
+func (this *HoverflySimulationErrorStub) PutHar(har HarView, options ModeArgumentsView) SimulationImportResult {
+	return SimulationImportResult{
+		err: fmt.Errorf("error"),
+	}
+}
+
+func (this *HoverflySimulationErrorStub) AppendHar(har HarView, options ModeArgumentsView) SimulationImportResult {
 	return SimulationImportResult{
 		err: fmt.Errorf("error"),
 	}
 }
 
+func (this *HoverflySimulationErrorStub) PutWireMock(mappings WireMockMappingsView) SimulationImportResult {
+	return SimulationImportResult{
+		err: fmt.Errorf("error"),
+	}
+}
+
+func (this *HoverflySimulationErrorStub) AppendWireMock(mappings WireMockMappingsView) SimulationImportResult {
+	return SimulationImportResult{
+		err: fmt.Errorf("error"),
+	}
+}
+
+func (this *HoverflySimulationErrorStub) GetSimulationPairs() []SimulationPairView {
+	return []SimulationPairView{}
+}
+
+func (this *HoverflySimulationErrorStub) GetSimulationPair(id string) (SimulationPairView, error) {
+	return SimulationPairView{}, fmt.Errorf("error")
+}
+
+func (this *HoverflySimulationErrorStub) PutSimulationPair(id string, pairView RequestMatcherResponsePairViewV6) (SimulationPairView, error) {
+	return SimulationPairView{}, fmt.Errorf("error")
+}
+
+func (this *HoverflySimulationErrorStub) DeleteSimulationPair(id string) error {
+	return fmt.Errorf("error")
+}
+
+func (this HoverflySimulationErrorStub) GetRedactionRules() []*RedactionRule {
+	return nil
+}
+
 type HoverflySimulationWarningStub struct{}
 
-func (this HoverflySimulationWarningStub) GetSimulation() (SimulationViewV5, error) {
-	return SimulationViewV5{}, fmt.Errorf("error")
+func (this HoverflySimulationWarningStub) GetSimulation() (SimulationViewV6, error) {
+	return SimulationViewV6{}, fmt.Errorf("error")
 }
 
-func (this HoverflySimulationWarningStub) GetFilteredSimulation(urlPattern string) (SimulationViewV5, error) {
-	return SimulationViewV5{}, fmt.Errorf("error")
+func (this HoverflySimulationWarningStub) GetFilteredSimulation(urlPattern string) (SimulationViewV6, error) {
+	return SimulationViewV6{}, fmt.Errorf("error")
 }
 
 func (this *HoverflySimulationWarningStub) DeleteSimulation() {}
 
-func (this *HoverflySimulationWarningStub) PutSimulation(simulation SimulationViewV5) SimulationImportResult {
+func (this *HoverflySimulationWarningStub) ReplaceSimulation(simulation SimulationViewV6) SimulationImportResult {
+	return SimulationImportResult{
+		WarningMessages: []SimulationImportWarning{{"This is a warning", "url"}},
+	}
+}
+
+func (this *HoverflySimulationWarningStub) AppendSimulation(simulation SimulationViewV6) SimulationImportResult {
+	return SimulationImportResult{
+		WarningMessages: []SimulationImportWarning{{"This is a warning", "url"}},
+	}
+}
+
+func (this *HoverflySimulationWarningStub) PutHar(har HarView, options ModeArgumentsView) SimulationImportResult {
+	return SimulationImportResult{
+		WarningMessages: []SimulationImportWarning{{"This is a warning", "url"}},
+	}
+}
+
+func (this *HoverflySimulationWarningStub) AppendHar(har HarView, options ModeArgumentsView) SimulationImportResult {
+	return SimulationImportResult{
+		WarningMessages: []SimulationImportWarning{{"This is a warning", "url"}},
+	}
+}
+
+func (this *HoverflySimulationWarningStub) PutWireMock(mappings WireMockMappingsView) SimulationImportResult {
 	return SimulationImportResult{
 		WarningMessages: []SimulationImportWarning{{"This is a warning", "url"}},
 	}
 }
 
+func (this *HoverflySimulationWarningStub) AppendWireMock(mappings WireMockMappingsView) SimulationImportResult {
+	return SimulationImportResult{
+		WarningMessages: []SimulationImportWarning{{"This is a warning", "url"}},
+	}
+}
+
+func (this *HoverflySimulationWarningStub) GetSimulationPairs() []SimulationPairView {
+	return []SimulationPairView{}
+}
+
+func (this *HoverflySimulationWarningStub) GetSimulationPair(id string) (SimulationPairView, error) {
+	return SimulationPairView{}, fmt.Errorf("error")
+}
+
+func (this *HoverflySimulationWarningStub) PutSimulationPair(id string, pairView RequestMatcherResponsePairViewV6) (SimulationPairView, error) {
+	return SimulationPairView{}, fmt.Errorf("error")
+}
+
+func (this *HoverflySimulationWarningStub) DeleteSimulationPair(id string) error {
+	return fmt.Errorf("error")
+}
+
+func (this HoverflySimulationWarningStub) GetRedactionRules() []*RedactionRule {
+	return nil
+}
+
 func TestSimulationHandler_Get_ReturnsSimulation(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -120,22 +304,22 @@ func TestSimulationHandler_Get_ReturnsSimulation(t *testing.T) {
 
 	Expect(response.Code).To(Equal(http.StatusOK))
 
-	simulationView, err := unmarshalSimulationViewV5(response.Body)
+	simulationView, err := unmarshalSimulationViewV6(response.Body)
 	Expect(err).To(BeNil())
 
-	Expect(simulationView.DataViewV5.RequestResponsePairs).To(HaveLen(1))
+	Expect(simulationView.DataViewV6.RequestResponsePairs).To(HaveLen(1))
 
-	Expect(simulationView.DataViewV5.RequestResponsePairs[0].RequestMatcher.Destination[0].Matcher).To(Equal("exact"))
-	Expect(simulationView.DataViewV5.RequestResponsePairs[0].RequestMatcher.Destination[0].Value).To(Equal("test.com"))
+	Expect(simulationView.DataViewV6.RequestResponsePairs[0].RequestMatcher.Destination[0].Matcher).To(Equal("exact"))
+	Expect(simulationView.DataViewV6.RequestResponsePairs[0].RequestMatcher.Destination[0].Value).To(Equal("test.com"))
 
-	Expect(simulationView.DataViewV5.RequestResponsePairs[0].RequestMatcher.Path[0].Matcher).To(Equal("exact"))
-	Expect(simulationView.DataViewV5.RequestResponsePairs[0].RequestMatcher.Path[0].Value).To(Equal("/testing"))
+	Expect(simulationView.DataViewV6.RequestResponsePairs[0].RequestMatcher.Path[0].Matcher).To(Equal("exact"))
+	Expect(simulationView.DataViewV6.RequestResponsePairs[0].RequestMatcher.Path[0].Value).To(Equal("/testing"))
 
-	Expect(simulationView.DataViewV5.RequestResponsePairs[0].Response.Body).To(Equal("test-body"))
+	Expect(simulationView.DataViewV6.RequestResponsePairs[0].Response.Body).To(Equal("test-body"))
 
-	Expect(simulationView.DataViewV5.GlobalActions.Delays).To(HaveLen(1))
-	Expect(simulationView.DataViewV5.GlobalActions.Delays[0].HttpMethod).To(Equal("GET"))
-	Expect(simulationView.DataViewV5.GlobalActions.Delays[0].Delay).To(Equal(100))
+	Expect(simulationView.DataViewV6.GlobalActions.Delays).To(HaveLen(1))
+	Expect(simulationView.DataViewV6.GlobalActions.Delays[0].HttpMethod).To(Equal("GET"))
+	Expect(simulationView.DataViewV6.GlobalActions.Delays[0].Delay).To(Equal(100))
 
 	Expect(simulationView.MetaView.SchemaVersion).To(Equal("v3"))
 	Expect(simulationView.MetaView.HoverflyVersion).To(Equal("test"))
@@ -174,10 +358,10 @@ func TestSimulationHandler_Get_WithEmptyUrlPatternShouldNotFilterSimulation(t *t
 
 	Expect(response.Code).To(Equal(http.StatusOK))
 
-	simulationView, err := unmarshalSimulationViewV5(response.Body)
+	simulationView, err := unmarshalSimulationViewV6(response.Body)
 	Expect(err).To(BeNil())
 
-	Expect(simulationView.DataViewV5.RequestResponsePairs).To(HaveLen(1))
+	Expect(simulationView.DataViewV6.RequestResponsePairs).To(HaveLen(1))
 	Expect(stubHoverfly.Filtered).To(BeFalse())
 }
 
@@ -194,14 +378,69 @@ func TestSimulationHandler_Get_WithUrlPatternShouldFilterSimulation(t *testing.T
 
 	Expect(response.Code).To(Equal(http.StatusOK))
 
-	simulationView, err := unmarshalSimulationViewV5(response.Body)
+	simulationView, err := unmarshalSimulationViewV6(response.Body)
 	Expect(err).To(BeNil())
 
-	Expect(simulationView.DataViewV5.RequestResponsePairs).To(HaveLen(1))
+	Expect(simulationView.DataViewV6.RequestResponsePairs).To(HaveLen(1))
 	Expect(stubHoverfly.Filtered).To(BeTrue())
 	Expect(stubHoverfly.UrlPattern).To(Equal("foo.com"))
 }
 
+func TestSimulationHandler_Get_WithLabelShouldOnlyReturnPairsWithLabel(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationStub{}
+	unit := SimulationHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("GET", "?label=payments", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Get, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	simulationView, err := unmarshalSimulationViewV6(response.Body)
+	Expect(err).To(BeNil())
+
+	Expect(simulationView.DataViewV6.RequestResponsePairs).To(HaveLen(1))
+	Expect(simulationView.DataViewV6.RequestResponsePairs[0].Labels).To(ConsistOf("payments"))
+
+	request, err = http.NewRequest("GET", "?label=payments&label=accounts", nil)
+	Expect(err).To(BeNil())
+
+	response = makeRequestOnHandler(unit.Get, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	simulationView, err = unmarshalSimulationViewV6(response.Body)
+	Expect(err).To(BeNil())
+
+	Expect(simulationView.DataViewV6.RequestResponsePairs).To(HaveLen(0))
+	Expect(simulationView.DataViewV6.GlobalActions.Delays).To(HaveLen(1))
+}
+
+func TestSimulationHandler_Get_RedactsSimulationWithRedactionRules(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationStub{
+		Redactions: mustRedactionRules([]RedactionRuleView{{Regex: "body"}}),
+	}
+	unit := SimulationHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("GET", "", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Get, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	simulationView, err := unmarshalSimulationViewV6(response.Body)
+	Expect(err).To(BeNil())
+
+	Expect(simulationView.DataViewV6.RequestResponsePairs).To(HaveLen(1))
+	Expect(simulationView.DataViewV6.RequestResponsePairs[0].Response.Body).To(Equal("test-REDACTED"))
+}
+
 func TestSimulationHandler_Delete_CallsDelete(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -230,22 +469,22 @@ func TestSimulationHandler_Delete_CallsGetAfterDelete(t *testing.T) {
 
 	response := makeRequestOnHandler(unit.Delete, request)
 
-	simulationView, err := unmarshalSimulationViewV5(response.Body)
+	simulationView, err := unmarshalSimulationViewV6(response.Body)
 	Expect(err).To(BeNil())
 
-	Expect(simulationView.DataViewV5.RequestResponsePairs).To(HaveLen(1))
+	Expect(simulationView.DataViewV6.RequestResponsePairs).To(HaveLen(1))
 
-	Expect(simulationView.DataViewV5.RequestResponsePairs[0].RequestMatcher.Destination[0].Matcher).To(Equal("exact"))
-	Expect(simulationView.DataViewV5.RequestResponsePairs[0].RequestMatcher.Destination[0].Value).To(Equal("test.com"))
+	Expect(simulationView.DataViewV6.RequestResponsePairs[0].RequestMatcher.Destination[0].Matcher).To(Equal("exact"))
+	Expect(simulationView.DataViewV6.RequestResponsePairs[0].RequestMatcher.Destination[0].Value).To(Equal("test.com"))
 
-	Expect(simulationView.DataViewV5.RequestResponsePairs[0].RequestMatcher.Path[0].Matcher).To(Equal("exact"))
-	Expect(simulationView.DataViewV5.RequestResponsePairs[0].RequestMatcher.Path[0].Value).To(Equal("/testing"))
+	Expect(simulationView.DataViewV6.RequestResponsePairs[0].RequestMatcher.Path[0].Matcher).To(Equal("exact"))
+	Expect(simulationView.DataViewV6.RequestResponsePairs[0].RequestMatcher.Path[0].Value).To(Equal("/testing"))
 
-	Expect(simulationView.DataViewV5.RequestResponsePairs[0].Response.Body).To(Equal("test-body"))
+	Expect(simulationView.DataViewV6.RequestResponsePairs[0].Response.Body).To(Equal("test-body"))
 
-	Expect(simulationView.DataViewV5.GlobalActions.Delays).To(HaveLen(1))
-	Expect(simulationView.DataViewV5.GlobalActions.Delays[0].HttpMethod).To(Equal("GET"))
-	Expect(simulationView.DataViewV5.GlobalActions.Delays[0].Delay).To(Equal(100))
+	Expect(simulationView.DataViewV6.GlobalActions.Delays).To(HaveLen(1))
+	Expect(simulationView.DataViewV6.GlobalActions.Delays[0].HttpMethod).To(Equal("GET"))
+	Expect(simulationView.DataViewV6.GlobalActions.Delays[0].Delay).To(Equal(100))
 
 	Expect(simulationView.MetaView.SchemaVersion).To(Equal("v3"))
 	Expect(simulationView.MetaView.HoverflyVersion).To(Equal("test"))
@@ -324,7 +563,7 @@ func TestSimulationHandler_Put_PassesDataIntoHoverfly(t *testing.T) {
 	Expect(stubHoverfly.Simulation.GlobalActions.Delays[0].Delay).To(Equal(200))
 }
 
-func TestSimulationHandler_Put_CallsDelete(t *testing.T) {
+func TestSimulationHandler_Put_ReplacesSimulationWithoutDeletingIt(t *testing.T) {
 	RegisterTestingT(t)
 
 	stubHoverfly := &HoverflySimulationStub{}
@@ -366,7 +605,8 @@ func TestSimulationHandler_Put_CallsDelete(t *testing.T) {
 
 	makeRequestOnHandler(unit.Put, request)
 
-	Expect(stubHoverfly.Deleted).To(BeTrue())
+	Expect(stubHoverfly.Replaced).To(BeTrue())
+	Expect(stubHoverfly.Deleted).To(BeFalse())
 }
 
 func TestSimulationHandler_Put_ReturnsErrorIfJsonDoesntMatchSchema_MissingDataKey(t *testing.T) {
@@ -426,6 +666,55 @@ func TestSimulationHandler_Put_ReturnsErrorIfJsonIsNotValid(t *testing.T) {
 	Expect(errorView.Error).To(Equal("Invalid JSON"))
 }
 
+func TestSimulationHandler_Put_ReadsYamlWithYamlContentType(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationStub{}
+
+	unit := SimulationHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("PUT", "", ioutil.NopCloser(bytes.NewBuffer([]byte(`
+data:
+  pairs:
+  - request:
+      destination:
+      - matcher: exact
+        value: test.org
+    response:
+      status: 200
+meta:
+  schemaVersion: v5
+`))))
+	Expect(err).To(BeNil())
+	request.Header.Set("Content-Type", "application/x-yaml")
+
+	response := makeRequestOnHandler(unit.Put, request)
+
+	Expect(response.Result().StatusCode).To(Equal(200))
+	Expect(stubHoverfly.Simulation.RequestResponsePairs[0].RequestMatcher.Destination[0].Value).To(Equal("test.org"))
+	Expect(stubHoverfly.Simulation.RequestResponsePairs[0].Response.Status).To(Equal(200))
+}
+
+func TestSimulationHandler_Put_ReturnsErrorIfYamlIsNotValid(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationErrorStub{}
+
+	unit := SimulationHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("PUT", "", ioutil.NopCloser(bytes.NewBuffer([]byte("data: [\n"))))
+	Expect(err).To(BeNil())
+	request.Header.Set("Content-Type", "text/yaml; charset=utf-8")
+
+	response := makeRequestOnHandler(unit.Put, request)
+
+	errorView, err := unmarshalErrorView(response.Body)
+	Expect(err).To(BeNil())
+
+	Expect(response.Result().StatusCode).To(Equal(400))
+	Expect(errorView.Error).To(Equal("Invalid YAML"))
+}
+
 func TestSimulationHandler_Put_ReturnsWarnings(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -489,7 +778,7 @@ func Test_SimulationHandler_Options_GetsOptions(t *testing.T) {
 	response := makeRequestOnHandler(unit.Options, request)
 
 	Expect(response.Code).To(Equal(http.StatusOK))
-	Expect(response.Header().Get("Allow")).To(Equal("OPTIONS, GET, PUT, DELETE"))
+	Expect(response.Header().Get("Allow")).To(Equal("OPTIONS, GET, PUT, POST, DELETE"))
 }
 
 func Test_SimulationHandler_OptionsSchema_GetsOptions(t *testing.T) {
@@ -507,17 +796,17 @@ func Test_SimulationHandler_OptionsSchema_GetsOptions(t *testing.T) {
 	Expect(response.Header().Get("Allow")).To(Equal("OPTIONS, GET"))
 }
 
-func unmarshalSimulationViewV5(buffer *bytes.Buffer) (SimulationViewV5, error) {
+func unmarshalSimulationViewV6(buffer *bytes.Buffer) (SimulationViewV6, error) {
 	body, err := ioutil.ReadAll(buffer)
 	if err != nil {
-		return SimulationViewV5{}, err
+		return SimulationViewV6{}, err
 	}
 
-	var simulationView SimulationViewV5
+	var simulationView SimulationViewV6
 
 	err = json.Unmarshal(body, &simulationView)
 	if err != nil {
-		return SimulationViewV5{}, err
+		return SimulationViewV6{}, err
 	}
 
 	return simulationView, nil
@@ -538,3 +827,680 @@ func unmarshalResultView(buffer *bytes.Buffer) (SimulationImportResult, error) {
 
 	return result, nil
 }
+
+func makeRequestOnSimulationHandler(unit *SimulationHandler, request *http.Request) *httptest.ResponseRecorder {
+	mux := bone.New()
+	unit.RegisterRoutes(mux, &handlers.AuthHandler{})
+
+	responseRecorder := httptest.NewRecorder()
+	mux.ServeHTTP(responseRecorder, request)
+	return responseRecorder
+}
+
+func newSimulationPairViewStub(id, destination string) SimulationPairView {
+	return SimulationPairView{
+		Id: id,
+		RequestMatcherResponsePairViewV6: RequestMatcherResponsePairViewV6{
+			RequestMatcher: RequestMatcherViewV5{
+				Destination: []MatcherViewV5{
+					NewMatcherView(matchers.Exact, destination),
+				},
+			},
+			Response: ResponseDetailsViewV6{
+				Status: 200,
+				Body:   destination,
+			},
+		},
+	}
+}
+
+func TestSimulationHandler_Post_AppendsSimulationWithoutDeleting(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationStub{}
+	unit := SimulationHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("POST", "", ioutil.NopCloser(bytes.NewBuffer([]byte(`
+	{
+		"data": {
+			"pairs": [
+				{
+					"request": {
+						"destination": [{"matcher": "exact", "value": "test.org"}]
+					},
+					"response": {
+						"status": 200
+					}
+				}
+			]
+		},
+		"meta": {
+			"schemaVersion": "v5"
+		}
+	}
+	`))))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Post, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(stubHoverfly.Deleted).To(BeFalse())
+	Expect(stubHoverfly.Appended).To(BeTrue())
+	Expect(stubHoverfly.Simulation.RequestResponsePairs).To(HaveLen(1))
+	Expect(stubHoverfly.Simulation.RequestResponsePairs[0].RequestMatcher.Destination[0].Value).To(Equal("test.org"))
+}
+
+func TestSimulationHandler_Post_ReturnsErrorIfJsonIsNotValid(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationStub{}
+	unit := SimulationHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("POST", "", ioutil.NopCloser(bytes.NewBuffer([]byte(`{}{}[^.^]{}{}`))))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Post, request)
+
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+	Expect(stubHoverfly.Appended).To(BeFalse())
+}
+
+func TestSimulationHandler_Post_ReturnsErrorIfHoverflyErrors(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := SimulationHandler{Hoverfly: &HoverflySimulationErrorStub{}}
+
+	request, err := http.NewRequest("POST", "", ioutil.NopCloser(bytes.NewBuffer([]byte(`{"data": {"pairs": []}, "meta": {"schemaVersion": "v5"}}`))))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Post, request)
+
+	Expect(response.Code).To(Equal(http.StatusInternalServerError))
+
+	errorView, err := unmarshalErrorView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(errorView.Error).To(Equal("An error occured: error"))
+}
+
+func TestSimulationHandler_GetPairs_ReturnsPairsWithIds(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationStub{
+		Pairs: []SimulationPairView{
+			newSimulationPairViewStub("1", "one.com"),
+			newSimulationPairViewStub("2", "two.com"),
+		},
+	}
+	unit := &SimulationHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("GET", "/api/v2/simulation/pairs", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnSimulationHandler(unit, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	var pairsView SimulationPairsView
+	Expect(json.Unmarshal(response.Body.Bytes(), &pairsView)).To(Succeed())
+	Expect(pairsView.Pairs).To(HaveLen(2))
+	Expect(pairsView.Pairs[0].Id).To(Equal("1"))
+	Expect(pairsView.Pairs[0].RequestMatcher.Destination[0].Value).To(Equal("one.com"))
+	Expect(pairsView.Pairs[1].Id).To(Equal("2"))
+}
+
+func TestSimulationHandler_GetPair_ReturnsPair(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationStub{
+		Pairs: []SimulationPairView{newSimulationPairViewStub("1", "one.com")},
+	}
+	unit := &SimulationHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("GET", "/api/v2/simulation/pairs/1", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnSimulationHandler(unit, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	var pairView SimulationPairView
+	Expect(json.Unmarshal(response.Body.Bytes(), &pairView)).To(Succeed())
+	Expect(pairView.Id).To(Equal("1"))
+	Expect(pairView.Response.Body).To(Equal("one.com"))
+}
+
+func TestSimulationHandler_GetPair_ReturnsNotFoundForUnknownPair(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &SimulationHandler{Hoverfly: &HoverflySimulationStub{}}
+
+	request, err := http.NewRequest("GET", "/api/v2/simulation/pairs/1", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnSimulationHandler(unit, request)
+
+	Expect(response.Code).To(Equal(http.StatusNotFound))
+
+	errorView, err := unmarshalErrorView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(errorView.Error).To(Equal("Pair 1 does not exist"))
+}
+
+func TestSimulationHandler_PutPair_UpdatesPair(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationStub{
+		Pairs: []SimulationPairView{newSimulationPairViewStub("1", "one.com")},
+	}
+	unit := &SimulationHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("PUT", "/api/v2/simulation/pairs/1", bytes.NewBufferString(`
+	{
+		"request": {
+			"destination": [{"matcher": "exact", "value": "updated.com"}]
+		},
+		"response": {
+			"status": 201
+		}
+	}`))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnSimulationHandler(unit, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	var pairView SimulationPairView
+	Expect(json.Unmarshal(response.Body.Bytes(), &pairView)).To(Succeed())
+	Expect(pairView.Id).To(Equal("1"))
+	Expect(pairView.RequestMatcher.Destination[0].Value).To(Equal("updated.com"))
+	Expect(pairView.Response.Status).To(Equal(201))
+}
+
+func TestSimulationHandler_PutPair_ReturnsNotFoundForUnknownPair(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &SimulationHandler{Hoverfly: &HoverflySimulationStub{}}
+
+	request, err := http.NewRequest("PUT", "/api/v2/simulation/pairs/1", bytes.NewBufferString(`{"request": {}, "response": {}}`))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnSimulationHandler(unit, request)
+
+	Expect(response.Code).To(Equal(http.StatusNotFound))
+}
+
+func TestSimulationHandler_PutPair_ReturnsBadRequestForMalformedJson(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationStub{
+		Pairs: []SimulationPairView{newSimulationPairViewStub("1", "one.com")},
+	}
+	unit := &SimulationHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("PUT", "/api/v2/simulation/pairs/1", bytes.NewBufferString(`{{`))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnSimulationHandler(unit, request)
+
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+}
+
+func TestSimulationHandler_PutPair_ReturnsBadRequestForInvalidPair(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationStub{
+		Pairs: []SimulationPairView{newSimulationPairViewStub("1", "one.com")},
+	}
+	unit := &SimulationHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("PUT", "/api/v2/simulation/pairs/1", bytes.NewBufferString(`
+	{
+		"request": {
+			"destination": [{"matcher": "exact", "value": "updated.com"}]
+		}
+	}`))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnSimulationHandler(unit, request)
+
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+
+	errorView, err := unmarshalErrorView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(errorView.Error).To(ContainSubstring("response is required"))
+
+	Expect(stubHoverfly.Pairs[0].RequestMatcher.Destination[0].Value).To(Equal("one.com"))
+}
+
+// hoverflyDeletingPairStub deletes a pair just before it is updated, as another request could
+type hoverflyDeletingPairStub struct {
+	*HoverflySimulationStub
+}
+
+func (this hoverflyDeletingPairStub) PutSimulationPair(id string, pairView RequestMatcherResponsePairViewV6) (SimulationPairView, error) {
+	this.DeleteSimulationPair(id)
+
+	return this.HoverflySimulationStub.PutSimulationPair(id, pairView)
+}
+
+func TestSimulationHandler_PutPair_ReturnsNotFoundForPairDeletedDuringUpdate(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &SimulationHandler{Hoverfly: hoverflyDeletingPairStub{&HoverflySimulationStub{
+		Pairs: []SimulationPairView{newSimulationPairViewStub("1", "one.com")},
+	}}}
+
+	request, err := http.NewRequest("PUT", "/api/v2/simulation/pairs/1", bytes.NewBufferString(`{"request": {}, "response": {}}`))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnSimulationHandler(unit, request)
+
+	Expect(response.Code).To(Equal(http.StatusNotFound))
+}
+
+func TestSimulationHandler_PutPair_ReturnsConflictForDuplicateRequestMatcher(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationStub{
+		Pairs: []SimulationPairView{
+			newSimulationPairViewStub("1", "one.com"),
+			newSimulationPairViewStub("2", "two.com"),
+		},
+	}
+	unit := &SimulationHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("PUT", "/api/v2/simulation/pairs/1", bytes.NewBufferString(`
+	{
+		"request": {
+			"destination": [{"matcher": "exact", "value": "two.com"}]
+		},
+		"response": {
+			"status": 200
+		}
+	}`))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnSimulationHandler(unit, request)
+
+	Expect(response.Code).To(Equal(http.StatusConflict))
+
+	errorView, err := unmarshalErrorView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(errorView.Error).To(Equal("Pair 2 has an identical request matcher"))
+}
+
+func TestSimulationHandler_DeletePair_DeletesPair(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationStub{
+		Pairs: []SimulationPairView{
+			newSimulationPairViewStub("1", "one.com"),
+			newSimulationPairViewStub("2", "two.com"),
+		},
+	}
+	unit := &SimulationHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("DELETE", "/api/v2/simulation/pairs/1", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnSimulationHandler(unit, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(stubHoverfly.Pairs).To(HaveLen(1))
+	Expect(stubHoverfly.Pairs[0].Id).To(Equal("2"))
+}
+
+func TestSimulationHandler_DeletePair_ReturnsNotFoundForUnknownPair(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &SimulationHandler{Hoverfly: &HoverflySimulationStub{}}
+
+	request, err := http.NewRequest("DELETE", "/api/v2/simulation/pairs/1", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnSimulationHandler(unit, request)
+
+	Expect(response.Code).To(Equal(http.StatusNotFound))
+}
+
+func Test_SimulationHandler_OptionsPair_GetsOptions(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &SimulationHandler{Hoverfly: &HoverflySimulationStub{}}
+
+	request, err := http.NewRequest("OPTIONS", "/api/v2/simulation/pairs/1", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnSimulationHandler(unit, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(response.Header().Get("Allow")).To(Equal("OPTIONS, GET, PUT, DELETE"))
+}
+
+const harStub = `{
+	"log": {
+		"version": "1.2",
+		"creator": {"name": "Browser", "version": "1"},
+		"entries": [
+			{
+				"startedDateTime": "2017-01-01T00:00:00.000Z",
+				"request": {"method": "GET", "url": "http://test.com/path"},
+				"response": {"status": 200, "content": {"text": "body"}}
+			}
+		]
+	}
+}`
+
+func TestSimulationHandler_Get_WithHarFormatReturnsHar(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := SimulationHandler{Hoverfly: &HoverflySimulationStub{}}
+
+	request, err := http.NewRequest("GET", "/api/v2/simulation?format=har", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Get, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	har, err := NewHarViewFromResponseBody(response.Body.Bytes())
+	Expect(err).To(BeNil())
+	Expect(har.Log.Creator.Version).To(Equal("test"))
+	Expect(har.Log.Entries).To(HaveLen(1))
+	Expect(har.Log.Entries[0].Request.Url).To(Equal("http://test.com/testing"))
+	Expect(har.Log.Entries[0].Response.Content.Text).To(Equal("test-body"))
+}
+
+func TestSimulationHandler_Put_WithHarFormatImportsHarWithOptions(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationStub{}
+	unit := SimulationHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("PUT", "/api/v2/simulation?format=har&headersWhitelist=Content-Type,Accept&stateful=true", bytes.NewBufferString(harStub))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Put, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(stubHoverfly.Replaced).To(BeTrue())
+	Expect(stubHoverfly.Deleted).To(BeFalse())
+	Expect(stubHoverfly.Appended).To(BeFalse())
+	Expect(stubHoverfly.Har.Log.Entries).To(HaveLen(1))
+	Expect(stubHoverfly.HarOptions).To(Equal(ModeArgumentsView{
+		Headers:  []string{"Content-Type", "Accept"},
+		Stateful: true,
+	}))
+}
+
+func TestSimulationHandler_Post_WithHarFormatAppendsHar(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationStub{}
+	unit := SimulationHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("POST", "/api/v2/simulation?format=har", bytes.NewBufferString(harStub))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Post, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(stubHoverfly.Deleted).To(BeFalse())
+	Expect(stubHoverfly.Appended).To(BeTrue())
+	Expect(stubHoverfly.Har.Log.Entries).To(HaveLen(1))
+	Expect(stubHoverfly.HarOptions).To(Equal(ModeArgumentsView{}))
+}
+
+func TestSimulationHandler_Put_WithHarFormatReturnsErrorIfNotAHarFile(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationStub{}
+	unit := SimulationHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("PUT", "/api/v2/simulation?format=har", bytes.NewBufferString(`{"data": {}}`))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Put, request)
+
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+	Expect(stubHoverfly.Deleted).To(BeFalse())
+
+	errorView, err := unmarshalErrorView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(errorView.Error).To(Equal("Invalid HAR file, missing log.version"))
+}
+
+func TestSimulationHandler_Put_WithHarFormatReturnsErrorIfHoverflyErrors(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := SimulationHandler{Hoverfly: &HoverflySimulationErrorStub{}}
+
+	request, err := http.NewRequest("PUT", "/api/v2/simulation?format=har", bytes.NewBufferString(harStub))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Put, request)
+
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+}
+
+const openApiStub = `
+openapi: 3.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        200:
+          content:
+            application/json:
+              example: []
+  /pets/{petId}:
+    delete: {}
+`
+
+func TestSimulationHandler_Put_WithOpenApiFormatImportsOperationsAndReturnsWarnings(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationStub{}
+	unit := SimulationHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("PUT", "/api/v2/simulation?format=openapi", bytes.NewBufferString(openApiStub))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Put, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(stubHoverfly.Replaced).To(BeTrue())
+	Expect(stubHoverfly.Deleted).To(BeFalse())
+	Expect(stubHoverfly.Appended).To(BeFalse())
+	Expect(stubHoverfly.Simulation.RequestResponsePairs).To(HaveLen(1))
+
+	result := SimulationImportResult{}
+	Expect(json.Unmarshal(response.Body.Bytes(), &result)).To(Succeed())
+	Expect(result.WarningMessages).To(Equal([]SimulationImportWarning{
+		{Message: "WARNING: Could not model DELETE /pets/{petId}, it has no responses"},
+	}))
+}
+
+func TestSimulationHandler_Post_WithOpenApiFormatAppendsOperations(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationStub{}
+	unit := SimulationHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("POST", "/api/v2/simulation?format=openapi", bytes.NewBufferString(openApiStub))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Post, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(stubHoverfly.Deleted).To(BeFalse())
+	Expect(stubHoverfly.Appended).To(BeTrue())
+	Expect(stubHoverfly.Simulation.RequestResponsePairs).To(HaveLen(1))
+}
+
+func TestSimulationHandler_Put_WithOpenApiFormatReturnsErrorIfNotASpecification(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationStub{}
+	unit := SimulationHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("PUT", "/api/v2/simulation?format=openapi", bytes.NewBufferString(`{"data": {}}`))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Put, request)
+
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+	Expect(stubHoverfly.Deleted).To(BeFalse())
+
+	errorView, err := unmarshalErrorView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(errorView.Error).To(Equal("Invalid OpenAPI specification, missing openapi version"))
+}
+
+const wireMockStub = `{
+	"request": {
+		"method": "GET",
+		"urlPath": "/pets"
+	},
+	"response": {
+		"status": 200,
+		"body": "pets"
+	}
+}`
+
+func TestSimulationHandler_Get_WithWireMockFormatReturnsMappings(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationStub{}
+	unit := SimulationHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("GET", "/api/v2/simulation?format=wiremock", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Get, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	mappings, err := NewWireMockMappingsViewFromResponseBody(response.Body.Bytes())
+	Expect(err).To(BeNil())
+	Expect(mappings.Mappings).To(HaveLen(1))
+	Expect(*mappings.Mappings[0].Request.UrlPath).To(Equal("/testing"))
+}
+
+func TestSimulationHandler_Get_WithCurlFormatReturnsScript(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationStub{}
+	unit := SimulationHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("GET", "/api/v2/simulation?format=curl&proxy=http://hoverfly:8500", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Get, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(response.Header().Get("Content-Type")).To(HavePrefix("text/plain"))
+	Expect(response.Body.String()).To(ContainSubstring("HOVERFLY_PROXY=${HOVERFLY_PROXY:-'http://hoverfly:8500'}"))
+	Expect(response.Body.String()).To(ContainSubstring(`curl --silent --show-error --proxy "$HOVERFLY_PROXY" -X 'GET' 'http://test.com/testing'`))
+}
+
+func TestSimulationHandler_Get_WithGoHttpTestFormatReturnsGoSource(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationStub{}
+	unit := SimulationHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("GET", "/api/v2/simulation?format=go-httptest&package=fixtures", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Get, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(response.Body.String()).To(ContainSubstring("package fixtures"))
+	Expect(response.Body.String()).To(ContainSubstring(`case r.URL.Path == "/testing":`))
+}
+
+func TestSimulationHandler_Get_WithGoHttpTestFormatReturnsErrorForInvalidPackage(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationStub{}
+	unit := SimulationHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("GET", "/api/v2/simulation?format=go-httptest&package=not-valid", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Get, request)
+
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+
+	errorView, err := unmarshalErrorView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(errorView.Error).To(Equal("not-valid is not a valid Go package name"))
+}
+
+func TestSimulationHandler_Put_WithWireMockFormatImportsMappings(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationStub{}
+	unit := SimulationHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("PUT", "/api/v2/simulation?format=wiremock", bytes.NewBufferString(wireMockStub))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Put, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(stubHoverfly.Replaced).To(BeTrue())
+	Expect(stubHoverfly.Deleted).To(BeFalse())
+	Expect(stubHoverfly.Appended).To(BeFalse())
+	Expect(stubHoverfly.WireMock.Mappings).To(HaveLen(1))
+}
+
+func TestSimulationHandler_Post_WithWireMockFormatAppendsMappings(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationStub{}
+	unit := SimulationHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("POST", "/api/v2/simulation?format=wiremock", bytes.NewBufferString(wireMockStub))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Post, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(stubHoverfly.Deleted).To(BeFalse())
+	Expect(stubHoverfly.Appended).To(BeTrue())
+	Expect(stubHoverfly.WireMock.Mappings).To(HaveLen(1))
+}
+
+func TestSimulationHandler_Put_WithWireMockFormatReturnsErrorIfNotMappings(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationStub{}
+	unit := SimulationHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("PUT", "/api/v2/simulation?format=wiremock", bytes.NewBufferString(`{"data": {}}`))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Put, request)
+
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+	Expect(stubHoverfly.Deleted).To(BeFalse())
+
+	errorView, err := unmarshalErrorView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(errorView.Error).To(Equal("Invalid WireMock mappings, expected a mapping or a list of mappings"))
+}
+
+func TestSimulationHandler_Put_WithWireMockFormatReturnsErrorIfHoverflyErrors(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := SimulationHandler{Hoverfly: &HoverflySimulationErrorStub{}}
+
+	request, err := http.NewRequest("PUT", "/api/v2/simulation?format=wiremock", bytes.NewBufferString(wireMockStub))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Put, request)
+
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+}
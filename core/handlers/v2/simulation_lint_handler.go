@@ -0,0 +1,74 @@
+package v2
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers"
+	"github.com/codegangsta/negroni"
+	"github.com/go-zoo/bone"
+)
+
+type HoverflySimulationLint interface {
+	GetSimulation() (SimulationViewV6, error)
+	LintSimulation(SimulationViewV6) SimulationLintView
+}
+
+type SimulationLintHandler struct {
+	Hoverfly HoverflySimulationLint
+}
+
+func (this *SimulationLintHandler) RegisterRoutes(mux *bone.Mux, am *handlers.AuthHandler) {
+	mux.Get("/api/v2/simulation/lint", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Get),
+	))
+	mux.Post("/api/v2/simulation/lint", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Post),
+	))
+	mux.Options("/api/v2/simulation/lint", negroni.New(
+		negroni.HandlerFunc(this.Options),
+	))
+}
+
+// Get lints the simulation Hoverfly is using
+func (this *SimulationLintHandler) Get(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	simulationView, err := this.Hoverfly.GetSimulation()
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	this.writeLint(w, simulationView)
+}
+
+// Post lints the simulation in the body without importing it, so duplicates which
+// would be dropped on import are also found
+func (this *SimulationLintHandler) Post(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	body, _ := ioutil.ReadAll(req.Body)
+
+	simulationView, err := newSimulationViewFromRequest(req, body)
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	this.writeLint(w, simulationView)
+}
+
+func (this *SimulationLintHandler) Options(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	w.Header().Add("Allow", "OPTIONS, GET, POST")
+	handlers.WriteResponse(w, []byte(""))
+}
+
+func (this *SimulationLintHandler) writeLint(w http.ResponseWriter, simulationView SimulationViewV6) {
+	bytes, err := json.Marshal(this.Hoverfly.LintSimulation(simulationView))
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	handlers.WriteResponse(w, bytes)
+}
@@ -0,0 +1,129 @@
+package v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+type HoverflySimulationLintStub struct {
+	HoverflySimulationStub
+	Linted SimulationViewV6
+}
+
+func (this *HoverflySimulationLintStub) LintSimulation(simulation SimulationViewV6) SimulationLintView {
+	this.Linted = simulation
+	return SimulationLintView{
+		Issues: []SimulationLintIssueView{
+			{
+				Type:    LintDuplicate,
+				Pair:    1,
+				Message: "duplicate",
+			},
+		},
+	}
+}
+
+func Test_SimulationLintHandler_Get_LintsTheCurrentSimulation(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationLintStub{}
+	unit := SimulationLintHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("GET", "/api/v2/simulation/lint", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Get, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(stubHoverfly.Linted.RequestResponsePairs).To(HaveLen(1))
+	Expect(stubHoverfly.Linted.RequestResponsePairs[0].Response.Body).To(Equal("test-body"))
+
+	lintView, err := unmarshalSimulationLintView(response.Body)
+	Expect(err).To(BeNil())
+
+	Expect(lintView.Issues).To(HaveLen(1))
+	Expect(lintView.Issues[0].Type).To(Equal("duplicate"))
+	Expect(lintView.Issues[0].Pair).To(Equal(1))
+}
+
+func Test_SimulationLintHandler_Post_LintsTheSimulationInTheBody(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationLintStub{}
+	unit := SimulationLintHandler{Hoverfly: stubHoverfly}
+
+	body := `{
+		"data": {
+			"pairs": [
+				{"request": {"path": [{"matcher": "exact", "value": "/a"}]}, "response": {"status": 200}},
+				{"request": {"path": [{"matcher": "exact", "value": "/a"}]}, "response": {"status": 201}}
+			],
+			"globalActions": {"delays": []}
+		},
+		"meta": {"schemaVersion": "v6"}
+	}`
+
+	request, err := http.NewRequest("POST", "/api/v2/simulation/lint", bytes.NewBufferString(body))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Post, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(stubHoverfly.Linted.RequestResponsePairs).To(HaveLen(2))
+	Expect(stubHoverfly.Deleted).To(BeFalse())
+	Expect(stubHoverfly.Simulation.RequestResponsePairs).To(BeNil())
+
+	lintView, err := unmarshalSimulationLintView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(lintView.Issues).To(HaveLen(1))
+}
+
+func Test_SimulationLintHandler_Post_ReturnsBadRequestForInvalidSimulation(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationLintStub{}
+	unit := SimulationLintHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("POST", "/api/v2/simulation/lint", bytes.NewBufferString("not a simulation"))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Post, request)
+
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+	Expect(stubHoverfly.Linted.RequestResponsePairs).To(BeNil())
+}
+
+func Test_SimulationLintHandler_Options_GetsOptions(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := SimulationLintHandler{Hoverfly: &HoverflySimulationLintStub{}}
+
+	request, err := http.NewRequest("OPTIONS", "/api/v2/simulation/lint", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Options, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(response.Header().Get("Allow")).To(Equal("OPTIONS, GET, POST"))
+}
+
+func unmarshalSimulationLintView(buffer *bytes.Buffer) (SimulationLintView, error) {
+	body, err := ioutil.ReadAll(buffer)
+	if err != nil {
+		return SimulationLintView{}, err
+	}
+
+	var lintView SimulationLintView
+
+	err = json.Unmarshal(body, &lintView)
+	if err != nil {
+		return SimulationLintView{}, err
+	}
+
+	return lintView, nil
+}
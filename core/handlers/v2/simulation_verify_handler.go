@@ -0,0 +1,69 @@
+package v2
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers"
+	"github.com/codegangsta/negroni"
+	"github.com/go-zoo/bone"
+)
+
+type HoverflySimulationVerify interface {
+	VerifySimulation(*DiffRulesView) (SimulationVerificationView, error)
+}
+
+type SimulationVerifyHandler struct {
+	Hoverfly HoverflySimulationVerify
+}
+
+func (this *SimulationVerifyHandler) RegisterRoutes(mux *bone.Mux, am *handlers.AuthHandler) {
+	mux.Post("/api/v2/simulation/verify", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Post),
+	))
+	mux.Options("/api/v2/simulation/verify", negroni.New(
+		negroni.HandlerFunc(this.Options),
+	))
+}
+
+// Post sends the requests of the simulation to the real service and reports which pairs still
+// describe it. The body can give the diff rules used to compare the responses.
+func (this *SimulationVerifyHandler) Post(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	var verifyView VerifySimulationView
+
+	body, _ := ioutil.ReadAll(req.Body)
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &verifyView); err != nil {
+			handlers.WriteErrorResponse(w, "Malformed JSON", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if verifyView.DiffRules != nil {
+		if err := verifyView.DiffRules.Validate(); err != nil {
+			handlers.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	verification, err := this.Hoverfly.VerifySimulation(verifyView.DiffRules)
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	bytes, err := json.Marshal(verification)
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	handlers.WriteResponse(w, bytes)
+}
+
+func (this *SimulationVerifyHandler) Options(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	w.Header().Add("Allow", "OPTIONS, POST")
+	handlers.WriteResponse(w, []byte(""))
+}
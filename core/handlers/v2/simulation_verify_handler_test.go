@@ -0,0 +1,129 @@
+package v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+type HoverflySimulationVerifyStub struct {
+	DiffRules *DiffRulesView
+}
+
+func (this *HoverflySimulationVerifyStub) VerifySimulation(diffRules *DiffRulesView) (SimulationVerificationView, error) {
+	this.DiffRules = diffRules
+	return SimulationVerificationView{
+		Passed: 1,
+		Failed: 1,
+		Pairs: []PairVerificationView{
+			{Pair: 0, Result: VerificationPassed},
+			{Pair: 1, Result: VerificationFailed, DiffEntries: []DiffReportEntry{{"status", "200", "404"}}},
+		},
+	}, nil
+}
+
+func Test_SimulationVerifyHandler_Post_VerifiesTheSimulation(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationVerifyStub{}
+	unit := SimulationVerifyHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("POST", "/api/v2/simulation/verify", ioutil.NopCloser(bytes.NewBufferString("")))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Post, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(stubHoverfly.DiffRules).To(BeNil())
+
+	verificationView, err := unmarshalSimulationVerificationView(response.Body)
+	Expect(err).To(BeNil())
+
+	Expect(verificationView.Passed).To(Equal(1))
+	Expect(verificationView.Failed).To(Equal(1))
+	Expect(verificationView.Pairs).To(HaveLen(2))
+	Expect(verificationView.Pairs[1].DiffEntries).To(ConsistOf(DiffReportEntry{"status", "200", "404"}))
+}
+
+func Test_SimulationVerifyHandler_Post_UsesDiffRulesInTheBody(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationVerifyStub{}
+	unit := SimulationVerifyHandler{Hoverfly: stubHoverfly}
+
+	body := `{"diffRules": {"ignoreHeaders": ["Date"], "tolerance": 0.5}}`
+	request, err := http.NewRequest("POST", "/api/v2/simulation/verify", ioutil.NopCloser(bytes.NewBufferString(body)))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Post, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(stubHoverfly.DiffRules).To(Equal(&DiffRulesView{
+		IgnoreHeaders: []string{"Date"},
+		Tolerance:     0.5,
+	}))
+}
+
+func Test_SimulationVerifyHandler_Post_RejectsInvalidDiffRules(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationVerifyStub{}
+	unit := SimulationVerifyHandler{Hoverfly: stubHoverfly}
+
+	body := `{"diffRules": {"ignoreRegexes": ["("]}}`
+	request, err := http.NewRequest("POST", "/api/v2/simulation/verify", ioutil.NopCloser(bytes.NewBufferString(body)))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Post, request)
+
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+	Expect(response.Body.String()).To(ContainSubstring("Diff rules have an invalid regex"))
+}
+
+func Test_SimulationVerifyHandler_Post_RejectsMalformedJson(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationVerifyStub{}
+	unit := SimulationVerifyHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("POST", "/api/v2/simulation/verify", ioutil.NopCloser(bytes.NewBufferString("{")))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Post, request)
+
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+}
+
+func Test_SimulationVerifyHandler_Options_GetsOptions(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := SimulationVerifyHandler{Hoverfly: &HoverflySimulationVerifyStub{}}
+
+	request, err := http.NewRequest("OPTIONS", "/api/v2/simulation/verify", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Options, request)
+
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(response.Header().Get("Allow")).To(Equal("OPTIONS, POST"))
+}
+
+func unmarshalSimulationVerificationView(buffer *bytes.Buffer) (SimulationVerificationView, error) {
+	body, err := ioutil.ReadAll(buffer)
+	if err != nil {
+		return SimulationVerificationView{}, err
+	}
+
+	var verificationView SimulationVerificationView
+
+	err = json.Unmarshal(body, &verificationView)
+	if err != nil {
+		return SimulationVerificationView{}, err
+	}
+
+	return verificationView, nil
+}
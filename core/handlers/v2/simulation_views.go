@@ -4,44 +4,62 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"mime"
+	"path/filepath"
 	"time"
 
 	"strings"
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/SpectoLabs/hoverfly/core/handlers/v1"
+	"github.com/SpectoLabs/hoverfly/core/util"
 	"github.com/xeipuuv/gojsonschema"
 )
 
-func NewSimulationViewFromResponseBody(responseBody []byte) (SimulationViewV5, error) {
-	var simulationView SimulationViewV5
+func NewSimulationViewFromResponseBody(responseBody []byte) (SimulationViewV6, error) {
+	var simulationView SimulationViewV6
 
 	jsonMap := make(map[string]interface{})
 
 	if err := json.Unmarshal(responseBody, &jsonMap); err != nil {
-		return SimulationViewV5{}, errors.New("Invalid JSON")
+		return SimulationViewV6{}, errors.New("Invalid JSON")
 	}
 
 	if jsonMap["meta"] == nil {
-		return SimulationViewV5{}, errors.New("Invalid JSON, missing \"meta\" object")
+		return SimulationViewV6{}, errors.New("Invalid JSON, missing \"meta\" object")
 	}
 
 	if jsonMap["meta"].(map[string]interface{})["schemaVersion"] == nil {
-		return SimulationViewV5{}, errors.New("Invalid JSON, missing \"meta.schemaVersion\" string")
+		return SimulationViewV6{}, errors.New("Invalid JSON, missing \"meta.schemaVersion\" string")
 	}
 
 	schemaVersion := jsonMap["meta"].(map[string]interface{})["schemaVersion"].(string)
 
-	if schemaVersion == "v5" {
-		err := ValidateSimulation(jsonMap, SimulationViewV5Schema)
+	if schemaVersion == "v6" {
+		err := ValidateSimulation(jsonMap, SimulationViewV6Schema)
 		if err != nil {
 			return simulationView, errors.New(fmt.Sprintf("Invalid %s simulation:", schemaVersion) + err.Error())
 		}
 
 		err = json.Unmarshal(responseBody, &simulationView)
 		if err != nil {
-			return SimulationViewV5{}, err
+			return SimulationViewV6{}, err
+		}
+	} else if schemaVersion == "v5" {
+		err := ValidateSimulation(jsonMap, SimulationViewV5Schema)
+		if err != nil {
+			return simulationView, errors.New(fmt.Sprintf("Invalid %s simulation:", schemaVersion) + err.Error())
+		}
+
+		var simulationViewV5 SimulationViewV5
+
+		err = json.Unmarshal(responseBody, &simulationViewV5)
+		if err != nil {
+			return SimulationViewV6{}, err
 		}
+
+		simulationView = upgradeV5(simulationViewV5)
 	} else if schemaVersion == "v4" || schemaVersion == "v3" {
 		err := ValidateSimulation(jsonMap, SimulationViewV4Schema)
 		if err != nil {
@@ -52,7 +70,7 @@ func NewSimulationViewFromResponseBody(responseBody []byte) (SimulationViewV5, e
 
 		err = json.Unmarshal(responseBody, &simulationViewV4)
 		if err != nil {
-			return SimulationViewV5{}, err
+			return SimulationViewV6{}, err
 		}
 
 		simulationView = upgradeV4(simulationViewV4)
@@ -66,7 +84,7 @@ func NewSimulationViewFromResponseBody(responseBody []byte) (SimulationViewV5, e
 
 		err = json.Unmarshal(responseBody, &simulationViewV2)
 		if err != nil {
-			return SimulationViewV5{}, err
+			return SimulationViewV6{}, err
 		}
 
 		simulationView = upgradeV2(simulationViewV2)
@@ -80,7 +98,7 @@ func NewSimulationViewFromResponseBody(responseBody []byte) (SimulationViewV5, e
 
 		err = json.Unmarshal(responseBody, &simulationViewV1)
 		if err != nil {
-			return SimulationViewV5{}, err
+			return SimulationViewV6{}, err
 		}
 
 		simulationView = upgradeV1(simulationViewV1)
@@ -92,6 +110,146 @@ func NewSimulationViewFromResponseBody(responseBody []byte) (SimulationViewV5, e
 	return simulationView, nil
 }
 
+// NewSimulationViewFromYaml reads a simulation written in YAML, which
+// follows the same schema as the JSON simulation
+func NewSimulationViewFromYaml(body []byte) (SimulationViewV6, error) {
+	jsonBody, err := util.YAMLToJSON(body)
+	if err != nil {
+		return SimulationViewV6{}, errors.New("Invalid YAML")
+	}
+
+	return NewSimulationViewFromResponseBody(jsonBody)
+}
+
+// IsYamlContentType returns whether a Content-Type header is one of
+// the media types used for YAML, which has no registered media type
+func IsYamlContentType(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	switch mediaType {
+	case "application/x-yaml", "application/yaml", "text/yaml", "text/x-yaml":
+		return true
+	}
+
+	return false
+}
+
+// IsYamlFile returns whether a file path has a YAML extension
+func IsYamlFile(path string) bool {
+	extension := filepath.Ext(path)
+
+	return extension == ".yaml" || extension == ".yml"
+}
+
+// MergeSimulationViews combines the pairs and delays of several simulations in order. When imported,
+// pairs with the same request matcher as an earlier pair are skipped, so the first of them is used.
+// As every view has already been upgraded, the merged simulation uses the v6 schema.
+func MergeSimulationViews(simulationViews []SimulationViewV6) SimulationViewV6 {
+	merged := SimulationViewV6{
+		DataViewV6{
+			RequestResponsePairs: []RequestMatcherResponsePairViewV6{},
+			GlobalActions: GlobalActionsView{
+				Delays: []v1.ResponseDelayView{},
+			},
+		},
+		MetaView{SchemaVersion: "v6"},
+	}
+
+	for _, simulationView := range simulationViews {
+		merged.RequestResponsePairs = append(merged.RequestResponsePairs, simulationView.RequestResponsePairs...)
+		merged.GlobalActions.Delays = append(merged.GlobalActions.Delays, simulationView.GlobalActions.Delays...)
+	}
+
+	return merged
+}
+
+// ReadSimulationDirectory reads the JSON and YAML simulations in a directory, merging them in lexical
+// order of their file names. Every file which is not a valid simulation is listed in the error.
+func ReadSimulationDirectory(directory string) (SimulationViewV6, error) {
+	files, err := ioutil.ReadDir(directory)
+	if err != nil {
+		return SimulationViewV6{}, fmt.Errorf("Could not read directory %s, %s", directory, err.Error())
+	}
+
+	simulations := []SimulationViewV6{}
+	fileErrors := []string{}
+	for _, file := range files {
+		filePath := filepath.Join(directory, file.Name())
+		if file.IsDir() || (filepath.Ext(filePath) != ".json" && !IsYamlFile(filePath)) {
+			continue
+		}
+
+		data, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			fileErrors = append(fileErrors, fmt.Sprintf("%s: %s", filePath, err.Error()))
+			continue
+		}
+
+		var simulation SimulationViewV6
+		if IsYamlFile(filePath) {
+			simulation, err = NewSimulationViewFromYaml(data)
+		} else {
+			simulation, err = NewSimulationViewFromResponseBody(data)
+		}
+		if err != nil {
+			fileErrors = append(fileErrors, fmt.Sprintf("%s: %s", filePath, err.Error()))
+			continue
+		}
+		simulations = append(simulations, simulation)
+	}
+
+	if len(fileErrors) > 0 {
+		return SimulationViewV6{}, fmt.Errorf("Could not read simulations in %s\n\n%s", directory, strings.Join(fileErrors, "\n"))
+	}
+	if len(simulations) == 0 {
+		return SimulationViewV6{}, fmt.Errorf("No JSON or YAML simulations found in %s", directory)
+	}
+
+	return MergeSimulationViews(simulations), nil
+}
+
+// FilterSimulationViewByLabels keeps only the pairs which have every one of the given labels
+func FilterSimulationViewByLabels(simulationView SimulationViewV6, labels []string) SimulationViewV6 {
+	filteredPairs := []RequestMatcherResponsePairViewV6{}
+	for _, pair := range simulationView.RequestResponsePairs {
+		hasLabels := true
+		for _, label := range labels {
+			if !pair.HasLabel(label) {
+				hasLabels = false
+				break
+			}
+		}
+
+		if hasLabels {
+			filteredPairs = append(filteredPairs, pair)
+		}
+	}
+
+	simulationView.RequestResponsePairs = filteredPairs
+	return simulationView
+}
+
+// NewSimulationPairViewFromResponseBody reads a single pair, validating it as a pair of a v6 simulation
+func NewSimulationPairViewFromResponseBody(responseBody []byte) (RequestMatcherResponsePairViewV6, error) {
+	var pairView RequestMatcherResponsePairViewV6
+
+	jsonMap := make(map[string]interface{})
+
+	if err := json.Unmarshal(responseBody, &jsonMap); err != nil {
+		return RequestMatcherResponsePairViewV6{}, errors.New("Invalid JSON")
+	}
+
+	if err := ValidateSimulation(jsonMap, SimulationPairViewV6Schema); err != nil {
+		return RequestMatcherResponsePairViewV6{}, errors.New("Invalid pair:" + err.Error())
+	}
+
+	if err := json.Unmarshal(responseBody, &pairView); err != nil {
+		return RequestMatcherResponsePairViewV6{}, err
+	}
+
+	return pairView, nil
+}
+
 func ValidateSimulation(json, schema map[string]interface{}) error {
 	jsonLoader := gojsonschema.NewGoLoader(json)
 	schemaLoader := gojsonschema.NewGoLoader(schema)
@@ -131,14 +289,14 @@ type MetaView struct {
 func NewMetaView(version string) *MetaView {
 	return &MetaView{
 		HoverflyVersion: version,
-		SchemaVersion:   "v5",
+		SchemaVersion:   "v6",
 		TimeExported:    time.Now().Format(time.RFC3339),
 	}
 }
 
-func BuildSimulationView(pairViews []RequestMatcherResponsePairViewV5, delayView v1.ResponseDelayPayloadView, version string) SimulationViewV5 {
-	return SimulationViewV5{
-		DataViewV5{
+func BuildSimulationView(pairViews []RequestMatcherResponsePairViewV6, delayView v1.ResponseDelayPayloadView, version string) SimulationViewV6 {
+	return SimulationViewV6{
+		DataViewV6{
 			RequestResponsePairs: pairViews,
 			GlobalActions: GlobalActionsView{
 				Delays: delayView.Data,
@@ -152,6 +310,7 @@ const deprecatedQueryMessage = "Usage of deprecated field `deprecatedQuery` on d
 const deprecatedQueryDocs = "https://hoverfly.readthedocs.io/en/latest/pages/troubleshooting/troubleshooting.html#why-does-my-simulation-have-a-deprecatedquery-field"
 const ContentLengthAndTransferEncodingMessage = "Response contains both Content-Length and Transfer-Encoding headers on data.pairs[%v].response, please remove one of these headers"
 const ContentLengthMismatchMessage = "Response contains incorrect Content-Length header on data.pairs[%v].response, please correct or remove header"
+const DuplicatePairIdMessage = "Pair id %s on data.pairs[%v].id is already used by another pair, so a new id was generated"
 
 type SimulationImportResult struct {
 	err             error                     `json:"error,omitempty"`
@@ -194,3 +353,11 @@ func (s *SimulationImportResult) AddContentLengthMismatchWarning(requestNumber i
 	}
 	s.WarningMessages = append(s.WarningMessages, SimulationImportWarning{Message: warning})
 }
+
+func (s *SimulationImportResult) AddDuplicatePairIdWarning(requestNumber int, id string) {
+	warning := fmt.Sprintf("WARNING: %s", fmt.Sprintf(DuplicatePairIdMessage, id, requestNumber))
+	if s.WarningMessages == nil {
+		s.WarningMessages = []SimulationImportWarning{}
+	}
+	s.WarningMessages = append(s.WarningMessages, SimulationImportWarning{Message: warning})
+}
@@ -278,6 +278,316 @@ func Test_NewSimulationViewFromResponseBody_WontCreateSimulationFromInvalidJson(
 	Expect(simulation.GlobalActions.Delays).To(HaveLen(0))
 }
 
+func Test_NewSimulationViewFromResponseBody_CanCreateSimulationFromV6Payload(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation, err := v2.NewSimulationViewFromResponseBody([]byte(`{
+		"data": {
+			"pairs": [
+				{
+					"id": "get-payment",
+					"labels": ["payments", "smoke"],
+					"description": "Returns a settled payment",
+					"request": {
+						"path": [{"matcher": "exact", "value": "/payments/1"}]
+					},
+					"response": {
+						"status": 200,
+						"body": "settled"
+					}
+				}
+			],
+			"globalActions": {
+				"delays": []
+			}
+		},
+		"meta": {
+			"schemaVersion": "v6"
+		}
+	}`))
+
+	Expect(err).To(BeNil())
+
+	Expect(simulation.RequestResponsePairs).To(HaveLen(1))
+
+	Expect(simulation.RequestResponsePairs[0].Id).To(Equal("get-payment"))
+	Expect(simulation.RequestResponsePairs[0].Labels).To(Equal([]string{"payments", "smoke"}))
+	Expect(simulation.RequestResponsePairs[0].Description).To(Equal("Returns a settled payment"))
+	Expect(simulation.RequestResponsePairs[0].RequestMatcher.Path[0].Value).To(Equal("/payments/1"))
+	Expect(simulation.RequestResponsePairs[0].Response.Body).To(Equal("settled"))
+}
+
+func Test_NewSimulationViewFromResponseBody_WontCreateSimulationFromV6PayloadWithInvalidLabels(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := v2.NewSimulationViewFromResponseBody([]byte(`{
+		"data": {
+			"pairs": [
+				{
+					"labels": "payments",
+					"request": {},
+					"response": {"status": 200}
+				}
+			],
+			"globalActions": {
+				"delays": []
+			}
+		},
+		"meta": {
+			"schemaVersion": "v6"
+		}
+	}`))
+
+	Expect(err).ToNot(BeNil())
+}
+
+func Test_NewSimulationViewFromResponseBody_UpgradesV5PayloadWithoutIds(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation, err := v2.NewSimulationViewFromResponseBody([]byte(`{
+		"data": {
+			"pairs": [
+				{
+					"request": {
+						"path": [{"matcher": "exact", "value": "/payments/1"}]
+					},
+					"response": {
+						"status": 200
+					}
+				}
+			],
+			"globalActions": {
+				"delays": []
+			}
+		},
+		"meta": {
+			"schemaVersion": "v5"
+		}
+	}`))
+
+	Expect(err).To(BeNil())
+
+	Expect(simulation.RequestResponsePairs).To(HaveLen(1))
+	Expect(simulation.RequestResponsePairs[0].Id).To(BeEmpty())
+	Expect(simulation.RequestResponsePairs[0].RequestMatcher.Path[0].Value).To(Equal("/payments/1"))
+}
+
+func Test_NewSimulationViewFromResponseBody_ReadsScheduledTransitionsFromV6Payload(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation, err := v2.NewSimulationViewFromResponseBody([]byte(`{
+		"data": {
+			"pairs": [
+				{
+					"request": {},
+					"response": {
+						"status": 201,
+						"scheduledTransitions": {
+							"order": {"value": "shipped", "delay": 100}
+						}
+					}
+				}
+			],
+			"globalActions": {
+				"delays": []
+			}
+		},
+		"meta": {
+			"schemaVersion": "v6",
+			"hoverflyVersion": "v0.11.0",
+			"timeExported": "2017-02-23T12:43:48Z"
+		}
+	}`))
+
+	Expect(err).To(BeNil())
+
+	Expect(simulation.RequestResponsePairs[0].Response.ScheduledTransitions).To(Equal(map[string]v2.StateTransitionView{
+		"order": {Value: "shipped", Delay: 100},
+	}))
+}
+
+func Test_NewSimulationViewFromResponseBody_WontCreateSimulationWithNegativeScheduledTransitionDelay(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := v2.NewSimulationViewFromResponseBody([]byte(`{
+		"data": {
+			"pairs": [
+				{
+					"request": {},
+					"response": {
+						"status": 201,
+						"scheduledTransitions": {
+							"order": {"value": "shipped", "delay": -1}
+						}
+					}
+				}
+			],
+			"globalActions": {
+				"delays": []
+			}
+		},
+		"meta": {
+			"schemaVersion": "v6",
+			"hoverflyVersion": "v0.11.0",
+			"timeExported": "2017-02-23T12:43:48Z"
+		}
+	}`))
+
+	Expect(err).ToNot(BeNil())
+}
+
+func Test_NewSimulationViewFromResponseBody_IgnoresScheduledTransitionsInV5Payload(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation, err := v2.NewSimulationViewFromResponseBody([]byte(`{
+		"data": {
+			"pairs": [
+				{
+					"request": {},
+					"response": {
+						"status": 201,
+						"scheduledTransitions": {
+							"order": {"value": "shipped", "delay": 100}
+						}
+					}
+				}
+			],
+			"globalActions": {
+				"delays": []
+			}
+		},
+		"meta": {
+			"schemaVersion": "v5",
+			"hoverflyVersion": "v0.11.0",
+			"timeExported": "2017-02-23T12:43:48Z"
+		}
+	}`))
+
+	Expect(err).To(BeNil())
+
+	Expect(simulation.RequestResponsePairs[0].Response.Status).To(Equal(201))
+	Expect(simulation.RequestResponsePairs[0].Response.ScheduledTransitions).To(BeNil())
+}
+
+func Test_NewSimulationViewFromYaml_CanCreateSimulationFromYaml(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation, err := v2.NewSimulationViewFromYaml([]byte(`
+data:
+  pairs:
+  - request:
+      path:
+      - matcher: exact
+        value: /pets
+    response:
+      status: 200
+      body: '{"id": 1}'
+meta:
+  schemaVersion: v5
+`))
+	Expect(err).To(BeNil())
+
+	Expect(simulation.RequestResponsePairs).To(HaveLen(1))
+	Expect(simulation.RequestResponsePairs[0].RequestMatcher.Path[0].Value).To(Equal("/pets"))
+	Expect(simulation.RequestResponsePairs[0].Response.Body).To(Equal(`{"id": 1}`))
+}
+
+func Test_NewSimulationViewFromYaml_WontCreateSimulationFromInvalidYaml(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := v2.NewSimulationViewFromYaml([]byte("data: [\n"))
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Invalid YAML"))
+
+	_, err = v2.NewSimulationViewFromYaml([]byte("meta:\n  schemaVersion: v5\n"))
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Invalid v5 simulation: data is required"))
+}
+
+func Test_IsYamlContentType_RecognisesYamlMediaTypes(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(v2.IsYamlContentType("application/x-yaml")).To(BeTrue())
+	Expect(v2.IsYamlContentType("application/yaml")).To(BeTrue())
+	Expect(v2.IsYamlContentType("text/yaml; charset=utf-8")).To(BeTrue())
+	Expect(v2.IsYamlContentType("application/json")).To(BeFalse())
+	Expect(v2.IsYamlContentType("")).To(BeFalse())
+}
+
+func Test_MergeSimulationViews_CombinesPairsAndDelaysInOrder(t *testing.T) {
+	RegisterTestingT(t)
+
+	first, err := v2.NewSimulationViewFromYaml([]byte(`
+data:
+  pairs:
+  - request:
+      path:
+      - matcher: exact
+        value: /a
+    response:
+      status: 200
+  globalActions:
+    delays:
+    - urlPattern: a.com
+      delay: 100
+meta:
+  schemaVersion: v5
+`))
+	Expect(err).To(BeNil())
+
+	second, err := v2.NewSimulationViewFromResponseBody([]byte(`{
+		"data": {
+			"pairs": [{"request": {"path": [{"matcher": "exact", "value": "/b"}]}, "response": {"status": 201}}]
+		},
+		"meta": {"schemaVersion": "v5"}
+	}`))
+	Expect(err).To(BeNil())
+
+	merged := v2.MergeSimulationViews([]v2.SimulationViewV6{first, second})
+
+	Expect(merged.RequestResponsePairs).To(HaveLen(2))
+	Expect(merged.RequestResponsePairs[0].RequestMatcher.Path[0].Value).To(Equal("/a"))
+	Expect(merged.RequestResponsePairs[1].RequestMatcher.Path[0].Value).To(Equal("/b"))
+	Expect(merged.GlobalActions.Delays).To(HaveLen(1))
+	Expect(merged.SchemaVersion).To(Equal("v6"))
+}
+
+func Test_FilterSimulationViewByLabels_KeepsPairsWithEveryLabel(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation := v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				{Id: "one", Labels: []string{"payments"}},
+				{Id: "two", Labels: []string{"payments", "smoke"}},
+				{Id: "three"},
+			},
+		},
+		v2.MetaView{SchemaVersion: "v6"},
+	}
+
+	Expect(v2.FilterSimulationViewByLabels(simulation, []string{"payments"}).RequestResponsePairs).To(HaveLen(2))
+
+	filtered := v2.FilterSimulationViewByLabels(simulation, []string{"payments", "smoke"})
+	Expect(filtered.RequestResponsePairs).To(HaveLen(1))
+	Expect(filtered.RequestResponsePairs[0].Id).To(Equal("two"))
+
+	Expect(v2.FilterSimulationViewByLabels(simulation, []string{"accounts"}).RequestResponsePairs).To(BeEmpty())
+	Expect(simulation.RequestResponsePairs).To(HaveLen(3))
+}
+
+func Test_SimulationImportResult_AddDuplicatePairIdWarning_AddsWarning(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := v2.SimulationImportResult{}
+	unit.AddDuplicatePairIdWarning(3, "get-payment")
+
+	Expect(unit.WarningMessages).To(HaveLen(1))
+	Expect(unit.WarningMessages[0].Message).To(ContainSubstring("WARNING"))
+	Expect(unit.WarningMessages[0].Message).To(ContainSubstring("get-payment"))
+	Expect(unit.WarningMessages[0].Message).To(ContainSubstring("data.pairs[3].id"))
+}
+
 func Test_SimulationImportResult_AddDeprecatedQueryWarning_AddsWarning(t *testing.T) {
 	RegisterTestingT(t)
 
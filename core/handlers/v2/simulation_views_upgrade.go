@@ -7,8 +7,8 @@ import (
 	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
 )
 
-func upgradeV1(originalSimulation SimulationViewV1) SimulationViewV5 {
-	var pairs []RequestMatcherResponsePairViewV5
+func upgradeV1(originalSimulation SimulationViewV1) SimulationViewV6 {
+	var pairs []RequestMatcherResponsePairViewV6
 	for _, pairV1 := range originalSimulation.RequestResponsePairViewV1 {
 
 		schemeMatchers := []MatcherViewV5{}
@@ -108,7 +108,7 @@ func upgradeV1(originalSimulation SimulationViewV1) SimulationViewV5 {
 
 		headersWithMatchers := getMatchersFromRequestHeaders(pairV1.Request.Headers)
 
-		pair := RequestMatcherResponsePairViewV5{
+		pair := RequestMatcherResponsePairViewV6{
 			RequestMatcher: RequestMatcherViewV5{
 				Scheme:          schemeMatchers,
 				Method:          methodMatchers,
@@ -119,7 +119,7 @@ func upgradeV1(originalSimulation SimulationViewV1) SimulationViewV5 {
 				RequiresState:   nil,
 				DeprecatedQuery: queryMatchers,
 			},
-			Response: ResponseDetailsViewV5{
+			Response: ResponseDetailsViewV6{
 				Body:             pairV1.Response.Body,
 				EncodedBody:      pairV1.Response.EncodedBody,
 				Headers:          pairV1.Response.Headers,
@@ -132,16 +132,16 @@ func upgradeV1(originalSimulation SimulationViewV1) SimulationViewV5 {
 		pairs = append(pairs, pair)
 	}
 
-	return SimulationViewV5{
-		DataViewV5{
+	return SimulationViewV6{
+		DataViewV6{
 			RequestResponsePairs: pairs,
 		},
 		newMetaView(originalSimulation.MetaView),
 	}
 }
 
-func upgradeV2(originalSimulation SimulationViewV2) SimulationViewV5 {
-	requestReponsePairs := []RequestMatcherResponsePairViewV5{}
+func upgradeV2(originalSimulation SimulationViewV2) SimulationViewV6 {
+	requestReponsePairs := []RequestMatcherResponsePairViewV6{}
 
 	for _, requestResponsePairV2 := range originalSimulation.DataViewV2.RequestResponsePairs {
 		schemeMatchers := []MatcherViewV5{}
@@ -176,7 +176,7 @@ func upgradeV2(originalSimulation SimulationViewV2) SimulationViewV5 {
 
 		headersWithMatchers := getMatchersFromRequestHeaders(requestResponsePairV2.RequestMatcher.Headers)
 
-		requestResponsePair := RequestMatcherResponsePairViewV5{
+		requestResponsePair := RequestMatcherResponsePairViewV6{
 			RequestMatcher: RequestMatcherViewV5{
 				Destination:     destinationMatchers,
 				Headers:         headersWithMatchers,
@@ -187,7 +187,7 @@ func upgradeV2(originalSimulation SimulationViewV2) SimulationViewV5 {
 				RequiresState:   nil,
 				DeprecatedQuery: queryMatchers,
 			},
-			Response: ResponseDetailsViewV5{
+			Response: ResponseDetailsViewV6{
 				Body:             requestResponsePairV2.Response.Body,
 				EncodedBody:      requestResponsePairV2.Response.EncodedBody,
 				Headers:          requestResponsePairV2.Response.Headers,
@@ -201,8 +201,8 @@ func upgradeV2(originalSimulation SimulationViewV2) SimulationViewV5 {
 		requestReponsePairs = append(requestReponsePairs, requestResponsePair)
 	}
 
-	return SimulationViewV5{
-		DataViewV5{
+	return SimulationViewV6{
+		DataViewV6{
 			RequestResponsePairs: requestReponsePairs,
 			GlobalActions:        originalSimulation.GlobalActions,
 		},
@@ -210,8 +210,8 @@ func upgradeV2(originalSimulation SimulationViewV2) SimulationViewV5 {
 	}
 }
 
-func upgradeV4(originalSimulation SimulationViewV4) SimulationViewV5 {
-	requestReponsePairs := []RequestMatcherResponsePairViewV5{}
+func upgradeV4(originalSimulation SimulationViewV4) SimulationViewV6 {
+	requestReponsePairs := []RequestMatcherResponsePairViewV6{}
 
 	for _, requestResponsePairV2 := range originalSimulation.DataViewV4.RequestResponsePairs {
 		schemeMatchers := []MatcherViewV5{}
@@ -263,7 +263,7 @@ func upgradeV4(originalSimulation SimulationViewV4) SimulationViewV5 {
 			}
 		}
 
-		requestResponsePair := RequestMatcherResponsePairViewV5{
+		requestResponsePair := RequestMatcherResponsePairViewV6{
 			RequestMatcher: RequestMatcherViewV5{
 				Destination:     destinationMatchers,
 				Method:          methodMatchers,
@@ -275,7 +275,7 @@ func upgradeV4(originalSimulation SimulationViewV4) SimulationViewV5 {
 				RequiresState:   requestResponsePairV2.RequestMatcher.RequiresState,
 				DeprecatedQuery: queryMatchers,
 			},
-			Response: ResponseDetailsViewV5{
+			Response: ResponseDetailsViewV6{
 				Body:             requestResponsePairV2.Response.Body,
 				EncodedBody:      requestResponsePairV2.Response.EncodedBody,
 				Headers:          requestResponsePairV2.Response.Headers,
@@ -289,8 +289,35 @@ func upgradeV4(originalSimulation SimulationViewV4) SimulationViewV5 {
 		requestReponsePairs = append(requestReponsePairs, requestResponsePair)
 	}
 
-	return SimulationViewV5{
-		DataViewV5{
+	return SimulationViewV6{
+		DataViewV6{
+			RequestResponsePairs: requestReponsePairs,
+			GlobalActions:        originalSimulation.GlobalActions,
+		},
+		newMetaView(originalSimulation.MetaView),
+	}
+}
+
+func upgradeV5(originalSimulation SimulationViewV5) SimulationViewV6 {
+	requestReponsePairs := []RequestMatcherResponsePairViewV6{}
+
+	for _, requestResponsePairV5 := range originalSimulation.DataViewV5.RequestResponsePairs {
+		requestReponsePairs = append(requestReponsePairs, RequestMatcherResponsePairViewV6{
+			RequestMatcher: requestResponsePairV5.RequestMatcher,
+			Response: ResponseDetailsViewV6{
+				Status:           requestResponsePairV5.Response.Status,
+				Body:             requestResponsePairV5.Response.Body,
+				EncodedBody:      requestResponsePairV5.Response.EncodedBody,
+				Headers:          requestResponsePairV5.Response.Headers,
+				Templated:        requestResponsePairV5.Response.Templated,
+				TransitionsState: requestResponsePairV5.Response.TransitionsState,
+				RemovesState:     requestResponsePairV5.Response.RemovesState,
+			},
+		})
+	}
+
+	return SimulationViewV6{
+		DataViewV6{
 			RequestResponsePairs: requestReponsePairs,
 			GlobalActions:        originalSimulation.GlobalActions,
 		},
@@ -362,7 +389,7 @@ func v2GetMatchersFromRequestFieldMatchersView(requestFieldMatchers *RequestFiel
 
 func newMetaView(originalMeta MetaView) MetaView {
 	return MetaView{
-		SchemaVersion:   "v5",
+		SchemaVersion:   "v6",
 		HoverflyVersion: originalMeta.HoverflyVersion,
 		TimeExported:    originalMeta.TimeExported,
 	}
@@ -3,6 +3,7 @@ package v2
 import (
 	"testing"
 
+	"github.com/SpectoLabs/hoverfly/core/handlers/v1"
 	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
 	"github.com/SpectoLabs/hoverfly/core/util"
 	. "github.com/onsi/gomega"
@@ -93,7 +94,7 @@ func Test_upgradeV1_ReturnsAnUpgradedSimulation(t *testing.T) {
 	Expect(upgradedSimulation.RequestResponsePairs[0].Response.EncodedBody).To(BeFalse())
 	Expect(upgradedSimulation.RequestResponsePairs[0].Response.Headers).To(HaveKeyWithValue("Test", []string{"headers"}))
 
-	Expect(upgradedSimulation.SchemaVersion).To(Equal("v5"))
+	Expect(upgradedSimulation.SchemaVersion).To(Equal("v6"))
 	Expect(upgradedSimulation.HoverflyVersion).To(Equal("test"))
 	Expect(upgradedSimulation.TimeExported).To(Equal("today"))
 }
@@ -311,7 +312,7 @@ func Test_upgradeV2_ReturnsAnUpgradedSimulation(t *testing.T) {
 	Expect(upgradedSimulation.RequestResponsePairs[0].Response.EncodedBody).To(BeFalse())
 	Expect(upgradedSimulation.RequestResponsePairs[0].Response.Headers).To(HaveKeyWithValue("Test", []string{"headers"}))
 
-	Expect(upgradedSimulation.SchemaVersion).To(Equal("v5"))
+	Expect(upgradedSimulation.SchemaVersion).To(Equal("v6"))
 	Expect(upgradedSimulation.HoverflyVersion).To(Equal("test"))
 	Expect(upgradedSimulation.TimeExported).To(Equal("today"))
 }
@@ -534,7 +535,7 @@ func Test_upgradeV4_ReturnsAnUpgradedSimulation(t *testing.T) {
 	Expect(upgradedSimulation.RequestResponsePairs[0].Response.EncodedBody).To(BeFalse())
 	Expect(upgradedSimulation.RequestResponsePairs[0].Response.Headers).To(HaveKeyWithValue("Test", []string{"headers"}))
 
-	Expect(upgradedSimulation.SchemaVersion).To(Equal("v5"))
+	Expect(upgradedSimulation.SchemaVersion).To(Equal("v6"))
 	Expect(upgradedSimulation.HoverflyVersion).To(Equal("test"))
 	Expect(upgradedSimulation.TimeExported).To(Equal("today"))
 }
@@ -846,3 +847,52 @@ func Test_upgradeV4_HandlesNewQueries(t *testing.T) {
 	Expect((*upgradedSimulation.RequestResponsePairs[0].RequestMatcher.Query)["test"][1].Matcher).To(Equal("glob"))
 	Expect((*upgradedSimulation.RequestResponsePairs[0].RequestMatcher.Query)["test"][1].Value).To(Equal("testglob"))
 }
+
+func Test_upgradeV5_ReturnsAnUpgradedSimulation(t *testing.T) {
+	RegisterTestingT(t)
+
+	v5Simulation := SimulationViewV5{
+		DataViewV5{
+			RequestResponsePairs: []RequestMatcherResponsePairViewV5{
+				{
+					RequestMatcher: RequestMatcherViewV5{
+						Path: []MatcherViewV5{
+							NewMatcherView(matchers.Exact, "/path"),
+						},
+					},
+					Response: ResponseDetailsViewV5{
+						Status:           200,
+						Body:             "body",
+						TransitionsState: map[string]string{"page": "2"},
+					},
+				},
+			},
+			GlobalActions: GlobalActionsView{
+				Delays: []v1.ResponseDelayView{
+					{
+						UrlPattern: "test.com",
+						Delay:      100,
+					},
+				},
+			},
+		},
+		v2Meta,
+	}
+
+	upgradedSimulation := upgradeV5(v5Simulation)
+
+	Expect(upgradedSimulation.RequestResponsePairs).To(HaveLen(1))
+
+	Expect(upgradedSimulation.RequestResponsePairs[0].Id).To(BeEmpty())
+	Expect(upgradedSimulation.RequestResponsePairs[0].Labels).To(BeEmpty())
+	Expect(upgradedSimulation.RequestResponsePairs[0].Description).To(BeEmpty())
+	Expect(upgradedSimulation.RequestResponsePairs[0].RequestMatcher.Path[0].Value).To(Equal("/path"))
+	Expect(upgradedSimulation.RequestResponsePairs[0].Response.Body).To(Equal("body"))
+	Expect(upgradedSimulation.RequestResponsePairs[0].Response.Status).To(Equal(200))
+	Expect(upgradedSimulation.RequestResponsePairs[0].Response.TransitionsState).To(Equal(map[string]string{"page": "2"}))
+
+	Expect(upgradedSimulation.GlobalActions.Delays).To(HaveLen(1))
+	Expect(upgradedSimulation.GlobalActions.Delays[0].UrlPattern).To(Equal("test.com"))
+
+	Expect(upgradedSimulation.SchemaVersion).To(Equal("v6"))
+}
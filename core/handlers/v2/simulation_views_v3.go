@@ -57,4 +57,8 @@ func (this ResponseDetailsViewV3) GetHeaders() map[string][]string { return this
 
 func (this ResponseDetailsViewV3) GetTransitionsState() map[string]string { return nil }
 
+func (this ResponseDetailsViewV3) GetScheduledTransitions() map[string]interfaces.StateTransition {
+	return nil
+}
+
 func (this ResponseDetailsViewV3) GetRemovesState() []string { return nil }
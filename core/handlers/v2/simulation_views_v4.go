@@ -63,6 +63,10 @@ func (this ResponseDetailsViewV4) GetTransitionsState() map[string]string {
 	return this.TransitionsState
 }
 
+func (this ResponseDetailsViewV4) GetScheduledTransitions() map[string]interfaces.StateTransition {
+	return nil
+}
+
 func (this ResponseDetailsViewV4) GetRemovesState() []string { return this.RemovesState }
 
 // Gets Headers - required for interfaces.Response
@@ -75,6 +75,10 @@ func (this ResponseDetailsViewV5) GetTransitionsState() map[string]string {
 	return this.TransitionsState
 }
 
+func (this ResponseDetailsViewV5) GetScheduledTransitions() map[string]interfaces.StateTransition {
+	return nil
+}
+
 func (this ResponseDetailsViewV5) GetRemovesState() []string { return this.RemovesState }
 
 // Gets Headers - required for interfaces.Response
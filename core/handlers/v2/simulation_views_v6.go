@@ -0,0 +1,94 @@
+package v2
+
+import (
+	"github.com/SpectoLabs/hoverfly/core/interfaces"
+)
+
+type SimulationViewV6 struct {
+	DataViewV6 `json:"data"`
+	MetaView   `json:"meta"`
+}
+
+type DataViewV6 struct {
+	RequestResponsePairs []RequestMatcherResponsePairViewV6 `json:"pairs"`
+	GlobalActions        GlobalActionsView                  `json:"globalActions"`
+}
+
+// RequestMatcherResponsePairViewV6 adds an id, labels and a description to each pair. The id
+// is generated on import when it is not given, and is what the journal records for a match.
+type RequestMatcherResponsePairViewV6 struct {
+	Id             string                `json:"id,omitempty"`
+	Labels         []string              `json:"labels,omitempty"`
+	Description    string                `json:"description,omitempty"`
+	RequestMatcher RequestMatcherViewV5  `json:"request"`
+	Response       ResponseDetailsViewV6 `json:"response"`
+}
+
+//Gets Response - required for interfaces.RequestResponsePairView
+func (this RequestMatcherResponsePairViewV6) GetResponse() interfaces.Response { return this.Response }
+
+// HasLabel returns whether the pair has been given the label
+func (this RequestMatcherResponsePairViewV6) HasLabel(label string) bool {
+	for _, pairLabel := range this.Labels {
+		if pairLabel == label {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ResponseDetailsViewV6 adds scheduled transitions to the response, which change or expire
+// state once their delay in milliseconds has passed
+type ResponseDetailsViewV6 struct {
+	Status               int                            `json:"status"`
+	Body                 string                         `json:"body"`
+	EncodedBody          bool                           `json:"encodedBody"`
+	Headers              map[string][]string            `json:"headers,omitempty"`
+	Templated            bool                           `json:"templated"`
+	TransitionsState     map[string]string              `json:"transitionsState,omitempty"`
+	ScheduledTransitions map[string]StateTransitionView `json:"scheduledTransitions,omitempty"`
+	RemovesState         []string                       `json:"removesState,omitempty"`
+}
+
+type StateTransitionView struct {
+	Value string `json:"value,omitempty"`
+	Delay int    `json:"delay"`
+}
+
+func (this StateTransitionView) GetValue() string { return this.Value }
+
+func (this StateTransitionView) GetDelay() int { return this.Delay }
+
+//Gets Status - required for interfaces.Response
+func (this ResponseDetailsViewV6) GetStatus() int { return this.Status }
+
+// Gets Body - required for interfaces.Response
+func (this ResponseDetailsViewV6) GetBody() string { return this.Body }
+
+// Gets EncodedBody - required for interfaces.Response
+func (this ResponseDetailsViewV6) GetEncodedBody() bool { return this.EncodedBody }
+
+func (this ResponseDetailsViewV6) GetTemplated() bool { return this.Templated }
+
+func (this ResponseDetailsViewV6) GetTransitionsState() map[string]string {
+	return this.TransitionsState
+}
+
+func (this ResponseDetailsViewV6) GetScheduledTransitions() map[string]interfaces.StateTransition {
+	if this.ScheduledTransitions == nil {
+		return nil
+	}
+
+	transitions := map[string]interfaces.StateTransition{}
+	for key, transition := range this.ScheduledTransitions {
+		transitions[key] = transition
+	}
+
+	return transitions
+}
+
+func (this ResponseDetailsViewV6) GetRemovesState() []string { return this.RemovesState }
+
+// Gets Headers - required for interfaces.Response
+func (this ResponseDetailsViewV6) GetHeaders() map[string][]string { return this.Headers }
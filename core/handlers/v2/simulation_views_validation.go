@@ -518,3 +518,133 @@ var v5MatchersMapDefinition = map[string]interface{}{
 		},
 	},
 }
+
+// V6 Schema
+
+var SimulationViewV6Schema = map[string]interface{}{
+	"description": "Hoverfly simulation schema",
+	"type":        "object",
+	"required": []string{
+		"data", "meta",
+	},
+	"additionalProperties": false,
+	"properties": map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"pairs": map[string]interface{}{
+					"type": "array",
+					"items": map[string]interface{}{
+						"$ref": "#/definitions/request-response-pair",
+					},
+				},
+				"globalActions": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"delays": map[string]interface{}{
+							"type": "array",
+							"items": map[string]interface{}{
+								"$ref": "#/definitions/delay",
+							},
+						},
+					},
+				},
+			},
+		},
+		"meta": map[string]interface{}{
+			"$ref": "#/definitions/meta",
+		},
+	},
+	"definitions": map[string]interface{}{
+		"request-response-pair": requestResponsePairV6Definition,
+		"request":               requestV5Definition,
+		"response":              responseDefinitionV6,
+		"field-matchers":        requestFieldMatchersV5Definition,
+		"headers":               headersDefinition,
+		"request-headers":       v5MatchersMapDefinition,
+		"request-queries":       v5MatchersMapDefinition,
+		"delay":                 delaysDefinition,
+		"meta":                  metaDefinition,
+	},
+}
+
+var responseDefinitionV6 = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"body": map[string]interface{}{
+			"type": "string",
+		},
+		"encodedBody": map[string]interface{}{
+			"type": "boolean",
+		},
+		"headers": map[string]interface{}{
+			"$ref": "#/definitions/headers",
+		},
+		"status": map[string]interface{}{
+			"type": "integer",
+		},
+		"templated": map[string]interface{}{
+			"type": "boolean",
+		},
+		"removesState": map[string]interface{}{
+			"type": "array",
+		},
+		"transitionsState": map[string]interface{}{
+			"type": "object",
+			"patternProperties": map[string]interface{}{
+				".{1,}": map[string]interface{}{"type": "string"},
+			},
+		},
+		"scheduledTransitions": map[string]interface{}{
+			"type": "object",
+			"patternProperties": map[string]interface{}{
+				".{1,}": map[string]interface{}{
+					"type":     "object",
+					"required": []string{"delay"},
+					"properties": map[string]interface{}{
+						"value": map[string]interface{}{"type": "string"},
+						"delay": map[string]interface{}{"type": "integer", "minimum": 0},
+					},
+				},
+			},
+		},
+	},
+}
+
+var requestResponsePairV6Definition = map[string]interface{}{
+	"type": "object",
+	"required": []string{
+		"request",
+		"response",
+	},
+	"properties": map[string]interface{}{
+		"id": map[string]interface{}{
+			"type": "string",
+		},
+		"labels": map[string]interface{}{
+			"type": "array",
+			"items": map[string]interface{}{
+				"type": "string",
+			},
+		},
+		"description": map[string]interface{}{
+			"type": "string",
+		},
+		"request": map[string]interface{}{
+			"$ref": "#/definitions/request",
+		},
+		"response": map[string]interface{}{
+			"$ref": "#/definitions/response",
+		},
+	},
+}
+
+// SimulationPairViewV6Schema validates a single pair of a v6 simulation
+var SimulationPairViewV6Schema = map[string]interface{}{
+	"allOf": []interface{}{
+		map[string]interface{}{
+			"$ref": "#/definitions/request-response-pair",
+		},
+	},
+	"definitions": SimulationViewV6Schema["definitions"],
+}
@@ -0,0 +1,146 @@
+package v2
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers"
+	"github.com/SpectoLabs/hoverfly/core/util"
+	"github.com/codegangsta/negroni"
+	"github.com/go-zoo/bone"
+)
+
+type HoverflySimulations interface {
+	GetSimulationNames() []string
+	GetActiveSimulations() []string
+	UseSimulations([]string) error
+	GetNamedSimulation(string) (SimulationViewV6, error)
+	PutNamedSimulation(string, SimulationViewV6) SimulationImportResult
+	DeleteNamedSimulation(string) error
+}
+
+type SimulationsHandler struct {
+	Hoverfly HoverflySimulations
+}
+
+func (this *SimulationsHandler) RegisterRoutes(mux *bone.Mux, am *handlers.AuthHandler) {
+	mux.Get("/api/v2/simulations", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Get),
+	))
+	mux.Put("/api/v2/simulations", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.Put),
+	))
+	mux.Options("/api/v2/simulations", negroni.New(
+		negroni.HandlerFunc(this.Options),
+	))
+
+	mux.Get("/api/v2/simulations/:name", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.GetSimulation),
+	))
+	mux.Put("/api/v2/simulations/:name", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.PutSimulation),
+	))
+	mux.Delete("/api/v2/simulations/:name", negroni.New(
+		negroni.HandlerFunc(am.RequireTokenAuthentication),
+		negroni.HandlerFunc(this.DeleteSimulation),
+	))
+	mux.Options("/api/v2/simulations/:name", negroni.New(
+		negroni.HandlerFunc(this.OptionsSimulation),
+	))
+}
+
+func (this *SimulationsHandler) Get(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	bytes, _ := json.Marshal(SimulationsView{
+		Simulations: this.Hoverfly.GetSimulationNames(),
+		Active:      this.Hoverfly.GetActiveSimulations(),
+	})
+
+	handlers.WriteResponse(w, bytes)
+}
+
+// Put selects the active simulations, only the active field of the body is used
+func (this *SimulationsHandler) Put(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	var simulationsView SimulationsView
+
+	err := handlers.ReadFromRequest(req, &simulationsView)
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = this.Hoverfly.UseSimulations(simulationsView.Active)
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	this.Get(w, req, next)
+}
+
+func (this *SimulationsHandler) GetSimulation(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	simulationView, err := this.Hoverfly.GetNamedSimulation(bone.GetValue(req, "name"))
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	bytes, _ := util.JSONMarshal(simulationView)
+
+	handlers.WriteResponse(w, bytes)
+}
+
+func (this *SimulationsHandler) PutSimulation(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	body, _ := ioutil.ReadAll(req.Body)
+
+	simulationView, err := newSimulationViewFromRequest(req, body)
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result := this.Hoverfly.PutNamedSimulation(bone.GetValue(req, "name"), simulationView)
+	if result.err != nil {
+		handlers.WriteErrorResponse(w, "An error occured: "+result.err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(result.WarningMessages) > 0 {
+		bytes, _ := util.JSONMarshal(result)
+
+		handlers.WriteResponse(w, bytes)
+		return
+	}
+
+	this.GetSimulation(w, req, next)
+}
+
+func (this *SimulationsHandler) DeleteSimulation(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+	name := bone.GetValue(req, "name")
+
+	if _, err := this.Hoverfly.GetNamedSimulation(name); err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	err := this.Hoverfly.DeleteNamedSimulation(name)
+	if err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	this.Get(w, req, next)
+}
+
+func (this *SimulationsHandler) Options(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	w.Header().Add("Allow", "OPTIONS, GET, PUT")
+	handlers.WriteResponse(w, []byte(""))
+}
+
+func (this *SimulationsHandler) OptionsSimulation(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	w.Header().Add("Allow", "OPTIONS, GET, PUT, DELETE")
+	handlers.WriteResponse(w, []byte(""))
+}
@@ -0,0 +1,254 @@
+package v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers"
+	"github.com/go-zoo/bone"
+	. "github.com/onsi/gomega"
+)
+
+type HoverflySimulationsStub struct {
+	Simulations map[string]SimulationViewV6
+	Active      []string
+}
+
+func (this *HoverflySimulationsStub) GetSimulationNames() []string {
+	names := []string{}
+	for name := range this.Simulations {
+		names = append(names, name)
+	}
+	return names
+}
+
+func (this *HoverflySimulationsStub) GetActiveSimulations() []string {
+	return this.Active
+}
+
+func (this *HoverflySimulationsStub) UseSimulations(names []string) error {
+	for _, name := range names {
+		if _, ok := this.Simulations[name]; !ok {
+			return fmt.Errorf("Simulation %s does not exist", name)
+		}
+	}
+	this.Active = names
+	return nil
+}
+
+func (this *HoverflySimulationsStub) GetNamedSimulation(name string) (SimulationViewV6, error) {
+	simulation, ok := this.Simulations[name]
+	if !ok {
+		return SimulationViewV6{}, fmt.Errorf("Simulation %s does not exist", name)
+	}
+	return simulation, nil
+}
+
+func (this *HoverflySimulationsStub) PutNamedSimulation(name string, simulation SimulationViewV6) SimulationImportResult {
+	this.Simulations[name] = simulation
+	return SimulationImportResult{}
+}
+
+func (this *HoverflySimulationsStub) DeleteNamedSimulation(name string) error {
+	for _, active := range this.Active {
+		if active == name {
+			return fmt.Errorf("Simulation %s is active and cannot be deleted", name)
+		}
+	}
+	delete(this.Simulations, name)
+	return nil
+}
+
+func makeRequestOnSimulationsHandler(unit *SimulationsHandler, request *http.Request) *httptest.ResponseRecorder {
+	mux := bone.New()
+	unit.RegisterRoutes(mux, &handlers.AuthHandler{})
+
+	responseRecorder := httptest.NewRecorder()
+	mux.ServeHTTP(responseRecorder, request)
+	return responseRecorder
+}
+
+func unmarshalSimulationsView(buffer *bytes.Buffer) (SimulationsView, error) {
+	body, err := ioutil.ReadAll(buffer)
+	if err != nil {
+		return SimulationsView{}, err
+	}
+
+	var simulationsView SimulationsView
+
+	err = json.Unmarshal(body, &simulationsView)
+	if err != nil {
+		return SimulationsView{}, err
+	}
+
+	return simulationsView, nil
+}
+
+func Test_SimulationsHandler_Get_ReturnsNamesAndActiveSimulations(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationsStub{
+		Simulations: map[string]SimulationViewV6{"happy": {}},
+		Active:      []string{"happy"},
+	}
+	unit := SimulationsHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("GET", "/api/v2/simulations", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnSimulationsHandler(&unit, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	simulationsView, err := unmarshalSimulationsView(response.Body)
+	Expect(err).To(BeNil())
+
+	Expect(simulationsView.Simulations).To(Equal([]string{"happy"}))
+	Expect(simulationsView.Active).To(Equal([]string{"happy"}))
+}
+
+func Test_SimulationsHandler_Put_UsesActiveSimulations(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationsStub{
+		Simulations: map[string]SimulationViewV6{"happy": {}, "outage": {}},
+	}
+	unit := SimulationsHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("PUT", "/api/v2/simulations", bytes.NewBufferString(`{"active": ["outage", "happy"]}`))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnSimulationsHandler(&unit, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	Expect(stubHoverfly.Active).To(Equal([]string{"outage", "happy"}))
+}
+
+func Test_SimulationsHandler_Put_ReturnsBadRequestForUnknownSimulation(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationsStub{
+		Simulations: map[string]SimulationViewV6{},
+	}
+	unit := SimulationsHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("PUT", "/api/v2/simulations", bytes.NewBufferString(`{"active": ["missing"]}`))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnSimulationsHandler(&unit, request)
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+
+	errorView, err := unmarshalErrorView(response.Body)
+	Expect(err).To(BeNil())
+	Expect(errorView.Error).To(Equal("Simulation missing does not exist"))
+}
+
+func Test_SimulationsHandler_PutSimulation_StoresNamedSimulation(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationsStub{
+		Simulations: map[string]SimulationViewV6{},
+	}
+	unit := SimulationsHandler{Hoverfly: stubHoverfly}
+
+	simulation := `{
+		"data": {
+			"pairs": [{
+				"request": {
+					"path": [{"matcher": "exact", "value": "/orders"}]
+				},
+				"response": {
+					"status": 503,
+					"body": "outage"
+				}
+			}],
+			"globalActions": {
+				"delays": []
+			}
+		},
+		"meta": {
+			"schemaVersion": "v5"
+		}
+	}`
+
+	request, err := http.NewRequest("PUT", "/api/v2/simulations/outage", bytes.NewBufferString(simulation))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnSimulationsHandler(&unit, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+
+	Expect(stubHoverfly.Simulations).To(HaveKey("outage"))
+	Expect(stubHoverfly.Simulations["outage"].RequestResponsePairs[0].Response.Body).To(Equal("outage"))
+
+	simulationView, err := unmarshalSimulationViewV6(response.Body)
+	Expect(err).To(BeNil())
+	Expect(simulationView.RequestResponsePairs[0].Response.Status).To(Equal(503))
+}
+
+func Test_SimulationsHandler_PutSimulation_ReturnsBadRequestForInvalidSimulation(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationsStub{
+		Simulations: map[string]SimulationViewV6{},
+	}
+	unit := SimulationsHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("PUT", "/api/v2/simulations/outage", bytes.NewBufferString("not json"))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnSimulationsHandler(&unit, request)
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+	Expect(stubHoverfly.Simulations).To(BeEmpty())
+}
+
+func Test_SimulationsHandler_GetSimulation_ReturnsNotFoundForUnknownSimulation(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationsStub{
+		Simulations: map[string]SimulationViewV6{},
+	}
+	unit := SimulationsHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("GET", "/api/v2/simulations/missing", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnSimulationsHandler(&unit, request)
+	Expect(response.Code).To(Equal(http.StatusNotFound))
+}
+
+func Test_SimulationsHandler_DeleteSimulation_ReturnsConflictForActiveSimulation(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationsStub{
+		Simulations: map[string]SimulationViewV6{"happy": {}},
+		Active:      []string{"happy"},
+	}
+	unit := SimulationsHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("DELETE", "/api/v2/simulations/happy", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnSimulationsHandler(&unit, request)
+	Expect(response.Code).To(Equal(http.StatusConflict))
+	Expect(stubHoverfly.Simulations).To(HaveKey("happy"))
+}
+
+func Test_SimulationsHandler_DeleteSimulation_DeletesNamedSimulation(t *testing.T) {
+	RegisterTestingT(t)
+
+	stubHoverfly := &HoverflySimulationsStub{
+		Simulations: map[string]SimulationViewV6{"happy": {}},
+	}
+	unit := SimulationsHandler{Hoverfly: stubHoverfly}
+
+	request, err := http.NewRequest("DELETE", "/api/v2/simulations/happy", nil)
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnSimulationsHandler(&unit, request)
+	Expect(response.Code).To(Equal(http.StatusOK))
+	Expect(stubHoverfly.Simulations).To(BeEmpty())
+}
@@ -40,7 +40,8 @@ func (this *StateHandler) RegisterRoutes(mux *bone.Mux, am *handlers.AuthHandler
 func (this *StateHandler) Get(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
 
 	marshal, err := json.Marshal(StateView{
-		State: this.Hoverfly.GetState(),
+		State:     this.Hoverfly.GetState(),
+		Scheduled: this.Hoverfly.GetScheduledStateTransitions(),
 	})
 
 	if err != nil {
@@ -70,10 +71,17 @@ func (this *StateHandler) Put(w http.ResponseWriter, req *http.Request, next htt
 		return
 	}
 
+	if err := toPut.Validate(); err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	this.Hoverfly.SetState(toPut.State)
+	this.Hoverfly.ScheduleStateTransitions(toPut.Scheduled)
 
 	marshal, _ := json.Marshal(StateView{
-		State: this.Hoverfly.GetState(),
+		State:     this.Hoverfly.GetState(),
+		Scheduled: this.Hoverfly.GetScheduledStateTransitions(),
 	})
 
 	handlers.WriteResponse(w, marshal)
@@ -93,10 +101,17 @@ func (this *StateHandler) Patch(w http.ResponseWriter, req *http.Request, next h
 		return
 	}
 
+	if err := toPatch.Validate(); err != nil {
+		handlers.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	this.Hoverfly.PatchState(toPatch.State)
+	this.Hoverfly.ScheduleStateTransitions(toPatch.Scheduled)
 
 	marshal, _ := json.Marshal(StateView{
-		State: this.Hoverfly.GetState(),
+		State:     this.Hoverfly.GetState(),
+		Scheduled: this.Hoverfly.GetScheduledStateTransitions(),
 	})
 
 	handlers.WriteResponse(w, marshal)
@@ -0,0 +1,46 @@
+package v2
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func Test_StateHandler_Put_RejectsNegativeDelays(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := StateHandler{}
+
+	request, err := http.NewRequest("PUT", "/api/v2/state", ioutil.NopCloser(bytes.NewBufferString(`{"state": {}, "scheduled": {"order": {"value": "shipped", "delay": -1}}}`)))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Put, request)
+
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+
+	errorView, err := unmarshalErrorView(response.Body)
+	Expect(err).To(BeNil())
+
+	Expect(errorView.Error).To(Equal("Scheduled transition for order has a negative delay"))
+}
+
+func Test_StateHandler_Patch_RejectsNegativeDelays(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := StateHandler{}
+
+	request, err := http.NewRequest("PATCH", "/api/v2/state", ioutil.NopCloser(bytes.NewBufferString(`{"state": {}, "scheduled": {"session": {"delay": -100}}}`)))
+	Expect(err).To(BeNil())
+
+	response := makeRequestOnHandler(unit.Patch, request)
+
+	Expect(response.Code).To(Equal(http.StatusBadRequest))
+
+	errorView, err := unmarshalErrorView(response.Body)
+	Expect(err).To(BeNil())
+
+	Expect(errorView.Error).To(Equal("Scheduled transition for session has a negative delay"))
+}
@@ -0,0 +1,160 @@
+package v2
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// TransformationRuleView changes the requests whose destination and path match its regexes, and the
+// responses to them, in modify, spy and capture mode. A rule without a destination or a path matches
+// any of them.
+type TransformationRuleView struct {
+	Destination string                      `json:"destination,omitempty"`
+	Path        string                      `json:"path,omitempty"`
+	Request     *RequestTransformationView  `json:"request,omitempty"`
+	Response    *ResponseTransformationView `json:"response,omitempty"`
+}
+
+// TransformationRulesView holds the rules in the order they are applied, every rule matching a
+// request is applied to it
+type TransformationRulesView struct {
+	Rules []TransformationRuleView `json:"rules"`
+}
+
+// MessageTransformationView changes the headers and body of a request or response. Headers are
+// removed before they are set, and are named case insensitively. The values at each JSONPath of
+// a JSON body are replaced with the JSON value given.
+type MessageTransformationView struct {
+	SetHeaders    map[string][]string `json:"setHeaders,omitempty"`
+	RemoveHeaders []string            `json:"removeHeaders,omitempty"`
+	SetJsonPaths  []JsonPathValueView `json:"setJsonPaths,omitempty"`
+}
+
+type JsonPathValueView struct {
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+// RequestTransformationView changes a request before it is sent. The destination replaces the host
+// the request is sent to, and the parts of the path matching the path regex are replaced with the
+// replacement, which may refer to the groups of the regex as $1.
+type RequestTransformationView struct {
+	Destination     string `json:"destination,omitempty"`
+	PathRegex       string `json:"pathRegex,omitempty"`
+	PathReplacement string `json:"pathReplacement,omitempty"`
+	MessageTransformationView
+}
+
+// ResponseTransformationView changes a response before it is returned, a status other than zero
+// replaces its status
+type ResponseTransformationView struct {
+	Status int `json:"status,omitempty"`
+	MessageTransformationView
+}
+
+func (this TransformationRuleView) Validate() error {
+	if _, err := regexp.Compile(this.Destination); err != nil {
+		return fmt.Errorf("Transformation destination is not a valid regex: %s", err.Error())
+	}
+
+	if _, err := regexp.Compile(this.Path); err != nil {
+		return fmt.Errorf("Transformation path is not a valid regex: %s", err.Error())
+	}
+
+	if this.Request == nil && this.Response == nil {
+		return fmt.Errorf("Transformation must change the request or the response")
+	}
+
+	if this.Request != nil {
+		if _, err := regexp.Compile(this.Request.PathRegex); err != nil {
+			return fmt.Errorf("Transformation path regex is not valid: %s", err.Error())
+		}
+
+		if err := this.Request.MessageTransformationView.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if this.Response != nil {
+		if this.Response.Status != 0 && (this.Response.Status < 100 || this.Response.Status > 999) {
+			return fmt.Errorf("Transformation status %d is not valid", this.Response.Status)
+		}
+
+		if err := this.Response.MessageTransformationView.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (this MessageTransformationView) Validate() error {
+	for _, jsonPathValue := range this.SetJsonPaths {
+		if _, err := parseRedactionJsonPath(jsonPathValue.Path); err != nil {
+			return fmt.Errorf("Transformation has an invalid JSONPath: %s", err.Error())
+		}
+	}
+
+	return nil
+}
+
+// TransformHeaders returns a copy of the headers with the headers removed and set
+func (this MessageTransformationView) TransformHeaders(headers map[string][]string) map[string][]string {
+	transformed := copyHeaders(headers)
+	if transformed == nil {
+		transformed = map[string][]string{}
+	}
+
+	for _, name := range this.RemoveHeaders {
+		deleteHeader(transformed, name)
+	}
+
+	for name, values := range this.SetHeaders {
+		deleteHeader(transformed, name)
+		transformed[http.CanonicalHeaderKey(name)] = append([]string{}, values...)
+	}
+
+	return transformed
+}
+
+func deleteHeader(headers map[string][]string, name string) {
+	for header := range headers {
+		if strings.EqualFold(header, name) {
+			delete(headers, header)
+		}
+	}
+}
+
+// MessageTransformation is a message transformation with its JSONPaths parsed, which is done once
+// when the rule is set rather than for every request or response
+type MessageTransformation struct {
+	MessageTransformationView
+
+	jsonPaths [][]jsonPathStep
+}
+
+func NewMessageTransformation(view MessageTransformationView) (*MessageTransformation, error) {
+	if err := view.Validate(); err != nil {
+		return nil, err
+	}
+
+	transformation := &MessageTransformation{MessageTransformationView: view}
+	for _, jsonPathValue := range view.SetJsonPaths {
+		steps, _ := parseRedactionJsonPath(jsonPathValue.Path)
+		transformation.jsonPaths = append(transformation.jsonPaths, steps)
+	}
+
+	return transformation, nil
+}
+
+// TransformBody replaces the values at the JSONPaths of a JSON body. Bodies which cannot be parsed
+// are returned unchanged.
+func (this MessageTransformation) TransformBody(body string) string {
+	for i, steps := range this.jsonPaths {
+		body, _ = setJsonPathValues(body, steps, this.SetJsonPaths[i].Value)
+	}
+
+	return body
+}
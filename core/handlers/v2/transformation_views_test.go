@@ -0,0 +1,85 @@
+package v2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func Test_TransformationRuleView_Validate_RejectsInvalidRules(t *testing.T) {
+	RegisterTestingT(t)
+
+	response := &ResponseTransformationView{Status: 503}
+
+	Expect(TransformationRuleView{Destination: `payments\.com`, Path: "^/v1", Response: response}.Validate()).To(BeNil())
+	Expect(TransformationRuleView{Destination: "payments(com", Response: response}.Validate()).ToNot(BeNil())
+	Expect(TransformationRuleView{Path: "[v1", Response: response}.Validate()).ToNot(BeNil())
+	Expect(TransformationRuleView{Path: "^/v1"}.Validate()).To(MatchError("Transformation must change the request or the response"))
+	Expect(TransformationRuleView{Request: &RequestTransformationView{PathRegex: "[v1"}}.Validate()).ToNot(BeNil())
+	Expect(TransformationRuleView{Response: &ResponseTransformationView{Status: 42}}.Validate()).To(MatchError("Transformation status 42 is not valid"))
+	Expect(TransformationRuleView{Response: &ResponseTransformationView{
+		MessageTransformationView: MessageTransformationView{SetJsonPaths: []JsonPathValueView{{Path: "user.id"}}},
+	}}.Validate()).ToNot(BeNil())
+}
+
+func Test_MessageTransformationView_TransformHeaders_RemovesThenSetsHeaders(t *testing.T) {
+	RegisterTestingT(t)
+
+	headers := map[string][]string{
+		"Server":       {"nginx"},
+		"X-Request-Id": {"1"},
+		"Content-Type": {"text/plain"},
+	}
+
+	unit := MessageTransformationView{
+		RemoveHeaders: []string{"server"},
+		SetHeaders: map[string][]string{
+			"content-type": {"application/json"},
+			"X-Api-Key":    {"key"},
+		},
+	}
+
+	Expect(unit.TransformHeaders(headers)).To(Equal(map[string][]string{
+		"X-Request-Id": {"1"},
+		"Content-Type": {"application/json"},
+		"X-Api-Key":    {"key"},
+	}))
+	Expect(headers).To(HaveKey("Server"))
+}
+
+func Test_NewMessageTransformation_ReturnsErrorForInvalidJsonPath(t *testing.T) {
+	RegisterTestingT(t)
+
+	transformation, err := NewMessageTransformation(MessageTransformationView{
+		SetJsonPaths: []JsonPathValueView{{Path: "user.id"}},
+	})
+
+	Expect(err).ToNot(BeNil())
+	Expect(transformation).To(BeNil())
+}
+
+func Test_MessageTransformation_TransformBody_SetsValuesAtJsonPaths(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit, err := NewMessageTransformation(MessageTransformationView{
+		SetJsonPaths: []JsonPathValueView{
+			{Path: "$.user.name", Value: "Jane"},
+			{Path: "$.items[*].price", Value: map[string]interface{}{"amount": 1}},
+		},
+	})
+	Expect(err).To(BeNil())
+
+	Expect(unit.TransformBody(`{"user": {"id": 1, "name": "John"}, "items": [{"price": 2}, {"price": 3}]}`)).To(Equal(
+		`{"items":[{"price":{"amount":1}},{"price":{"amount":1}}],"user":{"id":1,"name":"Jane"}}`))
+}
+
+func Test_MessageTransformation_TransformBody_LeavesBodiesWhichAreNotJson(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit, err := NewMessageTransformation(MessageTransformationView{
+		SetJsonPaths: []JsonPathValueView{{Path: "$.user.name", Value: "Jane"}},
+	})
+	Expect(err).To(BeNil())
+
+	Expect(unit.TransformBody("<user><name>John</name></user>")).To(Equal("<user><name>John</name></user>"))
+}
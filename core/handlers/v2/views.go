@@ -1,6 +1,9 @@
 package v2
 
 import (
+	"fmt"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v1"
 	"github.com/SpectoLabs/hoverfly/core/metrics"
 )
 
@@ -19,14 +22,32 @@ type MiddlewareView struct {
 }
 
 type ModeView struct {
-	Mode      string            `json:"mode"`
-	Arguments ModeArgumentsView `json:"arguments,omitempty"`
+	Mode      string              `json:"mode"`
+	Arguments ModeArgumentsView   `json:"arguments,omitempty"`
+	Skipped   *CaptureSkippedView `json:"skipped,omitempty"`
+	Shadowed  *ShadowStatsView    `json:"shadowed,omitempty"`
+}
+
+// ShadowStatsView counts the requests shadow mode has sent to the real service. Pending requests are
+// waiting in the queue, and dropped requests were not sent because the queue was full.
+type ShadowStatsView struct {
+	Pending  int `json:"pending"`
+	Compared int `json:"compared"`
+	Diverged int `json:"diverged"`
+	Failed   int `json:"failed"`
+	Dropped  int `json:"dropped"`
 }
 
 type ModeArgumentsView struct {
-	Headers          []string `json:"headersWhitelist,omitempty"`
-	MatchingStrategy *string  `json:"matchingStrategy,omitempty"`
-	Stateful         bool     `json:"stateful,omitempty"`
+	Headers          []string            `json:"headersWhitelist,omitempty"`
+	MatchingStrategy *string             `json:"matchingStrategy,omitempty"`
+	Stateful         bool                `json:"stateful,omitempty"`
+	Redactions       []RedactionRuleView `json:"redactions,omitempty"`
+	MatcherPolicy    *MatcherPolicyView  `json:"matcherPolicy,omitempty"`
+	CapturePolicy    string              `json:"capturePolicy,omitempty"`
+	CaptureFilter    *CaptureFilterView  `json:"captureFilter,omitempty"`
+	RecordMatchers   string              `json:"recordMatchers,omitempty"`
+	DiffRules        *DiffRulesView      `json:"diffRules,omitempty"`
 }
 
 type IsWebServerView struct {
@@ -61,13 +82,13 @@ type CacheView struct {
 
 type CachedResponseView struct {
 	Key          string                            `json:"key"`
-	MatchingPair *RequestMatcherResponsePairViewV5 `json:"matchingPair,omitempty"`
+	MatchingPair *RequestMatcherResponsePairViewV6 `json:"matchingPair,omitempty"`
 	HeaderMatch  bool                              `json:"headerMatch"`
 	ClosestMiss  *ClosestMissView                  `json:"closestMiss"`
 }
 
 type ClosestMissView struct {
-	Response       ResponseDetailsViewV5 `json:"response"`
+	Response       ResponseDetailsViewV6 `json:"response"`
 	RequestMatcher RequestMatcherViewV5  `json:"requestMatcher"`
 	MissedFields   []string              `json:"missedFields"`
 }
@@ -85,14 +106,135 @@ type JournalEntryView struct {
 	Mode        string              `json:"mode"`
 	TimeStarted string              `json:"timeStarted"`
 	Latency     float64             `json:"latency"`
+	PairId      string              `json:"pairId,omitempty"`
 }
 
 type JournalEntryFilterView struct {
 	Request *RequestMatcherViewV5 `json:"request"`
 }
 
+type SimulationsView struct {
+	Simulations []string `json:"simulations"`
+	Active      []string `json:"active"`
+}
+
+type SimulationPairView struct {
+	Id string `json:"id"`
+	RequestMatcherResponsePairViewV6
+}
+
+type SimulationPairsView struct {
+	Pairs []SimulationPairView `json:"pairs"`
+}
+
+// PairNotFoundError is returned when there is no pair with the given id
+type PairNotFoundError struct {
+	Id string
+}
+
+func (this PairNotFoundError) Error() string {
+	return fmt.Sprintf("Pair %s does not exist", this.Id)
+}
+
+// DuplicatePairError is returned when a pair would have the same request matcher as another pair
+type DuplicatePairError struct {
+	Id string
+}
+
+func (this DuplicatePairError) Error() string {
+	return fmt.Sprintf("Pair %s has an identical request matcher", this.Id)
+}
+
+// SimulationLintView lists the problems found in a simulation which do not stop it from being
+// imported, but mean some of its pairs are never used or do not behave as intended
+type SimulationLintView struct {
+	Issues []SimulationLintIssueView `json:"issues"`
+}
+
+type SimulationLintIssueView struct {
+	Type    string `json:"type"`
+	Pair    int    `json:"pair"`
+	PairId  string `json:"pairId,omitempty"`
+	Message string `json:"message"`
+}
+
+const (
+	LintDuplicate        = "duplicate"
+	LintShadowed         = "shadowed"
+	LintInvalidMatcher   = "invalid-matcher"
+	LintInvalidTemplate  = "invalid-template"
+	LintUnreachableState = "unreachable-state"
+)
+
+// SimulationVerificationView reports whether each pair of a simulation still describes the real
+// service, by sending the request the pair matches to it and comparing the responses. Pairs are
+// skipped when the request cannot be built from them.
+type SimulationVerificationView struct {
+	Passed  int                    `json:"passed"`
+	Failed  int                    `json:"failed"`
+	Skipped int                    `json:"skipped"`
+	Pairs   []PairVerificationView `json:"pairs"`
+}
+
+type PairVerificationView struct {
+	Pair        int                          `json:"pair"`
+	PairId      string                       `json:"pairId,omitempty"`
+	Result      string                       `json:"result"`
+	Request     *SimpleRequestDefinitionView `json:"request,omitempty"`
+	Message     string                       `json:"message,omitempty"`
+	DiffEntries []DiffReportEntry            `json:"diffEntries,omitempty"`
+}
+
+const (
+	VerificationPassed  = "passed"
+	VerificationFailed  = "failed"
+	VerificationSkipped = "skipped"
+)
+
+// VerifySimulationView holds the diff rules used when the responses of a simulation are verified
+type VerifySimulationView struct {
+	DiffRules *DiffRulesView `json:"diffRules,omitempty"`
+}
+
+// SimulationCoverageView counts how often each pair and global delay has been used since
+// the coverage was last reset
+type SimulationCoverageView struct {
+	Since  string              `json:"since"`
+	Pairs  []PairCoverageView  `json:"pairs"`
+	Delays []DelayCoverageView `json:"delays"`
+}
+
+type PairCoverageView struct {
+	Hits int `json:"hits"`
+	RequestMatcherResponsePairViewV6
+}
+
+type DelayCoverageView struct {
+	Hits int `json:"hits"`
+	v1.ResponseDelayView
+}
+
 type StateView struct {
-	State map[string]string `json:"state"`
+	State     map[string]string                       `json:"state"`
+	Scheduled map[string]ScheduledStateTransitionView `json:"scheduled,omitempty"`
+}
+
+// ScheduledStateTransitionView is a state change applied once Delay
+// milliseconds have passed. Without a value the key expires instead.
+type ScheduledStateTransitionView struct {
+	Value string `json:"value,omitempty"`
+	Delay int    `json:"delay"`
+	At    string `json:"at,omitempty"`
+}
+
+func (this StateView) Validate() error {
+	for key, transition := range this.Scheduled {
+		if transition.Delay < 0 {
+			return fmt.Errorf("Scheduled transition for %s has a negative delay", key)
+		}
+	}
+
+	return nil
 }
 
 type DiffView struct {
@@ -0,0 +1,517 @@
+package v2
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v1"
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+)
+
+// WireMockStartedState is the state every WireMock scenario starts in
+const WireMockStartedState = "Started"
+
+// WireMock gives mappings without a priority this priority, 1 being the highest
+const wireMockDefaultPriority = 5
+
+const wireMockSkippedMessage = "WARNING: mappings[%v].%s is not supported, the mapping was not imported"
+const wireMockIgnoredMessage = "WARNING: mappings[%v].%s is not supported and was ignored"
+
+// WireMockMappingsView is a list of WireMock stub mappings, as found in the
+// mappings directory of WireMock or returned by its /__admin/mappings endpoint
+type WireMockMappingsView struct {
+	Mappings []WireMockMappingView `json:"mappings"`
+}
+
+type WireMockMappingView struct {
+	Id                    string               `json:"id,omitempty"`
+	Name                  string               `json:"name,omitempty"`
+	Priority              int                  `json:"priority,omitempty"`
+	Request               WireMockRequestView  `json:"request"`
+	Response              WireMockResponseView `json:"response"`
+	ScenarioName          string               `json:"scenarioName,omitempty"`
+	RequiredScenarioState string               `json:"requiredScenarioState,omitempty"`
+	NewScenarioState      string               `json:"newScenarioState,omitempty"`
+	PostServeActions      interface{}          `json:"postServeActions,omitempty"`
+}
+
+type WireMockRequestView struct {
+	Method               string                         `json:"method,omitempty"`
+	Url                  *string                        `json:"url,omitempty"`
+	UrlPath              *string                        `json:"urlPath,omitempty"`
+	UrlPattern           *string                        `json:"urlPattern,omitempty"`
+	UrlPathPattern       *string                        `json:"urlPathPattern,omitempty"`
+	QueryParameters      map[string]WireMockPatternView `json:"queryParameters,omitempty"`
+	Headers              map[string]WireMockPatternView `json:"headers,omitempty"`
+	BodyPatterns         []WireMockPatternView          `json:"bodyPatterns,omitempty"`
+	BasicAuthCredentials *WireMockBasicAuthView         `json:"basicAuthCredentials,omitempty"`
+	Cookies              interface{}                    `json:"cookies,omitempty"`
+	MultipartPatterns    interface{}                    `json:"multipartPatterns,omitempty"`
+	CustomMatcher        interface{}                    `json:"customMatcher,omitempty"`
+}
+
+type WireMockBasicAuthView struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type WireMockPatternView struct {
+	EqualTo             *string     `json:"equalTo,omitempty"`
+	Contains            *string     `json:"contains,omitempty"`
+	Matches             *string     `json:"matches,omitempty"`
+	DoesNotMatch        *string     `json:"doesNotMatch,omitempty"`
+	EqualToJson         interface{} `json:"equalToJson,omitempty"`
+	MatchesJsonPath     interface{} `json:"matchesJsonPath,omitempty"`
+	EqualToXml          *string     `json:"equalToXml,omitempty"`
+	MatchesXPath        interface{} `json:"matchesXPath,omitempty"`
+	CaseInsensitive     bool        `json:"caseInsensitive,omitempty"`
+	Absent              bool        `json:"absent,omitempty"`
+	IgnoreArrayOrder    bool        `json:"ignoreArrayOrder,omitempty"`
+	IgnoreExtraElements bool        `json:"ignoreExtraElements,omitempty"`
+}
+
+type WireMockResponseView struct {
+	Status                 int                    `json:"status,omitempty"`
+	Body                   string                 `json:"body,omitempty"`
+	JsonBody               interface{}            `json:"jsonBody,omitempty"`
+	Base64Body             string                 `json:"base64Body,omitempty"`
+	Headers                map[string]interface{} `json:"headers,omitempty"`
+	BodyFileName           interface{}            `json:"bodyFileName,omitempty"`
+	FixedDelayMilliseconds interface{}            `json:"fixedDelayMilliseconds,omitempty"`
+	DelayDistribution      interface{}            `json:"delayDistribution,omitempty"`
+	ChunkedDribbleDelay    interface{}            `json:"chunkedDribbleDelay,omitempty"`
+	Transformers           interface{}            `json:"transformers,omitempty"`
+	Fault                  interface{}            `json:"fault,omitempty"`
+	ProxyBaseUrl           interface{}            `json:"proxyBaseUrl,omitempty"`
+}
+
+// NewWireMockMappingsViewFromResponseBody reads either a list of mappings or a single
+// mapping, as WireMock allows both in the files of its mappings directory
+func NewWireMockMappingsViewFromResponseBody(body []byte) (WireMockMappingsView, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return WireMockMappingsView{}, errors.New("Invalid JSON")
+	}
+
+	if _, ok := fields["mappings"]; ok {
+		var mappings WireMockMappingsView
+		if err := json.Unmarshal(body, &mappings); err != nil {
+			return WireMockMappingsView{}, fmt.Errorf("Invalid WireMock mappings, %s", err.Error())
+		}
+		return mappings, nil
+	}
+
+	if _, ok := fields["request"]; ok {
+		var mapping WireMockMappingView
+		if err := json.Unmarshal(body, &mapping); err != nil {
+			return WireMockMappingsView{}, fmt.Errorf("Invalid WireMock mapping, %s", err.Error())
+		}
+		return WireMockMappingsView{Mappings: []WireMockMappingView{mapping}}, nil
+	}
+
+	return WireMockMappingsView{}, errors.New("Invalid WireMock mappings, expected a mapping or a list of mappings")
+}
+
+// NewSimulationViewFromWireMock converts WireMock mappings into pairs, ordered by their priority. Scenarios
+// become state keys named after the scenario, and the returned state holds the Started state of each one,
+// which has to be set for the first step of a scenario to match. Mappings using constructs which would
+// change what they match on are left out, other unsupported constructs are ignored, and both are warned about.
+func NewSimulationViewFromWireMock(mappings WireMockMappingsView) (SimulationViewV6, map[string]string, []SimulationImportWarning) {
+	indexes := make([]int, len(mappings.Mappings))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return wireMockPriority(mappings.Mappings[indexes[i]]) < wireMockPriority(mappings.Mappings[indexes[j]])
+	})
+
+	pairs := []RequestMatcherResponsePairViewV6{}
+	scenarios := map[string]string{}
+	warnings := []SimulationImportWarning{}
+
+	for _, index := range indexes {
+		conversion := &wireMockConversion{index: index}
+
+		pair := conversion.convert(mappings.Mappings[index])
+		warnings = append(warnings, conversion.warnings...)
+		if conversion.skipped {
+			continue
+		}
+
+		if scenario := mappings.Mappings[index].ScenarioName; scenario != "" {
+			scenarios[scenario] = WireMockStartedState
+		}
+		pairs = append(pairs, pair)
+	}
+
+	return BuildSimulationView(pairs, v1.ResponseDelayPayloadView{Data: []v1.ResponseDelayView{}}, ""), scenarios, warnings
+}
+
+func wireMockPriority(mapping WireMockMappingView) int {
+	if mapping.Priority == 0 {
+		return wireMockDefaultPriority
+	}
+
+	return mapping.Priority
+}
+
+type wireMockConversion struct {
+	index    int
+	skipped  bool
+	warnings []SimulationImportWarning
+}
+
+func (this *wireMockConversion) skip(field string) {
+	this.skipped = true
+	this.warnings = append(this.warnings, SimulationImportWarning{Message: fmt.Sprintf(wireMockSkippedMessage, this.index, field)})
+}
+
+func (this *wireMockConversion) ignore(field string) {
+	this.warnings = append(this.warnings, SimulationImportWarning{Message: fmt.Sprintf(wireMockIgnoredMessage, this.index, field)})
+}
+
+func (this *wireMockConversion) convert(mapping WireMockMappingView) RequestMatcherResponsePairViewV6 {
+	request := mapping.Request
+	requestMatcher := RequestMatcherViewV5{}
+
+	if request.Method != "" && request.Method != "ANY" {
+		requestMatcher.Method = []MatcherViewV5{NewMatcherView(matchers.Exact, request.Method)}
+	}
+
+	switch {
+	case request.Url != nil:
+		requestUrl, err := url.Parse(*request.Url)
+		if err != nil {
+			this.skip("request.url")
+			break
+		}
+		requestMatcher.Path = []MatcherViewV5{NewMatcherView(matchers.Exact, requestUrl.Path)}
+
+		// The url of a mapping includes the query, so requests with any other query do not match
+		query := QueryMatcherViewV5{}
+		for key, values := range requestUrl.Query() {
+			query[key] = []MatcherViewV5{NewMatcherView(matchers.Exact, strings.Join(values, ";"))}
+		}
+		requestMatcher.Query = &query
+	case request.UrlPath != nil:
+		requestMatcher.Path = []MatcherViewV5{NewMatcherView(matchers.Exact, *request.UrlPath)}
+	case request.UrlPathPattern != nil:
+		requestMatcher.Path = []MatcherViewV5{NewMatcherView(matchers.Regex, wholeValueRegex(*request.UrlPathPattern))}
+	case request.UrlPattern != nil:
+		this.skip("request.urlPattern")
+	}
+
+	if len(request.QueryParameters) > 0 {
+		if requestMatcher.Query == nil {
+			requestMatcher.Query = &QueryMatcherViewV5{}
+		}
+		for key, pattern := range request.QueryParameters {
+			matcher, unsupported := pattern.matcherView()
+			if unsupported != "" {
+				this.skip(fmt.Sprintf("request.queryParameters.%s.%s", key, unsupported))
+				continue
+			}
+			(*requestMatcher.Query)[key] = append((*requestMatcher.Query)[key], matcher)
+		}
+	}
+
+	for key, pattern := range request.Headers {
+		if requestMatcher.Headers == nil {
+			requestMatcher.Headers = map[string][]MatcherViewV5{}
+		}
+		matcher, unsupported := pattern.matcherView()
+		if unsupported != "" {
+			this.skip(fmt.Sprintf("request.headers.%s.%s", key, unsupported))
+			continue
+		}
+		requestMatcher.Headers[key] = append(requestMatcher.Headers[key], matcher)
+	}
+
+	if request.BasicAuthCredentials != nil {
+		if requestMatcher.Headers == nil {
+			requestMatcher.Headers = map[string][]MatcherViewV5{}
+		}
+		credentials := request.BasicAuthCredentials.Username + ":" + request.BasicAuthCredentials.Password
+		requestMatcher.Headers["Authorization"] = []MatcherViewV5{
+			NewMatcherView(matchers.Exact, "Basic "+base64.StdEncoding.EncodeToString([]byte(credentials))),
+		}
+	}
+
+	for i, pattern := range request.BodyPatterns {
+		matcher, unsupported := pattern.matcherView()
+		if unsupported != "" {
+			this.skip(fmt.Sprintf("request.bodyPatterns[%v].%s", i, unsupported))
+			continue
+		}
+		requestMatcher.Body = append(requestMatcher.Body, matcher)
+	}
+
+	if request.Cookies != nil {
+		this.skip("request.cookies")
+	}
+	if request.MultipartPatterns != nil {
+		this.skip("request.multipartPatterns")
+	}
+	if request.CustomMatcher != nil {
+		this.skip("request.customMatcher")
+	}
+
+	response := ResponseDetailsViewV6{
+		Status: mapping.Response.Status,
+		Body:   mapping.Response.Body,
+	}
+	if response.Status == 0 {
+		response.Status = 200
+	}
+
+	switch {
+	case mapping.Response.JsonBody != nil:
+		body, _ := json.Marshal(mapping.Response.JsonBody)
+		response.Body = string(body)
+	case mapping.Response.Base64Body != "":
+		response.Body = mapping.Response.Base64Body
+		response.EncodedBody = true
+	}
+
+	for name, value := range mapping.Response.Headers {
+		if response.Headers == nil {
+			response.Headers = map[string][]string{}
+		}
+		switch value := value.(type) {
+		case []interface{}:
+			for _, item := range value {
+				response.Headers[name] = append(response.Headers[name], fmt.Sprint(item))
+			}
+		default:
+			response.Headers[name] = []string{fmt.Sprint(value)}
+		}
+	}
+
+	if mapping.Response.Fault != nil {
+		this.skip("response.fault")
+	}
+	if mapping.Response.ProxyBaseUrl != nil {
+		this.skip("response.proxyBaseUrl")
+	}
+	if mapping.Response.BodyFileName != nil {
+		this.skip("response.bodyFileName")
+	}
+	for field, value := range map[string]interface{}{
+		"response.fixedDelayMilliseconds": mapping.Response.FixedDelayMilliseconds,
+		"response.delayDistribution":      mapping.Response.DelayDistribution,
+		"response.chunkedDribbleDelay":    mapping.Response.ChunkedDribbleDelay,
+		"response.transformers":           mapping.Response.Transformers,
+		"postServeActions":                mapping.PostServeActions,
+	} {
+		if value != nil {
+			this.ignore(field)
+		}
+	}
+	sort.Slice(this.warnings, func(i, j int) bool {
+		return this.warnings[i].Message < this.warnings[j].Message
+	})
+
+	if mapping.ScenarioName != "" {
+		if mapping.RequiredScenarioState != "" {
+			requestMatcher.RequiresState = map[string]string{mapping.ScenarioName: mapping.RequiredScenarioState}
+		}
+		if mapping.NewScenarioState != "" {
+			response.TransitionsState = map[string]string{mapping.ScenarioName: mapping.NewScenarioState}
+		}
+	}
+
+	return RequestMatcherResponsePairViewV6{
+		RequestMatcher: requestMatcher,
+		Response:       response,
+	}
+}
+
+// matcherView returns the Hoverfly matcher for the pattern, or the name of
+// the part of the pattern which could not be converted into one
+func (this WireMockPatternView) matcherView() (MatcherViewV5, string) {
+	switch {
+	case this.Absent:
+		return MatcherViewV5{}, "absent"
+	case this.IgnoreArrayOrder:
+		return MatcherViewV5{}, "ignoreArrayOrder"
+	case this.IgnoreExtraElements && this.EqualToJson == nil:
+		return MatcherViewV5{}, "ignoreExtraElements"
+	case this.EqualTo != nil && this.CaseInsensitive:
+		return NewMatcherView(matchers.Regex, "(?i)^"+regexp.QuoteMeta(*this.EqualTo)+"$"), ""
+	case this.EqualTo != nil:
+		return NewMatcherView(matchers.Exact, *this.EqualTo), ""
+	case this.Contains != nil:
+		return NewMatcherView(matchers.Regex, regexp.QuoteMeta(*this.Contains)), ""
+	case this.Matches != nil:
+		return NewMatcherView(matchers.Regex, wholeValueRegex(*this.Matches)), ""
+	case this.EqualToXml != nil:
+		return NewMatcherView(matchers.Xml, *this.EqualToXml), ""
+	case this.EqualToJson != nil:
+		matcher := matchers.Json
+		if this.IgnoreExtraElements {
+			matcher = matchers.JsonPartial
+		}
+		if value, ok := this.EqualToJson.(string); ok {
+			return NewMatcherView(matcher, value), ""
+		}
+		value, _ := json.Marshal(this.EqualToJson)
+		return NewMatcherView(matcher, string(value)), ""
+	case this.MatchesJsonPath != nil:
+		if value, ok := this.MatchesJsonPath.(string); ok {
+			return NewMatcherView(matchers.JsonPath, value), ""
+		}
+		return MatcherViewV5{}, "matchesJsonPath"
+	case this.MatchesXPath != nil:
+		if value, ok := this.MatchesXPath.(string); ok {
+			return NewMatcherView(matchers.Xpath, value), ""
+		}
+		return MatcherViewV5{}, "matchesXPath"
+	case this.DoesNotMatch != nil:
+		return MatcherViewV5{}, "doesNotMatch"
+	}
+
+	return MatcherViewV5{}, "pattern"
+}
+
+// WireMock regexes have to match the whole value, whereas Hoverfly regexes can match part of it
+func wholeValueRegex(expression string) string {
+	return "^(?:" + expression + ")$"
+}
+
+// NewWireMockViewFromSimulation converts the pairs of a simulation into WireMock mappings. WireMock does
+// not match on the destination or scheme, so these matchers are left out, as are pairs requiring more than
+// one state key. Sequences are started in the Started state of WireMock rather than at 1.
+func NewWireMockViewFromSimulation(simulation SimulationViewV6) WireMockMappingsView {
+	mappings := []WireMockMappingView{}
+
+	for _, pair := range simulation.RequestResponsePairs {
+		if len(pair.RequestMatcher.RequiresState) > 1 || len(pair.Response.TransitionsState) > 1 {
+			continue
+		}
+
+		request := WireMockRequestView{
+			Method: "ANY",
+		}
+		if len(pair.RequestMatcher.Method) > 0 && pair.RequestMatcher.Method[0].Matcher == matchers.Exact {
+			request.Method = fmt.Sprint(pair.RequestMatcher.Method[0].Value)
+		}
+
+		if len(pair.RequestMatcher.Path) > 0 {
+			pattern := newWireMockPatternView(pair.RequestMatcher.Path[0])
+			if pattern.EqualTo != nil {
+				request.UrlPath = pattern.EqualTo
+			} else if pattern.Matches != nil {
+				request.UrlPathPattern = pattern.Matches
+			}
+		}
+
+		if pair.RequestMatcher.Query != nil {
+			for key, queryMatchers := range *pair.RequestMatcher.Query {
+				if len(queryMatchers) == 0 {
+					continue
+				}
+				if request.QueryParameters == nil {
+					request.QueryParameters = map[string]WireMockPatternView{}
+				}
+				request.QueryParameters[key] = newWireMockPatternView(queryMatchers[0])
+			}
+		}
+
+		for key, headerMatchers := range pair.RequestMatcher.Headers {
+			if len(headerMatchers) == 0 {
+				continue
+			}
+			if request.Headers == nil {
+				request.Headers = map[string]WireMockPatternView{}
+			}
+			request.Headers[key] = newWireMockPatternView(headerMatchers[0])
+		}
+
+		for _, bodyMatcher := range pair.RequestMatcher.Body {
+			request.BodyPatterns = append(request.BodyPatterns, newWireMockPatternView(bodyMatcher))
+		}
+
+		response := WireMockResponseView{
+			Status: pair.Response.Status,
+		}
+		if pair.Response.EncodedBody {
+			response.Base64Body = pair.Response.Body
+		} else {
+			response.Body = pair.Response.Body
+		}
+		for name, values := range pair.Response.Headers {
+			if response.Headers == nil {
+				response.Headers = map[string]interface{}{}
+			}
+			if len(values) == 1 {
+				response.Headers[name] = values[0]
+			} else {
+				response.Headers[name] = values
+			}
+		}
+
+		mapping := WireMockMappingView{
+			Request:  request,
+			Response: response,
+		}
+		for key, value := range pair.RequestMatcher.RequiresState {
+			mapping.ScenarioName = key
+			mapping.RequiredScenarioState = wireMockScenarioState(key, value)
+		}
+		for key, value := range pair.Response.TransitionsState {
+			if mapping.ScenarioName != "" && mapping.ScenarioName != key {
+				continue
+			}
+			mapping.ScenarioName = key
+			mapping.NewScenarioState = wireMockScenarioState(key, value)
+		}
+
+		mappings = append(mappings, mapping)
+	}
+
+	return WireMockMappingsView{Mappings: mappings}
+}
+
+func newWireMockPatternView(matcher MatcherViewV5) WireMockPatternView {
+	value := fmt.Sprint(matcher.Value)
+
+	switch matcher.Matcher {
+	case matchers.Exact:
+		return WireMockPatternView{EqualTo: &value}
+	case matchers.Glob:
+		expression := "^" + strings.Replace(regexp.QuoteMeta(value), `\*`, ".*", -1) + "$"
+		return WireMockPatternView{Matches: &expression}
+	case matchers.Regex:
+		expression := ".*(?:" + value + ").*"
+		if strings.HasPrefix(value, "^(?:") && strings.HasSuffix(value, ")$") {
+			expression = strings.TrimSuffix(strings.TrimPrefix(value, "^(?:"), ")$")
+		}
+		return WireMockPatternView{Matches: &expression}
+	case matchers.Json:
+		return WireMockPatternView{EqualToJson: value}
+	case matchers.JsonPartial:
+		return WireMockPatternView{EqualToJson: value, IgnoreExtraElements: true}
+	case matchers.JsonPath:
+		return WireMockPatternView{MatchesJsonPath: value}
+	case matchers.Xml:
+		return WireMockPatternView{EqualToXml: &value}
+	case matchers.Xpath:
+		return WireMockPatternView{MatchesXPath: value}
+	}
+
+	return WireMockPatternView{Matches: &value}
+}
+
+func wireMockScenarioState(key, value string) string {
+	if strings.HasPrefix(key, "sequence:") && value == "1" {
+		return WireMockStartedState
+	}
+
+	return value
+}
@@ -0,0 +1,303 @@
+package v2
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	. "github.com/onsi/gomega"
+)
+
+func Test_NewWireMockMappingsViewFromResponseBody_ReadsListOfMappings(t *testing.T) {
+	RegisterTestingT(t)
+
+	mappings, err := NewWireMockMappingsViewFromResponseBody([]byte(`{
+		"mappings": [
+			{"request": {"urlPath": "/one"}, "response": {"status": 200}},
+			{"request": {"urlPath": "/two"}, "response": {"status": 200}}
+		]
+	}`))
+
+	Expect(err).To(BeNil())
+	Expect(mappings.Mappings).To(HaveLen(2))
+	Expect(*mappings.Mappings[1].Request.UrlPath).To(Equal("/two"))
+}
+
+func Test_NewWireMockMappingsViewFromResponseBody_ReadsSingleMapping(t *testing.T) {
+	RegisterTestingT(t)
+
+	mappings, err := NewWireMockMappingsViewFromResponseBody([]byte(`{
+		"request": {"urlPath": "/one"},
+		"response": {"status": 200}
+	}`))
+
+	Expect(err).To(BeNil())
+	Expect(mappings.Mappings).To(HaveLen(1))
+	Expect(*mappings.Mappings[0].Request.UrlPath).To(Equal("/one"))
+}
+
+func Test_NewWireMockMappingsViewFromResponseBody_Errors(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := NewWireMockMappingsViewFromResponseBody([]byte(`{{`))
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Invalid JSON"))
+
+	_, err = NewWireMockMappingsViewFromResponseBody([]byte(`{"data": {}}`))
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Invalid WireMock mappings, expected a mapping or a list of mappings"))
+}
+
+func Test_NewSimulationViewFromWireMock_ConvertsRequestPatterns(t *testing.T) {
+	RegisterTestingT(t)
+
+	mappings, err := NewWireMockMappingsViewFromResponseBody([]byte(`{
+		"request": {
+			"method": "POST",
+			"urlPathPattern": "/pets/[0-9]+",
+			"queryParameters": {
+				"search": {"contains": "dog"}
+			},
+			"headers": {
+				"Accept": {"equalTo": "application/json", "caseInsensitive": true},
+				"X-Id": {"matches": "[a-z]+"}
+			},
+			"bodyPatterns": [
+				{"equalToJson": {"name": "Rex"}},
+				{"matchesJsonPath": "$.name"}
+			],
+			"basicAuthCredentials": {"username": "user", "password": "pass"}
+		},
+		"response": {
+			"jsonBody": {"id": 1},
+			"headers": {
+				"Content-Type": "application/json",
+				"Set-Cookie": ["a=1", "b=2"]
+			}
+		}
+	}`))
+	Expect(err).To(BeNil())
+
+	simulation, scenarios, warnings := NewSimulationViewFromWireMock(mappings)
+	Expect(warnings).To(BeEmpty())
+	Expect(scenarios).To(BeEmpty())
+	Expect(simulation.RequestResponsePairs).To(HaveLen(1))
+
+	pair := simulation.RequestResponsePairs[0]
+	Expect(pair.RequestMatcher).To(Equal(RequestMatcherViewV5{
+		Method: []MatcherViewV5{NewMatcherView(matchers.Exact, "POST")},
+		Path:   []MatcherViewV5{NewMatcherView(matchers.Regex, "^(?:/pets/[0-9]+)$")},
+		Query: &QueryMatcherViewV5{
+			"search": []MatcherViewV5{NewMatcherView(matchers.Regex, "dog")},
+		},
+		Headers: map[string][]MatcherViewV5{
+			"Accept":        []MatcherViewV5{NewMatcherView(matchers.Regex, "(?i)^application/json$")},
+			"X-Id":          []MatcherViewV5{NewMatcherView(matchers.Regex, "^(?:[a-z]+)$")},
+			"Authorization": []MatcherViewV5{NewMatcherView(matchers.Exact, "Basic dXNlcjpwYXNz")},
+		},
+		Body: []MatcherViewV5{
+			NewMatcherView(matchers.Json, `{"name":"Rex"}`),
+			NewMatcherView(matchers.JsonPath, "$.name"),
+		},
+	}))
+	Expect(pair.Response).To(Equal(ResponseDetailsViewV6{
+		Status: 200,
+		Body:   `{"id":1}`,
+		Headers: map[string][]string{
+			"Content-Type": {"application/json"},
+			"Set-Cookie":   {"a=1", "b=2"},
+		},
+	}))
+}
+
+func Test_NewSimulationViewFromWireMock_ConvertsUrlIntoPathAndExactQuery(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation, _, _ := NewSimulationViewFromWireMock(WireMockMappingsView{
+		Mappings: []WireMockMappingView{
+			{Request: WireMockRequestView{Url: stringPointer("/pets?type=dog")}},
+			{Request: WireMockRequestView{Url: stringPointer("/pets")}},
+		},
+	})
+
+	Expect(simulation.RequestResponsePairs[0].RequestMatcher.Path).To(Equal([]MatcherViewV5{NewMatcherView(matchers.Exact, "/pets")}))
+	Expect(simulation.RequestResponsePairs[0].RequestMatcher.Query).To(Equal(&QueryMatcherViewV5{
+		"type": []MatcherViewV5{NewMatcherView(matchers.Exact, "dog")},
+	}))
+	Expect(simulation.RequestResponsePairs[1].RequestMatcher.Query).To(Equal(&QueryMatcherViewV5{}))
+}
+
+func Test_NewSimulationViewFromWireMock_OrdersMappingsByPriority(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation, _, _ := NewSimulationViewFromWireMock(WireMockMappingsView{
+		Mappings: []WireMockMappingView{
+			{Request: WireMockRequestView{UrlPath: stringPointer("/default")}},
+			{Priority: 10, Request: WireMockRequestView{UrlPath: stringPointer("/low")}},
+			{Priority: 1, Request: WireMockRequestView{UrlPath: stringPointer("/high")}},
+		},
+	})
+
+	Expect(simulation.RequestResponsePairs).To(HaveLen(3))
+	Expect(simulation.RequestResponsePairs[0].RequestMatcher.Path[0].Value).To(Equal("/high"))
+	Expect(simulation.RequestResponsePairs[1].RequestMatcher.Path[0].Value).To(Equal("/default"))
+	Expect(simulation.RequestResponsePairs[2].RequestMatcher.Path[0].Value).To(Equal("/low"))
+}
+
+func Test_NewSimulationViewFromWireMock_ConvertsScenariosIntoState(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation, scenarios, _ := NewSimulationViewFromWireMock(WireMockMappingsView{
+		Mappings: []WireMockMappingView{
+			{
+				ScenarioName:          "To do list",
+				RequiredScenarioState: "Started",
+				NewScenarioState:      "Item added",
+				Request:               WireMockRequestView{Method: "POST"},
+			},
+			{
+				ScenarioName:          "To do list",
+				RequiredScenarioState: "Item added",
+				Request:               WireMockRequestView{Method: "GET"},
+			},
+		},
+	})
+
+	Expect(scenarios).To(Equal(map[string]string{"To do list": "Started"}))
+
+	Expect(simulation.RequestResponsePairs[0].RequestMatcher.RequiresState).To(Equal(map[string]string{"To do list": "Started"}))
+	Expect(simulation.RequestResponsePairs[0].Response.TransitionsState).To(Equal(map[string]string{"To do list": "Item added"}))
+	Expect(simulation.RequestResponsePairs[1].RequestMatcher.RequiresState).To(Equal(map[string]string{"To do list": "Item added"}))
+	Expect(simulation.RequestResponsePairs[1].Response.TransitionsState).To(BeNil())
+}
+
+func Test_NewSimulationViewFromWireMock_ReportsUnsupportedConstructs(t *testing.T) {
+	RegisterTestingT(t)
+
+	mappings, err := NewWireMockMappingsViewFromResponseBody([]byte(`{
+		"mappings": [
+			{
+				"request": {
+					"urlPath": "/skipped",
+					"headers": {"X-Test": {"absent": true}},
+					"cookies": {"session": {"equalTo": "1"}}
+				},
+				"response": {"status": 200}
+			},
+			{
+				"request": {"urlPath": "/kept"},
+				"response": {
+					"status": 200,
+					"fixedDelayMilliseconds": 100,
+					"transformers": ["response-template"]
+				}
+			}
+		]
+	}`))
+	Expect(err).To(BeNil())
+
+	simulation, _, warnings := NewSimulationViewFromWireMock(mappings)
+
+	Expect(simulation.RequestResponsePairs).To(HaveLen(1))
+	Expect(simulation.RequestResponsePairs[0].RequestMatcher.Path[0].Value).To(Equal("/kept"))
+
+	Expect(warnings).To(Equal([]SimulationImportWarning{
+		{Message: "WARNING: mappings[0].request.cookies is not supported, the mapping was not imported"},
+		{Message: "WARNING: mappings[0].request.headers.X-Test.absent is not supported, the mapping was not imported"},
+		{Message: "WARNING: mappings[1].response.fixedDelayMilliseconds is not supported and was ignored"},
+		{Message: "WARNING: mappings[1].response.transformers is not supported and was ignored"},
+	}))
+}
+
+func Test_NewSimulationViewFromWireMock_ConvertsEqualToJsonIgnoringExtraElementsIntoJsonPartial(t *testing.T) {
+	RegisterTestingT(t)
+
+	mappings, err := NewWireMockMappingsViewFromResponseBody([]byte(`{
+		"request": {
+			"urlPath": "/pets",
+			"bodyPatterns": [
+				{"equalToJson": {"name": "Rex"}, "ignoreExtraElements": true}
+			]
+		},
+		"response": {"status": 201}
+	}`))
+	Expect(err).To(BeNil())
+
+	simulation, _, warnings := NewSimulationViewFromWireMock(mappings)
+	Expect(warnings).To(BeEmpty())
+	Expect(simulation.RequestResponsePairs).To(HaveLen(1))
+	Expect(simulation.RequestResponsePairs[0].RequestMatcher.Body).To(Equal([]MatcherViewV5{
+		NewMatcherView(matchers.JsonPartial, `{"name":"Rex"}`),
+	}))
+
+	wireMock := NewWireMockViewFromSimulation(simulation)
+	Expect(wireMock.Mappings[0].Request.BodyPatterns).To(Equal([]WireMockPatternView{
+		{EqualToJson: `{"name":"Rex"}`, IgnoreExtraElements: true},
+	}))
+}
+
+func Test_NewWireMockViewFromSimulation_ConvertsPairsIntoMappings(t *testing.T) {
+	RegisterTestingT(t)
+
+	wireMock := NewWireMockViewFromSimulation(SimulationViewV6{
+		DataViewV6{
+			RequestResponsePairs: []RequestMatcherResponsePairViewV6{
+				{
+					RequestMatcher: RequestMatcherViewV5{
+						Method:      []MatcherViewV5{NewMatcherView(matchers.Exact, "GET")},
+						Destination: []MatcherViewV5{NewMatcherView(matchers.Exact, "test.com")},
+						Path:        []MatcherViewV5{NewMatcherView(matchers.Glob, "/pets/*")},
+						Query: &QueryMatcherViewV5{
+							"type": []MatcherViewV5{NewMatcherView(matchers.Regex, "^(?:dog|cat)$")},
+						},
+						Headers: map[string][]MatcherViewV5{
+							"Accept": []MatcherViewV5{NewMatcherView(matchers.Regex, "json")},
+						},
+						Body:          []MatcherViewV5{NewMatcherView(matchers.Json, `{"name":"Rex"}`)},
+						RequiresState: map[string]string{"sequence:1": "1"},
+					},
+					Response: ResponseDetailsViewV6{
+						Status:           200,
+						Body:             "aGVsbG8=",
+						EncodedBody:      true,
+						Headers:          map[string][]string{"Content-Type": {"text/plain"}, "Set-Cookie": {"a=1", "b=2"}},
+						TransitionsState: map[string]string{"sequence:1": "2"},
+					},
+				},
+			},
+		},
+		MetaView{},
+	})
+
+	Expect(wireMock.Mappings).To(HaveLen(1))
+
+	mapping := wireMock.Mappings[0]
+	Expect(mapping.ScenarioName).To(Equal("sequence:1"))
+	Expect(mapping.RequiredScenarioState).To(Equal("Started"))
+	Expect(mapping.NewScenarioState).To(Equal("2"))
+
+	Expect(mapping.Request).To(Equal(WireMockRequestView{
+		Method:         "GET",
+		UrlPathPattern: stringPointer(`^/pets/.*$`),
+		QueryParameters: map[string]WireMockPatternView{
+			"type": {Matches: stringPointer("dog|cat")},
+		},
+		Headers: map[string]WireMockPatternView{
+			"Accept": {Matches: stringPointer(".*(?:json).*")},
+		},
+		BodyPatterns: []WireMockPatternView{
+			{EqualToJson: `{"name":"Rex"}`},
+		},
+	}))
+	Expect(mapping.Response).To(Equal(WireMockResponseView{
+		Status:     200,
+		Base64Body: "aGVsbG8=",
+		Headers: map[string]interface{}{
+			"Content-Type": "text/plain",
+			"Set-Cookie":   []string{"a=1", "b=2"},
+		},
+	}))
+}
+
+func stringPointer(value string) *string {
+	return &value
+}
@@ -0,0 +1,139 @@
+package hoverfly
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v1"
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/SpectoLabs/hoverfly/core/state"
+)
+
+// PutHar replaces the simulation with the entries of a HAR file. The matchers are built the same way
+// as when capturing, using the headers whitelist and stateful arguments from the options.
+func (hf *Hoverfly) PutHar(har v2.HarView, options v2.ModeArgumentsView) v2.SimulationImportResult {
+	simulationView, err := hf.newSimulationViewFromHar(har, options)
+	if err != nil {
+		result := v2.SimulationImportResult{}
+		result.AddError(err)
+		return result
+	}
+
+	return hf.ReplaceSimulation(simulationView)
+}
+
+// AppendHar adds the entries of a HAR file to the simulation in the same way as AppendSimulation
+func (hf *Hoverfly) AppendHar(har v2.HarView, options v2.ModeArgumentsView) v2.SimulationImportResult {
+	simulationView, err := hf.newSimulationViewFromHar(har, options)
+	if err != nil {
+		result := v2.SimulationImportResult{}
+		result.AddError(err)
+		return result
+	}
+
+	return hf.AppendSimulation(simulationView)
+}
+
+// ImportFromHarDisk imports a HAR file from disk, matching on the same fields as capture does by default
+func (hf *Hoverfly) ImportFromHarDisk(path string) error {
+	simulation, err := hf.readHarSimulation(path)
+	if err != nil {
+		return err
+	}
+
+	return hf.importSimulation(simulation)
+}
+
+func (hf *Hoverfly) readHarSimulation(path string) (v2.SimulationViewV6, error) {
+	har, err := readHarFile(path)
+	if err != nil {
+		return v2.SimulationViewV6{}, err
+	}
+
+	return hf.newSimulationViewFromHar(har, v2.ModeArgumentsView{})
+}
+
+func readHarFile(path string) (v2.HarView, error) {
+	body, err := ioutil.ReadFile(path)
+	if err != nil {
+		return v2.HarView{}, fmt.Errorf("Got error while opening HAR file, error %s", err.Error())
+	}
+
+	har, err := v2.NewHarViewFromResponseBody(body)
+	if err != nil {
+		return v2.HarView{}, fmt.Errorf("Got error while parsing HAR file, error %s", err.Error())
+	}
+
+	return har, nil
+}
+
+func (hf *Hoverfly) newSimulationViewFromHar(har v2.HarView, options v2.ModeArgumentsView) (v2.SimulationViewV6, error) {
+	simulation := models.NewSimulation()
+	sequenceState := state.NewState()
+
+	for i, entry := range har.Log.Entries {
+		request, response, err := newRequestResponseFromHarEntry(entry)
+		if err != nil {
+			return v2.SimulationViewV6{}, fmt.Errorf("Could not import log.entries[%v], %s", i, err.Error())
+		}
+
+		pair := newCapturedPair(&request, &response, options.Headers)
+		if options.Stateful {
+			simulation.AddPairInSequence(&pair, sequenceState)
+		} else {
+			simulation.AddPair(&pair)
+		}
+	}
+
+	pairViews := []v2.RequestMatcherResponsePairViewV6{}
+	for _, pair := range simulation.GetMatchingPairs() {
+		pairViews = append(pairViews, pair.BuildView())
+	}
+
+	return v2.BuildSimulationView(pairViews, v1.ResponseDelayPayloadView{Data: []v1.ResponseDelayView{}}, hf.version), nil
+}
+
+func newRequestResponseFromHarEntry(entry v2.HarEntryView) (models.RequestDetails, models.ResponseDetails, error) {
+	requestUrl, err := url.Parse(entry.Request.Url)
+	if err != nil {
+		return models.RequestDetails{}, models.ResponseDetails{}, fmt.Errorf("invalid request url %s", entry.Request.Url)
+	}
+
+	request := models.RequestDetails{
+		Method:      entry.Request.Method,
+		Scheme:      requestUrl.Scheme,
+		Destination: requestUrl.Host,
+		Path:        requestUrl.Path,
+		Query:       requestUrl.Query(),
+		Headers:     v2.NewHeadersFromHarNameValueViews(entry.Request.Headers),
+	}
+	if entry.Request.PostData != nil {
+		request.Body = entry.Request.PostData.Text
+	}
+
+	body := entry.Response.Content.Text
+	if entry.Response.Content.Encoding == "base64" {
+		decoded, err := base64.StdEncoding.DecodeString(body)
+		if err != nil {
+			return models.RequestDetails{}, models.ResponseDetails{}, fmt.Errorf("response content is not valid base64")
+		}
+		body = string(decoded)
+	}
+
+	// HAR files hold the decoded body, so the encoding and length of the body on
+	// the wire no longer describe it and would break clients if served
+	headers := v2.NewHeadersFromHarNameValueViews(entry.Response.Headers)
+	delete(headers, "Content-Encoding")
+	delete(headers, "Content-Length")
+
+	response := models.ResponseDetails{
+		Status:  entry.Response.Status,
+		Body:    body,
+		Headers: headers,
+	}
+
+	return request, response, nil
+}
@@ -0,0 +1,233 @@
+package hoverfly
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	"github.com/SpectoLabs/hoverfly/core/models"
+	. "github.com/onsi/gomega"
+)
+
+func newHarEntry(method, url, requestBody string, status int, responseBody string) v2.HarEntryView {
+	entry := v2.HarEntryView{
+		Request: v2.HarRequestView{
+			Method: method,
+			Url:    url,
+			Headers: []v2.HarNameValueView{
+				{Name: "content-type", Value: "application/json"},
+				{Name: "accept", Value: "*/*"},
+			},
+		},
+		Response: v2.HarResponseView{
+			Status: status,
+			Headers: []v2.HarNameValueView{
+				{Name: "Content-Type", Value: "text/plain"},
+			},
+			Content: v2.HarContentView{
+				Text: responseBody,
+			},
+		},
+	}
+
+	if requestBody != "" {
+		entry.Request.PostData = &v2.HarPostDataView{Text: requestBody}
+	}
+
+	return entry
+}
+
+func newHar(entries ...v2.HarEntryView) v2.HarView {
+	return v2.HarView{
+		Log: v2.HarLogView{
+			Version: "1.2",
+			Entries: entries,
+		},
+	}
+}
+
+func Test_Hoverfly_PutHar_ImportsEntriesAsExactMatchers(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	result := unit.PutHar(newHar(
+		newHarEntry("POST", "https://test.com/path?b=2&a=1", `{"test": true}`, 201, "created"),
+	), v2.ModeArgumentsView{})
+	Expect(result.GetError()).To(BeNil())
+
+	pairs := unit.Simulation.GetMatchingPairs()
+	Expect(pairs).To(HaveLen(1))
+
+	requestMatcher := pairs[0].RequestMatcher
+	Expect(requestMatcher.Method).To(Equal([]models.RequestFieldMatchers{{Matcher: matchers.Exact, Value: "POST"}}))
+	Expect(requestMatcher.Scheme).To(Equal([]models.RequestFieldMatchers{{Matcher: matchers.Exact, Value: "https"}}))
+	Expect(requestMatcher.Destination).To(Equal([]models.RequestFieldMatchers{{Matcher: matchers.Exact, Value: "test.com"}}))
+	Expect(requestMatcher.Path).To(Equal([]models.RequestFieldMatchers{{Matcher: matchers.Exact, Value: "/path"}}))
+	Expect(requestMatcher.Body).To(Equal([]models.RequestFieldMatchers{{Matcher: matchers.Json, Value: `{"test": true}`}}))
+	Expect((*requestMatcher.Query)["a"]).To(Equal([]models.RequestFieldMatchers{{Matcher: matchers.Exact, Value: "1"}}))
+	Expect((*requestMatcher.Query)["b"]).To(Equal([]models.RequestFieldMatchers{{Matcher: matchers.Exact, Value: "2"}}))
+	Expect(requestMatcher.Headers).To(BeEmpty())
+
+	Expect(pairs[0].Response.Status).To(Equal(201))
+	Expect(pairs[0].Response.Body).To(Equal("created"))
+	Expect(pairs[0].Response.Headers).To(Equal(map[string][]string{"Content-Type": []string{"text/plain"}}))
+}
+
+func Test_Hoverfly_PutHar_UsesHeadersWhitelist(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.PutHar(newHar(
+		newHarEntry("GET", "http://test.com/path", "", 200, "ok"),
+	), v2.ModeArgumentsView{Headers: []string{"Accept"}})
+
+	Expect(unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Headers).To(Equal(map[string][]models.RequestFieldMatchers{
+		"Accept": {
+			{Matcher: matchers.Exact, Value: "*/*"},
+		},
+	}))
+}
+
+func Test_Hoverfly_PutHar_ImportsRepeatedRequestsAsSequenceWhenStateful(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.PutHar(newHar(
+		newHarEntry("GET", "http://test.com/path", "", 200, "first"),
+		newHarEntry("GET", "http://test.com/path", "", 200, "second"),
+	), v2.ModeArgumentsView{Stateful: true})
+
+	pairs := unit.Simulation.GetMatchingPairs()
+	Expect(pairs).To(HaveLen(2))
+	Expect(pairs[0].RequestMatcher.RequiresState).To(Equal(map[string]string{"sequence:1": "1"}))
+	Expect(pairs[0].Response.TransitionsState).To(Equal(map[string]string{"sequence:1": "2"}))
+	Expect(pairs[1].RequestMatcher.RequiresState).To(Equal(map[string]string{"sequence:1": "2"}))
+
+	Expect(unit.GetState()).To(Equal(map[string]string{"sequence:1": "1"}))
+}
+
+func Test_Hoverfly_PutHar_KeepsOnlyFirstOfRepeatedRequestsWhenNotStateful(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.PutHar(newHar(
+		newHarEntry("GET", "http://test.com/path", "", 200, "first"),
+		newHarEntry("GET", "http://test.com/path", "", 200, "second"),
+	), v2.ModeArgumentsView{})
+
+	pairs := unit.Simulation.GetMatchingPairs()
+	Expect(pairs).To(HaveLen(1))
+	Expect(pairs[0].Response.Body).To(Equal("first"))
+}
+
+func Test_Hoverfly_PutHar_DecodesBase64ContentAndDropsWireHeaders(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	entry := newHarEntry("GET", "http://test.com/path", "", 200, "aGVsbG8=")
+	entry.Response.Content.Encoding = "base64"
+	entry.Response.Headers = append(entry.Response.Headers,
+		v2.HarNameValueView{Name: "content-encoding", Value: "gzip"},
+		v2.HarNameValueView{Name: "content-length", Value: "25"},
+	)
+
+	result := unit.PutHar(newHar(entry), v2.ModeArgumentsView{})
+	Expect(result.GetError()).To(BeNil())
+
+	pair := unit.Simulation.GetMatchingPairs()[0]
+	Expect(pair.Response.Body).To(Equal("hello"))
+	Expect(pair.Response.Headers).To(Equal(map[string][]string{"Content-Type": []string{"text/plain"}}))
+}
+
+func Test_Hoverfly_PutHar_ErrorsOnInvalidEntry(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	entry := newHarEntry("GET", "http://test.com/path", "", 200, "not base64!")
+	entry.Response.Content.Encoding = "base64"
+
+	result := unit.PutHar(newHar(newHarEntry("GET", "http://test.com/other", "", 200, "ok"), entry), v2.ModeArgumentsView{})
+	Expect(result.GetError()).ToNot(BeNil())
+	Expect(result.GetError().Error()).To(Equal("Could not import log.entries[1], response content is not valid base64"))
+
+	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(0))
+}
+
+func Test_Hoverfly_PutHar_ReplacesExistingSimulation(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.PutHar(newHar(
+		newHarEntry("GET", "http://test.com/one", "", 200, "one"),
+	), v2.ModeArgumentsView{})
+
+	result := unit.PutHar(newHar(
+		newHarEntry("GET", "http://test.com/two", "", 200, "two"),
+	), v2.ModeArgumentsView{})
+	Expect(result.GetError()).To(BeNil())
+
+	pairs := unit.Simulation.GetMatchingPairs()
+	Expect(pairs).To(HaveLen(1))
+	Expect(pairs[0].Response.Body).To(Equal("two"))
+}
+
+func Test_Hoverfly_AppendHar_AddsToExistingSimulation(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.PutHar(newHar(
+		newHarEntry("GET", "http://test.com/one", "", 200, "one"),
+	), v2.ModeArgumentsView{})
+
+	unit.AppendHar(newHar(
+		newHarEntry("GET", "http://test.com/one", "", 200, "duplicate"),
+		newHarEntry("GET", "http://test.com/two", "", 200, "two"),
+	), v2.ModeArgumentsView{})
+
+	pairs := unit.Simulation.GetMatchingPairs()
+	Expect(pairs).To(HaveLen(2))
+	Expect(pairs[0].Response.Body).To(Equal("one"))
+	Expect(pairs[1].Response.Body).To(Equal("two"))
+}
+
+func Test_Hoverfly_Import_ImportsHarFileFromDisk(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "hoverfly-har")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+
+	harPath := filepath.Join(dir, "recording.har")
+	Expect(ioutil.WriteFile(harPath, []byte(`{
+		"log": {
+			"version": "1.2",
+			"creator": {"name": "Browser", "version": "1"},
+			"entries": [
+				{
+					"startedDateTime": "2017-01-01T00:00:00.000Z",
+					"request": {"method": "GET", "url": "http://test.com/path", "headers": []},
+					"response": {"status": 200, "headers": [], "content": {"text": "body"}}
+				}
+			]
+		}
+	}`), 0644)).To(Succeed())
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	Expect(unit.Import(harPath)).To(Succeed())
+
+	pairs := unit.Simulation.GetMatchingPairs()
+	Expect(pairs).To(HaveLen(1))
+	Expect(pairs[0].Response.Body).To(Equal("body"))
+}
@@ -24,6 +24,7 @@ import (
 type Hoverfly struct {
 	CacheMatcher   matching.CacheMatcher
 	Authentication backends.Authentication
+	Persistence    cache.Cache
 
 	HTTP    *http.Client
 	Cfg     *Configuration
@@ -44,6 +45,22 @@ type Hoverfly struct {
 	templator     *templating.Templator
 
 	responsesDiff map[v2.SimpleRequestDefinitionView][]v2.DiffReport
+	diffMutex     sync.RWMutex
+
+	coverage coverage
+
+	persistenceMutex   sync.Mutex
+	pendingPersistence pendingPersistence
+
+	simulations       map[string]v2.SimulationViewV6
+	activeSimulations []string
+	simulationsMutex  sync.RWMutex
+
+	modeRoutes      []modeRoute
+	modeRoutesMutex sync.RWMutex
+
+	transformationRules      []transformationRule
+	transformationRulesMutex sync.RWMutex
 }
 
 func NewHoverfly() *Hoverfly {
@@ -53,27 +70,27 @@ func NewHoverfly() *Hoverfly {
 	hoverfly := &Hoverfly{
 		Simulation:     models.NewSimulation(),
 		Authentication: authBackend,
-		Counter:        metrics.NewModeCounter([]string{modes.Simulate, modes.Synthesize, modes.Modify, modes.Capture, modes.Spy, modes.Diff}),
+		Counter:        metrics.NewModeCounter([]string{modes.Simulate, modes.Synthesize, modes.Modify, modes.Capture, modes.Spy, modes.Diff, modes.Hybrid, modes.Shadow}),
 		StoreLogsHook:  NewStoreLogsHook(),
 		Journal:        journal.NewJournal(),
 		Cfg:            InitSettings(),
 		state:          state.NewState(),
 		templator:      templating.NewTemplator(),
 		responsesDiff:  make(map[v2.SimpleRequestDefinitionView][]v2.DiffReport),
+		simulations:    map[string]v2.SimulationViewV6{},
 	}
 
 	hoverfly.version = "v0.17.4"
+	hoverfly.state.OnScheduledTransitionsApplied(hoverfly.persistState)
+	hoverfly.coverage.reset()
 
 	log.AddHook(hoverfly.StoreLogsHook)
 
 	modeMap := make(map[string]modes.Mode)
 
-	modeMap[modes.Capture] = &modes.CaptureMode{Hoverfly: hoverfly}
-	modeMap[modes.Simulate] = &modes.SimulateMode{Hoverfly: hoverfly, MatchingStrategy: "strongest"}
-	modeMap[modes.Modify] = &modes.ModifyMode{Hoverfly: hoverfly}
-	modeMap[modes.Synthesize] = &modes.SynthesizeMode{Hoverfly: hoverfly}
-	modeMap[modes.Spy] = &modes.SpyMode{Hoverfly: hoverfly}
-	modeMap[modes.Diff] = &modes.DiffMode{Hoverfly: hoverfly}
+	for _, modeName := range []string{modes.Capture, modes.Simulate, modes.Modify, modes.Synthesize, modes.Spy, modes.Diff, modes.Hybrid, modes.Shadow} {
+		modeMap[modeName] = newMode(hoverfly, modeName)
+	}
 
 	hoverfly.modeMap = modeMap
 
@@ -82,6 +99,42 @@ func NewHoverfly() *Hoverfly {
 	return hoverfly
 }
 
+// modeHoverfly is what any of the modes needs from Hoverfly to process requests
+type modeHoverfly interface {
+	modes.HoverflyCapture
+	modes.HoverflySimulate
+	modes.HoverflyModify
+	modes.HoverflySynthesize
+	modes.HoverflySpy
+	modes.HoverflyDiff
+	modes.HoverflyHybrid
+	modes.HoverflyShadow
+}
+
+// newMode creates a mode which processes requests with the Hoverfly, or nil when the mode does not exist
+func newMode(hf modeHoverfly, modeName string) modes.Mode {
+	switch modeName {
+	case modes.Capture:
+		return &modes.CaptureMode{Hoverfly: hf}
+	case modes.Simulate:
+		return &modes.SimulateMode{Hoverfly: hf, MatchingStrategy: "strongest"}
+	case modes.Modify:
+		return &modes.ModifyMode{Hoverfly: hf}
+	case modes.Synthesize:
+		return &modes.SynthesizeMode{Hoverfly: hf}
+	case modes.Spy:
+		return &modes.SpyMode{Hoverfly: hf}
+	case modes.Diff:
+		return &modes.DiffMode{Hoverfly: hf}
+	case modes.Hybrid:
+		return &modes.HybridMode{Hoverfly: hf}
+	case modes.Shadow:
+		return &modes.ShadowMode{Hoverfly: hf}
+	}
+
+	return nil
+}
+
 func NewHoverflyWithConfiguration(cfg *Configuration) *Hoverfly {
 	hoverfly := NewHoverfly()
 
@@ -177,25 +230,32 @@ func (hf *Hoverfly) StopProxy() {
 // processRequest - processes incoming requests and based on proxy state (record/playback)
 // returns HTTP response.
 func (hf *Hoverfly) processRequest(req *http.Request) *http.Response {
+	response, _ := hf.processRequestWithMode(req)
+	return response
+}
+
+// processRequestWithMode processes a request as processRequest does, also returning the mode the
+// request was processed in, which is the mode of the route it matched or else the mode of Hoverfly
+func (hf *Hoverfly) processRequestWithMode(req *http.Request) (*http.Response, string) {
 	requestDetails, err := models.NewRequestDetailsFromHttpRequest(req)
 	if err != nil {
-		return modes.ErrorResponse(req, err, "Could not interpret HTTP request")
+		return modes.ErrorResponse(req, err, "Could not interpret HTTP request"), hf.Cfg.GetMode()
 	}
 
-	modeName := hf.Cfg.GetMode()
-	mode := hf.modeMap[modeName]
+	modeName, mode := hf.routeRequest(requestDetails)
 	response, err := mode.Process(req, requestDetails)
 
 	// Don't delete the error
 	// and definitely don't delay people in capture mode
 	if err != nil || modeName == modes.Capture {
-		return response
+		return response, modeName
 	}
 
-	respDelay := hf.Simulation.ResponseDelays.GetDelay(requestDetails)
+	respDelay := hf.Simulation.GetResponseDelays().GetDelay(requestDetails)
 	if respDelay != nil {
+		hf.coverage.addDelayHit(*respDelay)
 		respDelay.Execute()
 	}
 
-	return response
+	return response, modeName
 }
@@ -5,9 +5,11 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/SpectoLabs/hoverfly/core/errors"
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/core/matching"
 	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
 	"github.com/SpectoLabs/hoverfly/core/models"
@@ -44,10 +46,25 @@ func (hf *Hoverfly) DoRequest(request *http.Request) (*http.Response, error) {
 
 // GetResponse returns stored response from cache
 func (hf *Hoverfly) GetResponse(requestDetails models.RequestDetails) (*models.ResponseDetails, *errors.HoverflyError) {
+	mode := (hf.modeMap[modes.Simulate]).(*modes.SimulateMode)
+
+	return hf.getResponse(requestDetails, mode.MatchingStrategy)
+}
+
+// getResponse matches the request with the matching strategy, the cache is only used when it is
+// the strategy of Hoverfly, as the cached responses were matched with it
+func (hf *Hoverfly) getResponse(requestDetails models.RequestDetails, matchingStrategy string) (*models.ResponseDetails, *errors.HoverflyError) {
 
 	var response models.ResponseDetails
 
-	cachedResponse, cacheErr := hf.CacheMatcher.GetCachedResponse(&requestDetails)
+	mode := (hf.modeMap[modes.Simulate]).(*modes.SimulateMode)
+	useCache := strings.EqualFold(matchingStrategy, mode.MatchingStrategy)
+
+	var cachedResponse *models.CachedResponse
+	cacheErr := errors.RecordedRequestNotInCacheError()
+	if useCache {
+		cachedResponse, cacheErr = hf.CacheMatcher.GetCachedResponse(&requestDetails)
+	}
 
 	// Get the cached response and return if there is a miss
 	if cacheErr == nil && cachedResponse.MatchingPair == nil {
@@ -55,15 +72,14 @@ func (hf *Hoverfly) GetResponse(requestDetails models.RequestDetails) (*models.R
 		// If it's cached, use that response
 	} else if cacheErr == nil {
 		response = cachedResponse.MatchingPair.Response
+		response.PairId = cachedResponse.MatchingPair.Id
 		//If it's not cached, perform matching to find a hit
 	} else {
-		mode := (hf.modeMap[modes.Simulate]).(*modes.SimulateMode)
-
 		// Matching
-		result := matching.Match(mode.MatchingStrategy, requestDetails, hf.Cfg.Webserver, hf.Simulation, hf.state)
+		result := matching.Match(matchingStrategy, requestDetails, hf.Cfg.Webserver, hf.Simulation, hf.state)
 
 		// Cache result
-		if result.Cachable {
+		if result.Cachable && useCache {
 			hf.CacheMatcher.SaveRequestMatcherResponsePair(requestDetails, result.Pair, result.Error)
 		}
 
@@ -80,13 +96,16 @@ func (hf *Hoverfly) GetResponse(requestDetails models.RequestDetails) (*models.R
 			return nil, errors.MatchingFailedError(result.Error.ClosestMiss)
 		} else {
 			response = result.Pair.Response
+			response.PairId = result.Pair.Id
 		}
 	}
 
+	hf.coverage.addPairHit(response.PairId)
+
 	// Templating applies at the end, once we have loaded a response. Comes BEFORE state transitions,
 	// as we use the current state in templates
 	if response.Templated == true {
-		responseBody, err := hf.templator.ApplyTemplate(&requestDetails, hf.state.State, response.Body)
+		responseBody, err := hf.templator.ApplyTemplate(&requestDetails, hf.state.Copy(), response.Body)
 		if err == nil {
 			response.Body = responseBody
 		} else {
@@ -101,12 +120,58 @@ func (hf *Hoverfly) GetResponse(requestDetails models.RequestDetails) (*models.R
 	if response.RemovesState != nil {
 		hf.state.RemoveState(response.RemovesState)
 	}
+	for key, transition := range response.ScheduledTransitions {
+		hf.state.ScheduleTransition(key, transition.Value, time.Duration(transition.Delay)*time.Millisecond)
+	}
+	if response.TransitionsState != nil || response.RemovesState != nil || response.ScheduledTransitions != nil {
+		hf.persistState()
+	}
 
 	return &response, nil
 }
 
 // save gets request fingerprint, extracts request body, status code and headers, then saves it to cache
-func (hf *Hoverfly) Save(request *models.RequestDetails, response *models.ResponseDetails, headersWhitelist []string, recordSequence bool) error {
+func (hf *Hoverfly) Save(request *models.RequestDetails, response *models.ResponseDetails, arguments *modes.ModeArguments) error {
+	pair := newCapturedPair(request, response, arguments.Headers)
+
+	rules := append(append([]*v2.RedactionRule{}, hf.Cfg.Redactions...), arguments.Redactions...)
+	if len(rules) > 0 || arguments.MatcherPolicy != nil {
+		pairView := pair.BuildView()
+		if arguments.MatcherPolicy != nil {
+			pairView = v2.ApplyMatcherPolicy(pairView, arguments.MatcherPolicy)
+		}
+		pairView = v2.RedactPairView(pairView, rules)
+		pair = *models.NewRequestMatcherResponsePairFromView(&pairView)
+	}
+
+	capturePolicy := arguments.CapturePolicy
+	if capturePolicy == "" && arguments.Stateful {
+		capturePolicy = modes.CaptureAppendAll
+	}
+
+	switch capturePolicy {
+	case modes.CaptureAppendAll:
+		hf.Simulation.AddPairInSequence(&pair, hf.state)
+		hf.persistState()
+	case modes.CaptureSequenceOnlyIfDifferent:
+		hf.Simulation.AddPairInSequenceIfDifferent(&pair, hf.state)
+		hf.persistState()
+	case modes.CaptureLastWins:
+		hf.Simulation.ReplacePair(&pair)
+	default:
+		hf.Simulation.AddPair(&pair)
+	}
+	hf.persistSimulation()
+
+	// In hybrid mode a miss for this request may have been cached before it was saved
+	hf.CacheMatcher.FlushCache()
+
+	return nil
+}
+
+// newCapturedPair builds a pair which matches exactly on the request, only
+// including the headers which are in the whitelist
+func newCapturedPair(request *models.RequestDetails, response *models.ResponseDetails, headersWhitelist []string) models.RequestMatcherResponsePair {
 	body := []models.RequestFieldMatchers{
 		{
 			Matcher: matchers.Exact,
@@ -167,7 +232,7 @@ func (hf *Hoverfly) Save(request *models.RequestDetails, response *models.Respon
 		})
 	}
 
-	pair := models.RequestMatcherResponsePair{
+	return models.RequestMatcherResponsePair{
 		RequestMatcher: models.RequestMatcher{
 			Path: []models.RequestFieldMatchers{
 				{
@@ -199,16 +264,9 @@ func (hf *Hoverfly) Save(request *models.RequestDetails, response *models.Respon
 		},
 		Response: *response,
 	}
-	if recordSequence {
-		hf.Simulation.AddPairInSequence(&pair, hf.state)
-	} else {
-		hf.Simulation.AddPair(&pair)
-	}
-
-	return nil
 }
 
-func (this Hoverfly) ApplyMiddleware(pair models.RequestResponsePair) (models.RequestResponsePair, error) {
+func (this *Hoverfly) ApplyMiddleware(pair models.RequestResponsePair) (models.RequestResponsePair, error) {
 	if this.Cfg.Middleware.IsSet() {
 		return this.Cfg.Middleware.Execute(pair)
 	}
@@ -12,6 +12,7 @@ import (
 	"github.com/SpectoLabs/hoverfly/core/matching"
 	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
 	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/SpectoLabs/hoverfly/core/modes"
 	. "github.com/onsi/gomega"
 )
 
@@ -120,6 +121,7 @@ func Test_Hoverfly_GetResponse_CanReturnResponseFromSimulationAndNotCache(t *tes
 				},
 			},
 		},
+		Id: "post-somehost",
 		Response: models.ResponseDetails{
 			Status: 200,
 			Body:   "response body",
@@ -137,6 +139,7 @@ func Test_Hoverfly_GetResponse_CanReturnResponseFromSimulationAndNotCache(t *tes
 
 	Expect(response.Status).To(Equal(http.StatusOK))
 	Expect(response.Body).To(Equal("response body"))
+	Expect(response.PairId).To(Equal("post-somehost"))
 }
 
 func Test_Hoverfly_GetResponse_WillCacheResponseIfNotInCache(t *testing.T) {
@@ -250,9 +253,9 @@ func Test_Hoverfly_GetResponse_WillCacheClosestMiss(t *testing.T) {
 	RegisterTestingT(t)
 
 	unit := NewHoverflyWithConfiguration(&Configuration{})
-	unit.PutSimulation(v2.SimulationViewV5{
-		v2.DataViewV5{
-			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+	unit.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
 				{
 					RequestMatcher: v2.RequestMatcherViewV5{
 						Method: []v2.MatcherViewV5{
@@ -262,7 +265,7 @@ func Test_Hoverfly_GetResponse_WillCacheClosestMiss(t *testing.T) {
 							},
 						},
 					},
-					Response: v2.ResponseDetailsViewV5{
+					Response: v2.ResponseDetailsViewV6{
 						Body: "closest",
 					},
 				},
@@ -440,7 +443,7 @@ func Test_Hoverfly_GetResponse_TransitioningBetweenStatesWhenSimulating(t *testi
 		}
 	}`
 
-	v5 := &v2.SimulationViewV5{}
+	v5 := &v2.SimulationViewV6{}
 
 	json.Unmarshal([]byte(simulation), v5)
 
@@ -544,6 +547,90 @@ func Test_Hoverfly_GetResponse_TransitioningBetweenStatesWhenSimulating(t *testi
 	Expect(string(response.Body)).To(Equal(`empty`))
 }
 
+func Test_Hoverfly_GetResponse_SchedulesStateTransitionsWhenSimulating(t *testing.T) {
+	RegisterTestingT(t)
+
+	simulation := `{
+		"data": {
+			"pairs": [{
+					"request": {
+						"path": [
+							{
+								"matcher": "exact",
+								"value": "/order"
+							}
+						]
+					},
+					"response": {
+						"status": 201,
+						"body": "created",
+						"transitionsState": {
+							"order": "created"
+						},
+						"scheduledTransitions": {
+							"order": {
+								"value": "shipped",
+								"delay": 20
+							}
+						}
+					}
+				},
+				{
+					"request": {
+						"path": [
+							{
+								"matcher": "exact",
+								"value": "/order/status"
+							}
+						],
+						"requiresState": {
+							"order": "shipped"
+						}
+					},
+					"response": {
+						"status": 200,
+						"body": "shipped"
+					}
+				}
+			],
+			"globalActions": {
+				"delays": []
+			}
+		},
+		"meta": {
+			"schemaVersion": "v6",
+			"hoverflyVersion": "v0.10.2",
+			"timeExported": "2017-02-23T12:43:48Z"
+		}
+	}`
+
+	simulationView := &v2.SimulationViewV6{}
+
+	json.Unmarshal([]byte(simulation), simulationView)
+
+	hoverfly := NewHoverfly()
+	hoverfly.CacheMatcher = matching.CacheMatcher{
+		RequestCache: cache.NewInMemoryCache(),
+	}
+	hoverfly.PutSimulation(*simulationView)
+
+	hoverfly.SetModeWithArguments(v2.ModeView{Mode: "simulate"})
+
+	response, _ := hoverfly.GetResponse(models.RequestDetails{
+		Path: "/order",
+	})
+	Expect(string(response.Body)).To(Equal(`created`))
+	Expect(hoverfly.GetState()).To(HaveKeyWithValue("order", "created"))
+	Expect(hoverfly.GetScheduledStateTransitions()).To(HaveKey("order"))
+
+	Eventually(hoverfly.GetState).Should(HaveKeyWithValue("order", "shipped"))
+
+	response, _ = hoverfly.GetResponse(models.RequestDetails{
+		Path: "/order/status",
+	})
+	Expect(string(response.Body)).To(Equal(`shipped`))
+}
+
 func Test_Hoverfly_GetResponse_GetNotRecordedRequest(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -581,7 +668,7 @@ func Test_Hoverfly_Save_SavesRequestAndResponseToSimulation(t *testing.T) {
 		Body:    "testresponsebody",
 		Headers: map[string][]string{"testheader": []string{"testvalue"}},
 		Status:  200,
-	}, nil, false)
+	}, &modes.ModeArguments{})
 
 	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(1))
 
@@ -624,7 +711,7 @@ func Test_Hoverfly_Save_DoesNotSaveRequestHeadersWhenGivenHeadersArrayIsNil(t *t
 		Body:    "testresponsebody",
 		Headers: map[string][]string{"testheader": []string{"testvalue"}},
 		Status:  200,
-	}, nil, false)
+	}, &modes.ModeArguments{})
 
 	Expect(unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Headers).To(BeEmpty())
 }
@@ -643,7 +730,7 @@ func Test_Hoverfly_Save_SavesAllRequestHeadersWhenGivenAnAsterisk(t *testing.T)
 		Body:    "testresponsebody",
 		Headers: map[string][]string{"testheader": []string{"testvalue"}},
 		Status:  200,
-	}, []string{"*"}, false)
+	}, &modes.ModeArguments{Headers: []string{"*"}})
 
 	Expect(unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Headers).To(HaveLen(2))
 	Expect(unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Headers["testheader"]).To(HaveLen(1))
@@ -672,7 +759,7 @@ func Test_Hoverfly_Save_SavesSpecificRequestHeadersWhenSpecifiedInHeadersArray(t
 		Body:    "testresponsebody",
 		Headers: map[string][]string{"testheader": []string{"testvalue"}},
 		Status:  200,
-	}, []string{"testheader"}, false)
+	}, &modes.ModeArguments{Headers: []string{"testheader"}})
 
 	Expect(unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Headers).To(HaveLen(1))
 	Expect(unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Headers["testheader"]).To(HaveLen(1))
@@ -696,7 +783,7 @@ func Test_Hoverfly_Save_DoesNotSaveAnyRequestHeaderIfItDoesNotMatchEntryInHeader
 		Body:    "testresponsebody",
 		Headers: map[string][]string{"testheader": []string{"testvalue"}},
 		Status:  200,
-	}, []string{"nonmatch"}, false)
+	}, &modes.ModeArguments{Headers: []string{"nonmatch"}})
 
 	Expect(unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Headers).To(BeEmpty())
 }
@@ -716,7 +803,7 @@ func Test_Hoverfly_Save_SavesMultipleRequestHeadersWhenMultiplesSpecifiedInHeade
 		Body:    "testresponsebody",
 		Headers: map[string][]string{"testheader": []string{"testvalue"}},
 		Status:  200,
-	}, []string{"testheader", "nonmatch"}, false)
+	}, &modes.ModeArguments{Headers: []string{"testheader", "nonmatch"}})
 
 	Expect(unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Headers).To(HaveLen(2))
 	Expect(unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Headers["testheader"]).To(HaveLen(1))
@@ -742,7 +829,7 @@ func Test_Hoverfly_Save_SavesIncompleteRequestAndResponseToSimulation(t *testing
 		Body:    "testresponsebody",
 		Headers: map[string][]string{"testheader": []string{"testvalue"}},
 		Status:  200,
-	}, nil, false)
+	}, &modes.ModeArguments{})
 
 	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(1))
 
@@ -789,7 +876,7 @@ func Test_Hoverfly_Save_SavesRequestBodyAsJsonPathIfContentTypeIsJson(t *testing
 		Headers: map[string][]string{
 			"Content-Type": []string{"application/json"},
 		},
-	}, &models.ResponseDetails{}, nil, false)
+	}, &models.ResponseDetails{}, &modes.ModeArguments{})
 
 	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(1))
 
@@ -808,7 +895,7 @@ func Test_Hoverfly_Save_SavesRequestBodyAsXmlPathIfContentTypeIsXml(t *testing.T
 		Headers: map[string][]string{
 			"Content-Type": {"application/xml"},
 		},
-	}, &models.ResponseDetails{}, nil, false)
+	}, &models.ResponseDetails{}, &modes.ModeArguments{})
 
 	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(1))
 
@@ -824,11 +911,11 @@ func Test_Hoverfly_Save_CanAddPairStatefully(t *testing.T) {
 
 	unit.Save(&models.RequestDetails{
 		Body: `body`,
-	}, &models.ResponseDetails{}, nil, true)
+	}, &models.ResponseDetails{}, &modes.ModeArguments{Stateful: true})
 
 	unit.Save(&models.RequestDetails{
 		Body: `body`,
-	}, &models.ResponseDetails{}, nil, true)
+	}, &models.ResponseDetails{}, &modes.ModeArguments{Stateful: true})
 
 	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(2))
 
@@ -838,3 +925,146 @@ func Test_Hoverfly_Save_CanAddPairStatefully(t *testing.T) {
 	Expect(unit.Simulation.GetMatchingPairs()[1].RequestMatcher.RequiresState).To(HaveLen(1))
 	Expect(unit.Simulation.GetMatchingPairs()[1].RequestMatcher.RequiresState["sequence:1"]).To(Equal("2"))
 }
+
+func Test_Hoverfly_Save_RedactsPairWithConfiguredRedactionRules(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{
+		Redactions: mustRedactionRules([]v2.RedactionRuleView{{Header: "Authorization"}}),
+	})
+
+	unit.Save(&models.RequestDetails{
+		Headers: map[string][]string{
+			"Authorization": {"Bearer secret"},
+		},
+	}, &models.ResponseDetails{
+		Body:    `{"token": "secret", "name": "test"}`,
+		Headers: map[string][]string{"Authorization": {"Bearer secret"}},
+		Status:  200,
+	}, &modes.ModeArguments{
+		Headers:    []string{"*"},
+		Redactions: mustRedactionRules([]v2.RedactionRuleView{{JsonPath: "$.token", Placeholder: "TOKEN"}}),
+	})
+
+	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(1))
+
+	pair := unit.Simulation.GetMatchingPairs()[0]
+	Expect(pair.RequestMatcher.Headers["Authorization"]).To(ConsistOf(models.RequestFieldMatchers{
+		Matcher: "exact",
+		Value:   "REDACTED",
+	}))
+	Expect(pair.Response.Headers["Authorization"]).To(ConsistOf("REDACTED"))
+	Expect(pair.Response.Body).To(Equal(`{"name":"test","token":"TOKEN"}`))
+}
+
+func Test_Hoverfly_Save_RedactsRequestMatcherToAnyValue(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.Save(&models.RequestDetails{
+		Query: map[string][]string{
+			"token": {"secret"},
+		},
+	}, &models.ResponseDetails{}, &modes.ModeArguments{
+		Redactions: mustRedactionRules([]v2.RedactionRuleView{{Query: "token", AnyValue: true}}),
+	})
+
+	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(1))
+	Expect((*unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Query)["token"]).To(ConsistOf(models.RequestFieldMatchers{
+		Matcher: "glob",
+		Value:   "*",
+	}))
+}
+
+func Test_Hoverfly_Save_AppliesMatcherPolicyBeforeRedacting(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.Save(&models.RequestDetails{
+		Method:      "POST",
+		Scheme:      "http",
+		Destination: "test.com",
+		Path:        "/users/42",
+		Query: map[string][]string{
+			"timestamp": {"1500000000"},
+		},
+		Body: `{"user": {"id": 42, "password": "secret"}, "requestedAt": "now"}`,
+		Headers: map[string][]string{
+			"Content-Type": {"application/json"},
+		},
+	}, &models.ResponseDetails{Status: 200}, &modes.ModeArguments{
+		Redactions: mustRedactionRules([]v2.RedactionRuleView{{JsonPath: "$..password"}}),
+		MatcherPolicy: mustMatcherPolicy(v2.MatcherPolicyView{
+			IgnoreFields:     []string{"scheme"},
+			IgnoreQuery:      []string{"timestamp"},
+			GlobPathSegments: []string{"number"},
+			BodyJsonPaths:    []string{"$.user"},
+		}),
+	})
+
+	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(1))
+
+	requestMatcher := unit.Simulation.GetMatchingPairs()[0].RequestMatcher
+	Expect(requestMatcher.Scheme).To(BeNil())
+	Expect(requestMatcher.Query).To(BeNil())
+	Expect(requestMatcher.Path).To(ConsistOf(models.RequestFieldMatchers{
+		Matcher: "glob",
+		Value:   "/users/*",
+	}))
+	Expect(requestMatcher.Body).To(ConsistOf(models.RequestFieldMatchers{
+		Matcher: "jsonpartial",
+		Value:   `{"user":{"id":42,"password":"REDACTED"}}`,
+	}))
+}
+
+func Test_Hoverfly_Save_KeepsFirstPairWithoutCapturePolicy(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.Save(&models.RequestDetails{Body: "body"}, &models.ResponseDetails{Body: "1"}, &modes.ModeArguments{})
+	unit.Save(&models.RequestDetails{Body: "body"}, &models.ResponseDetails{Body: "2"}, &modes.ModeArguments{})
+
+	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(1))
+	Expect(unit.Simulation.GetMatchingPairs()[0].Response.Body).To(Equal("1"))
+}
+
+func Test_Hoverfly_Save_AppliesCapturePolicies(t *testing.T) {
+	RegisterTestingT(t)
+
+	bodies := func(capturePolicy string) []string {
+		unit := NewHoverflyWithConfiguration(&Configuration{})
+		for _, body := range []string{"1", "1", "2"} {
+			unit.Save(&models.RequestDetails{Body: "body"}, &models.ResponseDetails{Body: body}, &modes.ModeArguments{
+				CapturePolicy: capturePolicy,
+			})
+		}
+
+		saved := []string{}
+		for _, pair := range unit.Simulation.GetMatchingPairs() {
+			saved = append(saved, pair.Response.Body)
+		}
+		return saved
+	}
+
+	Expect(bodies(modes.CaptureAppendAll)).To(Equal([]string{"1", "1", "2"}))
+	Expect(bodies(modes.CaptureFirstWins)).To(Equal([]string{"1"}))
+	Expect(bodies(modes.CaptureLastWins)).To(Equal([]string{"2"}))
+	Expect(bodies(modes.CaptureSequenceOnlyIfDifferent)).To(Equal([]string{"1", "2"}))
+}
+
+func mustRedactionRules(views []v2.RedactionRuleView) []*v2.RedactionRule {
+	rules, err := v2.NewRedactionRules(views)
+	Expect(err).To(BeNil())
+
+	return rules
+}
+
+func mustMatcherPolicy(view v2.MatcherPolicyView) *v2.MatcherPolicy {
+	policy, err := v2.NewMatcherPolicy(view)
+	Expect(err).To(BeNil())
+
+	return policy
+}
@@ -6,6 +6,7 @@ import (
 	"regexp"
 
 	"strings"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/SpectoLabs/hoverfly/core/handlers/v1"
@@ -15,11 +16,10 @@ import (
 	"github.com/SpectoLabs/hoverfly/core/middleware"
 	"github.com/SpectoLabs/hoverfly/core/models"
 	"github.com/SpectoLabs/hoverfly/core/modes"
-	"github.com/SpectoLabs/hoverfly/core/state"
 	"github.com/SpectoLabs/hoverfly/core/util"
 )
 
-func (this Hoverfly) GetDestination() string {
+func (this *Hoverfly) GetDestination() string {
 	return this.Cfg.Destination
 }
 
@@ -38,7 +38,7 @@ func (hf *Hoverfly) SetDestination(destination string) (err error) {
 	return
 }
 
-func (this Hoverfly) GetMode() v2.ModeView {
+func (this *Hoverfly) GetMode() v2.ModeView {
 	return this.modeMap[this.Cfg.Mode].View()
 }
 
@@ -49,7 +49,36 @@ func (this *Hoverfly) SetMode(mode string) error {
 }
 
 func (this *Hoverfly) SetModeWithArguments(modeView v2.ModeView) error {
+	modeArguments, err := this.newModeArguments(modeView)
+	if err != nil {
+		return err
+	}
 
+	this.Cfg.SetMode(modeView.Mode)
+	if this.Cfg.GetMode() == "capture" {
+		this.CacheMatcher.FlushCache()
+	} else if this.Cfg.GetMode() == "simulate" {
+		this.CacheMatcher.PreloadCache(this.Simulation)
+	} else if this.Cfg.GetMode() == "spy" {
+		this.CacheMatcher.PreloadCache(this.Simulation)
+	} else if this.Cfg.GetMode() == "hybrid" {
+		this.CacheMatcher.PreloadCache(this.Simulation)
+	} else if this.Cfg.GetMode() == "shadow" {
+		this.CacheMatcher.PreloadCache(this.Simulation)
+	}
+
+	this.modeMap[this.Cfg.GetMode()].SetArguments(modeArguments)
+
+	log.WithFields(log.Fields{
+		"mode": this.Cfg.GetMode(),
+	}).Info("Mode has been changed")
+
+	return nil
+}
+
+// newModeArguments validates a mode and its arguments, which are given both for the mode of
+// Hoverfly and for the modes of routes
+func (this *Hoverfly) newModeArguments(modeView v2.ModeView) (modes.ModeArguments, error) {
 	availableModes := map[string]bool{
 		modes.Simulate:   true,
 		modes.Capture:    true,
@@ -57,28 +86,78 @@ func (this *Hoverfly) SetModeWithArguments(modeView v2.ModeView) error {
 		modes.Synthesize: true,
 		modes.Spy:        true,
 		modes.Diff:       true,
+		modes.Hybrid:     true,
+		modes.Shadow:     true,
 	}
 
 	if modeView.Mode == "" || !availableModes[modeView.Mode] {
 		log.WithFields(log.Fields{
 			"mode": modeView.Mode,
 		}).Error("Unknown mode")
-		return fmt.Errorf("Not a valid mode")
+		return modes.ModeArguments{}, fmt.Errorf("Not a valid mode")
 	}
 
-	if this.Cfg.Webserver && modeView.Mode == modes.Capture {
-		log.Error("Cannot change the mode of Hoverfly to capture when running as a webserver")
-		return fmt.Errorf("Cannot change the mode of Hoverfly to capture when running as a webserver")
+	if this.Cfg.Webserver && (modeView.Mode == modes.Capture || modeView.Mode == modes.Hybrid) {
+		log.Errorf("Cannot change the mode of Hoverfly to %s when running as a webserver", modeView.Mode)
+		return modes.ModeArguments{}, fmt.Errorf("Cannot change the mode of Hoverfly to %s when running as a webserver", modeView.Mode)
 	}
 
 	for _, header := range modeView.Arguments.Headers {
 		if header == "*" {
 			if len(modeView.Arguments.Headers) > 1 {
-				return errors.New("Must provide a list containing only an asterix, or a list containing only headers names")
+				return modes.ModeArguments{}, errors.New("Must provide a list containing only an asterix, or a list containing only headers names")
 			}
 		}
 	}
 
+	var redactions []*v2.RedactionRule
+	if modeView.Arguments.Redactions != nil {
+		var err error
+		if redactions, err = v2.NewRedactionRules(modeView.Arguments.Redactions); err != nil {
+			return modes.ModeArguments{}, err
+		}
+	}
+
+	var matcherPolicy *v2.MatcherPolicy
+	if modeView.Arguments.MatcherPolicy != nil {
+		var err error
+		if matcherPolicy, err = v2.NewMatcherPolicy(*modeView.Arguments.MatcherPolicy); err != nil {
+			return modes.ModeArguments{}, err
+		}
+	}
+
+	var captureFilter *v2.CaptureFilter
+	if modeView.Arguments.CaptureFilter != nil {
+		var err error
+		if captureFilter, err = v2.NewCaptureFilter(*modeView.Arguments.CaptureFilter); err != nil {
+			return modes.ModeArguments{}, err
+		}
+	}
+
+	switch modeView.Arguments.CapturePolicy {
+	case "", modes.CaptureAppendAll, modes.CaptureSequenceOnlyIfDifferent:
+	case modes.CaptureFirstWins, modes.CaptureLastWins:
+		if modeView.Arguments.Stateful {
+			return modes.ModeArguments{}, fmt.Errorf("Capture policy %s cannot be used when capturing statefully", modeView.Arguments.CapturePolicy)
+		}
+	default:
+		return modes.ModeArguments{}, fmt.Errorf("Capture policy %s is not valid, expected appendAll, firstWins, lastWins or sequenceOnlyIfDifferent", modeView.Arguments.CapturePolicy)
+	}
+
+	var diffRules *v2.DiffRules
+	if modeView.Arguments.DiffRules != nil {
+		var err error
+		if diffRules, err = v2.NewDiffRules(*modeView.Arguments.DiffRules); err != nil {
+			return modes.ModeArguments{}, err
+		}
+	}
+
+	switch modeView.Arguments.RecordMatchers {
+	case "", modes.RecordExactMatchers, modes.RecordRelaxedMatchers:
+	default:
+		return modes.ModeArguments{}, fmt.Errorf("Record matchers %s is not valid, expected exact or relaxed", modeView.Arguments.RecordMatchers)
+	}
+
 	matchingStrategy := modeView.Arguments.MatchingStrategy
 	if modeView.Mode == modes.Simulate {
 		if matchingStrategy == nil {
@@ -86,35 +165,24 @@ func (this *Hoverfly) SetModeWithArguments(modeView v2.ModeView) error {
 		}
 
 		if strings.ToLower(*matchingStrategy) != "strongest" && strings.ToLower(*matchingStrategy) != "first" {
-			return errors.New("Only matching strategy of 'first' or 'strongest' is permitted")
+			return modes.ModeArguments{}, errors.New("Only matching strategy of 'first' or 'strongest' is permitted")
 		}
 	}
 
-	this.Cfg.SetMode(modeView.Mode)
-	if this.Cfg.GetMode() == "capture" {
-		this.CacheMatcher.FlushCache()
-	} else if this.Cfg.GetMode() == "simulate" {
-		this.CacheMatcher.PreloadCache(*this.Simulation)
-	} else if this.Cfg.GetMode() == "spy" {
-		this.CacheMatcher.PreloadCache(*this.Simulation)
-	}
-
-	modeArguments := modes.ModeArguments{
+	return modes.ModeArguments{
 		Headers:          modeView.Arguments.Headers,
 		MatchingStrategy: matchingStrategy,
 		Stateful:         modeView.Arguments.Stateful,
-	}
-
-	this.modeMap[this.Cfg.GetMode()].SetArguments(modeArguments)
-
-	log.WithFields(log.Fields{
-		"mode": this.Cfg.GetMode(),
-	}).Info("Mode has been changed")
-
-	return nil
+		Redactions:       redactions,
+		MatcherPolicy:    matcherPolicy,
+		CapturePolicy:    modeView.Arguments.CapturePolicy,
+		CaptureFilter:    captureFilter,
+		RecordMatchers:   modeView.Arguments.RecordMatchers,
+		DiffRules:        diffRules,
+	}, nil
 }
 
-func (hf Hoverfly) GetMiddleware() (string, string, string) {
+func (hf *Hoverfly) GetMiddleware() (string, string, string) {
 	script, _ := hf.Cfg.Middleware.GetScript()
 	return hf.Cfg.Middleware.Binary, script, hf.Cfg.Middleware.Remote
 }
@@ -123,6 +191,7 @@ func (hf *Hoverfly) SetMiddleware(binary, script, remote string) error {
 	newMiddleware := &middleware.Middleware{}
 	if binary == "" && script == "" && remote == "" {
 		hf.Cfg.Middleware = *newMiddleware
+		hf.persistMiddleware()
 		return nil
 	}
 
@@ -167,27 +236,39 @@ func (hf *Hoverfly) SetMiddleware(binary, script, remote string) error {
 		return err
 	}
 	hf.Cfg.Middleware = *newMiddleware
+	hf.persistMiddleware()
 	return nil
 }
 
-func (hf Hoverfly) GetRequestCacheCount() (int, error) {
+func (hf *Hoverfly) GetRequestCacheCount() (int, error) {
 	return len(hf.Simulation.GetMatchingPairs()), nil
 }
 
-func (this Hoverfly) GetCache() (v2.CacheView, error) {
+func (this *Hoverfly) GetCache() (v2.CacheView, error) {
 	return this.CacheMatcher.GetAllResponses()
 }
 
-func (hf Hoverfly) FlushCache() error {
+func (hf *Hoverfly) FlushCache() error {
 	return hf.CacheMatcher.FlushCache()
 }
 
 func (hf *Hoverfly) SetResponseDelays(payloadView v1.ResponseDelayPayloadView) error {
-	err := models.ValidateResponseDelayPayload(payloadView)
+	responseDelays, err := newResponseDelayList(payloadView)
 	if err != nil {
 		return err
 	}
 
+	hf.Simulation.SetResponseDelays(responseDelays)
+	hf.persistSimulation()
+	return nil
+}
+
+func newResponseDelayList(payloadView v1.ResponseDelayPayloadView) (*models.ResponseDelayList, error) {
+	err := models.ValidateResponseDelayPayload(payloadView)
+	if err != nil {
+		return nil, err
+	}
+
 	var responseDelays models.ResponseDelayList
 
 	for _, responseDelayView := range payloadView.Data {
@@ -198,36 +279,36 @@ func (hf *Hoverfly) SetResponseDelays(payloadView v1.ResponseDelayPayloadView) e
 		})
 	}
 
-	hf.Simulation.ResponseDelays = &responseDelays
-	return nil
+	return &responseDelays, nil
 }
 
 func (hf *Hoverfly) DeleteResponseDelays() {
-	hf.Simulation.ResponseDelays = &models.ResponseDelayList{}
+	hf.Simulation.SetResponseDelays(&models.ResponseDelayList{})
+	hf.persistSimulation()
 }
 
-func (hf Hoverfly) GetStats() metrics.Stats {
+func (hf *Hoverfly) GetStats() metrics.Stats {
 	return hf.Counter.Flush()
 }
 
-func (hf Hoverfly) GetSimulation() (v2.SimulationViewV5, error) {
-	pairViews := make([]v2.RequestMatcherResponsePairViewV5, 0)
+func (hf *Hoverfly) GetSimulation() (v2.SimulationViewV6, error) {
+	pairViews := make([]v2.RequestMatcherResponsePairViewV6, 0)
 
 	for _, v := range hf.Simulation.GetMatchingPairs() {
 		pairViews = append(pairViews, v.BuildView())
 	}
 
 	return v2.BuildSimulationView(pairViews,
-		hf.Simulation.ResponseDelays.ConvertToResponseDelayPayloadView(),
+		hf.Simulation.GetResponseDelays().ConvertToResponseDelayPayloadView(),
 		hf.version), nil
 }
 
-func (hf Hoverfly) GetFilteredSimulation(urlPattern string) (v2.SimulationViewV5, error) {
-	pairViews := make([]v2.RequestMatcherResponsePairViewV5, 0)
+func (hf *Hoverfly) GetFilteredSimulation(urlPattern string) (v2.SimulationViewV6, error) {
+	pairViews := make([]v2.RequestMatcherResponsePairViewV6, 0)
 	regexPattern, err := regexp.Compile(urlPattern)
 
 	if err != nil {
-		return v2.SimulationViewV5{}, err
+		return v2.SimulationViewV6{}, err
 	}
 
 	for _, v := range hf.Simulation.GetMatchingPairs() {
@@ -246,69 +327,243 @@ func (hf Hoverfly) GetFilteredSimulation(urlPattern string) (v2.SimulationViewV5
 	}
 
 	return v2.BuildSimulationView(pairViews,
-		hf.Simulation.ResponseDelays.ConvertToResponseDelayPayloadView(),
+		hf.Simulation.GetResponseDelays().ConvertToResponseDelayPayloadView(),
 		hf.version), nil
 }
 
-func (this *Hoverfly) PutSimulation(simulationView v2.SimulationViewV5) v2.SimulationImportResult {
-	result := this.importRequestResponsePairViews(simulationView.DataViewV5.RequestResponsePairs)
+// GetRedactionRules returns the redaction rules Hoverfly was started with followed by those of
+// capture mode, which are applied again when the simulation is exported
+func (hf *Hoverfly) GetRedactionRules() []*v2.RedactionRule {
+	rules := append([]*v2.RedactionRule{}, hf.Cfg.Redactions...)
+
+	return append(rules, hf.modeMap[modes.Capture].(*modes.CaptureMode).Arguments.Redactions...)
+}
+
+func (this *Hoverfly) PutSimulation(simulationView v2.SimulationViewV6) v2.SimulationImportResult {
+	result := this.importRequestResponsePairViews(simulationView.DataViewV6.RequestResponsePairs)
 
 	result.AddError(this.SetResponseDelays(v1.ResponseDelayPayloadView{Data: simulationView.GlobalActions.Delays}))
 
+	this.persistSimulation()
+	this.persistState()
+
+	return result
+}
+
+// ReplaceSimulation builds the given simulation on its own before swapping it in, rather than
+// deleting the current simulation and then importing, so requests are never matched against an
+// empty or partly imported simulation. The current simulation is kept if the given one is invalid.
+// Any named simulations which were in use are no longer active once it is replaced.
+func (hf *Hoverfly) ReplaceSimulation(simulationView v2.SimulationViewV6) v2.SimulationImportResult {
+	hf.clearActiveSimulations()
+
+	return hf.replaceSimulation(simulationView)
+}
+
+// replaceSimulation swaps in the given simulation as ReplaceSimulation does, leaving the active
+// named simulations as they are
+func (hf *Hoverfly) replaceSimulation(simulationView v2.SimulationViewV6) v2.SimulationImportResult {
+	responseDelays, err := newResponseDelayList(v1.ResponseDelayPayloadView{Data: simulationView.GlobalActions.Delays})
+	if err != nil {
+		result := v2.SimulationImportResult{}
+		result.AddError(err)
+		return result
+	}
+
+	replacement := models.NewSimulation()
+	replacement.SetResponseDelays(responseDelays)
+	result, initialStates := hf.addRequestResponsePairViews(replacement, simulationView.RequestResponsePairs)
+
+	hf.Simulation.ReplaceWith(replacement)
+	hf.state.InitializeSequences(initialStates)
+	hf.reloadCacheMatcher()
+
+	hf.persistSimulation()
+	hf.persistState()
+
 	return result
 }
 
 func (this *Hoverfly) DeleteSimulation() {
+	this.clearActiveSimulations()
 	this.Simulation.DeleteMatchingPairs()
 	this.DeleteResponseDelays()
 	this.FlushCache()
 }
 
-func (this Hoverfly) GetVersion() string {
+// AppendSimulation adds the pairs and delays of the given simulation to those already
+// loaded. Pairs with a request matcher identical to one already loaded are skipped.
+func (this *Hoverfly) AppendSimulation(simulationView v2.SimulationViewV6) v2.SimulationImportResult {
+	result := this.appendRequestResponsePairViews(simulationView.DataViewV6.RequestResponsePairs)
+
+	delays := this.Simulation.GetResponseDelays().ConvertToResponseDelayPayloadView()
+	for _, delay := range simulationView.GlobalActions.Delays {
+		duplicate := false
+		for _, savedDelay := range delays.Data {
+			if savedDelay == delay {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			delays.Data = append(delays.Data, delay)
+		}
+	}
+
+	result.AddError(this.SetResponseDelays(delays))
+	this.FlushCache()
+
+	this.persistSimulation()
+	this.persistState()
+
+	return result
+}
+
+func (this *Hoverfly) GetSimulationPairs() []v2.SimulationPairView {
+	pairViews := []v2.SimulationPairView{}
+
+	for _, pair := range this.Simulation.GetMatchingPairs() {
+		pairViews = append(pairViews, v2.SimulationPairView{
+			Id:                               pair.Id,
+			RequestMatcherResponsePairViewV6: pair.BuildView(),
+		})
+	}
+
+	return pairViews
+}
+
+func (this *Hoverfly) GetSimulationPair(id string) (v2.SimulationPairView, error) {
+	pair, ok := this.Simulation.GetPair(id)
+	if !ok {
+		return v2.SimulationPairView{}, v2.PairNotFoundError{Id: id}
+	}
+
+	return v2.SimulationPairView{
+		Id:                               pair.Id,
+		RequestMatcherResponsePairViewV6: pair.BuildView(),
+	}, nil
+}
+
+// PutSimulationPair replaces the pair with the given id, which keeps its id and its position
+// in the simulation. It fails if another pair already has an identical request matcher.
+func (this *Hoverfly) PutSimulationPair(id string, pairView v2.RequestMatcherResponsePairViewV6) (v2.SimulationPairView, error) {
+	pair := models.NewRequestMatcherResponsePairFromView(&pairView)
+
+	err := this.Simulation.UpdatePair(id, *pair)
+	if err != nil {
+		return v2.SimulationPairView{}, err
+	}
+
+	this.state.AddSequences(pair.RequestMatcher.RequiresState)
+	this.FlushCache()
+
+	this.persistSimulation()
+	this.persistState()
+
+	return this.GetSimulationPair(id)
+}
+
+func (this *Hoverfly) DeleteSimulationPair(id string) error {
+	if !this.Simulation.DeletePair(id) {
+		return v2.PairNotFoundError{Id: id}
+	}
+
+	this.FlushCache()
+	this.persistSimulation()
+
+	return nil
+}
+
+func (this *Hoverfly) GetVersion() string {
 	return this.version
 }
 
-func (this Hoverfly) GetUpstreamProxy() string {
+func (this *Hoverfly) GetUpstreamProxy() string {
 	return this.Cfg.UpstreamProxy
 }
 
-func (this Hoverfly) IsWebServer() bool {
+func (this *Hoverfly) IsWebServer() bool {
 
 	return this.Cfg.Webserver
 }
 
-func (this Hoverfly) IsMiddlewareSet() bool {
+func (this *Hoverfly) IsMiddlewareSet() bool {
 	return this.Cfg.Middleware.IsSet()
 }
 
 func (this *Hoverfly) GetState() map[string]string {
-	return this.state.State
+	return this.state.Copy()
 }
 
 func (this *Hoverfly) SetState(state map[string]string) {
 	this.state.SetState(state)
+	this.persistState()
 }
 
 func (this *Hoverfly) PatchState(toPatch map[string]string) {
 	this.state.PatchState(toPatch)
+	this.persistState()
 }
 
 func (this *Hoverfly) ClearState() {
-	this.state = state.NewState()
+	this.state.ClearState()
+	this.persistState()
+}
+
+func (this *Hoverfly) GetScheduledStateTransitions() map[string]v2.ScheduledStateTransitionView {
+	now := time.Now()
+	views := map[string]v2.ScheduledStateTransitionView{}
+
+	for key, transition := range this.state.GetScheduledTransitions() {
+		delay := int(transition.At.Sub(now) / time.Millisecond)
+		if delay < 0 {
+			delay = 0
+		}
+
+		views[key] = v2.ScheduledStateTransitionView{
+			Value: transition.Value,
+			Delay: delay,
+			At:    transition.At.Format(time.RFC3339Nano),
+		}
+	}
+
+	return views
+}
+
+func (this *Hoverfly) ScheduleStateTransitions(transitions map[string]v2.ScheduledStateTransitionView) {
+	for key, transition := range transitions {
+		this.state.ScheduleTransition(key, transition.Value, time.Duration(transition.Delay)*time.Millisecond)
+	}
+
+	if len(transitions) > 0 {
+		this.persistState()
+	}
 }
 
 func (this *Hoverfly) GetDiff() map[v2.SimpleRequestDefinitionView][]v2.DiffReport {
-	return this.responsesDiff
+	this.diffMutex.RLock()
+	defer this.diffMutex.RUnlock()
+
+	diffs := make(map[v2.SimpleRequestDefinitionView][]v2.DiffReport, len(this.responsesDiff))
+	for requestView, diffReports := range this.responsesDiff {
+		diffs[requestView] = diffReports
+	}
+
+	return diffs
 }
 
 func (this *Hoverfly) ClearDiff() {
+	this.diffMutex.Lock()
 	this.responsesDiff = make(map[v2.SimpleRequestDefinitionView][]v2.DiffReport)
+	this.diffMutex.Unlock()
 }
 
 func (this *Hoverfly) AddDiff(requestView v2.SimpleRequestDefinitionView, diffReport v2.DiffReport) {
 	if len(diffReport.DiffEntries) > 0 {
+		this.diffMutex.Lock()
 		diffs := this.responsesDiff[requestView]
-		this.responsesDiff[requestView] = append(diffs, diffReport)
+		this.responsesDiff[requestView] = append(diffs[:len(diffs):len(diffs)], diffReport)
+		this.diffMutex.Unlock()
 	}
 }
 
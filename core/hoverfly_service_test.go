@@ -2,6 +2,7 @@ package hoverfly
 
 import (
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -17,7 +18,7 @@ import (
 )
 
 var (
-	pairOne = v2.RequestMatcherResponsePairViewV5{
+	pairOne = v2.RequestMatcherResponsePairViewV6{
 		RequestMatcher: v2.RequestMatcherViewV5{
 			Destination: []v2.MatcherViewV5{
 				v2.NewMatcherView(matchers.Exact, "test.com"),
@@ -26,12 +27,12 @@ var (
 				v2.NewMatcherView(matchers.Exact, "/testing"),
 			},
 		},
-		Response: v2.ResponseDetailsViewV5{
+		Response: v2.ResponseDetailsViewV6{
 			Body: "test-body",
 		},
 	}
 
-	pairTwo = v2.RequestMatcherResponsePairViewV5{
+	pairTwo = v2.RequestMatcherResponsePairViewV6{
 		RequestMatcher: v2.RequestMatcherViewV5{
 			Path: []v2.MatcherViewV5{
 				{
@@ -40,7 +41,7 @@ var (
 				},
 			},
 		},
-		Response: v2.ResponseDetailsViewV5{
+		Response: v2.ResponseDetailsViewV6{
 			Body: "pair2-body",
 		},
 	}
@@ -105,7 +106,7 @@ func Test_Hoverfly_GetSimulation_ReturnsBlankSimulation_ifThereIsNoData(t *testi
 	Expect(simulation.RequestResponsePairs).To(HaveLen(0))
 	Expect(simulation.GlobalActions.Delays).To(HaveLen(0))
 
-	Expect(simulation.MetaView.SchemaVersion).To(Equal("v5"))
+	Expect(simulation.MetaView.SchemaVersion).To(Equal("v6"))
 	Expect(simulation.MetaView.HoverflyVersion).To(MatchRegexp(`v\d+.\d+.\d+`))
 	Expect(simulation.MetaView.TimeExported).ToNot(BeNil())
 }
@@ -202,23 +203,23 @@ func Test_Hoverfly_GetSimulation_ReturnsMultipleRequestResponsePairs(t *testing.
 	simulation, err := unit.GetSimulation()
 	Expect(err).To(BeNil())
 
-	Expect(simulation.DataViewV5.RequestResponsePairs).To(HaveLen(2))
+	Expect(simulation.DataViewV6.RequestResponsePairs).To(HaveLen(2))
 
 	Expect(simulation.RequestResponsePairs[0].RequestMatcher.Destination[0].Matcher).To(Equal("exact"))
 	Expect(simulation.RequestResponsePairs[0].RequestMatcher.Destination[0].Value).To(Equal("testhost-0.com"))
 	Expect(simulation.RequestResponsePairs[0].RequestMatcher.Path[0].Matcher).To(Equal("exact"))
 	Expect(simulation.RequestResponsePairs[0].RequestMatcher.Path[0].Value).To(Equal("/test"))
 
-	Expect(simulation.DataViewV5.RequestResponsePairs[0].Response.Status).To(Equal(200))
-	Expect(simulation.DataViewV5.RequestResponsePairs[0].Response.Body).To(Equal("test"))
+	Expect(simulation.DataViewV6.RequestResponsePairs[0].Response.Status).To(Equal(200))
+	Expect(simulation.DataViewV6.RequestResponsePairs[0].Response.Body).To(Equal("test"))
 
 	Expect(simulation.RequestResponsePairs[1].RequestMatcher.Destination[0].Matcher).To(Equal("exact"))
 	Expect(simulation.RequestResponsePairs[1].RequestMatcher.Destination[0].Value).To(Equal("testhost-1.com"))
 	Expect(simulation.RequestResponsePairs[1].RequestMatcher.Path[0].Matcher).To(Equal("exact"))
 	Expect(simulation.RequestResponsePairs[1].RequestMatcher.Path[0].Value).To(Equal("/test"))
 
-	Expect(simulation.DataViewV5.RequestResponsePairs[1].Response.Status).To(Equal(200))
-	Expect(simulation.DataViewV5.RequestResponsePairs[1].Response.Body).To(Equal("test"))
+	Expect(simulation.DataViewV6.RequestResponsePairs[1].Response.Status).To(Equal(200))
+	Expect(simulation.DataViewV6.RequestResponsePairs[1].Response.Body).To(Equal("test"))
 }
 
 func Test_Hoverfly_GetSimulation_ReturnsMultipleDelays(t *testing.T) {
@@ -243,15 +244,15 @@ func Test_Hoverfly_GetSimulation_ReturnsMultipleDelays(t *testing.T) {
 	simulation, err := unit.GetSimulation()
 	Expect(err).To(BeNil())
 
-	Expect(simulation.DataViewV5.GlobalActions.Delays).To(HaveLen(2))
+	Expect(simulation.DataViewV6.GlobalActions.Delays).To(HaveLen(2))
 
-	Expect(simulation.DataViewV5.GlobalActions.Delays[0].UrlPattern).To(Equal("test-pattern"))
-	Expect(simulation.DataViewV5.GlobalActions.Delays[0].HttpMethod).To(Equal(""))
-	Expect(simulation.DataViewV5.GlobalActions.Delays[0].Delay).To(Equal(100))
+	Expect(simulation.DataViewV6.GlobalActions.Delays[0].UrlPattern).To(Equal("test-pattern"))
+	Expect(simulation.DataViewV6.GlobalActions.Delays[0].HttpMethod).To(Equal(""))
+	Expect(simulation.DataViewV6.GlobalActions.Delays[0].Delay).To(Equal(100))
 
-	Expect(simulation.DataViewV5.GlobalActions.Delays[1].UrlPattern).To(Equal(""))
-	Expect(simulation.DataViewV5.GlobalActions.Delays[1].HttpMethod).To(Equal("test"))
-	Expect(simulation.DataViewV5.GlobalActions.Delays[1].Delay).To(Equal(200))
+	Expect(simulation.DataViewV6.GlobalActions.Delays[1].UrlPattern).To(Equal(""))
+	Expect(simulation.DataViewV6.GlobalActions.Delays[1].HttpMethod).To(Equal("test"))
+	Expect(simulation.DataViewV6.GlobalActions.Delays[1].Delay).To(Equal(200))
 }
 
 func Test_Hoverfly_GetFilteredSimulation_WithPlainTextUrlQuery(t *testing.T) {
@@ -361,7 +362,7 @@ func Test_Hoverfly_GetFilteredSimulation_ReturnBlankSimulation_IfThereIsNoMatch(
 	Expect(simulation.RequestResponsePairs).To(HaveLen(0))
 	Expect(simulation.GlobalActions.Delays).To(HaveLen(0))
 
-	Expect(simulation.MetaView.SchemaVersion).To(Equal("v5"))
+	Expect(simulation.MetaView.SchemaVersion).To(Equal("v6"))
 	Expect(simulation.MetaView.HoverflyVersion).To(MatchRegexp(`v\d+.\d+.\d+`))
 	Expect(simulation.MetaView.TimeExported).ToNot(BeNil())
 }
@@ -458,9 +459,9 @@ func Test_Hoverfly_PutSimulation_ImportsRecordings(t *testing.T) {
 
 	unit := NewHoverflyWithConfiguration(&Configuration{})
 
-	simulationToImport := v2.SimulationViewV5{
-		v2.DataViewV5{
-			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{pairOne},
+	simulationToImport := v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{pairOne},
 			GlobalActions: v2.GlobalActionsView{
 				Delays: []v1.ResponseDelayView{},
 			},
@@ -491,9 +492,9 @@ func Test_Hoverfly_PutSimulation_ImportsSimulationViews(t *testing.T) {
 
 	unit := NewHoverflyWithConfiguration(&Configuration{})
 
-	simulationToImport := v2.SimulationViewV5{
-		v2.DataViewV5{
-			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{pairTwo},
+	simulationToImport := v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{pairTwo},
 			GlobalActions: v2.GlobalActionsView{
 				Delays: []v1.ResponseDelayView{},
 			},
@@ -523,9 +524,9 @@ func Test_Hoverfly_PutSimulation_ImportsDelays(t *testing.T) {
 
 	unit := NewHoverflyWithConfiguration(&Configuration{})
 
-	simulationToImport := v2.SimulationViewV5{
-		v2.DataViewV5{
-			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{},
+	simulationToImport := v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{},
 			GlobalActions: v2.GlobalActionsView{
 				Delays: []v1.ResponseDelayView{delayOne, delayTwo},
 			},
@@ -863,6 +864,267 @@ func Test_Hoverfly_SetModeWithArguments_AsteriskCanOnlyBeValidAsTheOnlyHeader(t
 	})).ToNot(Succeed())
 }
 
+func Test_Hoverfly_SetModeWithArguments_StoresRedactionRules(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	Expect(unit.SetModeWithArguments(v2.ModeView{
+		Mode: "capture",
+		Arguments: v2.ModeArgumentsView{
+			Redactions: []v2.RedactionRuleView{{Header: "Authorization"}},
+		},
+	})).To(Succeed())
+
+	storedMode := unit.modeMap[modes.Capture].View()
+	Expect(storedMode.Arguments.Redactions).To(ConsistOf(v2.RedactionRuleView{Header: "Authorization"}))
+}
+
+func Test_Hoverfly_SetModeWithArguments_RejectsInvalidRedactionRules(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	Expect(unit.SetModeWithArguments(v2.ModeView{
+		Mode: "capture",
+		Arguments: v2.ModeArgumentsView{
+			Redactions: []v2.RedactionRuleView{{Header: "Authorization", Query: "token"}},
+		},
+	})).ToNot(Succeed())
+
+	Expect(unit.SetModeWithArguments(v2.ModeView{
+		Mode: "capture",
+		Arguments: v2.ModeArgumentsView{
+			Redactions: []v2.RedactionRuleView{{JsonPath: "password"}},
+		},
+	})).ToNot(Succeed())
+}
+
+func Test_Hoverfly_SetModeWithArguments_StoresMatcherPolicy(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	policy := &v2.MatcherPolicyView{
+		IgnoreQuery:      []string{"timestamp"},
+		GlobPathSegments: []string{"uuid"},
+	}
+
+	Expect(unit.SetModeWithArguments(v2.ModeView{
+		Mode: "capture",
+		Arguments: v2.ModeArgumentsView{
+			MatcherPolicy: policy,
+		},
+	})).To(Succeed())
+
+	storedMode := unit.modeMap[modes.Capture].View()
+	Expect(storedMode.Arguments.MatcherPolicy).To(Equal(policy))
+}
+
+func Test_Hoverfly_SetModeWithArguments_RejectsInvalidMatcherPolicy(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	Expect(unit.SetModeWithArguments(v2.ModeView{
+		Mode: "capture",
+		Arguments: v2.ModeArgumentsView{
+			MatcherPolicy: &v2.MatcherPolicyView{
+				IgnoreFields: []string{"headers"},
+			},
+		},
+	})).ToNot(Succeed())
+}
+
+func Test_Hoverfly_SetModeWithArguments_StoresCapturePolicy(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	Expect(unit.SetModeWithArguments(v2.ModeView{
+		Mode: "capture",
+		Arguments: v2.ModeArgumentsView{
+			CapturePolicy: "lastWins",
+		},
+	})).To(Succeed())
+
+	storedMode := unit.modeMap[modes.Capture].View()
+	Expect(storedMode.Arguments.CapturePolicy).To(Equal("lastWins"))
+}
+
+func Test_Hoverfly_SetModeWithArguments_RejectsInvalidCapturePolicies(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	Expect(unit.SetModeWithArguments(v2.ModeView{
+		Mode: "capture",
+		Arguments: v2.ModeArgumentsView{
+			CapturePolicy: "everything",
+		},
+	})).ToNot(Succeed())
+
+	Expect(unit.SetModeWithArguments(v2.ModeView{
+		Mode: "capture",
+		Arguments: v2.ModeArgumentsView{
+			CapturePolicy: "firstWins",
+			Stateful:      true,
+		},
+	})).ToNot(Succeed())
+
+	Expect(unit.SetModeWithArguments(v2.ModeView{
+		Mode: "capture",
+		Arguments: v2.ModeArgumentsView{
+			CapturePolicy: "sequenceOnlyIfDifferent",
+			Stateful:      true,
+		},
+	})).To(Succeed())
+}
+
+func Test_Hoverfly_SetModeWithArguments_CanSetModeToHybrid(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	Expect(unit.SetModeWithArguments(v2.ModeView{
+		Mode: "hybrid",
+		Arguments: v2.ModeArgumentsView{
+			RecordMatchers: "relaxed",
+		},
+	})).To(Succeed())
+
+	Expect(unit.Cfg.Mode).To(Equal("hybrid"))
+	Expect(unit.modeMap[modes.Hybrid].View().Arguments.RecordMatchers).To(Equal("relaxed"))
+}
+
+func Test_Hoverfly_SetModeWithArguments_CanSetModeToShadow(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	Expect(unit.SetModeWithArguments(v2.ModeView{
+		Mode: "shadow",
+	})).To(Succeed())
+
+	Expect(unit.Cfg.Mode).To(Equal("shadow"))
+	Expect(unit.GetMode().Shadowed).To(Equal(&v2.ShadowStatsView{}))
+}
+
+func Test_Hoverfly_SetModeWithArguments_RejectsInvalidRecordMatchers(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	err := unit.SetModeWithArguments(v2.ModeView{
+		Mode: "hybrid",
+		Arguments: v2.ModeArgumentsView{
+			RecordMatchers: "loose",
+		},
+	})
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Record matchers loose is not valid, expected exact or relaxed"))
+}
+
+func Test_Hoverfly_SetModeWithArguments_SetsDiffRules(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	diffRules := &v2.DiffRulesView{
+		IgnoreHeaders:   []string{"Date"},
+		IgnoreJsonPaths: []string{"$.id"},
+		Tolerance:       0.5,
+	}
+
+	Expect(unit.SetModeWithArguments(v2.ModeView{
+		Mode: "diff",
+		Arguments: v2.ModeArgumentsView{
+			DiffRules: diffRules,
+		},
+	})).To(Succeed())
+
+	Expect(unit.modeMap[modes.Diff].View().Arguments.DiffRules).To(Equal(diffRules))
+}
+
+func Test_Hoverfly_SetModeWithArguments_RejectsInvalidDiffRules(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	err := unit.SetModeWithArguments(v2.ModeView{
+		Mode: "diff",
+		Arguments: v2.ModeArgumentsView{
+			DiffRules: &v2.DiffRulesView{
+				IgnoreJsonPaths: []string{"id"},
+			},
+		},
+	})
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Diff rules have an invalid JSONPath: JSONPath id must start with $"))
+}
+
+func Test_Hoverfly_SetModeWithArguments_CannotSetModeToHybridWhenRunningAsAWebserver(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{
+		Webserver: true,
+	})
+
+	Expect(unit.SetModeWithArguments(v2.ModeView{
+		Mode: "hybrid",
+	})).ToNot(Succeed())
+	Expect(unit.Cfg.Mode).ToNot(Equal("hybrid"))
+}
+
+func Test_Hoverfly_SetModeWithArguments_RejectsInvalidCaptureFilter(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	Expect(unit.SetModeWithArguments(v2.ModeView{
+		Mode: "capture",
+		Arguments: v2.ModeArgumentsView{
+			CaptureFilter: &v2.CaptureFilterView{
+				Statuses: []string{"success"},
+			},
+		},
+	})).ToNot(Succeed())
+
+	Expect(unit.SetModeWithArguments(v2.ModeView{
+		Mode: "capture",
+		Arguments: v2.ModeArgumentsView{
+			CaptureFilter: &v2.CaptureFilterView{
+				Statuses: []string{"2xx"},
+			},
+		},
+	})).To(Succeed())
+
+	storedMode := unit.modeMap[modes.Capture].View()
+	Expect(storedMode.Arguments.CaptureFilter.Statuses).To(ConsistOf("2xx"))
+	Expect(storedMode.Skipped).To(Equal(&v2.CaptureSkippedView{}))
+}
+
+func Test_Hoverfly_GetRedactionRules_ReturnsConfiguredAndCaptureModeRules(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{
+		Redactions: mustRedactionRules([]v2.RedactionRuleView{{Header: "Authorization"}}),
+	})
+
+	Expect(unit.SetModeWithArguments(v2.ModeView{
+		Mode: "capture",
+		Arguments: v2.ModeArgumentsView{
+			Redactions: []v2.RedactionRuleView{{Query: "token"}},
+		},
+	})).To(Succeed())
+
+	Expect(v2.RedactionRuleViews(unit.GetRedactionRules())).To(Equal([]v2.RedactionRuleView{
+		{Header: "Authorization"},
+		{Query: "token"},
+	}))
+	Expect(unit.Cfg.Redactions).To(HaveLen(1))
+}
+
 func Test_Hoverfly_AddDiff_AddEntry(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -990,3 +1252,252 @@ func Test_Hoverfly_DeletePACFile(t *testing.T) {
 
 	Expect(unit.Cfg.PACFile).To(BeNil())
 }
+
+func Test_Hoverfly_ScheduleStateTransitions_AreReturnedWithRemainingDelay(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.ScheduleStateTransitions(map[string]v2.ScheduledStateTransitionView{
+		"order":   {Value: "shipped", Delay: 60000},
+		"session": {Delay: 60000},
+	})
+
+	scheduled := unit.GetScheduledStateTransitions()
+
+	Expect(scheduled).To(HaveLen(2))
+	Expect(scheduled["order"].Value).To(Equal("shipped"))
+	Expect(scheduled["order"].Delay).To(BeNumerically("~", 60000, 1000))
+	Expect(scheduled["order"].At).ToNot(BeEmpty())
+	Expect(scheduled["session"].Value).To(BeEmpty())
+}
+
+func Test_Hoverfly_AppendSimulation_MergesPairsAndDelays(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{pairOne},
+			GlobalActions: v2.GlobalActionsView{
+				Delays: []v1.ResponseDelayView{delayOne},
+			},
+		},
+		v2.MetaView{},
+	})
+
+	result := unit.AppendSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{pairOne, pairTwo},
+			GlobalActions: v2.GlobalActionsView{
+				Delays: []v1.ResponseDelayView{delayOne, delayTwo},
+			},
+		},
+		v2.MetaView{},
+	})
+	Expect(result.GetError()).To(BeNil())
+
+	simulation, err := unit.GetSimulation()
+	Expect(err).To(BeNil())
+
+	Expect(simulation.RequestResponsePairs).To(HaveLen(2))
+	Expect(simulation.RequestResponsePairs[0].Response.Body).To(Equal("test-body"))
+	Expect(simulation.RequestResponsePairs[1].Response.Body).To(Equal("pair2-body"))
+
+	Expect(simulation.GlobalActions.Delays).To(Equal([]v1.ResponseDelayView{delayOne, delayTwo}))
+}
+
+func Test_Hoverfly_AppendSimulation_KeepsExistingPairIdsAndState(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{pairOne},
+		},
+		v2.MetaView{},
+	})
+	id := unit.GetSimulationPairs()[0].Id
+
+	unit.SetState(map[string]string{"sequence:1": "2"})
+
+	sequencedPair := pairTwo
+	sequencedPair.RequestMatcher.RequiresState = map[string]string{"sequence:1": "1"}
+	otherSequencedPair := pairTwo
+	otherSequencedPair.RequestMatcher.RequiresState = map[string]string{"sequence:2": "1"}
+
+	unit.AppendSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{sequencedPair, otherSequencedPair},
+		},
+		v2.MetaView{},
+	})
+
+	pairs := unit.GetSimulationPairs()
+	Expect(pairs).To(HaveLen(3))
+	Expect(pairs[0].Id).To(Equal(id))
+
+	Expect(unit.GetState()).To(Equal(map[string]string{
+		"sequence:1": "2",
+		"sequence:2": "1",
+	}))
+}
+
+func Test_Hoverfly_GetSimulationPair_ReturnsPairById(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{pairOne, pairTwo},
+		},
+		v2.MetaView{},
+	})
+
+	pairs := unit.GetSimulationPairs()
+	Expect(pairs).To(HaveLen(2))
+
+	pair, err := unit.GetSimulationPair(pairs[1].Id)
+	Expect(err).To(BeNil())
+	Expect(pair.Id).To(Equal(pairs[1].Id))
+	Expect(pair.Response.Body).To(Equal("pair2-body"))
+
+	_, err = unit.GetSimulationPair("unknown")
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Pair unknown does not exist"))
+}
+
+func Test_Hoverfly_PutSimulationPair_UpdatesPairAndFlushesCache(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{pairOne},
+		},
+		v2.MetaView{},
+	})
+	id := unit.GetSimulationPairs()[0].Id
+
+	unit.CacheMatcher.SaveRequestMatcherResponsePair(models.RequestDetails{Destination: "test.com", Path: "/testing"}, nil, nil)
+
+	updatedPair := pairOne
+	updatedPair.Response = v2.ResponseDetailsViewV6{Body: "updated-body"}
+
+	pair, err := unit.PutSimulationPair(id, updatedPair)
+	Expect(err).To(BeNil())
+	Expect(pair.Id).To(Equal(id))
+	Expect(pair.Response.Body).To(Equal("updated-body"))
+
+	cache, _ := unit.CacheMatcher.GetAllResponses()
+	Expect(cache.Cache).To(HaveLen(0))
+}
+
+func Test_Hoverfly_PutSimulationPair_ErrorsOnDuplicateRequestMatcher(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{pairOne, pairTwo},
+		},
+		v2.MetaView{},
+	})
+	pairs := unit.GetSimulationPairs()
+
+	_, err := unit.PutSimulationPair(pairs[1].Id, pairOne)
+	Expect(err).ToNot(BeNil())
+
+	pair, _ := unit.GetSimulationPair(pairs[1].Id)
+	Expect(pair.Response.Body).To(Equal("pair2-body"))
+}
+
+func Test_Hoverfly_DeleteSimulationPair_RemovesPair(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{pairOne, pairTwo},
+		},
+		v2.MetaView{},
+	})
+	pairs := unit.GetSimulationPairs()
+
+	Expect(unit.DeleteSimulationPair(pairs[0].Id)).To(Succeed())
+
+	remaining := unit.GetSimulationPairs()
+	Expect(remaining).To(HaveLen(1))
+	Expect(remaining[0].Id).To(Equal(pairs[1].Id))
+
+	err := unit.DeleteSimulationPair(pairs[0].Id)
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal(fmt.Sprintf("Pair %s does not exist", pairs[0].Id)))
+}
+
+func Test_Hoverfly_ReplaceSimulation_ReplacesPairsAndReloadsCache(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{newWatchedPairView("/orders", "old")},
+		},
+		v2.MetaView{},
+	})
+	unit.SetModeWithArguments(v2.ModeView{Mode: "simulate"})
+
+	response, err := unit.GetResponse(models.RequestDetails{Path: "/orders"})
+	Expect(err).To(BeNil())
+	Expect(response.Body).To(Equal("old"))
+
+	result := unit.ReplaceSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{newWatchedPairView("/orders", "new")},
+			GlobalActions: v2.GlobalActionsView{
+				Delays: []v1.ResponseDelayView{{UrlPattern: "test.com", Delay: 100}},
+			},
+		},
+		v2.MetaView{},
+	})
+	Expect(result.GetError()).To(BeNil())
+
+	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(1))
+	Expect(unit.Simulation.GetResponseDelays().ConvertToResponseDelayPayloadView().Data).To(HaveLen(1))
+
+	response, err = unit.GetResponse(models.RequestDetails{Path: "/orders"})
+	Expect(err).To(BeNil())
+	Expect(response.Body).To(Equal("new"))
+}
+
+func Test_Hoverfly_ReplaceSimulation_KeepsSimulationWhenDelaysAreInvalid(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{newWatchedPairView("/orders", "old")},
+		},
+		v2.MetaView{},
+	})
+
+	result := unit.ReplaceSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{newWatchedPairView("/orders", "new")},
+			GlobalActions: v2.GlobalActionsView{
+				Delays: []v1.ResponseDelayView{{UrlPattern: "[", Delay: 100}},
+			},
+		},
+		v2.MetaView{},
+	})
+	Expect(result.GetError()).ToNot(BeNil())
+
+	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(1))
+	Expect(unit.Simulation.GetMatchingPairs()[0].Response.Body).To(Equal("old"))
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/SpectoLabs/hoverfly/core/handlers/v1"
 	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/SpectoLabs/hoverfly/core/modes"
 	. "github.com/onsi/gomega"
 )
 
@@ -158,6 +159,36 @@ func Test_Hoverfly_processRequest_CaptureModeReturnsResponseAndSavesIt(t *testin
 	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(1))
 }
 
+func Test_Hoverfly_processRequest_HybridModeRecordsMissesAndSimulatesThem(t *testing.T) {
+	RegisterTestingT(t)
+
+	server, unit := testTools(201, `{'message': 'here'}`)
+	defer server.Close()
+
+	Expect(unit.SetModeWithArguments(v2.ModeView{
+		Mode: "hybrid",
+		Arguments: v2.ModeArgumentsView{
+			CapturePolicy: "appendAll",
+		},
+	})).To(Succeed())
+
+	r, err := http.NewRequest("GET", "http://somehost.com", nil)
+	Expect(err).To(BeNil())
+
+	resp := unit.processRequest(r)
+
+	Expect(resp).ToNot(BeNil())
+	Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(1))
+
+	// the miss cached for the first request must not send the second one upstream again
+	resp = unit.processRequest(r)
+
+	Expect(resp).ToNot(BeNil())
+	Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(1))
+}
+
 func Test_Hoverfly_processRequest_CanSimulateRequest(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -467,6 +498,41 @@ func Test_Hoverfly_processRequest_CanHandleResponseDiff(t *testing.T) {
 
 }
 
+func Test_Hoverfly_processRequest_ShadowModeReturnsSimulatedResponseAndRecordsDiff(t *testing.T) {
+	RegisterTestingT(t)
+
+	server, unit := testTools(201, `{'message': 'actual'}`)
+	defer server.Close()
+
+	unit.Save(&models.RequestDetails{
+		Method:      "GET",
+		Scheme:      "http",
+		Destination: "somehost.com",
+	}, &models.ResponseDetails{
+		Status: 201,
+		Body:   `{"message": "expected"}`,
+	}, &modes.ModeArguments{})
+
+	Expect(unit.SetModeWithArguments(v2.ModeView{Mode: "shadow"})).To(Succeed())
+
+	r, err := http.NewRequest("GET", "http://somehost.com", nil)
+	Expect(err).To(BeNil())
+
+	resp := unit.processRequest(r)
+
+	Expect(resp).ToNot(BeNil())
+	body, err := ioutil.ReadAll(resp.Body)
+	Expect(err).To(BeNil())
+	Expect(string(body)).To(Equal(`{"message": "expected"}`))
+
+	requestDef := v2.SimpleRequestDefinitionView{
+		Method: "GET",
+		Host:   "somehost.com"}
+	Eventually(func() []v2.DiffReport { return unit.GetDiff()[requestDef] }).Should(HaveLen(1))
+	Expect(unit.GetDiff()[requestDef][0].DiffEntries).To(ContainElement(
+		v2.DiffReportEntry{Field: "body/message", Expected: "expected", Actual: "actual"}))
+}
+
 func TestMatchOnRequestBody(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -486,7 +552,7 @@ func TestMatchOnRequestBody(t *testing.T) {
 			Body:   fmt.Sprintf("body here, number=%d", i),
 		}
 
-		unit.Save(req, resp, nil, false)
+		unit.Save(req, resp, &modes.ModeArguments{})
 	}
 
 	// now getting responses
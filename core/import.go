@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"os"
 	"path"
+	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
@@ -16,13 +17,37 @@ import (
 	log "github.com/Sirupsen/logrus"
 	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/core/models"
-	"github.com/SpectoLabs/hoverfly/core/state"
 )
 
-// Import is a function that based on input decides whether it is a local resource or whether
-// it should fetch it from remote server. It then imports given payload into the database
-// or returns an error
-func (hf *Hoverfly) Import(uri string) error {
+// Import reads the given files, directories and URLs, merged in the order given, and replaces the
+// simulation with them in a single swap, so requests are never matched against a partly imported
+// simulation. Nothing is changed unless all of them can be read.
+func (hf *Hoverfly) Import(uris ...string) error {
+	simulations := []v2.SimulationViewV6{}
+	for _, uri := range uris {
+		simulation, err := hf.readImport(uri)
+		if err != nil {
+			return err
+		}
+		simulations = append(simulations, simulation)
+	}
+
+	return hf.importSimulation(v2.MergeSimulationViews(simulations))
+}
+
+// importSimulation replaces the simulation with one which has been imported, logging its warnings
+func (hf *Hoverfly) importSimulation(simulation v2.SimulationViewV6) error {
+	result := hf.ReplaceSimulation(simulation)
+	for _, warning := range result.WarningMessages {
+		log.Warn(warning.Message)
+	}
+
+	return result.GetError()
+}
+
+// readImport decides whether the given uri is a local resource or whether it should be fetched
+// from a remote server, and reads the simulation from it
+func (hf *Hoverfly) readImport(uri string) (v2.SimulationViewV6, error) {
 
 	// assuming file URI is URL:
 	if isURL(uri) {
@@ -30,23 +55,31 @@ func (hf *Hoverfly) Import(uri string) error {
 			"isURL":      isURL(uri),
 			"importFrom": uri,
 		}).Info("URL")
-		return hf.ImportFromURL(uri)
+		return readSimulationURL(uri)
 	}
 	// assuming file URI is disk location
+	info, err := os.Stat(uri)
+	if err == nil && info.IsDir() {
+		return v2.ReadSimulationDirectory(uri)
+	}
+
 	ext := path.Ext(uri)
-	if ext != ".json" {
-		return fmt.Errorf("Failed to import payloads, only JSON files are acceppted. Given file: %s", uri)
+	if ext != ".json" && ext != ".har" && !v2.IsYamlFile(uri) {
+		return v2.SimulationViewV6{}, fmt.Errorf("Failed to import payloads, only JSON, YAML and HAR files or directories of JSON and YAML files are accepted. Given file: %s", uri)
 	}
 	// checking whether it exists
 	exists, err := exists(uri)
 	if err != nil {
-		return fmt.Errorf("Failed to import payloads from %s. Got error: %s", uri, err.Error())
+		return v2.SimulationViewV6{}, fmt.Errorf("Failed to import payloads from %s. Got error: %s", uri, err.Error())
 	}
 	if exists {
-		// file is JSON and it exist
-		return hf.ImportFromDisk(uri)
+		if ext == ".har" {
+			return hf.readHarSimulation(uri)
+		}
+		// file is JSON or YAML and it exist
+		return readSimulationFile(uri)
 	}
-	return fmt.Errorf("Failed to import payloads, given file '%s' does not exist", uri)
+	return v2.SimulationViewV6{}, fmt.Errorf("Failed to import payloads, given file '%s' does not exist", uri)
 }
 
 // URL is regexp to match http urls
@@ -87,53 +120,111 @@ func exists(path string) (bool, error) {
 // ImportFromDisk - takes one string value and tries to open a file, then parse it into recordedRequests structure
 // (which is default format in which Hoverfly exports captured requests) and imports those requests into the database
 func (hf *Hoverfly) ImportFromDisk(path string) error {
+	simulation, err := readSimulationFile(path)
+	if err != nil {
+		return err
+	}
+
+	return hf.importSimulation(simulation)
+}
+
+// ImportFromDirectory imports the JSON and YAML simulations in a directory as a single simulation, merging them
+// in lexical order of their file names. Nothing is imported unless every file can be read, and the error lists
+// each file which could not be.
+func (hf *Hoverfly) ImportFromDirectory(directory string) error {
+	simulation, err := v2.ReadSimulationDirectory(directory)
+	if err != nil {
+		return err
+	}
+
+	return hf.importSimulation(simulation)
+}
+
+func readSimulationFile(path string) (v2.SimulationViewV6, error) {
 	pairsFile, err := os.Open(path)
 	if err != nil {
-		return fmt.Errorf("Got error while opening payloads file, error %s", err.Error())
+		return v2.SimulationViewV6{}, fmt.Errorf("Got error while opening payloads file, error %s", err.Error())
 	}
+	defer pairsFile.Close()
 
-	var simulation v2.SimulationViewV5
+	var simulation v2.SimulationViewV6
 
 	body, err := ioutil.ReadAll(pairsFile)
 	if err != nil {
-		return fmt.Errorf("Got error while parsing payloads, error %s", err.Error())
+		return v2.SimulationViewV6{}, fmt.Errorf("Got error while parsing payloads, error %s", err.Error())
 	}
 
-	err = json.Unmarshal(body, &simulation)
+	if v2.IsYamlFile(path) {
+		simulation, err = v2.NewSimulationViewFromYaml(body)
+	} else {
+		simulation, err = v2.NewSimulationViewFromResponseBody(body)
+	}
 	if err != nil {
-		return fmt.Errorf("Got error while parsing payloads, error %s", err.Error())
+		return v2.SimulationViewV6{}, fmt.Errorf("Got error while parsing payloads, error %s", err.Error())
 	}
 
-	return hf.PutSimulation(simulation).GetError()
+	return simulation, nil
 }
 
 // ImportFromURL - takes one string value and tries connect to a remote server, then parse response body into
 // recordedRequests structure (which is default format in which Hoverfly exports captured requests) and
 // imports those requests into the database
 func (hf *Hoverfly) ImportFromURL(url string) error {
+	simulation, err := readSimulationURL(url)
+	if err != nil {
+		return err
+	}
+
+	return hf.importSimulation(simulation)
+}
+
+func readSimulationURL(url string) (v2.SimulationViewV6, error) {
 	resp, err := http.DefaultClient.Get(url)
 
 	if err != nil {
-		return fmt.Errorf("Failed to fetch given URL, error %s", err.Error())
+		return v2.SimulationViewV6{}, fmt.Errorf("Failed to fetch given URL, error %s", err.Error())
 	}
+	defer resp.Body.Close()
 
-	var simulation v2.SimulationViewV5
+	var simulation v2.SimulationViewV6
 
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("Got error while parsing payloads, error %s", err.Error())
+		return v2.SimulationViewV6{}, fmt.Errorf("Got error while parsing payloads, error %s", err.Error())
 	}
 
-	err = json.Unmarshal(body, &simulation)
+	if v2.IsYamlFile(resp.Request.URL.Path) || v2.IsYamlContentType(resp.Header.Get("Content-Type")) {
+		simulation, err = v2.NewSimulationViewFromYaml(body)
+	} else {
+		err = json.Unmarshal(body, &simulation)
+	}
 	if err != nil {
-		return fmt.Errorf("Got error while parsing payloads, error %s", err.Error())
+		return v2.SimulationViewV6{}, fmt.Errorf("Got error while parsing payloads, error %s", err.Error())
 	}
 
-	return hf.PutSimulation(simulation).GetError()
+	return simulation, nil
 }
 
 // importRequestResponsePairViews - a function to save given pairs into the database.
-func (hf *Hoverfly) importRequestResponsePairViews(pairViews []v2.RequestMatcherResponsePairViewV5) v2.SimulationImportResult {
+func (hf *Hoverfly) importRequestResponsePairViews(pairViews []v2.RequestMatcherResponsePairViewV6) v2.SimulationImportResult {
+	importResult, initialStates := hf.addRequestResponsePairViews(hf.Simulation, pairViews)
+	if len(pairViews) > 0 {
+		hf.state.InitializeSequences(initialStates)
+	}
+
+	return importResult
+}
+
+// appendRequestResponsePairViews - adds the given pairs to those already in the simulation, only
+// initializing the sequences which are not yet in the state
+func (hf *Hoverfly) appendRequestResponsePairViews(pairViews []v2.RequestMatcherResponsePairViewV6) v2.SimulationImportResult {
+	importResult, initialStates := hf.addRequestResponsePairViews(hf.Simulation, pairViews)
+	hf.state.AddSequences(initialStates)
+
+	return importResult
+}
+
+func (hf *Hoverfly) addRequestResponsePairViews(simulation *models.Simulation, pairViews []v2.RequestMatcherResponsePairViewV6) (v2.SimulationImportResult, map[string]string) {
 	importResult := v2.SimulationImportResult{}
 	initialStates := map[string]string{}
 	if len(pairViews) > 0 {
@@ -143,7 +234,15 @@ func (hf *Hoverfly) importRequestResponsePairViews(pairViews []v2.RequestMatcher
 
 			pair := models.NewRequestMatcherResponsePairFromView(&pairView)
 
-			hf.Simulation.AddPair(pair)
+			// An identical pair is skipped by AddPair, otherwise a pair reusing an id is given a new one
+			if pair.Id != "" {
+				if savedPair, ok := simulation.GetPair(pair.Id); ok && !reflect.DeepEqual(savedPair.RequestMatcher, pair.RequestMatcher) {
+					importResult.AddDuplicatePairIdWarning(i, pair.Id)
+					pair.Id = ""
+				}
+			}
+
+			simulation.AddPair(pair)
 			for k, v := range pair.RequestMatcher.RequiresState {
 				initialStates[k] = v
 			}
@@ -167,15 +266,13 @@ func (hf *Hoverfly) importRequestResponsePairViews(pairViews []v2.RequestMatcher
 			continue
 		}
 
-		hf.state = state.NewStateFromState(initialStates)
-
 		log.WithFields(log.Fields{
 			"total":      len(pairViews),
 			"successful": success,
 			"failed":     failed,
 		}).Info("payloads imported")
-		return importResult
+		return importResult, initialStates
 	}
 
-	return importResult
+	return importResult, initialStates
 }
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/SpectoLabs/hoverfly/core/cache"
@@ -13,6 +14,7 @@ import (
 	"github.com/SpectoLabs/hoverfly/core/matching"
 	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
 	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/SpectoLabs/hoverfly/core/state"
 	. "github.com/onsi/gomega"
 )
 
@@ -178,18 +180,159 @@ func TestImportFromURLMalformedJSON(t *testing.T) {
 	Expect(err).ToNot(BeNil())
 }
 
+func TestImportFromYamlFile(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "simulations")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+
+	Expect(ioutil.WriteFile(filepath.Join(dir, "simulation.yaml"), []byte(yamlSimulation("/a")), 0644)).To(Succeed())
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	err = unit.Import(filepath.Join(dir, "simulation.yaml"))
+	Expect(err).To(BeNil())
+
+	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(1))
+	Expect(unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Path[0].Value).To(Equal("/a"))
+}
+
+func TestImportFromDirectory_MergesFilesInLexicalOrder(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "simulations")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+
+	jsonSimulation := `{"data": {"pairs": [{"request": {"path": [{"matcher": "exact", "value": "/b"}]}, "response": {"status": 200}}]}, "meta": {"schemaVersion": "v5"}}`
+	Expect(ioutil.WriteFile(filepath.Join(dir, "2-b.json"), []byte(jsonSimulation), 0644)).To(Succeed())
+	Expect(ioutil.WriteFile(filepath.Join(dir, "1-a.yml"), []byte(yamlSimulation("/a")), 0644)).To(Succeed())
+	Expect(ioutil.WriteFile(filepath.Join(dir, "3-c.yaml"), []byte(yamlSimulation("/c")), 0644)).To(Succeed())
+	Expect(ioutil.WriteFile(filepath.Join(dir, "README.md"), []byte("not a simulation"), 0644)).To(Succeed())
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	err = unit.Import(dir)
+	Expect(err).To(BeNil())
+
+	pairs := unit.Simulation.GetMatchingPairs()
+	Expect(pairs).To(HaveLen(3))
+	Expect(pairs[0].RequestMatcher.Path[0].Value).To(Equal("/a"))
+	Expect(pairs[1].RequestMatcher.Path[0].Value).To(Equal("/b"))
+	Expect(pairs[2].RequestMatcher.Path[0].Value).To(Equal("/c"))
+}
+
+func TestImportFromDirectory_ListsEachFileWhichCannotBeRead(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "simulations")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+
+	Expect(ioutil.WriteFile(filepath.Join(dir, "a.yaml"), []byte(yamlSimulation("/a")), 0644)).To(Succeed())
+	Expect(ioutil.WriteFile(filepath.Join(dir, "b.json"), []byte(`{{`), 0644)).To(Succeed())
+	Expect(ioutil.WriteFile(filepath.Join(dir, "c.yaml"), []byte("data: [\n"), 0644)).To(Succeed())
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	err = unit.Import(dir)
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring(filepath.Join(dir, "b.json") + ": Invalid JSON"))
+	Expect(err.Error()).To(ContainSubstring(filepath.Join(dir, "c.yaml") + ": Invalid YAML"))
+	Expect(err.Error()).ToNot(ContainSubstring("a.yaml"))
+
+	Expect(unit.Simulation.GetMatchingPairs()).To(BeEmpty())
+}
+
+func Test_Hoverfly_Import_MergesImportsInOrder(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "import")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+
+	Expect(ioutil.WriteFile(filepath.Join(dir, "b.yaml"), []byte(yamlSimulation("/b")), 0644)).To(Succeed())
+	Expect(ioutil.WriteFile(filepath.Join(dir, "a.yaml"), []byte(yamlSimulation("/a")), 0644)).To(Succeed())
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	err = unit.Import(filepath.Join(dir, "b.yaml"), filepath.Join(dir, "a.yaml"))
+	Expect(err).To(BeNil())
+
+	pairs := unit.Simulation.GetMatchingPairs()
+	Expect(pairs).To(HaveLen(2))
+	Expect(pairs[0].RequestMatcher.Path[0].Value).To(Equal("/b"))
+	Expect(pairs[1].RequestMatcher.Path[0].Value).To(Equal("/a"))
+}
+
+func Test_Hoverfly_Import_KeepsSimulationWhenAnImportIsInvalid(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "import")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+
+	Expect(ioutil.WriteFile(filepath.Join(dir, "a.yaml"), []byte(yamlSimulation("/a")), 0644)).To(Succeed())
+	Expect(ioutil.WriteFile(filepath.Join(dir, "b.json"), []byte(`{"data": {}}`), 0644)).To(Succeed())
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	Expect(unit.Import(filepath.Join(dir, "a.yaml"))).To(Succeed())
+
+	err = unit.Import(dir)
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring(filepath.Join(dir, "b.json")))
+
+	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(1))
+	Expect(unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Path[0].Value).To(Equal("/a"))
+}
+
+func Test_Hoverfly_Import_ReplacesTheSimulation(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "import")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+
+	Expect(ioutil.WriteFile(filepath.Join(dir, "a.yaml"), []byte(yamlSimulation("/a")), 0644)).To(Succeed())
+	Expect(ioutil.WriteFile(filepath.Join(dir, "b.yaml"), []byte(yamlSimulation("/b")), 0644)).To(Succeed())
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	Expect(unit.Import(filepath.Join(dir, "a.yaml"))).To(Succeed())
+	Expect(unit.ImportFromDisk(filepath.Join(dir, "b.yaml"))).To(Succeed())
+
+	pairs := unit.Simulation.GetMatchingPairs()
+	Expect(pairs).To(HaveLen(1))
+	Expect(pairs[0].RequestMatcher.Path[0].Value).To(Equal("/b"))
+}
+
+func yamlSimulation(path string) string {
+	return `
+data:
+  pairs:
+  - request:
+      path:
+      - matcher: exact
+        value: ` + path + `
+    response:
+      status: 200
+meta:
+  schemaVersion: v5
+`
+}
+
 func TestImportRequestResponsePairs_CanImportASinglePair(t *testing.T) {
 	RegisterTestingT(t)
 
 	cache := cache.NewInMemoryCache()
 	cfg := Configuration{Webserver: false}
 	cacheMatcher := matching.CacheMatcher{RequestCache: cache, Webserver: cfg.Webserver}
-	hv := Hoverfly{Cfg: &cfg, CacheMatcher: cacheMatcher, Simulation: models.NewSimulation()}
+	hv := Hoverfly{Cfg: &cfg, CacheMatcher: cacheMatcher, Simulation: models.NewSimulation(), state: state.NewState()}
 
 	RegisterTestingT(t)
 
-	originalPair := v2.RequestMatcherResponsePairViewV5{
-		Response: v2.ResponseDetailsViewV5{
+	originalPair := v2.RequestMatcherResponsePairViewV6{
+		Response: v2.ResponseDetailsViewV6{
 			Status:      200,
 			Body:        "hello_world",
 			EncodedBody: false,
@@ -236,11 +379,12 @@ func TestImportRequestResponsePairs_CanImportASinglePair(t *testing.T) {
 				},
 			}}}
 
-	hv.importRequestResponsePairViews([]v2.RequestMatcherResponsePairViewV5{originalPair})
-	result := hv.importRequestResponsePairViews([]v2.RequestMatcherResponsePairViewV5{originalPair})
+	hv.importRequestResponsePairViews([]v2.RequestMatcherResponsePairViewV6{originalPair})
+	result := hv.importRequestResponsePairViews([]v2.RequestMatcherResponsePairViewV6{originalPair})
 	Expect(result.WarningMessages).To(HaveLen(0))
 
 	Expect(hv.Simulation.GetMatchingPairs()[0]).To(Equal(models.RequestMatcherResponsePair{
+		Id: hv.Simulation.GetMatchingPairs()[0].Id,
 		Response: models.ResponseDetails{
 			Status:    200,
 			Body:      "hello_world",
@@ -296,12 +440,12 @@ func TestImportImportRequestResponsePairs_CanImportAMultiplePairsAndSetTemplateE
 	cache := cache.NewInMemoryCache()
 	cfg := Configuration{Webserver: false}
 	cacheMatcher := matching.CacheMatcher{RequestCache: cache, Webserver: cfg.Webserver}
-	hv := Hoverfly{Cfg: &cfg, CacheMatcher: cacheMatcher, Simulation: models.NewSimulation()}
+	hv := Hoverfly{Cfg: &cfg, CacheMatcher: cacheMatcher, Simulation: models.NewSimulation(), state: state.NewState()}
 
 	RegisterTestingT(t)
 
-	originalPair1 := v2.RequestMatcherResponsePairViewV5{
-		Response: v2.ResponseDetailsViewV5{
+	originalPair1 := v2.RequestMatcherResponsePairViewV6{
+		Response: v2.ResponseDetailsViewV6{
 			Status:      200,
 			Body:        "hello_world",
 			EncodedBody: false,
@@ -365,11 +509,12 @@ func TestImportImportRequestResponsePairs_CanImportAMultiplePairsAndSetTemplateE
 	}
 	originalPair3.Response.Templated = true
 
-	result := hv.importRequestResponsePairViews([]v2.RequestMatcherResponsePairViewV5{originalPair1, originalPair2, originalPair3})
+	result := hv.importRequestResponsePairViews([]v2.RequestMatcherResponsePairViewV6{originalPair1, originalPair2, originalPair3})
 	Expect(result.WarningMessages).To(HaveLen(0))
 
 	Expect(hv.Simulation.GetMatchingPairs()).To(HaveLen(3))
 	Expect(hv.Simulation.GetMatchingPairs()[0]).To(Equal(models.RequestMatcherResponsePair{
+		Id: hv.Simulation.GetMatchingPairs()[0].Id,
 		Response: models.ResponseDetails{
 			Status:    200,
 			Body:      "hello_world",
@@ -419,6 +564,7 @@ func TestImportImportRequestResponsePairs_CanImportAMultiplePairsAndSetTemplateE
 	}))
 
 	Expect(hv.Simulation.GetMatchingPairs()[1]).To(Equal(models.RequestMatcherResponsePair{
+		Id: hv.Simulation.GetMatchingPairs()[1].Id,
 		Response: models.ResponseDetails{
 			Status:    200,
 			Body:      "hello_world",
@@ -468,6 +614,7 @@ func TestImportImportRequestResponsePairs_CanImportAMultiplePairsAndSetTemplateE
 	}))
 
 	Expect(hv.Simulation.GetMatchingPairs()[2]).To(Equal(models.RequestMatcherResponsePair{
+		Id: hv.Simulation.GetMatchingPairs()[2].Id,
 		Response: models.ResponseDetails{
 			Status:    200,
 			Body:      "hello_world",
@@ -523,7 +670,7 @@ func TestImportImportRequestResponsePairs_CanImportARequesResponsePairView(t *te
 	cache := cache.NewInMemoryCache()
 	cfg := Configuration{Webserver: false}
 	cacheMatcher := matching.CacheMatcher{RequestCache: cache, Webserver: cfg.Webserver}
-	hv := Hoverfly{Cfg: &cfg, CacheMatcher: cacheMatcher, Simulation: models.NewSimulation()}
+	hv := Hoverfly{Cfg: &cfg, CacheMatcher: cacheMatcher, Simulation: models.NewSimulation(), state: state.NewState()}
 
 	RegisterTestingT(t)
 
@@ -536,19 +683,19 @@ func TestImportImportRequestResponsePairs_CanImportARequesResponsePairView(t *te
 		},
 	}
 
-	responseView := v2.ResponseDetailsViewV5{
+	responseView := v2.ResponseDetailsViewV6{
 		Status:      200,
 		Body:        "hello_world",
 		EncodedBody: false,
 		Headers:     map[string][]string{"Hoverfly": []string{"testing"}},
 	}
 
-	requestResponsePair := v2.RequestMatcherResponsePairViewV5{
+	requestResponsePair := v2.RequestMatcherResponsePairViewV6{
 		Response:       responseView,
 		RequestMatcher: request,
 	}
 
-	result := hv.importRequestResponsePairViews([]v2.RequestMatcherResponsePairViewV5{requestResponsePair})
+	result := hv.importRequestResponsePairViews([]v2.RequestMatcherResponsePairViewV6{requestResponsePair})
 	Expect(result.WarningMessages).To(HaveLen(0))
 
 	Expect(len(hv.Simulation.GetMatchingPairs())).To(Equal(1))
@@ -573,12 +720,12 @@ func TestImportImportRequestResponsePairs_CanImportASingleBase64EncodedPair(t *t
 	cache := cache.NewInMemoryCache()
 	cfg := Configuration{Webserver: false}
 	cacheMatcher := matching.CacheMatcher{RequestCache: cache, Webserver: cfg.Webserver}
-	hv := Hoverfly{Cfg: &cfg, CacheMatcher: cacheMatcher, Simulation: models.NewSimulation()}
+	hv := Hoverfly{Cfg: &cfg, CacheMatcher: cacheMatcher, Simulation: models.NewSimulation(), state: state.NewState()}
 
 	RegisterTestingT(t)
 
-	encodedPair := v2.RequestMatcherResponsePairViewV5{
-		Response: v2.ResponseDetailsViewV5{
+	encodedPair := v2.RequestMatcherResponsePairViewV6{
+		Response: v2.ResponseDetailsViewV6{
 			Status:      200,
 			Body:        base64String("hello_world"),
 			EncodedBody: true,
@@ -625,7 +772,7 @@ func TestImportImportRequestResponsePairs_CanImportASingleBase64EncodedPair(t *t
 		},
 	}
 
-	result := hv.importRequestResponsePairViews([]v2.RequestMatcherResponsePairViewV5{encodedPair})
+	result := hv.importRequestResponsePairViews([]v2.RequestMatcherResponsePairViewV6{encodedPair})
 	Expect(result.WarningMessages).To(HaveLen(0))
 
 	Expect(hv.Simulation.GetMatchingPairs()[0]).ToNot(Equal(models.RequestResponsePair{
@@ -649,12 +796,12 @@ func TestImportImportRequestResponsePairs_SetsState(t *testing.T) {
 	cache := cache.NewInMemoryCache()
 	cfg := Configuration{Webserver: false}
 	cacheMatcher := matching.CacheMatcher{RequestCache: cache, Webserver: cfg.Webserver}
-	hv := Hoverfly{Cfg: &cfg, CacheMatcher: cacheMatcher, Simulation: models.NewSimulation()}
+	hv := Hoverfly{Cfg: &cfg, CacheMatcher: cacheMatcher, Simulation: models.NewSimulation(), state: state.NewState()}
 
 	RegisterTestingT(t)
 
-	encodedPair := v2.RequestMatcherResponsePairViewV5{
-		Response: v2.ResponseDetailsViewV5{
+	encodedPair := v2.RequestMatcherResponsePairViewV6{
+		Response: v2.ResponseDetailsViewV6{
 			Status:      200,
 			Body:        base64String("hello_world"),
 			EncodedBody: true,
@@ -672,7 +819,7 @@ func TestImportImportRequestResponsePairs_SetsState(t *testing.T) {
 		},
 	}
 
-	result := hv.importRequestResponsePairViews([]v2.RequestMatcherResponsePairViewV5{encodedPair})
+	result := hv.importRequestResponsePairViews([]v2.RequestMatcherResponsePairViewV6{encodedPair})
 	Expect(result.WarningMessages).To(HaveLen(0))
 
 	Expect(hv.state.GetState("sequence:1")).To(Equal("1"))
@@ -684,12 +831,12 @@ func TestImportImportRequestResponsePairs_ReturnsWarningsIfDeprecatedQuerytSet(t
 	cache := cache.NewInMemoryCache()
 	cfg := Configuration{Webserver: false}
 	cacheMatcher := matching.CacheMatcher{RequestCache: cache, Webserver: cfg.Webserver}
-	hv := Hoverfly{Cfg: &cfg, CacheMatcher: cacheMatcher, Simulation: models.NewSimulation()}
+	hv := Hoverfly{Cfg: &cfg, CacheMatcher: cacheMatcher, Simulation: models.NewSimulation(), state: state.NewState()}
 
 	RegisterTestingT(t)
 
-	encodedPair := v2.RequestMatcherResponsePairViewV5{
-		Response: v2.ResponseDetailsViewV5{
+	encodedPair := v2.RequestMatcherResponsePairViewV6{
+		Response: v2.ResponseDetailsViewV6{
 			Status:      200,
 			Body:        base64String("hello_world"),
 			EncodedBody: true,
@@ -704,24 +851,74 @@ func TestImportImportRequestResponsePairs_ReturnsWarningsIfDeprecatedQuerytSet(t
 		},
 	}
 
-	result := hv.importRequestResponsePairViews([]v2.RequestMatcherResponsePairViewV5{encodedPair})
+	result := hv.importRequestResponsePairViews([]v2.RequestMatcherResponsePairViewV6{encodedPair})
 
 	Expect(result.WarningMessages).To(HaveLen(1))
 	Expect(result.WarningMessages[0].Message).To(ContainSubstring("data.pairs[0].request.deprecatedQuery"))
 }
 
+func TestImportImportRequestResponsePairs_GeneratesNewIdIfIdIsUsedByADifferentPair(t *testing.T) {
+	RegisterTestingT(t)
+
+	cache := cache.NewInMemoryCache()
+	cfg := Configuration{Webserver: false}
+	cacheMatcher := matching.CacheMatcher{RequestCache: cache, Webserver: cfg.Webserver}
+	hv := Hoverfly{Cfg: &cfg, CacheMatcher: cacheMatcher, Simulation: models.NewSimulation(), state: state.NewState()}
+
+	pairOne := v2.RequestMatcherResponsePairViewV6{
+		Id: "payment",
+		RequestMatcher: v2.RequestMatcherViewV5{
+			Path: []v2.MatcherViewV5{
+				{
+					Matcher: "exact",
+					Value:   "/payments/1",
+				},
+			},
+		},
+		Response: v2.ResponseDetailsViewV6{
+			Status: 200,
+		},
+	}
+
+	pairTwo := v2.RequestMatcherResponsePairViewV6{
+		Id: "payment",
+		RequestMatcher: v2.RequestMatcherViewV5{
+			Path: []v2.MatcherViewV5{
+				{
+					Matcher: "exact",
+					Value:   "/payments/2",
+				},
+			},
+		},
+		Response: v2.ResponseDetailsViewV6{
+			Status: 200,
+		},
+	}
+
+	result := hv.importRequestResponsePairViews([]v2.RequestMatcherResponsePairViewV6{pairOne, pairTwo})
+
+	Expect(result.WarningMessages).To(HaveLen(1))
+	Expect(result.WarningMessages[0].Message).To(ContainSubstring("data.pairs[1].id"))
+
+	pairs := hv.Simulation.GetMatchingPairs()
+	Expect(pairs).To(HaveLen(2))
+	Expect(pairs[0].Id).To(Equal("payment"))
+	Expect(pairs[1].Id).ToNot(Equal("payment"))
+	Expect(pairs[1].Id).ToNot(BeEmpty())
+}
+
 func TestImportImportRequestResponsePairs_ReturnsWarningsContentLengthAndTransferEncodingSet(t *testing.T) {
 	RegisterTestingT(t)
 
 	cache := cache.NewInMemoryCache()
 	cfg := Configuration{Webserver: false}
 	cacheMatcher := matching.CacheMatcher{RequestCache: cache, Webserver: cfg.Webserver}
-	hv := Hoverfly{Cfg: &cfg, CacheMatcher: cacheMatcher, Simulation: models.NewSimulation()}
+	hv := Hoverfly{Cfg: &cfg, CacheMatcher: cacheMatcher, Simulation: models.NewSimulation(), state: state.NewState()}
 
 	RegisterTestingT(t)
 
-	encodedPair := v2.RequestMatcherResponsePairViewV5{
-		Response: v2.ResponseDetailsViewV5{
+	encodedPair := v2.RequestMatcherResponsePairViewV6{
+		Response: v2.ResponseDetailsViewV6{
 			Status:      200,
 			Body:        base64String("hello_world"),
 			EncodedBody: true,
@@ -740,7 +937,7 @@ func TestImportImportRequestResponsePairs_ReturnsWarningsContentLengthAndTransfe
 		},
 	}
 
-	result := hv.importRequestResponsePairViews([]v2.RequestMatcherResponsePairViewV5{encodedPair})
+	result := hv.importRequestResponsePairViews([]v2.RequestMatcherResponsePairViewV6{encodedPair})
 
 	Expect(result.WarningMessages).To(HaveLen(1))
 	Expect(result.WarningMessages[0].Message).To(ContainSubstring("Response contains both Content-Length and Transfer-Encoding headers on data.pairs[0].response"))
@@ -752,12 +949,12 @@ func TestImportImportRequestResponsePairs_ReturnsWarningsContentLengthMismatch(t
 	cache := cache.NewInMemoryCache()
 	cfg := Configuration{Webserver: false}
 	cacheMatcher := matching.CacheMatcher{RequestCache: cache, Webserver: cfg.Webserver}
-	hv := Hoverfly{Cfg: &cfg, CacheMatcher: cacheMatcher, Simulation: models.NewSimulation()}
+	hv := Hoverfly{Cfg: &cfg, CacheMatcher: cacheMatcher, Simulation: models.NewSimulation(), state: state.NewState()}
 
 	RegisterTestingT(t)
 
-	encodedPair := v2.RequestMatcherResponsePairViewV5{
-		Response: v2.ResponseDetailsViewV5{
+	encodedPair := v2.RequestMatcherResponsePairViewV6{
+		Response: v2.ResponseDetailsViewV6{
 			Status:      200,
 			Body:        base64String("hello_world"),
 			EncodedBody: true,
@@ -775,7 +972,7 @@ func TestImportImportRequestResponsePairs_ReturnsWarningsContentLengthMismatch(t
 		},
 	}
 
-	result := hv.importRequestResponsePairViews([]v2.RequestMatcherResponsePairViewV5{encodedPair})
+	result := hv.importRequestResponsePairViews([]v2.RequestMatcherResponsePairViewV6{encodedPair})
 
 	Expect(result.WarningMessages).To(HaveLen(1))
 	Expect(result.WarningMessages[0].Message).To(ContainSubstring("Response contains incorrect Content-Length header on data.pairs[0].response, please correct or remove header"))
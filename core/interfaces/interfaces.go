@@ -22,5 +22,11 @@ type Response interface {
 	GetTemplated() bool
 	GetHeaders() map[string][]string
 	GetTransitionsState() map[string]string
+	GetScheduledTransitions() map[string]StateTransition
 	GetRemovesState() []string
 }
+
+type StateTransition interface {
+	GetValue() string
+	GetDelay() int
+}
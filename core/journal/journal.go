@@ -7,6 +7,7 @@ import (
 
 	sorting "sort"
 	"strings"
+	"sync"
 
 	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/core/matching"
@@ -22,11 +23,13 @@ type JournalEntry struct {
 	Mode        string
 	TimeStarted time.Time
 	Latency     time.Duration
+	PairId      string
 }
 
 type Journal struct {
 	entries    []JournalEntry
 	EntryLimit int
+	mutex      sync.RWMutex
 }
 
 func NewJournal() *Journal {
@@ -51,22 +54,33 @@ func (this *Journal) NewEntry(request *http.Request, response *http.Response, mo
 		Headers: response.Header,
 	}
 
-	if len(this.entries) >= this.EntryLimit {
-		this.entries = append(this.entries[:0], this.entries[1:]...)
-	}
-
-	this.entries = append(this.entries, JournalEntry{
+	entry := JournalEntry{
 		Request:     &payloadRequest,
 		Response:    payloadResponse,
 		Mode:        mode,
 		TimeStarted: started,
 		Latency:     time.Since(started),
-	})
+		PairId:      util.GetPairId(response),
+	}
+
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+
+	// Entries are never modified in place, a new slice is built instead so
+	// that a snapshot taken by a reader is not affected by new entries
+	entries := this.entries
+	if len(entries) >= this.EntryLimit {
+		entries = entries[len(entries)-this.EntryLimit+1:]
+	}
+
+	newEntries := make([]JournalEntry, len(entries), len(entries)+1)
+	copy(newEntries, entries)
+	this.entries = append(newEntries, entry)
 
 	return nil
 }
 
-func (this Journal) GetEntries(offset int, limit int, from *time.Time, to *time.Time, sort string) (v2.JournalView, error) {
+func (this *Journal) GetEntries(offset int, limit int, from *time.Time, to *time.Time, sort string) (v2.JournalView, error) {
 	journalView := v2.JournalView{
 		Journal: []v2.JournalEntryView{},
 		Offset:  0,
@@ -84,11 +98,12 @@ func (this Journal) GetEntries(offset int, limit int, from *time.Time, to *time.
 		return journalView, err
 	}
 
+	entries := this.getEntries()
 	selectedEntries := []JournalEntry{}
 
 	// Filtering
 	if from != nil || to != nil {
-		for _, entry := range this.entries {
+		for _, entry := range entries {
 			if from != nil && entry.TimeStarted.Before(*from) {
 				continue
 			}
@@ -98,7 +113,7 @@ func (this Journal) GetEntries(offset int, limit int, from *time.Time, to *time.
 			selectedEntries = append(selectedEntries, entry)
 		}
 	} else {
-		selectedEntries = append(selectedEntries, this.entries...)
+		selectedEntries = append(selectedEntries, entries...)
 	}
 
 	// Sorting
@@ -136,7 +151,7 @@ func (this Journal) GetEntries(offset int, limit int, from *time.Time, to *time.
 	return journalView, nil
 }
 
-func (this Journal) GetFilteredEntries(journalEntryFilterView v2.JournalEntryFilterView) ([]v2.JournalEntryView, error) {
+func (this *Journal) GetFilteredEntries(journalEntryFilterView v2.JournalEntryFilterView) ([]v2.JournalEntryView, error) {
 	filteredEntries := []v2.JournalEntryView{}
 	if this.EntryLimit == 0 {
 		return filteredEntries, fmt.Errorf("Journal disabled")
@@ -153,7 +168,7 @@ func (this Journal) GetFilteredEntries(journalEntryFilterView v2.JournalEntryFil
 		Headers:         models.NewRequestFieldMatchersFromMapView(journalEntryFilterView.Request.Headers),
 	}
 
-	allEntries := convertJournalEntries(this.entries)
+	allEntries := convertJournalEntries(this.getEntries())
 
 	for _, entry := range allEntries {
 		if requestMatcher.Body == nil && requestMatcher.Destination == nil &&
@@ -197,11 +212,21 @@ func (this *Journal) DeleteEntries() error {
 		return fmt.Errorf("Journal disabled")
 	}
 
+	this.mutex.Lock()
 	this.entries = []JournalEntry{}
+	this.mutex.Unlock()
 
 	return nil
 }
 
+// getEntries returns a snapshot of the journal entries
+func (this *Journal) getEntries() []JournalEntry {
+	this.mutex.RLock()
+	defer this.mutex.RUnlock()
+
+	return this.entries
+}
+
 func convertJournalEntries(entries []JournalEntry) []v2.JournalEntryView {
 
 	journalEntryViews := []v2.JournalEntryView{}
@@ -213,6 +238,7 @@ func convertJournalEntries(entries []JournalEntry) []v2.JournalEntryView {
 			Mode:        journalEntry.Mode,
 			TimeStarted: journalEntry.TimeStarted.Format(RFC3339Milli),
 			Latency:     journalEntry.Latency.Seconds() * 1e3,
+			PairId:      journalEntry.PairId,
 		})
 	}
 
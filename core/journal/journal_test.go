@@ -5,12 +5,14 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strconv"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/core/journal"
 	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	"github.com/SpectoLabs/hoverfly/core/util"
 	. "github.com/onsi/gomega"
 )
 
@@ -70,6 +72,34 @@ func Test_Journal_NewEntry_AddsJournalEntryToEntries(t *testing.T) {
 	Expect(entries[0].Latency).To(BeNumerically("<", 1))
 }
 
+func Test_Journal_NewEntry_RecordsTheMatchedPairId(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := journal.NewJournal()
+
+	request, _ := http.NewRequest("GET", "http://hoverfly.io", nil)
+
+	err := unit.NewEntry(request, &http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewBufferString("test body")),
+		Request:    util.WithPairId(request, "get-payment"),
+	}, "simulate", time.Now())
+	Expect(err).To(BeNil())
+
+	err = unit.NewEntry(request, &http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewBufferString("test body")),
+	}, "capture", time.Now())
+	Expect(err).To(BeNil())
+
+	journalView, err := unit.GetEntries(0, 25, nil, nil, "")
+	Expect(err).To(BeNil())
+
+	Expect(journalView.Journal).To(HaveLen(2))
+	Expect(journalView.Journal[0].PairId).To(Equal("get-payment"))
+	Expect(journalView.Journal[1].PairId).To(Equal(""))
+}
+
 func Test_Journal_NewEntry_RespectsEntryLimit(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -761,3 +791,39 @@ func Test_Journal_GetFilteredEntries_WillReturnEmptyIfRequestMatcherIsEmpty(t *t
 		Request: &v2.RequestMatcherViewV5{},
 	})).To(HaveLen(0))
 }
+
+func Test_Journal_NewEntry_CanBeCalledConcurrentlyWithReads(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := journal.NewJournal()
+	unit.EntryLimit = 10
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			request, _ := http.NewRequest("GET", "http://hoverfly.io", nil)
+			unit.NewEntry(request, &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("test body")),
+			}, "test-mode", time.Now())
+		}()
+		go func() {
+			defer wg.Done()
+			unit.GetEntries(0, 25, nil, nil, "latency:desc")
+			unit.GetFilteredEntries(v2.JournalEntryFilterView{
+				Request: &v2.RequestMatcherViewV5{
+					Destination: []v2.MatcherViewV5{
+						v2.NewMatcherView(matchers.Exact, "hoverfly.io"),
+					},
+				},
+			})
+		}()
+	}
+	wg.Wait()
+
+	journalView, err := unit.GetEntries(0, 25, nil, nil, "")
+	Expect(err).To(BeNil())
+	Expect(journalView.Journal).To(HaveLen(10))
+}
@@ -0,0 +1,274 @@
+package hoverfly
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	"github.com/SpectoLabs/hoverfly/core/models"
+)
+
+// LintSimulation looks for pairs which can be imported but are never used or do not behave as
+// intended. Pairs are checked in order, as the first matching strategy uses the earliest pair
+// which matches a request. The simulation is not imported.
+func (hf *Hoverfly) LintSimulation(simulationView v2.SimulationViewV6) v2.SimulationLintView {
+	pairs := []models.RequestMatcherResponsePair{}
+	for _, pairView := range simulationView.RequestResponsePairs {
+		pairs = append(pairs, *models.NewRequestMatcherResponsePairFromView(&pairView))
+	}
+
+	reachableStates := getReachableStates(pairs)
+
+	lint := v2.SimulationLintView{
+		Issues: []v2.SimulationLintIssueView{},
+	}
+
+	addIssue := func(issueType string, pairIndex int, format string, args ...interface{}) {
+		lint.Issues = append(lint.Issues, v2.SimulationLintIssueView{
+			Type:    issueType,
+			Pair:    pairIndex,
+			PairId:  pairs[pairIndex].Id,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	for i, pair := range pairs {
+		for _, field := range getNamedFieldMatchers(pair.RequestMatcher) {
+			for _, matcher := range field.matchers {
+				matcherName := strings.ToLower(matcher.Matcher)
+				if validator, ok := matchers.Validators[matcherName]; ok {
+					if err := validator(matcher.Value); err != nil {
+						addIssue(v2.LintInvalidMatcher, i, "data.pairs[%v].request.%s has an invalid %s value: %s", i, field.name, matcherName, err.Error())
+					}
+				}
+			}
+		}
+
+		if pair.Response.Templated {
+			if err := hf.templator.ValidateTemplate(pair.Response.Body); err != nil {
+				addIssue(v2.LintInvalidTemplate, i, "data.pairs[%v].response.body is templated but cannot be parsed: %s", i, err.Error())
+			}
+		}
+
+		for j := 0; j < i; j++ {
+			if reflect.DeepEqual(pairs[j].RequestMatcher, pair.RequestMatcher) {
+				addIssue(v2.LintDuplicate, i, "data.pairs[%v] has the same request matcher as data.pairs[%v], so only the first of them is used", i, j)
+				break
+			}
+
+			if requestMatcherShadows(pairs[j].RequestMatcher, pair.RequestMatcher) {
+				addIssue(v2.LintShadowed, i, "data.pairs[%v] is never used with the first matching strategy, as every request it matches is matched by data.pairs[%v] first", i, j)
+				break
+			}
+		}
+
+		for _, key := range getSortedKeys(pair.RequestMatcher.RequiresState) {
+			value := pair.RequestMatcher.RequiresState[key]
+			if !reachableStates[key][value] {
+				addIssue(v2.LintUnreachableState, i, "data.pairs[%v].request.requiresState needs %s to be %s, but no pair sets it to that value", i, key, value)
+			}
+		}
+	}
+
+	return lint
+}
+
+// getReachableStates returns the values each state key can be given by the pairs, including
+// the value sequences start with
+func getReachableStates(pairs []models.RequestMatcherResponsePair) map[string]map[string]bool {
+	reachableStates := map[string]map[string]bool{}
+	addState := func(key, value string) {
+		if reachableStates[key] == nil {
+			reachableStates[key] = map[string]bool{}
+		}
+		reachableStates[key][value] = true
+	}
+
+	for _, pair := range pairs {
+		for key, value := range pair.Response.TransitionsState {
+			addState(key, value)
+		}
+		for key, transition := range pair.Response.ScheduledTransitions {
+			if transition.Value != "" {
+				addState(key, transition.Value)
+			}
+		}
+		for key := range pair.RequestMatcher.RequiresState {
+			if strings.Contains(key, "sequence:") {
+				addState(key, "1")
+			}
+		}
+	}
+
+	return reachableStates
+}
+
+type namedFieldMatchers struct {
+	name     string
+	matchers []models.RequestFieldMatchers
+}
+
+func getNamedFieldMatchers(requestMatcher models.RequestMatcher) []namedFieldMatchers {
+	fields := []namedFieldMatchers{
+		{"path", requestMatcher.Path},
+		{"method", requestMatcher.Method},
+		{"destination", requestMatcher.Destination},
+		{"scheme", requestMatcher.Scheme},
+		{"deprecatedQuery", requestMatcher.DeprecatedQuery},
+		{"body", requestMatcher.Body},
+	}
+
+	for _, key := range getSortedMatcherKeys(requestMatcher.Headers) {
+		fields = append(fields, namedFieldMatchers{"headers." + key, requestMatcher.Headers[key]})
+	}
+
+	if requestMatcher.Query != nil {
+		for _, key := range getSortedMatcherKeys(*requestMatcher.Query) {
+			fields = append(fields, namedFieldMatchers{"query." + key, (*requestMatcher.Query)[key]})
+		}
+	}
+
+	return fields
+}
+
+// requestMatcherShadows returns whether every request matched by the later request matcher is also
+// matched by the earlier one. Only matchers which can be compared without running them against a
+// request are understood, so some shadowed pairs are not found but none are wrongly reported.
+func requestMatcherShadows(earlier, later models.RequestMatcher) bool {
+	if !fieldMatchersShadow(earlier.Path, later.Path) ||
+		!fieldMatchersShadow(earlier.Method, later.Method) ||
+		!fieldMatchersShadow(earlier.Destination, later.Destination) ||
+		!fieldMatchersShadow(earlier.Scheme, later.Scheme) ||
+		!fieldMatchersShadow(earlier.DeprecatedQuery, later.DeprecatedQuery) ||
+		!fieldMatchersShadow(earlier.Body, later.Body) {
+		return false
+	}
+
+	if !mapMatchersShadow(earlier.Headers, later.Headers) {
+		return false
+	}
+
+	if earlier.Query != nil {
+		if later.Query == nil {
+			return false
+		}
+
+		// An empty query matcher only matches requests without a query
+		if len(*earlier.Query) == 0 && len(*later.Query) != 0 {
+			return false
+		}
+
+		if !mapMatchersShadow(*earlier.Query, *later.Query) {
+			return false
+		}
+	}
+
+	for key, value := range earlier.RequiresState {
+		if laterValue, ok := later.RequiresState[key]; !ok || laterValue != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// mapMatchersShadow compares header or query matchers, whose keys are not case sensitive
+func mapMatchersShadow(earlier, later map[string][]models.RequestFieldMatchers) bool {
+	for earlierKey, earlierMatchers := range earlier {
+		found := false
+		for laterKey, laterMatchers := range later {
+			if strings.ToLower(earlierKey) == strings.ToLower(laterKey) {
+				found = fieldMatchersShadow(earlierMatchers, laterMatchers)
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// fieldMatchersShadow returns whether each of the earlier matchers is sure to match when the later matchers do
+func fieldMatchersShadow(earlier, later []models.RequestFieldMatchers) bool {
+	for _, earlierMatcher := range earlier {
+		shadowed := false
+		for _, laterMatcher := range later {
+			if matcherShadows(earlierMatcher, laterMatcher) {
+				shadowed = true
+				break
+			}
+		}
+
+		if !shadowed {
+			return false
+		}
+	}
+
+	return true
+}
+
+func matcherShadows(earlier, later models.RequestFieldMatchers) bool {
+	earlierName := getMatcherName(earlier)
+	laterName := getMatcherName(later)
+
+	if earlierName == laterName && reflect.DeepEqual(earlier.Value, later.Value) {
+		return true
+	}
+
+	if earlierName == matchers.Glob && earlier.Value == "*" {
+		return true
+	}
+
+	laterValue, ok := later.Value.(string)
+	if !ok {
+		return false
+	}
+
+	switch {
+	case laterName == matchers.Exact && earlierName == matchers.Exact:
+		return matchers.ExactMatch(earlier.Value, laterValue)
+	case laterName == matchers.Exact && earlierName == matchers.Glob:
+		return matchers.GlobMatch(earlier.Value, laterValue)
+	case laterName == matchers.Exact && earlierName == matchers.Regex:
+		return matchers.RegexMatch(earlier.Value, laterValue)
+	case laterName == matchers.Glob && earlierName == matchers.Glob:
+		// The wildcards of the later glob are matched as they are, so the earlier glob must cover them
+		return matchers.GlobMatch(earlier.Value, laterValue)
+	}
+
+	return false
+}
+
+func getMatcherName(matcher models.RequestFieldMatchers) string {
+	if matcher.Matcher == "" {
+		return matchers.Exact
+	}
+
+	return strings.ToLower(matcher.Matcher)
+}
+
+func getSortedKeys(values map[string]string) []string {
+	keys := []string{}
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func getSortedMatcherKeys(values map[string][]models.RequestFieldMatchers) []string {
+	keys := []string{}
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
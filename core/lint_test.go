@@ -0,0 +1,134 @@
+package hoverfly
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	. "github.com/onsi/gomega"
+)
+
+func lintPairs(pairs string) v2.SimulationLintView {
+	simulationView, err := v2.NewSimulationViewFromResponseBody([]byte(`{
+		"data": {
+			"pairs": [` + pairs + `],
+			"globalActions": {"delays": []}
+		},
+		"meta": {"schemaVersion": "v6"}
+	}`))
+	Expect(err).To(BeNil())
+
+	return NewHoverflyWithConfiguration(&Configuration{}).LintSimulation(simulationView)
+}
+
+func Test_Hoverfly_LintSimulation_ReturnsNoIssuesForDistinctPairs(t *testing.T) {
+	RegisterTestingT(t)
+
+	lint := lintPairs(`
+		{"request": {"path": [{"matcher": "exact", "value": "/a"}]}, "response": {"status": 200}},
+		{"request": {"path": [{"matcher": "exact", "value": "/b"}]}, "response": {"status": 200}},
+		{"request": {"path": [{"matcher": "glob", "value": "*"}]}, "response": {"status": 404}}
+	`)
+
+	Expect(lint.Issues).To(BeEmpty())
+}
+
+func Test_Hoverfly_LintSimulation_FindsDuplicatePairs(t *testing.T) {
+	RegisterTestingT(t)
+
+	lint := lintPairs(`
+		{"request": {"path": [{"matcher": "exact", "value": "/a"}]}, "response": {"status": 200}},
+		{"id": "again", "request": {"path": [{"matcher": "exact", "value": "/a"}]}, "response": {"status": 201}}
+	`)
+
+	Expect(lint.Issues).To(HaveLen(1))
+	Expect(lint.Issues[0].Type).To(Equal(v2.LintDuplicate))
+	Expect(lint.Issues[0].Pair).To(Equal(1))
+	Expect(lint.Issues[0].PairId).To(Equal("again"))
+	Expect(lint.Issues[0].Message).To(ContainSubstring("data.pairs[0]"))
+}
+
+func Test_Hoverfly_LintSimulation_FindsPairsShadowedByEarlierPairs(t *testing.T) {
+	RegisterTestingT(t)
+
+	lint := lintPairs(`
+		{"request": {"path": [{"matcher": "glob", "value": "/api/*"}]}, "response": {"status": 200}},
+		{"request": {"path": [{"matcher": "exact", "value": "/api/users"}], "method": [{"matcher": "exact", "value": "GET"}]}, "response": {"status": 200}},
+		{"request": {"path": [{"matcher": "glob", "value": "/api/users/*"}]}, "response": {"status": 200}},
+		{"request": {"path": [{"matcher": "exact", "value": "/other"}]}, "response": {"status": 200}}
+	`)
+
+	Expect(lint.Issues).To(HaveLen(2))
+	Expect(lint.Issues[0].Type).To(Equal(v2.LintShadowed))
+	Expect(lint.Issues[0].Pair).To(Equal(1))
+	Expect(lint.Issues[1].Type).To(Equal(v2.LintShadowed))
+	Expect(lint.Issues[1].Pair).To(Equal(2))
+}
+
+func Test_Hoverfly_LintSimulation_DoesNotReportPairsWhichAnEarlierPairOnlyPartlyCovers(t *testing.T) {
+	RegisterTestingT(t)
+
+	lint := lintPairs(`
+		{"request": {"path": [{"matcher": "glob", "value": "/api/*"}], "headers": {"Accept": [{"matcher": "exact", "value": "application/json"}]}}, "response": {"status": 200}},
+		{"request": {"path": [{"matcher": "exact", "value": "/api/users"}]}, "response": {"status": 200}},
+		{"request": {"path": [{"matcher": "regex", "value": "^/api"}], "requiresState": {"loggedIn": "true"}}, "response": {"status": 200, "transitionsState": {"loggedIn": "true"}}},
+		{"request": {"path": [{"matcher": "exact", "value": "/api/users"}]}, "response": {"status": 200}},
+		{"request": {"path": [{"matcher": "glob", "value": "/api/*"}], "query": {}}, "response": {"status": 200}},
+		{"request": {"path": [{"matcher": "exact", "value": "/api/orders"}], "query": {"page": [{"matcher": "exact", "value": "1"}]}}, "response": {"status": 200}}
+	`)
+
+	Expect(lint.Issues).To(HaveLen(1))
+	Expect(lint.Issues[0].Type).To(Equal(v2.LintDuplicate))
+	Expect(lint.Issues[0].Pair).To(Equal(3))
+}
+
+func Test_Hoverfly_LintSimulation_FindsInvalidMatcherValues(t *testing.T) {
+	RegisterTestingT(t)
+
+	lint := lintPairs(`
+		{"request": {"path": [{"matcher": "regex", "value": "/api/[a-z"}]}, "response": {"status": 200}},
+		{"request": {"body": [{"matcher": "jsonpath", "value": "$.name["}]}, "response": {"status": 200}},
+		{"request": {"headers": {"X-Data": [{"matcher": "xpath", "value": "/list/item["}]}}, "response": {"status": 200}},
+		{"request": {"body": [{"matcher": "jsonpath", "value": "$.name"}]}, "response": {"status": 200}}
+	`)
+
+	Expect(lint.Issues).To(HaveLen(3))
+	Expect(lint.Issues[0].Type).To(Equal(v2.LintInvalidMatcher))
+	Expect(lint.Issues[0].Message).To(ContainSubstring("data.pairs[0].request.path has an invalid regex value"))
+	Expect(lint.Issues[1].Type).To(Equal(v2.LintInvalidMatcher))
+	Expect(lint.Issues[1].Message).To(ContainSubstring("data.pairs[1].request.body has an invalid jsonpath value"))
+	Expect(lint.Issues[2].Type).To(Equal(v2.LintInvalidMatcher))
+	Expect(lint.Issues[2].Message).To(ContainSubstring("data.pairs[2].request.headers.X-Data has an invalid xpath value"))
+}
+
+func Test_Hoverfly_LintSimulation_FindsTemplatesWhichCannotBeParsed(t *testing.T) {
+	RegisterTestingT(t)
+
+	lint := lintPairs(`
+		{"request": {"path": [{"matcher": "exact", "value": "/a"}]}, "response": {"status": 200, "templated": true, "body": "{{ Request.Path.[0] "}},
+		{"request": {"path": [{"matcher": "exact", "value": "/b"}]}, "response": {"status": 200, "templated": true, "body": "{{ Request.Path.[0] }}"}},
+		{"request": {"path": [{"matcher": "exact", "value": "/c"}]}, "response": {"status": 200, "body": "{{ not templated"}}
+	`)
+
+	Expect(lint.Issues).To(HaveLen(1))
+	Expect(lint.Issues[0].Type).To(Equal(v2.LintInvalidTemplate))
+	Expect(lint.Issues[0].Pair).To(Equal(0))
+}
+
+func Test_Hoverfly_LintSimulation_FindsRequiredStatesWhichAreNeverSet(t *testing.T) {
+	RegisterTestingT(t)
+
+	lint := lintPairs(`
+		{"request": {"path": [{"matcher": "exact", "value": "/basket"}], "requiresState": {"basket": "full"}}, "response": {"status": 200}},
+		{"request": {"path": [{"matcher": "exact", "value": "/basket"}], "requiresState": {"basket": "empty"}}, "response": {"status": 200}},
+		{"request": {"path": [{"matcher": "exact", "value": "/add"}]}, "response": {"status": 200, "transitionsState": {"basket": "full"}}},
+		{"request": {"path": [{"matcher": "exact", "value": "/login"}], "requiresState": {"session": "active"}}, "response": {"status": 200}},
+		{"request": {"path": [{"matcher": "exact", "value": "/logout"}]}, "response": {"status": 200, "scheduledTransitions": {"session": {"value": "active", "delay": 10}}}},
+		{"request": {"path": [{"matcher": "exact", "value": "/next"}], "requiresState": {"sequence:1": "1"}}, "response": {"status": 200, "transitionsState": {"sequence:1": "2"}}},
+		{"request": {"path": [{"matcher": "exact", "value": "/next"}], "requiresState": {"sequence:1": "2"}}, "response": {"status": 200}}
+	`)
+
+	Expect(lint.Issues).To(HaveLen(1))
+	Expect(lint.Issues[0].Type).To(Equal(v2.LintUnreachableState))
+	Expect(lint.Issues[0].Pair).To(Equal(1))
+	Expect(lint.Issues[0].Message).To(ContainSubstring("basket to be empty"))
+}
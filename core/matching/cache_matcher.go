@@ -81,7 +81,7 @@ func (this CacheMatcher) GetAllResponses() (v2.CacheView, error) {
 	for key, v := range records {
 		if cachedResponse, err := models.NewCachedResponseFromBytes(v); err == nil {
 
-			var pair *v2.RequestMatcherResponsePairViewV5
+			var pair *v2.RequestMatcherResponsePairViewV6
 			var closestMiss *v2.ClosestMissView
 
 			if cachedResponse.MatchingPair != nil {
@@ -159,7 +159,17 @@ func (this CacheMatcher) FlushCache() error {
 	return this.RequestCache.DeleteData()
 }
 
-func (this CacheMatcher) PreloadCache(simulation models.Simulation) error {
+// ReloadCache invalidates every cached response, then eagerly caches the pairs of the
+// given simulation. It is used when the simulation is swapped for a different one.
+func (this CacheMatcher) ReloadCache(simulation *models.Simulation) error {
+	if err := this.FlushCache(); err != nil {
+		return err
+	}
+
+	return this.PreloadCache(simulation)
+}
+
+func (this CacheMatcher) PreloadCache(simulation *models.Simulation) error {
 	if this.RequestCache == nil {
 		return errors.NoCacheSetError()
 	}
@@ -69,7 +69,7 @@ func Test_CacheMatcher_PreloadCache_WillReturnErrorIfCacheIsNil(t *testing.T) {
 	RegisterTestingT(t)
 	unit := matching.CacheMatcher{}
 
-	err := unit.PreloadCache(models.Simulation{})
+	err := unit.PreloadCache(models.NewSimulation())
 	Expect(err).ToNot(BeNil())
 	Expect(err.Error()).To(Equal("No cache set"))
 }
@@ -97,7 +97,7 @@ func Test_CacheMatcher_PreloadCache_WillNotCacheIncompleteRequestMatchers(t *tes
 		},
 	})
 
-	err := unit.PreloadCache(*simulation)
+	err := unit.PreloadCache(simulation)
 
 	Expect(err).To(BeNil())
 	Expect(unit.RequestCache.GetAllKeys()).To(HaveLen(0))
@@ -157,7 +157,7 @@ func Test_CacheMatcher_PreloadCache_WillPreemptivelyCacheFullExactMatchRequestMa
 		},
 	})
 
-	err := unit.PreloadCache(*simulation)
+	err := unit.PreloadCache(simulation)
 
 	Expect(err).To(BeNil())
 	Expect(unit.RequestCache.GetAllKeys()).To(HaveLen(1))
@@ -186,7 +186,7 @@ func Test_CacheMatcher_PreloadCache_WillNotPreemptivelyCacheRequestMatchersWitho
 		},
 	})
 
-	err := unit.PreloadCache(*simulation)
+	err := unit.PreloadCache(simulation)
 
 	Expect(err).To(BeNil())
 	Expect(unit.RequestCache.GetAllKeys()).To(HaveLen(0))
@@ -260,7 +260,7 @@ func Test_CacheMatcher_PreloadCache_WillCheckAllRequestMatchersInSimulation(t *t
 		},
 	})
 
-	err := unit.PreloadCache(*simulation)
+	err := unit.PreloadCache(simulation)
 
 	Expect(err).To(BeNil())
 	Expect(unit.RequestCache.GetAllKeys()).To(HaveLen(1))
@@ -342,7 +342,7 @@ func Test_CacheMatcher_PreloadCache_WillNotCacheMatchersWithHeaders(t *testing.T
 		},
 	})
 
-	err := unit.PreloadCache(*simulation)
+	err := unit.PreloadCache(simulation)
 
 	Expect(err).To(BeNil())
 	Expect(unit.RequestCache.GetAllKeys()).To(HaveLen(0))
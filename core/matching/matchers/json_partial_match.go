@@ -0,0 +1,80 @@
+package matchers
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+var JsonPartial = "jsonpartial"
+
+// JsonPartialMatch matches when every value of the matcher value is in the JSON to match. Objects
+// may have other keys, and each element of an array in the matcher value has to be contained in
+// one of the elements of the array it is compared with.
+func JsonPartialMatch(match interface{}, toMatch string) bool {
+	matchString, ok := match.(string)
+	if !ok {
+		return false
+	}
+
+	var matchingObject interface{}
+	if err := json.Unmarshal([]byte(matchString), &matchingObject); err != nil {
+		return false
+	}
+
+	var toMatchObject interface{}
+	if err := json.Unmarshal([]byte(toMatch), &toMatchObject); err != nil {
+		return false
+	}
+
+	return jsonContains(toMatchObject, matchingObject)
+}
+
+func ValidateJsonPartial(match interface{}) error {
+	matchString, ok := match.(string)
+	if !ok {
+		return fmt.Errorf("value must be a string")
+	}
+
+	var matchingObject interface{}
+	return json.Unmarshal([]byte(matchString), &matchingObject)
+}
+
+func jsonContains(actual, expected interface{}) bool {
+	switch expectedValue := expected.(type) {
+	case map[string]interface{}:
+		actualValue, ok := actual.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, value := range expectedValue {
+			if _, found := actualValue[key]; !found || !jsonContains(actualValue[key], value) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		actualValue, ok := actual.([]interface{})
+		if !ok {
+			return false
+		}
+		for _, value := range expectedValue {
+			if !jsonArrayContains(actualValue, value) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return reflect.DeepEqual(actual, expected)
+}
+
+func jsonArrayContains(actual []interface{}, expected interface{}) bool {
+	for _, value := range actual {
+		if jsonContains(value, expected) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,59 @@
+package matchers_test
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	. "github.com/onsi/gomega"
+)
+
+func Test_JsonPartialMatch_MatchesFalseWithIncorrectDataType(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.JsonPartialMatch(1, `{"test": true}`)).To(BeFalse())
+}
+
+func Test_JsonPartialMatch_MatchesTrueWithSameJSON(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.JsonPartialMatch(`{"test":{"json":true}}`, `{"test": {"json": true}}`)).To(BeTrue())
+}
+
+func Test_JsonPartialMatch_MatchesTrueWhenJSONHasOtherKeys(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.JsonPartialMatch(`{"user":{"id":1}}`, `{"user":{"id":1,"name":"test"},"timestamp":123}`)).To(BeTrue())
+}
+
+func Test_JsonPartialMatch_MatchesFalseWhenValueIsDifferent(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.JsonPartialMatch(`{"user":{"id":1}}`, `{"user":{"id":2,"name":"test"}}`)).To(BeFalse())
+}
+
+func Test_JsonPartialMatch_MatchesFalseWhenKeyIsMissing(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.JsonPartialMatch(`{"user":{"id":null}}`, `{"user":{"name":"test"}}`)).To(BeFalse())
+}
+
+func Test_JsonPartialMatch_MatchesArrayElementsInAnyElement(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.JsonPartialMatch(`{"items":[{"id":3},{"id":1}]}`, `{"items":[{"id":1,"n":"a"},{"id":2},{"id":3}]}`)).To(BeTrue())
+	Expect(matchers.JsonPartialMatch(`{"items":[{"id":4}]}`, `{"items":[{"id":1},{"id":2}]}`)).To(BeFalse())
+}
+
+func Test_JsonPartialMatch_MatchesFalseWithInvalidJSON(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.JsonPartialMatch(`{"test":`, `{"test":true}`)).To(BeFalse())
+	Expect(matchers.JsonPartialMatch(`{"test":true}`, `{"test":`)).To(BeFalse())
+}
+
+func Test_ValidateJsonPartial_ReturnsErrorForInvalidJSON(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.ValidateJsonPartial(`{"test":`)).ToNot(BeNil())
+	Expect(matchers.ValidateJsonPartial(`{"test":true}`)).To(BeNil())
+}
@@ -52,6 +52,15 @@ func JsonPathExecution(matchString, toMatch string) (string, error) {
 	return buf.String(), nil
 }
 
+func ValidateJsonPath(match interface{}) error {
+	matchString, ok := match.(string)
+	if !ok || matchString == "" {
+		return fmt.Errorf("value must be a string")
+	}
+
+	return jsonpath.New("").Parse(prepareJsonPathQuery(matchString))
+}
+
 func prepareJsonPathQuery(query string) string {
 	if string(query[0:1]) != "{" && string(query[len(query)-1:]) != "}" {
 		query = fmt.Sprintf("{%s}", query)
@@ -53,3 +53,11 @@ func Test_JsonPathMatch_MatchesFalseWithIncorrectJsonMatch_WithExpression(t *tes
 
 	Expect(matchers.JsonPathMatch("$.test[*]?(@.field == \"test\")", `{"test": [{"field": "not-test"}]}`)).To(BeFalse())
 }
+
+func Test_ValidateJsonPath_ReturnsErrorForInvalidJsonPath(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.ValidateJsonPath("$.test")).To(BeNil())
+	Expect(matchers.ValidateJsonPath("$.test[")).ToNot(BeNil())
+	Expect(matchers.ValidateJsonPath("")).ToNot(BeNil())
+}
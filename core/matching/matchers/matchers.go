@@ -6,11 +6,23 @@ var Matchers = map[string]MatcherFunc{
 	// Default matcher
 	"": ExactMatch,
 
-	Exact:    ExactMatch,
-	Glob:     GlobMatch,
-	Json:     JsonMatch,
-	JsonPath: JsonPathMatch,
-	Regex:    RegexMatch,
-	Xml:      XmlMatch,
-	Xpath:    XpathMatch,
+	Exact:       ExactMatch,
+	Glob:        GlobMatch,
+	Json:        JsonMatch,
+	JsonPartial: JsonPartialMatch,
+	JsonPath:    JsonPathMatch,
+	Regex:       RegexMatch,
+	Xml:         XmlMatch,
+	Xpath:       XpathMatch,
+}
+
+type MatcherValidator func(data interface{}) error
+
+// Validators check the values of the matchers which are parsed before they are used, as
+// a value which cannot be parsed never matches
+var Validators = map[string]MatcherValidator{
+	JsonPartial: ValidateJsonPartial,
+	JsonPath:    ValidateJsonPath,
+	Regex:       ValidateRegex,
+	Xpath:       ValidateXpath,
 }
@@ -1,6 +1,9 @@
 package matchers
 
-import "regexp"
+import (
+	"fmt"
+	"regexp"
+)
 
 var Regex = "regex"
 
@@ -17,3 +20,13 @@ func RegexMatch(match interface{}, toMatch string) bool {
 
 	return result
 }
+
+func ValidateRegex(match interface{}) error {
+	matchString, ok := match.(string)
+	if !ok {
+		return fmt.Errorf("value must be a string")
+	}
+
+	_, err := regexp.Compile(matchString)
+	return err
+}
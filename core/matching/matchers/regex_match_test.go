@@ -23,3 +23,11 @@ func Test_RegexMatch_MatchesFalseWithIncorrectRegexMatch(t *testing.T) {
 
 	Expect(matchers.RegexMatch("t[o|a]st", `test`)).To(BeFalse())
 }
+
+func Test_ValidateRegex_ReturnsErrorForInvalidRegex(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.ValidateRegex("t[o|a]st")).To(BeNil())
+	Expect(matchers.ValidateRegex("t[o|a")).ToNot(BeNil())
+	Expect(matchers.ValidateRegex(1)).ToNot(BeNil())
+}
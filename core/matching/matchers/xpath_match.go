@@ -2,6 +2,7 @@ package matchers
 
 import (
 	"bytes"
+	"fmt"
 
 	"github.com/ChrisTrenkamp/goxpath"
 	"github.com/ChrisTrenkamp/goxpath/tree"
@@ -25,6 +26,16 @@ func XpathMatch(match interface{}, toMatch string) bool {
 	return len(results) > 0
 }
 
+func ValidateXpath(match interface{}) error {
+	matchString, ok := match.(string)
+	if !ok {
+		return fmt.Errorf("value must be a string")
+	}
+
+	_, err := goxpath.Parse(matchString)
+	return err
+}
+
 func XpathExecution(matchString, toMatch string) (tree.NodeSet, error) {
 	xpathRule, err := goxpath.Parse(matchString)
 	if err != nil {
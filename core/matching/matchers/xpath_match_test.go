@@ -61,3 +61,10 @@ func Test_XpathMatch_MatchesTrue_WithoutHeader(t *testing.T) {
 
 	Expect(matchers.XpathMatch("/list/item/field", "<list><item><field></field></item></list>")).To(BeTrue())
 }
+
+func Test_ValidateXpath_ReturnsErrorForInvalidXpath(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(matchers.ValidateXpath("/list/item")).To(BeNil())
+	Expect(matchers.ValidateXpath("/list/item[")).ToNot(BeNil())
+}
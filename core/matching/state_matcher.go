@@ -15,10 +15,11 @@ func StateMatcher(currentState *state.State, requiredState map[string]string) *F
 	}
 
 	for key, value := range requiredState {
-		if _, ok := currentState.State[key]; !ok {
+		currentValue, ok := currentState.GetValue(key)
+		if !ok {
 			matched = false
 		}
-		if currentState.State[key] != value {
+		if currentValue != value {
 			matched = false
 		} else {
 			score++
@@ -54,6 +54,7 @@ func (s *StrongestMatchStrategy) PostMatching(req models.RequestDetails, request
 
 	if s.matched == true && s.score >= s.strongestMatchScore {
 		s.requestMatch = &models.RequestMatcherResponsePair{
+			Id:             matchingPair.Id,
 			RequestMatcher: requestMatcher,
 			Response:       matchingPair.Response,
 		}
@@ -67,7 +68,7 @@ func (s *StrongestMatchStrategy) PostMatching(req models.RequestDetails, request
 			RequestMatcher: view.RequestMatcher,
 			Response:       view.Response,
 			MissedFields:   s.missedFields,
-			State:          state.State,
+			State:          state.Copy(),
 		}
 	}
 
@@ -66,6 +66,10 @@ func (this ResponseDetailsView) GetTemplated() bool { return false }
 
 func (this ResponseDetailsView) GetTransitionsState() map[string]string { return nil }
 
+func (this ResponseDetailsView) GetScheduledTransitions() map[string]interfaces.StateTransition {
+	return nil
+}
+
 func (this ResponseDetailsView) GetRemovesState() []string { return nil }
 
 func (this ResponseDetailsView) GetHeaders() map[string][]string { return this.Headers }
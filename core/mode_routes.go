@@ -0,0 +1,164 @@
+package hoverfly
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/SpectoLabs/hoverfly/core/errors"
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/SpectoLabs/hoverfly/core/modes"
+)
+
+// modeRoute processes the requests to a destination and path in a mode of its own, so that its
+// arguments and anything it counts are kept apart from the mode of Hoverfly and other routes
+type modeRoute struct {
+	destination *regexp.Regexp
+	path        *regexp.Regexp
+	view        v2.ModeRouteView
+	mode        modes.Mode
+}
+
+// stop stops the workers of a shadow mode route, which would otherwise keep running once the
+// route is replaced or deleted
+func (this modeRoute) stop() {
+	if shadowMode, ok := this.mode.(*modes.ShadowMode); ok {
+		shadowMode.Stop()
+	}
+}
+
+func (this modeRoute) matches(requestDetails models.RequestDetails) bool {
+	return this.destination.MatchString(requestDetails.Destination) && this.path.MatchString(requestDetails.Path)
+}
+
+// routeHoverfly is the Hoverfly of the mode of a route, which matches requests with the matching
+// strategy of the route rather than the one of Hoverfly
+type routeHoverfly struct {
+	*Hoverfly
+	matchingStrategy string
+}
+
+func (this routeHoverfly) GetResponse(requestDetails models.RequestDetails) (*models.ResponseDetails, *errors.HoverflyError) {
+	return this.Hoverfly.getResponse(requestDetails, this.matchingStrategy)
+}
+
+func (hf *Hoverfly) newModeRoute(routeView v2.ModeRouteView) (modeRoute, error) {
+	if err := routeView.Validate(); err != nil {
+		return modeRoute{}, err
+	}
+
+	modeArguments, err := hf.newModeArguments(routeView.ModeView)
+	if err != nil {
+		return modeRoute{}, fmt.Errorf("Route for destination %q and path %q is not valid: %s", routeView.Destination, routeView.Path, err.Error())
+	}
+
+	matchingStrategy := "strongest"
+	if modeArguments.MatchingStrategy != nil {
+		matchingStrategy = strings.ToLower(*modeArguments.MatchingStrategy)
+	}
+
+	if matchingStrategy != "strongest" && matchingStrategy != "first" {
+		return modeRoute{}, fmt.Errorf("Route for destination %q and path %q is not valid: Only matching strategy of 'first' or 'strongest' is permitted", routeView.Destination, routeView.Path)
+	}
+
+	mode := newMode(routeHoverfly{Hoverfly: hf, matchingStrategy: matchingStrategy}, routeView.Mode)
+	mode.SetArguments(modeArguments)
+
+	return modeRoute{
+		destination: regexp.MustCompile(routeView.Destination),
+		path:        regexp.MustCompile(routeView.Path),
+		view:        routeView,
+		mode:        mode,
+	}, nil
+}
+
+// routeRequest returns the mode of the first route matching the request, or the mode of Hoverfly
+// when there is none
+func (hf *Hoverfly) routeRequest(requestDetails models.RequestDetails) (string, modes.Mode) {
+	hf.modeRoutesMutex.RLock()
+	defer hf.modeRoutesMutex.RUnlock()
+
+	for _, route := range hf.modeRoutes {
+		if route.matches(requestDetails) {
+			return route.view.Mode, route.mode
+		}
+	}
+
+	modeName := hf.Cfg.GetMode()
+	return modeName, hf.modeMap[modeName]
+}
+
+func (hf *Hoverfly) GetModeRoutes() v2.ModeRoutesView {
+	hf.modeRoutesMutex.RLock()
+	defer hf.modeRoutesMutex.RUnlock()
+
+	routesView := v2.ModeRoutesView{Routes: []v2.ModeRouteView{}}
+	for _, route := range hf.modeRoutes {
+		routesView.Routes = append(routesView.Routes, v2.ModeRouteView{
+			Destination: route.view.Destination,
+			Path:        route.view.Path,
+			ModeView:    route.mode.View(),
+		})
+	}
+
+	return routesView
+}
+
+// SetModeRoutes replaces every route, leaving them as they were when any of the new ones is not valid
+func (hf *Hoverfly) SetModeRoutes(routesView v2.ModeRoutesView) error {
+	routes := []modeRoute{}
+	for _, routeView := range routesView.Routes {
+		route, err := hf.newModeRoute(routeView)
+		if err != nil {
+			return err
+		}
+		routes = append(routes, route)
+	}
+
+	hf.modeRoutesMutex.Lock()
+	replaced := hf.modeRoutes
+	hf.modeRoutes = routes
+	hf.modeRoutesMutex.Unlock()
+
+	for _, route := range replaced {
+		route.stop()
+	}
+
+	log.WithFields(log.Fields{
+		"routes": len(routes),
+	}).Info("Mode routes have been changed")
+
+	return nil
+}
+
+func (hf *Hoverfly) AddModeRoute(routeView v2.ModeRouteView) error {
+	route, err := hf.newModeRoute(routeView)
+	if err != nil {
+		return err
+	}
+
+	hf.modeRoutesMutex.Lock()
+	hf.modeRoutes = append(hf.modeRoutes, route)
+	hf.modeRoutesMutex.Unlock()
+
+	log.WithFields(log.Fields{
+		"mode":        routeView.Mode,
+		"destination": routeView.Destination,
+		"path":        routeView.Path,
+	}).Info("Mode route has been added")
+
+	return nil
+}
+
+func (hf *Hoverfly) DeleteModeRoutes() {
+	hf.modeRoutesMutex.Lock()
+	deleted := hf.modeRoutes
+	hf.modeRoutes = nil
+	hf.modeRoutesMutex.Unlock()
+
+	for _, route := range deleted {
+		route.stop()
+	}
+}
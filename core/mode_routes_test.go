@@ -0,0 +1,309 @@
+package hoverfly
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/SpectoLabs/hoverfly/core/modes"
+	"github.com/SpectoLabs/hoverfly/core/util"
+	. "github.com/onsi/gomega"
+)
+
+func Test_Hoverfly_AddModeRoute_RoutesMatchingRequestsToTheirMode(t *testing.T) {
+	RegisterTestingT(t)
+
+	server, unit := testTools(201, `{'message': 'real'}`)
+	defer server.Close()
+
+	unit.Save(&models.RequestDetails{
+		Method:      "GET",
+		Scheme:      "http",
+		Destination: "payments.com",
+	}, &models.ResponseDetails{
+		Status: 200,
+		Body:   "simulated",
+	}, &modes.ModeArguments{})
+
+	Expect(unit.SetMode("capture")).To(Succeed())
+	Expect(unit.AddModeRoute(v2.ModeRouteView{
+		Destination: `^payments\.com$`,
+		ModeView:    v2.ModeView{Mode: "simulate"},
+	})).To(Succeed())
+
+	simulated, err := http.NewRequest("GET", "http://payments.com", nil)
+	Expect(err).To(BeNil())
+
+	response, mode := unit.processRequestWithMode(simulated)
+	Expect(mode).To(Equal("simulate"))
+	Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+	captured, err := http.NewRequest("GET", "http://catalog.com", nil)
+	Expect(err).To(BeNil())
+
+	response, mode = unit.processRequestWithMode(captured)
+	Expect(mode).To(Equal("capture"))
+	Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(2))
+}
+
+func Test_Hoverfly_AddModeRoute_MatchesOnPathAndTriesRoutesInOrder(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	Expect(unit.SetMode("simulate")).To(Succeed())
+
+	Expect(unit.AddModeRoute(v2.ModeRouteView{
+		Destination: "catalog.com",
+		Path:        "^/items",
+		ModeView:    v2.ModeView{Mode: "spy"},
+	})).To(Succeed())
+	Expect(unit.AddModeRoute(v2.ModeRouteView{
+		Destination: "catalog.com",
+		ModeView:    v2.ModeView{Mode: "capture"},
+	})).To(Succeed())
+
+	modeName, _ := unit.routeRequest(models.RequestDetails{Destination: "catalog.com", Path: "/items/1"})
+	Expect(modeName).To(Equal("spy"))
+
+	modeName, _ = unit.routeRequest(models.RequestDetails{Destination: "catalog.com", Path: "/orders"})
+	Expect(modeName).To(Equal("capture"))
+
+	modeName, _ = unit.routeRequest(models.RequestDetails{Destination: "payments.com", Path: "/items/1"})
+	Expect(modeName).To(Equal("simulate"))
+}
+
+func Test_Hoverfly_AddModeRoute_KeepsArgumentsOfEachRoute(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	Expect(unit.AddModeRoute(v2.ModeRouteView{
+		Destination: "catalog.com",
+		ModeView: v2.ModeView{
+			Mode:      "capture",
+			Arguments: v2.ModeArgumentsView{CapturePolicy: "lastWins"},
+		},
+	})).To(Succeed())
+	Expect(unit.SetModeWithArguments(v2.ModeView{
+		Mode:      "capture",
+		Arguments: v2.ModeArgumentsView{Stateful: true},
+	})).To(Succeed())
+
+	routes := unit.GetModeRoutes().Routes
+	Expect(routes).To(HaveLen(1))
+	Expect(routes[0].Destination).To(Equal("catalog.com"))
+	Expect(routes[0].Mode).To(Equal("capture"))
+	Expect(routes[0].Arguments.CapturePolicy).To(Equal("lastWins"))
+	Expect(routes[0].Arguments.Stateful).To(BeFalse())
+
+	Expect(unit.GetMode().Arguments.CapturePolicy).To(BeEmpty())
+}
+
+func Test_Hoverfly_AddModeRoute_RejectsInvalidRoutes(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	Expect(unit.AddModeRoute(v2.ModeRouteView{
+		ModeView: v2.ModeView{Mode: "replay"},
+	})).ToNot(Succeed())
+
+	Expect(unit.AddModeRoute(v2.ModeRouteView{
+		Destination: "catalog(",
+		ModeView:    v2.ModeView{Mode: "capture"},
+	})).ToNot(Succeed())
+
+	err := unit.AddModeRoute(v2.ModeRouteView{
+		Destination: "catalog.com",
+		ModeView: v2.ModeView{
+			Mode:      "capture",
+			Arguments: v2.ModeArgumentsView{CapturePolicy: "everything"},
+		},
+	})
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring(`Route for destination "catalog.com" and path "" is not valid`))
+
+	Expect(unit.AddModeRoute(v2.ModeRouteView{
+		Destination: "catalog.com",
+		ModeView: v2.ModeView{
+			Mode:      "spy",
+			Arguments: v2.ModeArgumentsView{MatchingStrategy: util.StringToPointer("weakest")},
+		},
+	})).ToNot(Succeed())
+
+	Expect(unit.GetModeRoutes().Routes).To(BeEmpty())
+}
+
+func Test_Hoverfly_AddModeRoute_MatchesWithTheMatchingStrategyOfTheRoute(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.Simulation.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Path: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Glob,
+					Value:   "*",
+				},
+			},
+		},
+		Response: models.ResponseDetails{
+			Status: 200,
+			Body:   "any path",
+		},
+	})
+	unit.Simulation.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Path: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "/items",
+				},
+			},
+		},
+		Response: models.ResponseDetails{
+			Status: 200,
+			Body:   "items",
+		},
+	})
+
+	Expect(unit.SetModeWithArguments(v2.ModeView{
+		Mode:      "simulate",
+		Arguments: v2.ModeArgumentsView{MatchingStrategy: util.StringToPointer("first")},
+	})).To(Succeed())
+	Expect(unit.AddModeRoute(v2.ModeRouteView{
+		Destination: `^catalog\.com$`,
+		ModeView: v2.ModeView{
+			Mode:      "simulate",
+			Arguments: v2.ModeArgumentsView{MatchingStrategy: util.StringToPointer("strongest")},
+		},
+	})).To(Succeed())
+
+	routed, err := http.NewRequest("GET", "http://catalog.com/items", nil)
+	Expect(err).To(BeNil())
+
+	response, mode := unit.processRequestWithMode(routed)
+	Expect(mode).To(Equal("simulate"))
+	Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("items")))
+
+	unrouted, err := http.NewRequest("GET", "http://payments.com/items", nil)
+	Expect(err).To(BeNil())
+
+	response, mode = unit.processRequestWithMode(unrouted)
+	Expect(mode).To(Equal("simulate"))
+	Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("any path")))
+
+	Expect(*unit.GetModeRoutes().Routes[0].Arguments.MatchingStrategy).To(Equal("strongest"))
+}
+
+func Test_Hoverfly_SetModeRoutes_ReplacesRoutesOnlyWhenAllAreValid(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	Expect(unit.SetModeRoutes(v2.ModeRoutesView{Routes: []v2.ModeRouteView{
+		{Destination: "payments.com", ModeView: v2.ModeView{Mode: "simulate"}},
+		{Destination: "catalog.com", ModeView: v2.ModeView{Mode: "capture"}},
+	}})).To(Succeed())
+
+	Expect(unit.SetModeRoutes(v2.ModeRoutesView{Routes: []v2.ModeRouteView{
+		{Destination: "orders.com", ModeView: v2.ModeView{Mode: "spy"}},
+		{Destination: "catalog.com", ModeView: v2.ModeView{Mode: "replay"}},
+	}})).ToNot(Succeed())
+
+	routes := unit.GetModeRoutes().Routes
+	Expect(routes).To(HaveLen(2))
+	Expect(routes[0].Destination).To(Equal("payments.com"))
+	Expect(routes[1].Destination).To(Equal("catalog.com"))
+
+	unit.DeleteModeRoutes()
+
+	Expect(unit.GetModeRoutes().Routes).To(BeEmpty())
+}
+
+func Test_Hoverfly_SetModeRoutes_StopsTheWorkersOfReplacedShadowRoutes(t *testing.T) {
+	RegisterTestingT(t)
+
+	server, unit := testTools(200, "real")
+	defer server.Close()
+
+	unit.Save(&models.RequestDetails{
+		Method:      "GET",
+		Scheme:      "http",
+		Destination: "payments.com",
+	}, &models.ResponseDetails{
+		Status: 200,
+		Body:   "simulated",
+	}, &modes.ModeArguments{})
+
+	Expect(unit.AddModeRoute(v2.ModeRouteView{
+		Destination: "payments.com",
+		ModeView:    v2.ModeView{Mode: "shadow"},
+	})).To(Succeed())
+
+	request, err := http.NewRequest("GET", "http://payments.com", nil)
+	Expect(err).To(BeNil())
+
+	_, mode := unit.processRequestWithMode(request)
+	Expect(mode).To(Equal("shadow"))
+
+	Eventually(func() int {
+		return unit.GetModeRoutes().Routes[0].Shadowed.Compared + unit.GetModeRoutes().Routes[0].Shadowed.Failed
+	}).Should(Equal(1))
+
+	unit.HTTP.Transport.(*http.Transport).CloseIdleConnections()
+	goroutines := runtime.NumGoroutine()
+
+	Expect(unit.SetModeRoutes(v2.ModeRoutesView{Routes: []v2.ModeRouteView{
+		{Destination: "payments.com", ModeView: v2.ModeView{Mode: "simulate"}},
+	}})).To(Succeed())
+
+	Eventually(runtime.NumGoroutine).Should(BeNumerically("<=", goroutines-modes.DefaultShadowWorkers))
+}
+
+func Test_Hoverfly_ModeRoutes_JournalRecordsTheEffectiveMode(t *testing.T) {
+	RegisterTestingT(t)
+
+	server, unit := testTools(201, `{'message': 'real'}`)
+	defer server.Close()
+
+	unit.Cfg.ProxyPort = "6667"
+
+	Expect(unit.SetMode("capture")).To(Succeed())
+	Expect(unit.AddModeRoute(v2.ModeRouteView{
+		Destination: "payments.com",
+		ModeView:    v2.ModeView{Mode: "simulate"},
+	})).To(Succeed())
+
+	Expect(unit.StartProxy()).To(Succeed())
+	defer unit.StopProxy()
+
+	proxyUrl, err := url.Parse("http://localhost:6667")
+	Expect(err).To(BeNil())
+
+	client := &http.Client{
+		Transport: &http.Transport{Proxy: http.ProxyURL(proxyUrl)},
+		Timeout:   5 * time.Second,
+	}
+	response, err := client.Get("http://payments.com/")
+	Expect(err).To(BeNil())
+	response.Body.Close()
+
+	Expect(response.StatusCode).To(Equal(http.StatusBadGateway))
+
+	journalView, err := unit.Journal.GetEntries(0, 25, nil, nil, "")
+	Expect(err).To(BeNil())
+	Expect(journalView.Journal).To(HaveLen(1))
+	Expect(journalView.Journal[0].Mode).To(Equal("simulate"))
+	Expect(unit.Counter.Counters["simulate"].Count()).To(Equal(int64(1)))
+	Expect(unit.Counter.Counters["capture"].Count()).To(Equal(int64(0)))
+}
@@ -10,7 +10,7 @@ import (
 
 type ClosestMiss struct {
 	RequestDetails RequestDetails
-	Response       v2.ResponseDetailsViewV5
+	Response       v2.ResponseDetailsViewV6
 	RequestMatcher v2.RequestMatcherViewV5
 	MissedFields   []string
 	State          map[string]string
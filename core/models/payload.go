@@ -180,12 +180,37 @@ func (r *RequestDetails) HashWithoutHost() string {
 // to be bytes, however headers should provide all required information for later decoding
 // by the client.
 type ResponseDetails struct {
-	Status           int
-	Body             string
-	Headers          map[string][]string
-	Templated        bool
-	TransitionsState map[string]string
-	RemovesState     []string
+	Status               int
+	Body                 string
+	Headers              map[string][]string
+	Templated            bool
+	TransitionsState     map[string]string
+	ScheduledTransitions map[string]StateTransition
+	RemovesState         []string
+	PairId               string
+}
+
+// StateTransition is a change of state applied Delay milliseconds after
+// the response is served. An empty Value removes the state key instead.
+type StateTransition struct {
+	Value string
+	Delay int
+}
+
+func NewScheduledTransitionsFromResponse(data interfaces.Response) map[string]StateTransition {
+	if data.GetScheduledTransitions() == nil {
+		return nil
+	}
+
+	transitions := map[string]StateTransition{}
+	for key, transition := range data.GetScheduledTransitions() {
+		transitions[key] = StateTransition{
+			Value: transition.GetValue(),
+			Delay: transition.GetDelay(),
+		}
+	}
+
+	return transitions
 }
 
 func NewResponseDetailsFromResponse(data interfaces.Response) ResponseDetails {
@@ -197,12 +222,13 @@ func NewResponseDetailsFromResponse(data interfaces.Response) ResponseDetails {
 	}
 
 	return ResponseDetails{
-		Status:           data.GetStatus(),
-		Body:             body,
-		Headers:          data.GetHeaders(),
-		Templated:        data.GetTemplated(),
-		TransitionsState: data.GetTransitionsState(),
-		RemovesState:     data.GetRemovesState(),
+		Status:               data.GetStatus(),
+		Body:                 body,
+		Headers:              data.GetHeaders(),
+		Templated:            data.GetTemplated(),
+		TransitionsState:     data.GetTransitionsState(),
+		ScheduledTransitions: NewScheduledTransitionsFromResponse(data),
+		RemovesState:         data.GetRemovesState(),
 	}
 }
 
@@ -241,7 +267,7 @@ func (r *ResponseDetails) ConvertToResponseDetailsView() v2.ResponseDetailsView
 	}
 }
 
-func (r *ResponseDetails) ConvertToResponseDetailsViewV5() v2.ResponseDetailsViewV5 {
+func (r *ResponseDetails) ConvertToResponseDetailsViewV6() v2.ResponseDetailsViewV6 {
 	needsEncoding := false
 
 	// Check headers for gzip
@@ -265,14 +291,26 @@ func (r *ResponseDetails) ConvertToResponseDetailsViewV5() v2.ResponseDetailsVie
 		body = base64.StdEncoding.EncodeToString([]byte(r.Body))
 	}
 
-	return v2.ResponseDetailsViewV5{
-		Status:           r.Status,
-		Body:             body,
-		Headers:          r.Headers,
-		EncodedBody:      needsEncoding,
-		Templated:        r.Templated,
-		RemovesState:     r.RemovesState,
-		TransitionsState: r.TransitionsState,
+	var scheduledTransitions map[string]v2.StateTransitionView
+	if r.ScheduledTransitions != nil {
+		scheduledTransitions = map[string]v2.StateTransitionView{}
+		for key, transition := range r.ScheduledTransitions {
+			scheduledTransitions[key] = v2.StateTransitionView{
+				Value: transition.Value,
+				Delay: transition.Delay,
+			}
+		}
+	}
+
+	return v2.ResponseDetailsViewV6{
+		Status:               r.Status,
+		Body:                 body,
+		Headers:              r.Headers,
+		EncodedBody:          needsEncoding,
+		Templated:            r.Templated,
+		RemovesState:         r.RemovesState,
+		TransitionsState:     r.TransitionsState,
+		ScheduledTransitions: scheduledTransitions,
 	}
 }
 
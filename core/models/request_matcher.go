@@ -61,11 +61,14 @@ func (this RequestFieldMatchers) BuildView() v2.MatcherViewV5 {
 }
 
 type RequestMatcherResponsePair struct {
+	Id             string
+	Labels         []string
+	Description    string
 	RequestMatcher RequestMatcher
 	Response       ResponseDetails
 }
 
-func NewRequestMatcherResponsePairFromView(view *v2.RequestMatcherResponsePairViewV5) *RequestMatcherResponsePair {
+func NewRequestMatcherResponsePairFromView(view *v2.RequestMatcherResponsePairViewV6) *RequestMatcherResponsePair {
 	for i, matcher := range view.RequestMatcher.DeprecatedQuery {
 		if matcher.Matcher == matchers.Exact {
 			sortedQuery := util.SortQueryString(matcher.Value.(string))
@@ -74,6 +77,9 @@ func NewRequestMatcherResponsePairFromView(view *v2.RequestMatcherResponsePairVi
 	}
 
 	return &RequestMatcherResponsePair{
+		Id:          view.Id,
+		Labels:      view.Labels,
+		Description: view.Description,
 		RequestMatcher: RequestMatcher{
 			Path:            NewRequestFieldMatchersFromView(view.RequestMatcher.Path),
 			Method:          NewRequestFieldMatchersFromView(view.RequestMatcher.Method),
@@ -89,7 +95,7 @@ func NewRequestMatcherResponsePairFromView(view *v2.RequestMatcherResponsePairVi
 	}
 }
 
-func (this *RequestMatcherResponsePair) BuildView() v2.RequestMatcherResponsePairViewV5 {
+func (this *RequestMatcherResponsePair) BuildView() v2.RequestMatcherResponsePairViewV6 {
 
 	var path, method, destination, scheme, query, body []v2.MatcherViewV5
 
@@ -162,7 +168,10 @@ func (this *RequestMatcherResponsePair) BuildView() v2.RequestMatcherResponsePai
 		}
 	}
 
-	return v2.RequestMatcherResponsePairViewV5{
+	return v2.RequestMatcherResponsePairViewV6{
+		Id:          this.Id,
+		Labels:      this.Labels,
+		Description: this.Description,
 		RequestMatcher: v2.RequestMatcherViewV5{
 			Path:            path,
 			Method:          method,
@@ -174,7 +183,7 @@ func (this *RequestMatcherResponsePair) BuildView() v2.RequestMatcherResponsePai
 			Query:           queriesWithMatchers,
 			RequiresState:   this.RequestMatcher.RequiresState,
 		},
-		Response: this.Response.ConvertToResponseDetailsViewV5(),
+		Response: this.Response.ConvertToResponseDetailsViewV6(),
 	}
 }
 
@@ -49,7 +49,7 @@ func Test_NewRequestFieldMatchers_BuildView(t *testing.T) {
 func Test_NewRequestMatcherResponsePairFromView_BuildsPair(t *testing.T) {
 	RegisterTestingT(t)
 
-	unit := models.NewRequestMatcherResponsePairFromView(&v2.RequestMatcherResponsePairViewV5{
+	unit := models.NewRequestMatcherResponsePairFromView(&v2.RequestMatcherResponsePairViewV6{
 		RequestMatcher: v2.RequestMatcherViewV5{
 			Path: []v2.MatcherViewV5{
 				{
@@ -74,7 +74,7 @@ func Test_NewRequestMatcherResponsePairFromView_BuildsPair(t *testing.T) {
 				},
 			},
 		},
-		Response: v2.ResponseDetailsViewV5{
+		Response: v2.ResponseDetailsViewV6{
 			Body: "body",
 		},
 	})
@@ -94,7 +94,7 @@ func Test_NewRequestMatcherResponsePairFromView_BuildsPair(t *testing.T) {
 func Test_NewRequestMatcherResponsePairFromView_LeavesHeadersWithMatchersNil(t *testing.T) {
 	RegisterTestingT(t)
 
-	unit := models.NewRequestMatcherResponsePairFromView(&v2.RequestMatcherResponsePairViewV5{
+	unit := models.NewRequestMatcherResponsePairFromView(&v2.RequestMatcherResponsePairViewV6{
 		RequestMatcher: v2.RequestMatcherViewV5{
 			Path: []v2.MatcherViewV5{
 				{
@@ -103,7 +103,7 @@ func Test_NewRequestMatcherResponsePairFromView_LeavesHeadersWithMatchersNil(t *
 				},
 			},
 		},
-		Response: v2.ResponseDetailsViewV5{},
+		Response: v2.ResponseDetailsViewV6{},
 	})
 
 	Expect(unit.RequestMatcher.Headers).To(BeNil())
@@ -112,7 +112,7 @@ func Test_NewRequestMatcherResponsePairFromView_LeavesHeadersWithMatchersNil(t *
 func Test_NewRequestMatcherResponsePairFromView_LeavesQueriesWithMatchersNil(t *testing.T) {
 	RegisterTestingT(t)
 
-	unit := models.NewRequestMatcherResponsePairFromView(&v2.RequestMatcherResponsePairViewV5{
+	unit := models.NewRequestMatcherResponsePairFromView(&v2.RequestMatcherResponsePairViewV6{
 		RequestMatcher: v2.RequestMatcherViewV5{
 			Path: []v2.MatcherViewV5{
 				{
@@ -121,7 +121,7 @@ func Test_NewRequestMatcherResponsePairFromView_LeavesQueriesWithMatchersNil(t *
 				},
 			},
 		},
-		Response: v2.ResponseDetailsViewV5{},
+		Response: v2.ResponseDetailsViewV6{},
 	})
 
 	Expect(unit.RequestMatcher.Query).To(BeNil())
@@ -130,7 +130,7 @@ func Test_NewRequestMatcherResponsePairFromView_LeavesQueriesWithMatchersNil(t *
 func Test_NewRequestMatcherResponsePairFromView_SortsDeprecatedQuery(t *testing.T) {
 	RegisterTestingT(t)
 
-	unit := models.NewRequestMatcherResponsePairFromView(&v2.RequestMatcherResponsePairViewV5{
+	unit := models.NewRequestMatcherResponsePairFromView(&v2.RequestMatcherResponsePairViewV6{
 		RequestMatcher: v2.RequestMatcherViewV5{
 			DeprecatedQuery: []v2.MatcherViewV5{
 				{
@@ -139,7 +139,7 @@ func Test_NewRequestMatcherResponsePairFromView_SortsDeprecatedQuery(t *testing.
 				},
 			},
 		},
-		Response: v2.ResponseDetailsViewV5{
+		Response: v2.ResponseDetailsViewV6{
 			Body: "body",
 		},
 	})
@@ -150,7 +150,7 @@ func Test_NewRequestMatcherResponsePairFromView_SortsDeprecatedQuery(t *testing.
 func Test_NewRequestMatcherResponsePairFromView_StoresTemplated(t *testing.T) {
 	RegisterTestingT(t)
 
-	unit := models.NewRequestMatcherResponsePairFromView(&v2.RequestMatcherResponsePairViewV5{
+	unit := models.NewRequestMatcherResponsePairFromView(&v2.RequestMatcherResponsePairViewV6{
 		RequestMatcher: v2.RequestMatcherViewV5{
 			Path: []v2.MatcherViewV5{
 				{
@@ -159,7 +159,7 @@ func Test_NewRequestMatcherResponsePairFromView_StoresTemplated(t *testing.T) {
 				},
 			},
 		},
-		Response: v2.ResponseDetailsViewV5{
+		Response: v2.ResponseDetailsViewV6{
 			Body:      "body",
 			Templated: true,
 		},
@@ -168,6 +168,29 @@ func Test_NewRequestMatcherResponsePairFromView_StoresTemplated(t *testing.T) {
 	Expect(unit.Response.Templated).To(BeTrue())
 }
 
+func Test_NewRequestMatcherResponsePairFromView_StoresIdLabelsAndDescription(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewRequestMatcherResponsePairFromView(&v2.RequestMatcherResponsePairViewV6{
+		Id:          "get-payment",
+		Labels:      []string{"payments"},
+		Description: "Returns a settled payment",
+		Response: v2.ResponseDetailsViewV6{
+			Body: "body",
+		},
+	})
+
+	Expect(unit.Id).To(Equal("get-payment"))
+	Expect(unit.Labels).To(Equal([]string{"payments"}))
+	Expect(unit.Description).To(Equal("Returns a settled payment"))
+
+	view := unit.BuildView()
+
+	Expect(view.Id).To(Equal("get-payment"))
+	Expect(view.Labels).To(Equal([]string{"payments"}))
+	Expect(view.Description).To(Equal("Returns a settled payment"))
+}
+
 func Test_RequestMatcher_BuildRequestDetailsFromExactMatches_GeneratesARequestDetails(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -4,13 +4,17 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/core/state"
+	"github.com/pborman/uuid"
 )
 
 type Simulation struct {
 	matchingPairs  []RequestMatcherResponsePair
 	ResponseDelays ResponseDelays
+	mutex          sync.RWMutex
 }
 
 func NewSimulation() *Simulation {
@@ -22,6 +26,9 @@ func NewSimulation() *Simulation {
 }
 
 func (this *Simulation) AddPair(pair *RequestMatcherResponsePair) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+
 	var duplicate bool
 	for _, savedPair := range this.matchingPairs {
 		duplicate = reflect.DeepEqual(pair.RequestMatcher, savedPair.RequestMatcher)
@@ -30,11 +37,67 @@ func (this *Simulation) AddPair(pair *RequestMatcherResponsePair) {
 		}
 	}
 	if !duplicate {
+		if pair.Id == "" {
+			pair.Id = uuid.New()
+		}
 		this.matchingPairs = append(this.matchingPairs, *pair)
 	}
 }
 
+// ReplacePair replaces the response of the pair with the same request matcher, keeping
+// its id, labels and description, or adds the pair if there is none
+func (this *Simulation) ReplacePair(pair *RequestMatcherResponsePair) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+
+	for i, savedPair := range this.matchingPairs {
+		if reflect.DeepEqual(pair.RequestMatcher, savedPair.RequestMatcher) {
+			savedPair.Response = pair.Response
+			this.matchingPairs[i] = savedPair
+			return
+		}
+	}
+
+	if pair.Id == "" {
+		pair.Id = uuid.New()
+	}
+	this.matchingPairs = append(this.matchingPairs, *pair)
+}
+
 func (this *Simulation) AddPairInSequence(pair *RequestMatcherResponsePair, state *state.State) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+
+	this.addPairInSequence(pair, state)
+}
+
+// AddPairInSequenceIfDifferent adds the pair in sequence unless the last pair with the same
+// request matcher has a response with the same status and body
+func (this *Simulation) AddPairInSequenceIfDifferent(pair *RequestMatcherResponsePair, state *state.State) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+
+	pairNoState := pair.RequestMatcher
+	pairNoState.RequiresState = nil
+
+	for i := len(this.matchingPairs) - 1; i >= 0; i-- {
+		savedPair := this.matchingPairs[i]
+
+		savedPairNoState := savedPair.RequestMatcher
+		savedPairNoState.RequiresState = nil
+
+		if reflect.DeepEqual(pairNoState, savedPairNoState) {
+			if savedPair.Response.Status == pair.Response.Status && savedPair.Response.Body == pair.Response.Body {
+				return
+			}
+			break
+		}
+	}
+
+	this.addPairInSequence(pair, state)
+}
+
+func (this *Simulation) addPairInSequence(pair *RequestMatcherResponsePair, state *state.State) {
 	var duplicate bool
 
 	updates := map[int]RequestMatcherResponsePair{}
@@ -54,13 +117,10 @@ func (this *Simulation) AddPairInSequence(pair *RequestMatcherResponsePair, stat
 		if duplicate {
 			counter = counter + 1
 
-			if savedPair.RequestMatcher.RequiresState == nil {
-				savedPair.RequestMatcher.RequiresState = map[string]string{}
-			}
-
-			if savedPair.Response.TransitionsState == nil {
-				savedPair.Response.TransitionsState = map[string]string{}
-			}
+			// Copy the state maps rather than updating them in place, as
+			// the saved pair may be shared with a snapshot being matched on
+			savedPair.RequestMatcher.RequiresState = copyStateMap(savedPair.RequestMatcher.RequiresState)
+			savedPair.Response.TransitionsState = copyStateMap(savedPair.Response.TransitionsState)
 
 			if pair.RequestMatcher.RequiresState == nil {
 				pair.RequestMatcher.RequiresState = map[string]string{}
@@ -98,14 +158,115 @@ func (this *Simulation) AddPairInSequence(pair *RequestMatcherResponsePair, stat
 		pair.RequestMatcher.RequiresState[sequenceKey] = strconv.Itoa(counter + 1)
 	}
 
+	if pair.Id == "" {
+		pair.Id = uuid.New()
+	}
 	this.matchingPairs = append(this.matchingPairs, *pair)
 }
 
+// GetMatchingPairs returns a snapshot of the pairs in the simulation. Pairs
+// are never modified in place, so the snapshot can be safely iterated over
+// while other requests add to the simulation
 func (this *Simulation) GetMatchingPairs() []RequestMatcherResponsePair {
-	return this.matchingPairs
+	this.mutex.RLock()
+	defer this.mutex.RUnlock()
+
+	pairs := make([]RequestMatcherResponsePair, len(this.matchingPairs))
+	copy(pairs, this.matchingPairs)
+
+	return pairs
+}
+
+func (this *Simulation) GetPair(id string) (RequestMatcherResponsePair, bool) {
+	this.mutex.RLock()
+	defer this.mutex.RUnlock()
+
+	for _, pair := range this.matchingPairs {
+		if pair.Id == id {
+			return pair, true
+		}
+	}
+
+	return RequestMatcherResponsePair{}, false
+}
+
+// UpdatePair replaces the pair with the given id, keeping both its id and its
+// position so the order in which pairs are matched does not change
+func (this *Simulation) UpdatePair(id string, pair RequestMatcherResponsePair) error {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+
+	index := -1
+	for i, savedPair := range this.matchingPairs {
+		if savedPair.Id == id {
+			index = i
+		} else if reflect.DeepEqual(pair.RequestMatcher, savedPair.RequestMatcher) {
+			return v2.DuplicatePairError{Id: savedPair.Id}
+		}
+	}
+
+	if index == -1 {
+		return v2.PairNotFoundError{Id: id}
+	}
+
+	pair.Id = id
+	this.matchingPairs[index] = pair
+
+	return nil
+}
+
+func (this *Simulation) DeletePair(id string) bool {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+
+	for i, savedPair := range this.matchingPairs {
+		if savedPair.Id == id {
+			this.matchingPairs = append(this.matchingPairs[:i], this.matchingPairs[i+1:]...)
+			return true
+		}
+	}
+
+	return false
 }
 
 func (this *Simulation) DeleteMatchingPairs() {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+
 	var pairs []RequestMatcherResponsePair
 	this.matchingPairs = pairs
 }
+
+// ReplaceWith swaps the pairs and response delays for those of another
+// simulation at once, so a request is never matched against a mix of the two
+func (this *Simulation) ReplaceWith(other *Simulation) {
+	pairs := other.GetMatchingPairs()
+	delays := other.GetResponseDelays()
+
+	this.mutex.Lock()
+	this.matchingPairs = pairs
+	this.ResponseDelays = delays
+	this.mutex.Unlock()
+}
+
+func (this *Simulation) GetResponseDelays() ResponseDelays {
+	this.mutex.RLock()
+	defer this.mutex.RUnlock()
+
+	return this.ResponseDelays
+}
+
+func (this *Simulation) SetResponseDelays(delays ResponseDelays) {
+	this.mutex.Lock()
+	this.ResponseDelays = delays
+	this.mutex.Unlock()
+}
+
+func copyStateMap(stateMap map[string]string) map[string]string {
+	copied := make(map[string]string, len(stateMap))
+	for k, v := range stateMap {
+		copied[k] = v
+	}
+
+	return copied
+}
@@ -1,6 +1,8 @@
 package models_test
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
@@ -15,7 +17,7 @@ func Test_Simulation_AddPair_CanAddAPairToTheArray(t *testing.T) {
 	unit := models.NewSimulation()
 
 	unit.AddPair(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -23,7 +25,7 @@ func Test_Simulation_AddPair_CanAddAPairToTheArray(t *testing.T) {
 				},
 			},
 		},
-		models.ResponseDetails{},
+		Response: models.ResponseDetails{},
 	})
 
 	Expect(unit.GetMatchingPairs()).To(HaveLen(1))
@@ -37,7 +39,7 @@ func Test_Simulation_AddPair_CanAddAFullPairToTheArray(t *testing.T) {
 	unit := models.NewSimulation()
 
 	unit.AddPair(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Body: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -81,7 +83,7 @@ func Test_Simulation_AddPair_CanAddAFullPairToTheArray(t *testing.T) {
 				},
 			},
 		},
-		models.ResponseDetails{
+		Response: models.ResponseDetails{
 			Body:    "testresponsebody",
 			Headers: map[string][]string{"testheader": []string{"testvalue"}},
 			Status:  200,
@@ -120,7 +122,7 @@ func Test_Simulation_AddPairInSequence_CanAddAFullPairToTheArray(t *testing.T) {
 	unit := models.NewSimulation()
 
 	unit.AddPairInSequence(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Body: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -164,7 +166,7 @@ func Test_Simulation_AddPairInSequence_CanAddAFullPairToTheArray(t *testing.T) {
 				},
 			},
 		},
-		models.ResponseDetails{
+		Response: models.ResponseDetails{
 			Body:    "testresponsebody",
 			Headers: map[string][]string{"testheader": []string{"testvalue"}},
 			Status:  200,
@@ -203,7 +205,7 @@ func Test_Simulation_AddPairInSequence_CanSequence(t *testing.T) {
 	unit := models.NewSimulation()
 
 	unit.AddPairInSequence(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -211,7 +213,7 @@ func Test_Simulation_AddPairInSequence_CanSequence(t *testing.T) {
 				},
 			},
 		},
-		models.ResponseDetails{
+		Response: models.ResponseDetails{
 			Body:    "1",
 			Headers: map[string][]string{"testheader": []string{"testvalue"}},
 			Status:  200,
@@ -219,7 +221,7 @@ func Test_Simulation_AddPairInSequence_CanSequence(t *testing.T) {
 	}, &state.State{State: map[string]string{}})
 
 	unit.AddPairInSequence(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -227,7 +229,7 @@ func Test_Simulation_AddPairInSequence_CanSequence(t *testing.T) {
 				},
 			},
 		},
-		models.ResponseDetails{
+		Response: models.ResponseDetails{
 			Body:    "2",
 			Headers: map[string][]string{"testheader": []string{"testvalue"}},
 			Status:  200,
@@ -235,7 +237,7 @@ func Test_Simulation_AddPairInSequence_CanSequence(t *testing.T) {
 	}, &state.State{State: map[string]string{}})
 
 	unit.AddPairInSequence(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -243,7 +245,7 @@ func Test_Simulation_AddPairInSequence_CanSequence(t *testing.T) {
 				},
 			},
 		},
-		models.ResponseDetails{
+		Response: models.ResponseDetails{
 			Body:    "3",
 			Headers: map[string][]string{"testheader": []string{"testvalue"}},
 			Status:  200,
@@ -280,7 +282,7 @@ func Test_Simulation_AddPairInSequence_CanBeUsedWithAddPair(t *testing.T) {
 	unit := models.NewSimulation()
 
 	unit.AddPair(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -288,7 +290,7 @@ func Test_Simulation_AddPairInSequence_CanBeUsedWithAddPair(t *testing.T) {
 				},
 			},
 		},
-		models.ResponseDetails{
+		Response: models.ResponseDetails{
 			Body:    "1",
 			Headers: map[string][]string{"testheader": []string{"testvalue"}},
 			Status:  200,
@@ -296,7 +298,7 @@ func Test_Simulation_AddPairInSequence_CanBeUsedWithAddPair(t *testing.T) {
 	})
 
 	unit.AddPairInSequence(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -304,7 +306,7 @@ func Test_Simulation_AddPairInSequence_CanBeUsedWithAddPair(t *testing.T) {
 				},
 			},
 		},
-		models.ResponseDetails{
+		Response: models.ResponseDetails{
 			Body:    "2",
 			Headers: map[string][]string{"testheader": []string{"testvalue"}},
 			Status:  200,
@@ -335,7 +337,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceTwoDifferentSequences(t *testi
 	state := state.NewState()
 
 	unit.AddPairInSequence(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -343,7 +345,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceTwoDifferentSequences(t *testi
 				},
 			},
 		},
-		models.ResponseDetails{
+		Response: models.ResponseDetails{
 			Body:    "1",
 			Headers: map[string][]string{"testheader": []string{"testvalue"}},
 			Status:  200,
@@ -351,7 +353,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceTwoDifferentSequences(t *testi
 	}, state)
 
 	unit.AddPairInSequence(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -359,7 +361,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceTwoDifferentSequences(t *testi
 				},
 			},
 		},
-		models.ResponseDetails{
+		Response: models.ResponseDetails{
 			Body:    "2",
 			Headers: map[string][]string{"testheader": []string{"testvalue"}},
 			Status:  200,
@@ -367,7 +369,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceTwoDifferentSequences(t *testi
 	}, state)
 
 	unit.AddPairInSequence(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -375,7 +377,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceTwoDifferentSequences(t *testi
 				},
 			},
 		},
-		models.ResponseDetails{
+		Response: models.ResponseDetails{
 			Body:    "different1",
 			Headers: map[string][]string{"testheader": []string{"testvalue"}},
 			Status:  200,
@@ -383,7 +385,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceTwoDifferentSequences(t *testi
 	}, state)
 
 	unit.AddPairInSequence(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -391,7 +393,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceTwoDifferentSequences(t *testi
 				},
 			},
 		},
-		models.ResponseDetails{
+		Response: models.ResponseDetails{
 			Body:    "different2",
 			Headers: map[string][]string{"testheader": []string{"testvalue"}},
 			Status:  200,
@@ -435,7 +437,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceThreeDifferentSequences(t *tes
 	state := state.NewState()
 
 	unit.AddPairInSequence(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -443,7 +445,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceThreeDifferentSequences(t *tes
 				},
 			},
 		},
-		models.ResponseDetails{
+		Response: models.ResponseDetails{
 			Body:    "1",
 			Headers: map[string][]string{"testheader": []string{"testvalue"}},
 			Status:  200,
@@ -451,7 +453,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceThreeDifferentSequences(t *tes
 	}, state)
 
 	unit.AddPairInSequence(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -459,7 +461,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceThreeDifferentSequences(t *tes
 				},
 			},
 		},
-		models.ResponseDetails{
+		Response: models.ResponseDetails{
 			Body:    "2",
 			Headers: map[string][]string{"testheader": []string{"testvalue"}},
 			Status:  200,
@@ -467,7 +469,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceThreeDifferentSequences(t *tes
 	}, state)
 
 	unit.AddPairInSequence(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -475,7 +477,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceThreeDifferentSequences(t *tes
 				},
 			},
 		},
-		models.ResponseDetails{
+		Response: models.ResponseDetails{
 			Body:    "different1",
 			Headers: map[string][]string{"testheader": []string{"testvalue"}},
 			Status:  200,
@@ -483,7 +485,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceThreeDifferentSequences(t *tes
 	}, state)
 
 	unit.AddPairInSequence(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -491,7 +493,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceThreeDifferentSequences(t *tes
 				},
 			},
 		},
-		models.ResponseDetails{
+		Response: models.ResponseDetails{
 			Body:    "different2",
 			Headers: map[string][]string{"testheader": []string{"testvalue"}},
 			Status:  200,
@@ -499,7 +501,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceThreeDifferentSequences(t *tes
 	}, state)
 
 	unit.AddPairInSequence(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -507,7 +509,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceThreeDifferentSequences(t *tes
 				},
 			},
 		},
-		models.ResponseDetails{
+		Response: models.ResponseDetails{
 			Body:    "third1",
 			Headers: map[string][]string{"testheader": []string{"testvalue"}},
 			Status:  200,
@@ -515,7 +517,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceThreeDifferentSequences(t *tes
 	}, state)
 
 	unit.AddPairInSequence(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -523,7 +525,7 @@ func Test_Simulation_AddPairInSequence_CanSequenceThreeDifferentSequences(t *tes
 				},
 			},
 		},
-		models.ResponseDetails{
+		Response: models.ResponseDetails{
 			Body:    "third2",
 			Headers: map[string][]string{"testheader": []string{"testvalue"}},
 			Status:  200,
@@ -578,7 +580,7 @@ func Test_Simulation_AddPair_WillNotSaveDuplicates(t *testing.T) {
 	unit := models.NewSimulation()
 
 	unit.AddPair(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -586,11 +588,11 @@ func Test_Simulation_AddPair_WillNotSaveDuplicates(t *testing.T) {
 				},
 			},
 		},
-		models.ResponseDetails{},
+		Response: models.ResponseDetails{},
 	})
 
 	unit.AddPair(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -598,7 +600,7 @@ func Test_Simulation_AddPair_WillNotSaveDuplicates(t *testing.T) {
 				},
 			},
 		},
-		models.ResponseDetails{},
+		Response: models.ResponseDetails{},
 	})
 
 	Expect(unit.GetMatchingPairs()).To(HaveLen(1))
@@ -610,7 +612,7 @@ func Test_Simulation_AddPair_WillSaveTwoWhenNotDuplicates(t *testing.T) {
 	unit := models.NewSimulation()
 
 	unit.AddPair(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -618,11 +620,11 @@ func Test_Simulation_AddPair_WillSaveTwoWhenNotDuplicates(t *testing.T) {
 				},
 			},
 		},
-		models.ResponseDetails{},
+		Response: models.ResponseDetails{},
 	})
 
 	unit.AddPair(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -630,7 +632,7 @@ func Test_Simulation_AddPair_WillSaveTwoWhenNotDuplicates(t *testing.T) {
 				},
 			},
 		},
-		models.ResponseDetails{},
+		Response: models.ResponseDetails{},
 	})
 
 	Expect(unit.GetMatchingPairs()).To(HaveLen(2))
@@ -644,7 +646,7 @@ func Test_Simulation_GetMatchingPairs(t *testing.T) {
 	unit := models.NewSimulation()
 
 	unit.AddPair(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -652,7 +654,7 @@ func Test_Simulation_GetMatchingPairs(t *testing.T) {
 				},
 			},
 		},
-		models.ResponseDetails{},
+		Response: models.ResponseDetails{},
 	})
 
 	Expect(unit.GetMatchingPairs()).To(HaveLen(1))
@@ -665,7 +667,7 @@ func Test_Simulation_DeleteMatchingPairs(t *testing.T) {
 	unit := models.NewSimulation()
 
 	unit.AddPair(&models.RequestMatcherResponsePair{
-		models.RequestMatcher{
+		RequestMatcher: models.RequestMatcher{
 			Destination: []models.RequestFieldMatchers{
 				{
 					Matcher: matchers.Exact,
@@ -673,10 +675,374 @@ func Test_Simulation_DeleteMatchingPairs(t *testing.T) {
 				},
 			},
 		},
-		models.ResponseDetails{},
+		Response: models.ResponseDetails{},
 	})
 
 	unit.DeleteMatchingPairs()
 
 	Expect(unit.GetMatchingPairs()).To(HaveLen(0))
 }
+
+func Test_Simulation_ReplaceWith_SwapsPairsAndDelays(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewSimulation()
+	unit.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Destination: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "old",
+				},
+			},
+		},
+	})
+
+	replacement := models.NewSimulation()
+	replacement.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Destination: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "new",
+				},
+			},
+		},
+	})
+	replacement.SetResponseDelays(&models.ResponseDelayList{
+		{
+			UrlPattern: "new",
+			Delay:      100,
+		},
+	})
+
+	unit.ReplaceWith(replacement)
+
+	Expect(unit.GetMatchingPairs()).To(HaveLen(1))
+	Expect(unit.GetMatchingPairs()[0].RequestMatcher.Destination[0].Value).To(Equal("new"))
+	Expect(unit.GetResponseDelays()).To(Equal(&models.ResponseDelayList{
+		{
+			UrlPattern: "new",
+			Delay:      100,
+		},
+	}))
+}
+
+func Test_Simulation_CanAddPairsWhilePairsAreBeingRead(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewSimulation()
+	sequenceState := state.NewState()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(3)
+		go func(i int) {
+			defer wg.Done()
+			unit.AddPair(&models.RequestMatcherResponsePair{
+				RequestMatcher: models.RequestMatcher{
+					Path: []models.RequestFieldMatchers{
+						{
+							Matcher: matchers.Exact,
+							Value:   fmt.Sprintf("/%v", i),
+						},
+					},
+				},
+			})
+		}(i)
+		go func() {
+			defer wg.Done()
+			unit.AddPairInSequence(&models.RequestMatcherResponsePair{
+				RequestMatcher: models.RequestMatcher{
+					Path: []models.RequestFieldMatchers{
+						{
+							Matcher: matchers.Exact,
+							Value:   "/sequence",
+						},
+					},
+				},
+			}, sequenceState)
+		}()
+		go func() {
+			defer wg.Done()
+			for _, pair := range unit.GetMatchingPairs() {
+				for range pair.RequestMatcher.RequiresState {
+				}
+			}
+			unit.GetResponseDelays()
+		}()
+	}
+	wg.Wait()
+
+	Expect(unit.GetMatchingPairs()).To(HaveLen(100))
+}
+
+func Test_Simulation_GetMatchingPairs_ReturnsSnapshot(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewSimulation()
+
+	unit.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Path: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "/one",
+				},
+			},
+		},
+	})
+
+	snapshot := unit.GetMatchingPairs()
+
+	unit.AddPair(&models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Path: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   "/two",
+				},
+			},
+		},
+	})
+
+	Expect(snapshot).To(HaveLen(1))
+	Expect(unit.GetMatchingPairs()).To(HaveLen(2))
+}
+
+func newDestinationPair(destination string) *models.RequestMatcherResponsePair {
+	return &models.RequestMatcherResponsePair{
+		RequestMatcher: models.RequestMatcher{
+			Destination: []models.RequestFieldMatchers{
+				{
+					Matcher: matchers.Exact,
+					Value:   destination,
+				},
+			},
+		},
+		Response: models.ResponseDetails{
+			Body: destination,
+		},
+	}
+}
+
+func Test_Simulation_AddPair_GivesEachPairAnId(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewSimulation()
+
+	unit.AddPair(newDestinationPair("one.com"))
+	unit.AddPair(newDestinationPair("two.com"))
+
+	pairs := unit.GetMatchingPairs()
+	Expect(pairs[0].Id).ToNot(BeEmpty())
+	Expect(pairs[1].Id).ToNot(BeEmpty())
+	Expect(pairs[0].Id).ToNot(Equal(pairs[1].Id))
+}
+
+func Test_Simulation_AddPair_KeepsAnExistingId(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewSimulation()
+
+	pair := newDestinationPair("one.com")
+	pair.Id = "my-id"
+	unit.AddPair(pair)
+
+	Expect(unit.GetMatchingPairs()[0].Id).To(Equal("my-id"))
+}
+
+func Test_Simulation_AddPairInSequence_GivesEachPairAnId(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewSimulation()
+	state := state.NewState()
+
+	unit.AddPairInSequence(newDestinationPair("one.com"), state)
+	unit.AddPairInSequence(newDestinationPair("one.com"), state)
+
+	pairs := unit.GetMatchingPairs()
+	Expect(pairs).To(HaveLen(2))
+	Expect(pairs[0].Id).ToNot(BeEmpty())
+	Expect(pairs[1].Id).ToNot(BeEmpty())
+	Expect(pairs[0].Id).ToNot(Equal(pairs[1].Id))
+}
+
+func Test_Simulation_ReplacePair_ReplacesResponseOfPairWithSameRequestMatcher(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewSimulation()
+
+	first := newDestinationPair("one.com")
+	first.Id = "first"
+	first.Labels = []string{"payments"}
+	unit.AddPair(first)
+	unit.AddPair(newDestinationPair("two.com"))
+
+	replacement := newDestinationPair("one.com")
+	replacement.Response.Body = "replaced"
+	unit.ReplacePair(replacement)
+
+	pairs := unit.GetMatchingPairs()
+	Expect(pairs).To(HaveLen(2))
+	Expect(pairs[0].Id).To(Equal("first"))
+	Expect(pairs[0].Labels).To(ConsistOf("payments"))
+	Expect(pairs[0].Response.Body).To(Equal("replaced"))
+	Expect(pairs[1].Response.Body).To(Equal("two.com"))
+}
+
+func Test_Simulation_ReplacePair_AddsPairWithNewRequestMatcher(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewSimulation()
+
+	unit.ReplacePair(newDestinationPair("one.com"))
+	unit.ReplacePair(newDestinationPair("two.com"))
+
+	pairs := unit.GetMatchingPairs()
+	Expect(pairs).To(HaveLen(2))
+	Expect(pairs[1].Id).ToNot(BeEmpty())
+	Expect(pairs[1].Response.Body).To(Equal("two.com"))
+}
+
+func Test_Simulation_AddPairInSequenceIfDifferent_OnlyAddsChangedResponses(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewSimulation()
+	state := state.NewState()
+
+	for _, body := range []string{"1", "1", "2", "2", "1"} {
+		pair := newDestinationPair("one.com")
+		pair.Response.Body = body
+		unit.AddPairInSequenceIfDifferent(pair, state)
+	}
+
+	pairs := unit.GetMatchingPairs()
+	Expect(pairs).To(HaveLen(3))
+
+	Expect(pairs[0].Response.Body).To(Equal("1"))
+	Expect(pairs[0].RequestMatcher.RequiresState["sequence:1"]).To(Equal("1"))
+	Expect(pairs[0].Response.TransitionsState["sequence:1"]).To(Equal("2"))
+
+	Expect(pairs[1].Response.Body).To(Equal("2"))
+	Expect(pairs[1].RequestMatcher.RequiresState["sequence:1"]).To(Equal("2"))
+	Expect(pairs[1].Response.TransitionsState["sequence:1"]).To(Equal("3"))
+
+	Expect(pairs[2].Response.Body).To(Equal("1"))
+	Expect(pairs[2].RequestMatcher.RequiresState["sequence:1"]).To(Equal("3"))
+}
+
+func Test_Simulation_AddPairInSequenceIfDifferent_AddsResponseWithDifferentStatus(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewSimulation()
+	state := state.NewState()
+
+	pair := newDestinationPair("one.com")
+	pair.Response.Status = 200
+	unit.AddPairInSequenceIfDifferent(pair, state)
+
+	pair = newDestinationPair("one.com")
+	pair.Response.Status = 500
+	unit.AddPairInSequenceIfDifferent(pair, state)
+
+	Expect(unit.GetMatchingPairs()).To(HaveLen(2))
+}
+
+func Test_Simulation_GetPair_ReturnsPairWithId(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewSimulation()
+	unit.AddPair(newDestinationPair("one.com"))
+	unit.AddPair(newDestinationPair("two.com"))
+
+	id := unit.GetMatchingPairs()[1].Id
+
+	pair, ok := unit.GetPair(id)
+	Expect(ok).To(BeTrue())
+	Expect(pair.Response.Body).To(Equal("two.com"))
+
+	_, ok = unit.GetPair("unknown")
+	Expect(ok).To(BeFalse())
+}
+
+func Test_Simulation_UpdatePair_KeepsIdAndPosition(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewSimulation()
+	unit.AddPair(newDestinationPair("one.com"))
+	unit.AddPair(newDestinationPair("two.com"))
+
+	id := unit.GetMatchingPairs()[0].Id
+
+	Expect(unit.UpdatePair(id, *newDestinationPair("updated.com"))).To(Succeed())
+
+	pairs := unit.GetMatchingPairs()
+	Expect(pairs).To(HaveLen(2))
+	Expect(pairs[0].Id).To(Equal(id))
+	Expect(pairs[0].RequestMatcher.Destination[0].Value).To(Equal("updated.com"))
+	Expect(pairs[1].Response.Body).To(Equal("two.com"))
+}
+
+func Test_Simulation_UpdatePair_ErrorsWhenAnotherPairHasIdenticalRequestMatcher(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewSimulation()
+	unit.AddPair(newDestinationPair("one.com"))
+	unit.AddPair(newDestinationPair("two.com"))
+
+	pairs := unit.GetMatchingPairs()
+
+	err := unit.UpdatePair(pairs[0].Id, *newDestinationPair("two.com"))
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal(fmt.Sprintf("Pair %s has an identical request matcher", pairs[1].Id)))
+
+	Expect(unit.GetMatchingPairs()[0].Response.Body).To(Equal("one.com"))
+}
+
+func Test_Simulation_UpdatePair_CanKeepItsOwnRequestMatcher(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewSimulation()
+	unit.AddPair(newDestinationPair("one.com"))
+
+	id := unit.GetMatchingPairs()[0].Id
+
+	pair := newDestinationPair("one.com")
+	pair.Response.Body = "updated"
+
+	Expect(unit.UpdatePair(id, *pair)).To(Succeed())
+	Expect(unit.GetMatchingPairs()[0].Response.Body).To(Equal("updated"))
+}
+
+func Test_Simulation_UpdatePair_ErrorsForUnknownId(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewSimulation()
+
+	err := unit.UpdatePair("unknown", *newDestinationPair("one.com"))
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Pair unknown does not exist"))
+}
+
+func Test_Simulation_DeletePair_RemovesOnlyThatPair(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := models.NewSimulation()
+	unit.AddPair(newDestinationPair("one.com"))
+	unit.AddPair(newDestinationPair("two.com"))
+	unit.AddPair(newDestinationPair("three.com"))
+
+	snapshot := unit.GetMatchingPairs()
+
+	Expect(unit.DeletePair(snapshot[1].Id)).To(BeTrue())
+	Expect(unit.DeletePair(snapshot[1].Id)).To(BeFalse())
+
+	pairs := unit.GetMatchingPairs()
+	Expect(pairs).To(HaveLen(2))
+	Expect(pairs[0].Id).To(Equal(snapshot[0].Id))
+	Expect(pairs[1].Id).To(Equal(snapshot[2].Id))
+
+	Expect(snapshot[1].Response.Body).To(Equal("two.com"))
+	Expect(snapshot[2].Response.Body).To(Equal("three.com"))
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"io/ioutil"
 	"net/http"
+	"sync"
 
 	"github.com/SpectoLabs/hoverfly/core/models"
 	"github.com/SpectoLabs/hoverfly/core/util"
@@ -15,40 +16,62 @@ import (
 type HoverflyCapture interface {
 	ApplyMiddleware(models.RequestResponsePair) (models.RequestResponsePair, error)
 	DoRequest(*http.Request) (*http.Response, error)
-	Save(*models.RequestDetails, *models.ResponseDetails, []string, bool) error
+	Save(*models.RequestDetails, *models.ResponseDetails, *ModeArguments) error
+	TransformRequest(models.RequestDetails) models.RequestDetails
+	TransformResponse(models.RequestDetails, models.ResponseDetails) (models.ResponseDetails, bool)
 }
 
 type CaptureMode struct {
 	Hoverfly  HoverflyCapture
 	Arguments ModeArguments
+
+	skipped      v2.CaptureSkippedView
+	skippedMutex sync.Mutex
 }
 
 func (this *CaptureMode) View() v2.ModeView {
+	var skipped *v2.CaptureSkippedView
+	if this.Arguments.CaptureFilter != nil {
+		this.skippedMutex.Lock()
+		skippedCopy := this.skipped
+		this.skippedMutex.Unlock()
+		skipped = &skippedCopy
+	}
+
 	return v2.ModeView{
-		Mode: Capture,
+		Mode:    Capture,
+		Skipped: skipped,
 		Arguments: v2.ModeArgumentsView{
 			Headers:          this.Arguments.Headers,
 			MatchingStrategy: this.Arguments.MatchingStrategy,
 			Stateful:         this.Arguments.Stateful,
+			Redactions:       this.Arguments.RedactionsView(),
+			MatcherPolicy:    this.Arguments.MatcherPolicyView(),
+			CapturePolicy:    this.Arguments.CapturePolicy,
+			CaptureFilter:    this.Arguments.CaptureFilterView(),
 		},
 	}
 }
 
 func (this *CaptureMode) SetArguments(arguments ModeArguments) {
 	this.Arguments = arguments
+
+	this.skippedMutex.Lock()
+	this.skipped = v2.CaptureSkippedView{}
+	this.skippedMutex.Unlock()
 }
 
 func (this *CaptureMode) GetArguments(arguments ModeArguments) {
 	this.Arguments = arguments
 }
 
-func (this CaptureMode) Process(request *http.Request, details models.RequestDetails) (*http.Response, error) {
+func (this *CaptureMode) Process(request *http.Request, details models.RequestDetails) (*http.Response, error) {
 	// this is mainly for testing, since when you create
 	if request.Body == nil {
 		request.Body = ioutil.NopCloser(bytes.NewBuffer([]byte("")))
 	}
 
-	pair, err := this.Hoverfly.ApplyMiddleware(models.RequestResponsePair{Request: details})
+	pair, err := this.Hoverfly.ApplyMiddleware(models.RequestResponsePair{Request: this.Hoverfly.TransformRequest(details)})
 	if err != nil {
 		return ReturnErrorAndLog(request, err, &pair, "There was an error when applying middleware to http request", Capture)
 	}
@@ -58,6 +81,12 @@ func (this CaptureMode) Process(request *http.Request, details models.RequestDet
 		return ReturnErrorAndLog(request, err, &pair, "There was an error when applying middleware to http request", Capture)
 	}
 
+	arguments := this.Arguments
+	skipReason := ""
+	if arguments.CaptureFilter != nil {
+		skipReason = arguments.CaptureFilter.SkipRequest(pair.Request.Method, pair.Request.Destination, pair.Request.Path)
+	}
+
 	response, err := this.Hoverfly.DoRequest(modifiedRequest)
 	if err != nil {
 		return ReturnErrorAndLog(request, err, &pair, "There was an error when forwarding the request to the intended destination", Capture)
@@ -71,12 +100,35 @@ func (this CaptureMode) Process(request *http.Request, details models.RequestDet
 		Headers: response.Header,
 	}
 
-	if this.Arguments.Headers == nil {
-		this.Arguments.Headers = []string{}
+	if transformed, ok := this.Hoverfly.TransformResponse(details, *responseObj); ok {
+		responseObj = &transformed
+		response = ReconstructResponse(modifiedRequest, models.RequestResponsePair{Response: transformed})
+	}
+
+	if skipReason == "" && arguments.CaptureFilter != nil {
+		skipReason = arguments.CaptureFilter.SkipResponse(response.StatusCode, response.Header.Get("Content-Type"))
+	}
+
+	if skipReason != "" {
+		this.skippedMutex.Lock()
+		this.skipped.Add(skipReason)
+		this.skippedMutex.Unlock()
+
+		log.WithFields(log.Fields{
+			"mode":     Capture,
+			"request":  GetRequestLogFields(&pair.Request),
+			"filtered": skipReason,
+		}).Info("request proxied but not captured")
+
+		return response, nil
+	}
+
+	if arguments.Headers == nil {
+		arguments.Headers = []string{}
 	}
 
 	// saving response body with request/response meta to cache
-	err = this.Hoverfly.Save(&pair.Request, responseObj, this.Arguments.Headers, this.Arguments.Stateful)
+	err = this.Hoverfly.Save(&pair.Request, responseObj, &arguments)
 	if err != nil {
 		return ReturnErrorAndLog(request, err, &pair, "There was an error when saving request and response", Capture)
 	}
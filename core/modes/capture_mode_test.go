@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/core/models"
 	"github.com/SpectoLabs/hoverfly/core/modes"
 	. "github.com/onsi/gomega"
@@ -38,14 +39,31 @@ func (this hoverflyCaptureStub) DoRequest(request *http.Request) (*http.Response
 }
 
 // Save - Stub implementation of modes.HoverflyCapture interface
-func (this *hoverflyCaptureStub) Save(request *models.RequestDetails, response *models.ResponseDetails, headersToSave []string, recordSequence bool) error {
+func (this *hoverflyCaptureStub) Save(request *models.RequestDetails, response *models.ResponseDetails, arguments *modes.ModeArguments) error {
 	this.SavedRequest = request
 	this.SavedResponse = response
-	this.SavedHeaders = headersToSave
+	this.SavedHeaders = arguments.Headers
 
 	return nil
 }
 
+// TransformRequest - Stub implementation of modes.HoverflyCapture interface
+func (this hoverflyCaptureStub) TransformRequest(request models.RequestDetails) models.RequestDetails {
+	if request.Destination == "transform.com" {
+		request.Path = "/transformed"
+	}
+	return request
+}
+
+// TransformResponse - Stub implementation of modes.HoverflyCapture interface
+func (this hoverflyCaptureStub) TransformResponse(request models.RequestDetails, response models.ResponseDetails) (models.ResponseDetails, bool) {
+	if request.Destination != "transform.com" {
+		return response, false
+	}
+	response.Body = "transformed " + response.Body
+	return response, true
+}
+
 func Test_CaptureMode_CanSetArguments(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -92,6 +110,35 @@ func Test_CaptureMode_WhenGivenARequestItWillMakeTheRequestAndSaveIt(t *testing.
 	Expect(hoverflyStub.SavedResponse.Body).To(Equal("test"))
 }
 
+func Test_CaptureMode_SavesAndReturnsTransformedRequestAndResponse(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverflyStub := &hoverflyCaptureStub{}
+
+	unit := &modes.CaptureMode{
+		Hoverfly: hoverflyStub,
+	}
+
+	requestDetails := models.RequestDetails{
+		Scheme:      "http",
+		Destination: "transform.com",
+		Path:        "/original",
+	}
+
+	request, err := http.NewRequest("GET", "http://transform.com/original", nil)
+	Expect(err).To(BeNil())
+
+	response, err := unit.Process(request, requestDetails)
+	Expect(err).To(BeNil())
+
+	responseBody, err := ioutil.ReadAll(response.Body)
+	Expect(err).To(BeNil())
+	Expect(string(responseBody)).To(Equal("transformed test"))
+
+	Expect(hoverflyStub.SavedRequest.Path).To(Equal("/transformed"))
+	Expect(hoverflyStub.SavedResponse.Body).To(Equal("transformed test"))
+}
+
 func Test_CaptureMode_IfHeadersArgumentNotSet_CallsSaveWithEmptyList(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -199,3 +246,100 @@ func Test_CaptureMode_WhenGivenABadRequestItWillError(t *testing.T) {
 	Expect(hoverflyStub.SavedRequest).To(BeNil())
 	Expect(hoverflyStub.SavedResponse).To(BeNil())
 }
+
+func Test_CaptureMode_ProxiesButDoesNotSaveFilteredRequests(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverflyStub := &hoverflyCaptureStub{}
+
+	unit := &modes.CaptureMode{
+		Hoverfly: hoverflyStub,
+	}
+	unit.SetArguments(modes.ModeArguments{
+		CaptureFilter: mustCaptureFilter(v2.CaptureFilterView{
+			IncludeDestination: `^api\.test\.com$`,
+		}),
+	})
+
+	request, err := http.NewRequest("GET", "http://positive-match.com", nil)
+	Expect(err).To(BeNil())
+
+	response, err := unit.Process(request, models.RequestDetails{
+		Scheme:      "http",
+		Destination: "positive-match.com",
+	})
+	Expect(err).To(BeNil())
+
+	responseBody, err := ioutil.ReadAll(response.Body)
+	Expect(err).To(BeNil())
+	Expect(string(responseBody)).To(Equal("test"))
+
+	Expect(hoverflyStub.SavedRequest).To(BeNil())
+	Expect(unit.View().Skipped).To(Equal(&v2.CaptureSkippedView{
+		Total:       1,
+		Destination: 1,
+	}))
+}
+
+func Test_CaptureMode_FiltersOnResponse(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverflyStub := &hoverflyCaptureStub{}
+
+	unit := &modes.CaptureMode{
+		Hoverfly: hoverflyStub,
+	}
+	unit.SetArguments(modes.ModeArguments{
+		CaptureFilter: mustCaptureFilter(v2.CaptureFilterView{
+			Statuses:     []string{"2xx"},
+			ContentTypes: []string{"application/json"},
+		}),
+	})
+
+	request, err := http.NewRequest("GET", "http://positive-match.com", nil)
+	Expect(err).To(BeNil())
+
+	_, err = unit.Process(request, models.RequestDetails{
+		Scheme:      "http",
+		Destination: "positive-match.com",
+	})
+	Expect(err).To(BeNil())
+
+	Expect(hoverflyStub.SavedRequest).To(BeNil())
+	Expect(unit.View().Skipped).To(Equal(&v2.CaptureSkippedView{
+		Total:       1,
+		ContentType: 1,
+	}))
+
+	unit.SetArguments(modes.ModeArguments{
+		CaptureFilter: mustCaptureFilter(v2.CaptureFilterView{
+			Statuses: []string{"2xx"},
+		}),
+	})
+	Expect(unit.View().Skipped).To(Equal(&v2.CaptureSkippedView{}))
+
+	_, err = unit.Process(request, models.RequestDetails{
+		Scheme:      "http",
+		Destination: "positive-match.com",
+	})
+	Expect(err).To(BeNil())
+
+	Expect(hoverflyStub.SavedRequest.Destination).To(Equal("positive-match.com"))
+}
+
+func Test_CaptureMode_View_DoesNotReportSkippedRequestsWithoutFilter(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &modes.CaptureMode{
+		Hoverfly: &hoverflyCaptureStub{},
+	}
+
+	Expect(unit.View().Skipped).To(BeNil())
+}
+
+func mustCaptureFilter(view v2.CaptureFilterView) *v2.CaptureFilter {
+	captureFilter, err := v2.NewCaptureFilter(view)
+	Expect(err).To(BeNil())
+
+	return captureFilter
+}
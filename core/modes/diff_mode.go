@@ -11,10 +11,14 @@ import (
 	"compress/flate"
 	"compress/gzip"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
@@ -42,6 +46,7 @@ func (this *DiffMode) View() v2.ModeView {
 			Headers:          this.Arguments.Headers,
 			MatchingStrategy: this.Arguments.MatchingStrategy,
 			Stateful:         this.Arguments.Stateful,
+			DiffRules:        this.Arguments.DiffRulesView(),
 		},
 	}
 }
@@ -52,7 +57,7 @@ func (this *DiffMode) SetArguments(arguments ModeArguments) {
 
 //TODO: We should only need one of these two parameters
 func (this *DiffMode) Process(request *http.Request, details models.RequestDetails) (*http.Response, error) {
-	this.DiffReport = v2.DiffReport{Timestamp: time.Now().Format(time.RFC3339)}
+	timestamp := time.Now().Format(time.RFC3339)
 
 	actualPair := models.RequestResponsePair{
 		Request: details,
@@ -71,10 +76,6 @@ func (this *DiffMode) Process(request *http.Request, details models.RequestDetai
 		return ReturnErrorAndLog(request, err, &actualPair, "There was an error when forwarding the request to the intended destination", Diff)
 	}
 
-	if this.Arguments.Headers == nil {
-		this.Arguments.Headers = []string{}
-	}
-
 	if simRespErr == nil {
 		respBody, _ := util.GetResponseBody(actualResponse)
 
@@ -84,7 +85,7 @@ func (this *DiffMode) Process(request *http.Request, details models.RequestDetai
 			Headers: actualResponse.Header,
 		}
 
-		this.diffResponse(simResponse, actualResponseDetails, this.Arguments.Headers)
+		this.DiffReport = DiffResponses(simResponse, actualResponseDetails, this.Arguments, timestamp)
 		this.Hoverfly.AddDiff(v2.SimpleRequestDefinitionView{
 			Method: modifiedRequest.Method,
 			Host:   modifiedRequest.URL.Host,
@@ -102,6 +103,19 @@ func (this *DiffMode) Process(request *http.Request, details models.RequestDetai
 	return actualResponse, nil
 }
 
+// DiffResponses compares a simulated response with the real one using the headers blacklist and
+// diff rules of the arguments. The report is built by its own DiffMode, so responses can be
+// compared while others are being compared.
+func DiffResponses(expected *models.ResponseDetails, actual *models.ResponseDetails, arguments ModeArguments, timestamp string) v2.DiffReport {
+	differ := &DiffMode{
+		DiffReport: v2.DiffReport{Timestamp: timestamp},
+		Arguments:  arguments,
+	}
+	differ.diffResponse(expected, actual, arguments.Headers)
+
+	return differ.DiffReport
+}
+
 func (this *DiffMode) diffResponse(expected *models.ResponseDetails, actual *models.ResponseDetails, headersBlacklist []string) {
 	if expected.Status != 0 && expected.Status != actual.Status {
 		this.addEntry("status", expected.Status, actual.Status)
@@ -110,6 +124,14 @@ func (this *DiffMode) diffResponse(expected *models.ResponseDetails, actual *mod
 	this.bodyDiff(expected, actual)
 }
 
+func (this *DiffMode) diffRules() v2.DiffRules {
+	if this.Arguments.DiffRules == nil {
+		return v2.DiffRules{}
+	}
+
+	return *this.Arguments.DiffRules
+}
+
 func (this *DiffMode) addEntry(parameterName string, expected interface{}, actual interface{}) {
 	this.DiffReport.DiffEntries = append(this.DiffReport.DiffEntries,
 		v2.DiffReportEntry{
@@ -127,9 +149,11 @@ func nullOrValue(value interface{}) string {
 }
 
 func (this *DiffMode) headerDiff(expected map[string][]string, actual map[string][]string, headersBlacklist []string) bool {
+	rules := this.diffRules()
+
 	same := true
 	for k := range expected {
-		shouldContinue := false
+		shouldContinue := rules.IgnoresHeader(k)
 		for _, header := range headersBlacklist {
 			if k == header || header == "*" {
 				shouldContinue = true
@@ -141,7 +165,7 @@ func (this *DiffMode) headerDiff(expected map[string][]string, actual map[string
 		if _, ok := actual[k]; !ok {
 			this.addEntry("header/"+k, expected[k], nil)
 			same = false
-		} else if !reflect.DeepEqual(expected[k], actual[k]) {
+		} else if !reflect.DeepEqual(headerValues(expected[k], rules), headerValues(actual[k], rules)) {
 			this.addEntry("header/"+k, expected[k], actual[k])
 			same = false
 		}
@@ -150,20 +174,35 @@ func (this *DiffMode) headerDiff(expected map[string][]string, actual map[string
 	return same
 }
 
+// headerValues returns the values of a header in order with the ignored parts replaced, as the
+// order in which a server sends the values of a header is rarely meaningful
+func headerValues(values []string, rules v2.DiffRules) []string {
+	compared := []string{}
+	for _, value := range values {
+		compared = append(compared, rules.IgnoreInValue(value))
+	}
+	sort.Strings(compared)
+
+	return compared
+}
+
 func (this *DiffMode) bodyDiff(expected *models.ResponseDetails, actual *models.ResponseDetails) bool {
-	var expectedJson, actualJson interface{}
+	rules := this.diffRules()
+	expectedBody := rules.IgnoreInBody(decompressedBody(expected))
+	actualBody := rules.IgnoreInBody(decompressedBody(actual))
 
-	err := unmarshalResponseToInterface(expected, &expectedJson)
-	if err != nil {
-		return this.doDeepEqual(expected.Body, actual.Body)
+	var expectedJson, actualJson interface{}
+	if unmarshalBodyToInterface(expectedBody, &expectedJson) == nil && unmarshalBodyToInterface(actualBody, &actualJson) == nil {
+		return this.jsonValueDiff("body", expectedJson, actualJson)
 	}
 
-	err = unmarshalResponseToInterface(actual, &actualJson)
-	if err != nil {
-		return this.doDeepEqual(expected.Body, actual.Body)
+	if expectedXml, err := parseXmlElement(expectedBody); err == nil {
+		if actualXml, err := parseXmlElement(actualBody); err == nil {
+			return this.xmlDiff("body", expectedXml, actualXml)
+		}
 	}
 
-	return this.JsonDiff("body", expectedJson.(map[string]interface{}), actualJson.(map[string]interface{}))
+	return this.doDeepEqual(expectedBody, actualBody)
 }
 
 func (this *DiffMode) doDeepEqual(expected string, actual string) bool {
@@ -174,18 +213,28 @@ func (this *DiffMode) doDeepEqual(expected string, actual string) bool {
 	return true
 }
 
-func unmarshalResponseToInterface(response *models.ResponseDetails, output interface{}) error {
-
-	body := []byte(response.Body)
-
+func decompressedBody(response *models.ResponseDetails) string {
 	encodings := response.Headers["Content-Encoding"]
-	decompressedBody, err := decompress(body, encodings)
+	decompressed, err := decompress([]byte(response.Body), encodings)
 	if err != nil {
-		fmt.Errorf("It wasn't possible to decompress the response body: %s ", err)
-	} else {
-		body = decompressedBody
+		log.WithFields(log.Fields{
+			"error": err.Error(),
+		}).Debug("It wasn't possible to decompress the response body")
+		return response.Body
 	}
 
+	return string(decompressed)
+}
+
+// unmarshalBodyToInterface decodes a JSON body, falling back to loosely written JSON such as
+// single quoted strings when the body is not valid JSON
+func unmarshalBodyToInterface(responseBody string, output interface{}) error {
+	if json.Unmarshal([]byte(responseBody), &output) == nil {
+		return nil
+	}
+
+	body := []byte(responseBody)
+
 	for i, ch := range body {
 		switch {
 		case ch == '\r':
@@ -200,8 +249,7 @@ func unmarshalResponseToInterface(response *models.ResponseDetails, output inter
 			body[i] = '"'
 		}
 	}
-	err = json.Unmarshal(body, &output)
-	return err
+	return json.Unmarshal(body, &output)
 }
 
 func decompress(body []byte, encodings []string) ([]byte, error) {
@@ -243,35 +291,233 @@ func decompress(body []byte, encodings []string) ([]byte, error) {
 	return body, err
 }
 
+// JsonDiff compares the keys of the expected object with the same keys of the actual one. Keys
+// which are only in the actual object are not reported.
 func (this *DiffMode) JsonDiff(prefix string, expected map[string]interface{}, actual map[string]interface{}) bool {
-	same := true
+	keys := []string{}
 	for k := range expected {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	same := true
+	for _, k := range keys {
 		param := prefix + "/" + k
 		if _, ok := actual[k]; !ok {
 			this.addEntry(param, expected[k], nil)
 			same = false
-		} else if reflect.TypeOf(expected[k]) != reflect.TypeOf(actual[k]) {
-			this.addEntry(param, expected[k], actual[k])
+		} else if !this.jsonValueDiff(param, expected[k], actual[k]) {
 			same = false
-		} else {
-			switch expected[k].(type) {
-			default:
-				if expected[k] != actual[k] {
-					this.addEntry(param, expected[k], actual[k])
-					same = false
-				}
-			case map[string]interface{}:
-				if !this.JsonDiff(param, expected[k].(map[string]interface{}), actual[k].(map[string]interface{})) {
-					same = false
-				}
-			case []interface{}:
-				if !reflect.DeepEqual(expected[k], actual[k]) {
-					this.addEntry(param, expected[k], actual[k])
-					same = false
+		}
+	}
+
+	return same
+}
+
+// jsonValueDiff compares any two decoded JSON values, reporting each path at which they differ, such
+// as body/items/0/price. Items missing from or added to an array are both reported.
+func (this *DiffMode) jsonValueDiff(param string, expected interface{}, actual interface{}) bool {
+	if reflect.TypeOf(expected) != reflect.TypeOf(actual) {
+		this.addEntry(param, expected, actual)
+		return false
+	}
+
+	switch expectedValue := expected.(type) {
+	case map[string]interface{}:
+		return this.JsonDiff(param, expectedValue, actual.(map[string]interface{}))
+	case []interface{}:
+		actualValue := actual.([]interface{})
+
+		same := true
+		for i := 0; i < len(expectedValue) || i < len(actualValue); i++ {
+			itemParam := param + "/" + strconv.Itoa(i)
+			switch {
+			case i >= len(actualValue):
+				this.addEntry(itemParam, expectedValue[i], nil)
+				same = false
+			case i >= len(expectedValue):
+				this.addEntry(itemParam, nil, actualValue[i])
+				same = false
+			case !this.jsonValueDiff(itemParam, expectedValue[i], actualValue[i]):
+				same = false
+			}
+		}
+		return same
+	case float64:
+		if !this.diffRules().NumbersEqual(expectedValue, actual.(float64)) {
+			this.addEntry(param, expected, actual)
+			return false
+		}
+	default:
+		if expected != actual {
+			this.addEntry(param, expected, actual)
+			return false
+		}
+	}
+
+	return true
+}
+
+type xmlElement struct {
+	name       string
+	attributes map[string]string
+	text       string
+	children   []*xmlElement
+}
+
+// parseXmlElement parses a body holding an XML document into its root element
+func parseXmlElement(body string) (*xmlElement, error) {
+	if !strings.HasPrefix(strings.TrimSpace(body), "<") {
+		return nil, fmt.Errorf("Body is not XML")
+	}
+
+	decoder := xml.NewDecoder(strings.NewReader(body))
+
+	var root *xmlElement
+	elements := []*xmlElement{}
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			element := &xmlElement{name: token.Name.Local, attributes: map[string]string{}}
+			for _, attribute := range token.Attr {
+				if attribute.Name.Space != "xmlns" && attribute.Name.Local != "xmlns" {
+					element.attributes[attribute.Name.Local] = attribute.Value
 				}
 			}
+
+			if len(elements) > 0 {
+				parent := elements[len(elements)-1]
+				parent.children = append(parent.children, element)
+			} else if root == nil {
+				root = element
+			} else {
+				return nil, fmt.Errorf("Body has more than one root element")
+			}
+			elements = append(elements, element)
+		case xml.EndElement:
+			elements = elements[:len(elements)-1]
+		case xml.CharData:
+			if len(elements) > 0 {
+				elements[len(elements)-1].text += string(token)
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, fmt.Errorf("Body has no root element")
+	}
+
+	return root, nil
+}
+
+// xmlDiff compares two XML documents, reporting the attributes and text which differ by their paths,
+// such as body/order/item[2]/price or body/order/@id. Elements are compared with the elements of the
+// same name in the same position, and only the elements which are repeated are given an index.
+func (this *DiffMode) xmlDiff(param string, expected *xmlElement, actual *xmlElement) bool {
+	if expected.name != actual.name {
+		this.addEntry(param, xmlValue(expected), xmlValue(actual))
+		return false
+	}
+
+	return this.xmlElementDiff(param+"/"+expected.name, expected, actual)
+}
+
+func (this *DiffMode) xmlElementDiff(param string, expected *xmlElement, actual *xmlElement) bool {
+	same := true
+
+	names := []string{}
+	for name := range expected.attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		attributeParam := param + "/@" + name
+		if actualValue, ok := actual.attributes[name]; !ok {
+			this.addEntry(attributeParam, expected.attributes[name], nil)
+			same = false
+		} else if !this.xmlValuesEqual(expected.attributes[name], actualValue) {
+			this.addEntry(attributeParam, expected.attributes[name], actualValue)
+			same = false
+		}
+	}
+
+	expectedText := strings.TrimSpace(expected.text)
+	actualText := strings.TrimSpace(actual.text)
+	if !this.xmlValuesEqual(expectedText, actualText) {
+		this.addEntry(param, expectedText, actualText)
+		same = false
+	}
+
+	expectedChildren, childNames := xmlChildrenByName(expected)
+	actualChildren, _ := xmlChildrenByName(actual)
+
+	for _, name := range childNames {
+		expectedElements := expectedChildren[name]
+		actualElements := actualChildren[name]
+		repeated := len(expectedElements) > 1 || len(actualElements) > 1
+
+		for i := 0; i < len(expectedElements) || i < len(actualElements); i++ {
+			childParam := param + "/" + name
+			if repeated {
+				childParam = fmt.Sprintf("%s[%d]", childParam, i+1)
+			}
+
+			switch {
+			case i >= len(actualElements):
+				this.addEntry(childParam, xmlValue(expectedElements[i]), nil)
+				same = false
+			case i >= len(expectedElements):
+				this.addEntry(childParam, nil, xmlValue(actualElements[i]))
+				same = false
+			case !this.xmlElementDiff(childParam, expectedElements[i], actualElements[i]):
+				same = false
+			}
 		}
 	}
 
 	return same
 }
+
+func (this *DiffMode) xmlValuesEqual(expected string, actual string) bool {
+	if expected == actual {
+		return true
+	}
+
+	expectedNumber, expectedErr := strconv.ParseFloat(expected, 64)
+	actualNumber, actualErr := strconv.ParseFloat(actual, 64)
+
+	return expectedErr == nil && actualErr == nil && this.diffRules().NumbersEqual(expectedNumber, actualNumber)
+}
+
+// xmlChildrenByName groups the children of an element by name, returning the names in the order
+// they first appear
+func xmlChildrenByName(element *xmlElement) (map[string][]*xmlElement, []string) {
+	children := map[string][]*xmlElement{}
+	names := []string{}
+	for _, child := range element.children {
+		if _, ok := children[child.name]; !ok {
+			names = append(names, child.name)
+		}
+		children[child.name] = append(children[child.name], child)
+	}
+
+	return children, names
+}
+
+// xmlValue describes an element in a report, by its text when it has no children
+func xmlValue(element *xmlElement) string {
+	if len(element.children) == 0 {
+		return strings.TrimSpace(element.text)
+	}
+
+	return "<" + element.name + ">"
+}
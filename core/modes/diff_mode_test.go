@@ -385,3 +385,155 @@ func Test_JsonDiff_WhenSameThenReturnsTrue(t *testing.T) {
 	Expect(result).To(Equal(true))
 	Expect(len(diffMode.DiffReport.DiffEntries)).To(Equal(0))
 }
+
+func Test_diffResponses_ReportsEachDifferingPathOfJsonBodies(t *testing.T) {
+	RegisterTestingT(t)
+
+	report := DiffResponses(&models.ResponseDetails{
+		Body: `{"order": {"id": 1, "items": [{"sku": "a", "quantity": 1}, {"sku": "b", "quantity": 2}]}}`,
+	}, &models.ResponseDetails{
+		Body: `{"order": {"id": 1, "items": [{"sku": "a", "quantity": 3}, {"sku": "c", "quantity": 2}, {"sku": "d"}]}}`,
+	}, ModeArguments{}, "")
+
+	Expect(report.DiffEntries).To(Equal([]v2.DiffReportEntry{
+		{"body/order/items/0/quantity", "1", "3"},
+		{"body/order/items/1/sku", "b", "c"},
+		{"body/order/items/2", "null", "map[sku:d]"},
+	}))
+}
+
+func Test_diffResponses_ReportsEachDifferingPathOfJsonBodiesWithEscapedStrings(t *testing.T) {
+	RegisterTestingT(t)
+
+	report := DiffResponses(&models.ResponseDetails{
+		Body: `{"message": "say \"hi\"", "count": 1}`,
+	}, &models.ResponseDetails{
+		Body: `{"message": "say \"hi\"", "count": 2}`,
+	}, ModeArguments{}, "")
+
+	Expect(report.DiffEntries).To(Equal([]v2.DiffReportEntry{
+		{"body/count", "1", "2"},
+	}))
+}
+
+func Test_diffResponses_ComparesJsonArrayBodies(t *testing.T) {
+	RegisterTestingT(t)
+
+	report := DiffResponses(&models.ResponseDetails{
+		Body: `[{"id": 1}, {"id": 2}]`,
+	}, &models.ResponseDetails{
+		Body: `[{"id": 1}]`,
+	}, ModeArguments{}, "")
+
+	Expect(report.DiffEntries).To(Equal([]v2.DiffReportEntry{
+		{"body/1", "map[id:2]", "null"},
+	}))
+}
+
+func Test_diffResponses_ReportsEachDifferingPathOfXmlBodies(t *testing.T) {
+	RegisterTestingT(t)
+
+	report := DiffResponses(&models.ResponseDetails{
+		Body: `<order id="1"><customer>Bob</customer><item><sku>a</sku></item><item><sku>b</sku></item></order>`,
+	}, &models.ResponseDetails{
+		Body: `<?xml version="1.0"?>
+<order id="2">
+	<customer>Bob</customer>
+	<item><sku>a</sku></item>
+	<item><sku>c</sku></item>
+	<item><sku>d</sku></item>
+</order>`,
+	}, ModeArguments{}, "")
+
+	Expect(report.DiffEntries).To(Equal([]v2.DiffReportEntry{
+		{"body/order/@id", "1", "2"},
+		{"body/order/item[2]/sku", "b", "c"},
+		{"body/order/item[3]", "null", "<item>"},
+	}))
+}
+
+func Test_diffResponses_ReportsDifferentXmlRootElements(t *testing.T) {
+	RegisterTestingT(t)
+
+	report := DiffResponses(&models.ResponseDetails{
+		Body: `<order>1</order>`,
+	}, &models.ResponseDetails{
+		Body: `<error>not found</error>`,
+	}, ModeArguments{}, "")
+
+	Expect(report.DiffEntries).To(Equal([]v2.DiffReportEntry{
+		{"body", "1", "not found"},
+	}))
+}
+
+func Test_diffResponses_IgnoresValuesOfDiffRules(t *testing.T) {
+	RegisterTestingT(t)
+
+	arguments := ModeArguments{
+		DiffRules: mustDiffRules(v2.DiffRulesView{
+			IgnoreHeaders:   []string{"date"},
+			IgnoreJsonPaths: []string{"$.id", "$..generatedAt"},
+			IgnoreXPaths:    []string{"//order/@id"},
+			IgnoreRegexes:   []string{`req-[0-9]+`},
+		}),
+	}
+
+	Expect(DiffResponses(&models.ResponseDetails{
+		Headers: map[string][]string{"Date": {"Mon, 01 Jan 2018"}, "X-Trace": {"req-1"}},
+		Body:    `{"id": "a", "meta": {"generatedAt": "2018-01-01"}, "request": "req-1", "name": "test"}`,
+	}, &models.ResponseDetails{
+		Headers: map[string][]string{"Date": {"Tue, 02 Jan 2018"}, "X-Trace": {"req-2"}},
+		Body:    `{"id": "b", "meta": {"generatedAt": "2018-01-02"}, "request": "req-2", "name": "test"}`,
+	}, arguments, "").DiffEntries).To(BeEmpty())
+
+	Expect(DiffResponses(&models.ResponseDetails{
+		Body: `<order id="1"><total>10</total></order>`,
+	}, &models.ResponseDetails{
+		Body: `<order id="2"><total>12</total></order>`,
+	}, arguments, "").DiffEntries).To(Equal([]v2.DiffReportEntry{
+		{"body/order/total", "10", "12"},
+	}))
+}
+
+func Test_diffResponses_ComparesNumbersWithTolerance(t *testing.T) {
+	RegisterTestingT(t)
+
+	arguments := ModeArguments{
+		DiffRules: mustDiffRules(v2.DiffRulesView{
+			Tolerance: 0.01,
+		}),
+	}
+
+	Expect(DiffResponses(&models.ResponseDetails{
+		Body: `{"price": 9.99, "total": 20}`,
+	}, &models.ResponseDetails{
+		Body: `{"price": 9.991, "total": 21}`,
+	}, arguments, "").DiffEntries).To(Equal([]v2.DiffReportEntry{
+		{"body/total", "20", "21"},
+	}))
+
+	Expect(DiffResponses(&models.ResponseDetails{
+		Body: `<price currency="GBP" rate="1.5">9.99</price>`,
+	}, &models.ResponseDetails{
+		Body: `<price currency="GBP" rate="1.501">9.98</price>`,
+	}, arguments, "").DiffEntries).To(BeEmpty())
+}
+
+func Test_diffResponses_ComparesHeaderValuesInAnyOrder(t *testing.T) {
+	RegisterTestingT(t)
+
+	report := DiffResponses(&models.ResponseDetails{
+		Headers: map[string][]string{"Vary": {"Accept", "Origin"}},
+	}, &models.ResponseDetails{
+		Headers: map[string][]string{"Vary": {"Origin", "Accept"}},
+	}, ModeArguments{}, "")
+
+	Expect(report.DiffEntries).To(BeEmpty())
+}
+
+func mustDiffRules(view v2.DiffRulesView) *v2.DiffRules {
+	diffRules, err := v2.NewDiffRules(view)
+	Expect(err).To(BeNil())
+
+	return diffRules
+}
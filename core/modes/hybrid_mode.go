@@ -0,0 +1,166 @@
+package modes
+
+import (
+	"net/http"
+
+	"github.com/SpectoLabs/hoverfly/core/errors"
+	"github.com/SpectoLabs/hoverfly/core/util"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/models"
+)
+
+type HoverflyHybrid interface {
+	GetResponse(models.RequestDetails) (*models.ResponseDetails, *errors.HoverflyError)
+	ApplyMiddleware(models.RequestResponsePair) (models.RequestResponsePair, error)
+	DoRequest(*http.Request) (*http.Response, error)
+	Save(*models.RequestDetails, *models.ResponseDetails, *ModeArguments) error
+}
+
+// relaxedMatcherPolicy is used for the pairs hybrid mode records when relaxed matchers are chosen,
+// so that requests which only differ by scheme or by ids in their path are served the same response
+var relaxedMatcherPolicy = v2.MatcherPolicyView{
+	IgnoreFields:     []string{"scheme"},
+	GlobPathSegments: []string{v2.PathSegmentUuid, v2.PathSegmentNumber},
+}
+
+type HybridMode struct {
+	Hoverfly  HoverflyHybrid
+	Arguments ModeArguments
+
+	// recordPolicy is the matcher policy of the arguments with the relaxed one added when
+	// relaxed matchers are chosen, compiled when the arguments are set
+	recordPolicy *v2.MatcherPolicy
+}
+
+func (this *HybridMode) View() v2.ModeView {
+	return v2.ModeView{
+		Mode: Hybrid,
+		Arguments: v2.ModeArgumentsView{
+			Headers:          this.Arguments.Headers,
+			MatchingStrategy: this.Arguments.MatchingStrategy,
+			Stateful:         this.Arguments.Stateful,
+			Redactions:       this.Arguments.RedactionsView(),
+			MatcherPolicy:    this.Arguments.MatcherPolicyView(),
+			CapturePolicy:    this.Arguments.CapturePolicy,
+			CaptureFilter:    this.Arguments.CaptureFilterView(),
+			RecordMatchers:   this.Arguments.RecordMatchers,
+		},
+	}
+}
+
+func (this *HybridMode) SetArguments(arguments ModeArguments) {
+	if arguments.MatchingStrategy == nil {
+		arguments.MatchingStrategy = util.StringToPointer("strongest")
+	}
+
+	this.Arguments = arguments
+
+	this.recordPolicy = arguments.MatcherPolicy
+	if arguments.RecordMatchers == RecordRelaxedMatchers {
+		// both policies have been validated, so the one combining them is valid too
+		this.recordPolicy, _ = v2.NewMatcherPolicy(withRelaxedMatchers(arguments.MatcherPolicyView()))
+	}
+}
+
+func (this *HybridMode) Process(request *http.Request, details models.RequestDetails) (*http.Response, error) {
+	pair := models.RequestResponsePair{
+		Request: details,
+	}
+
+	response, matchingErr := this.Hoverfly.GetResponse(details)
+	if matchingErr != nil {
+		return this.record(request, pair)
+	}
+
+	pair.Response = *response
+
+	if pair, err := this.Hoverfly.ApplyMiddleware(pair); err == nil {
+		return ReconstructResponse(request, pair), nil
+	} else {
+		return ReturnErrorAndLog(request, err, &pair, "There was an error when executing middleware", Hybrid)
+	}
+}
+
+// record calls the real service for a request which has no match in the simulation,
+// saving the response so that the next request like it is simulated
+func (this *HybridMode) record(request *http.Request, pair models.RequestResponsePair) (*http.Response, error) {
+	pair, err := this.Hoverfly.ApplyMiddleware(pair)
+	if err != nil {
+		return ReturnErrorAndLog(request, err, &pair, "There was an error when applying middleware to http request", Hybrid)
+	}
+
+	modifiedRequest, err := ReconstructRequestForPassThrough(pair)
+	if err != nil {
+		return ReturnErrorAndLog(request, err, &pair, "There was an error when applying middleware to http request", Hybrid)
+	}
+
+	log.Info("Going to call real server")
+	response, err := this.Hoverfly.DoRequest(modifiedRequest)
+	if err != nil {
+		return ReturnErrorAndLog(request, err, &pair, "There was an error when forwarding the request to the intended destination", Hybrid)
+	}
+
+	arguments := this.Arguments
+	if arguments.CaptureFilter != nil {
+		skipReason := arguments.CaptureFilter.SkipRequest(pair.Request.Method, pair.Request.Destination, pair.Request.Path)
+		if skipReason == "" {
+			skipReason = arguments.CaptureFilter.SkipResponse(response.StatusCode, response.Header.Get("Content-Type"))
+		}
+		if skipReason != "" {
+			log.WithFields(log.Fields{
+				"mode":     Hybrid,
+				"request":  GetRequestLogFields(&pair.Request),
+				"filtered": skipReason,
+			}).Info("request proxied but not recorded")
+
+			return response, nil
+		}
+	}
+
+	respBody, _ := util.GetResponseBody(response)
+
+	responseObj := &models.ResponseDetails{
+		Status:  response.StatusCode,
+		Body:    string(respBody),
+		Headers: response.Header,
+	}
+
+	if arguments.Headers == nil {
+		arguments.Headers = []string{}
+	}
+
+	arguments.MatcherPolicy = this.recordPolicy
+
+	err = this.Hoverfly.Save(&pair.Request, responseObj, &arguments)
+	if err != nil {
+		return ReturnErrorAndLog(request, err, &pair, "There was an error when saving request and response", Hybrid)
+	}
+
+	log.WithFields(log.Fields{
+		"mode":     Hybrid,
+		"request":  GetRequestLogFields(&pair.Request),
+		"response": GetResponseLogFields(responseObj),
+	}).Info("request and response recorded")
+
+	return response, nil
+}
+
+// withRelaxedMatchers adds the relaxed matcher policy to the one given in the mode arguments
+func withRelaxedMatchers(policy *v2.MatcherPolicyView) v2.MatcherPolicyView {
+	relaxed := v2.MatcherPolicyView{
+		IgnoreFields:     append([]string{}, relaxedMatcherPolicy.IgnoreFields...),
+		GlobPathSegments: append([]string{}, relaxedMatcherPolicy.GlobPathSegments...),
+	}
+
+	if policy != nil {
+		relaxed.IgnoreFields = append(relaxed.IgnoreFields, policy.IgnoreFields...)
+		relaxed.IgnoreQuery = policy.IgnoreQuery
+		relaxed.GlobPathSegments = append(relaxed.GlobPathSegments, policy.GlobPathSegments...)
+		relaxed.BodyJsonPaths = policy.BodyJsonPaths
+	}
+
+	return relaxed
+}
@@ -0,0 +1,215 @@
+package modes_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/errors"
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/SpectoLabs/hoverfly/core/modes"
+	. "github.com/onsi/gomega"
+)
+
+type hoverflyHybridStub struct {
+	SavedRequest   *models.RequestDetails
+	SavedResponse  *models.ResponseDetails
+	SavedArguments *modes.ModeArguments
+}
+
+// GetResponse - Stub implementation of modes.HoverflyHybrid interface
+func (this *hoverflyHybridStub) GetResponse(requestDetails models.RequestDetails) (*models.ResponseDetails, *errors.HoverflyError) {
+	if requestDetails.Destination == "positive-match.com" {
+		return &models.ResponseDetails{
+			Status: 201,
+			Body:   "simulated",
+		}, nil
+	}
+
+	return nil, &errors.HoverflyError{
+		Message: "matching-error",
+	}
+}
+
+// ApplyMiddleware - Stub implementation of modes.HoverflyHybrid interface
+func (this *hoverflyHybridStub) ApplyMiddleware(pair models.RequestResponsePair) (models.RequestResponsePair, error) {
+	if pair.Request.Path == "middleware-error" {
+		return pair, fmt.Errorf("middleware-error")
+	}
+	return pair, nil
+}
+
+// DoRequest - Stub implementation of modes.HoverflyHybrid interface
+func (this *hoverflyHybridStub) DoRequest(request *http.Request) (*http.Response, error) {
+	if request.Host == "error.com" {
+		return nil, fmt.Errorf("Could not reach error.com")
+	}
+
+	return &http.Response{
+		StatusCode: 200,
+		Header:     http.Header{"Content-Type": []string{"text/plain"}},
+		Body:       ioutil.NopCloser(bytes.NewBufferString("test")),
+	}, nil
+}
+
+// Save - Stub implementation of modes.HoverflyHybrid interface
+func (this *hoverflyHybridStub) Save(request *models.RequestDetails, response *models.ResponseDetails, arguments *modes.ModeArguments) error {
+	this.SavedRequest = request
+	this.SavedResponse = response
+	this.SavedArguments = arguments
+
+	return nil
+}
+
+func Test_HybridMode_SetArguments_DefaultsMatchingStrategyToStrongest(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &modes.HybridMode{
+		Hoverfly: &hoverflyHybridStub{},
+	}
+
+	unit.SetArguments(modes.ModeArguments{
+		RecordMatchers: modes.RecordRelaxedMatchers,
+	})
+
+	Expect(*unit.View().Arguments.MatchingStrategy).To(Equal("strongest"))
+	Expect(unit.View().Arguments.RecordMatchers).To(Equal("relaxed"))
+}
+
+func Test_HybridMode_WhenGivenAMatchingRequestItReturnsTheSimulatedResponseWithoutSaving(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverflyStub := &hoverflyHybridStub{}
+	unit := &modes.HybridMode{
+		Hoverfly: hoverflyStub,
+	}
+
+	response, err := unit.Process(&http.Request{}, models.RequestDetails{
+		Destination: "positive-match.com",
+	})
+	Expect(err).To(BeNil())
+
+	Expect(response.StatusCode).To(Equal(201))
+	Expect(hoverflyStub.SavedRequest).To(BeNil())
+}
+
+func Test_HybridMode_WhenGivenANonMatchingRequestItWillMakeTheRequestAndSaveIt(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverflyStub := &hoverflyHybridStub{}
+	unit := &modes.HybridMode{
+		Hoverfly: hoverflyStub,
+	}
+
+	request, err := http.NewRequest("GET", "http://negative-match.com", nil)
+	Expect(err).To(BeNil())
+
+	response, err := unit.Process(request, models.RequestDetails{
+		Scheme:      "http",
+		Destination: "negative-match.com",
+	})
+	Expect(err).To(BeNil())
+
+	Expect(response.StatusCode).To(Equal(200))
+
+	responseBody, err := ioutil.ReadAll(response.Body)
+	Expect(err).To(BeNil())
+	Expect(string(responseBody)).To(Equal("test"))
+
+	Expect(hoverflyStub.SavedRequest.Destination).To(Equal("negative-match.com"))
+	Expect(hoverflyStub.SavedResponse.Body).To(Equal("test"))
+	Expect(hoverflyStub.SavedArguments.Headers).To(Equal([]string{}))
+	Expect(hoverflyStub.SavedArguments.MatcherPolicy).To(BeNil())
+}
+
+func Test_HybridMode_RecordsRelaxedMatchersWhenChosen(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverflyStub := &hoverflyHybridStub{}
+	unit := &modes.HybridMode{
+		Hoverfly: hoverflyStub,
+	}
+
+	unit.SetArguments(modes.ModeArguments{
+		RecordMatchers: modes.RecordRelaxedMatchers,
+		MatcherPolicy: mustMatcherPolicy(v2.MatcherPolicyView{
+			IgnoreQuery: []string{"timestamp"},
+		}),
+	})
+
+	request, err := http.NewRequest("GET", "http://negative-match.com", nil)
+	Expect(err).To(BeNil())
+
+	_, err = unit.Process(request, models.RequestDetails{
+		Scheme:      "http",
+		Destination: "negative-match.com",
+	})
+	Expect(err).To(BeNil())
+
+	Expect(*hoverflyStub.SavedArguments.MatcherPolicyView()).To(Equal(v2.MatcherPolicyView{
+		IgnoreFields:     []string{"scheme"},
+		IgnoreQuery:      []string{"timestamp"},
+		GlobPathSegments: []string{"uuid", "number"},
+	}))
+	Expect(*unit.Arguments.MatcherPolicyView()).To(Equal(v2.MatcherPolicyView{
+		IgnoreQuery: []string{"timestamp"},
+	}))
+}
+
+func Test_HybridMode_ProxiesButDoesNotSaveFilteredRequests(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverflyStub := &hoverflyHybridStub{}
+	unit := &modes.HybridMode{
+		Hoverfly: hoverflyStub,
+	}
+
+	unit.SetArguments(modes.ModeArguments{
+		CaptureFilter: mustCaptureFilter(v2.CaptureFilterView{
+			ContentTypes: []string{"application/json"},
+		}),
+	})
+
+	request, err := http.NewRequest("GET", "http://negative-match.com", nil)
+	Expect(err).To(BeNil())
+
+	response, err := unit.Process(request, models.RequestDetails{
+		Scheme:      "http",
+		Destination: "negative-match.com",
+	})
+	Expect(err).To(BeNil())
+
+	Expect(response.StatusCode).To(Equal(200))
+	Expect(hoverflyStub.SavedRequest).To(BeNil())
+}
+
+func Test_HybridMode_WhenTheRealServerCannotBeReachedItReturnsAnError(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverflyStub := &hoverflyHybridStub{}
+	unit := &modes.HybridMode{
+		Hoverfly: hoverflyStub,
+	}
+
+	request, err := http.NewRequest("GET", "http://error.com", nil)
+	Expect(err).To(BeNil())
+
+	response, err := unit.Process(request, models.RequestDetails{
+		Scheme:      "http",
+		Destination: "error.com",
+	})
+	Expect(err).ToNot(BeNil())
+
+	Expect(response.StatusCode).To(Equal(http.StatusBadGateway))
+	Expect(hoverflyStub.SavedRequest).To(BeNil())
+}
+
+func mustMatcherPolicy(view v2.MatcherPolicyView) *v2.MatcherPolicy {
+	policy, err := v2.NewMatcherPolicy(view)
+	Expect(err).To(BeNil())
+
+	return policy
+}
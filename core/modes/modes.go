@@ -13,6 +13,7 @@ import (
 	"github.com/SpectoLabs/goproxy"
 	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/SpectoLabs/hoverfly/core/util"
 )
 
 // SimulateMode - default mode when Hoverfly looks for captured requests to respond
@@ -33,6 +34,28 @@ const Spy = "spy"
 // DiffMode - calls real service and compares response with simulation
 const Diff = "diff"
 
+// ShadowMode - simulateMode but will also call real service in the background and compare its response with simulation
+const Shadow = "shadow"
+
+// HybridMode - simulateMode but will call real service and capture the response when cache miss
+const Hybrid = "hybrid"
+
+// Record matchers decide the request matchers of the pairs hybrid mode records. Exact matchers are
+// the same as capture mode's, relaxed matchers do not match on scheme and glob ids in the path.
+const (
+	RecordExactMatchers   = "exact"
+	RecordRelaxedMatchers = "relaxed"
+)
+
+// Capture policies decide what is saved when a request is captured more than once. Without one,
+// every response is saved in sequence when stateful is set and only the first one is saved otherwise.
+const (
+	CaptureAppendAll               = "appendAll"
+	CaptureFirstWins               = "firstWins"
+	CaptureLastWins                = "lastWins"
+	CaptureSequenceOnlyIfDifferent = "sequenceOnlyIfDifferent"
+)
+
 type Mode interface {
 	Process(*http.Request, models.RequestDetails) (*http.Response, error)
 	SetArguments(arguments ModeArguments)
@@ -43,6 +66,44 @@ type ModeArguments struct {
 	Headers          []string
 	MatchingStrategy *string
 	Stateful         bool
+	Redactions       []*v2.RedactionRule
+	MatcherPolicy    *v2.MatcherPolicy
+	CapturePolicy    string
+	CaptureFilter    *v2.CaptureFilter
+	RecordMatchers   string
+	DiffRules        *v2.DiffRules
+}
+
+// MatcherPolicyView returns the view of the matcher policy, or nil when there is none
+func (this ModeArguments) MatcherPolicyView() *v2.MatcherPolicyView {
+	if this.MatcherPolicy == nil {
+		return nil
+	}
+
+	return &this.MatcherPolicy.MatcherPolicyView
+}
+
+// CaptureFilterView returns the view of the capture filter, or nil when there is none
+func (this ModeArguments) CaptureFilterView() *v2.CaptureFilterView {
+	if this.CaptureFilter == nil {
+		return nil
+	}
+
+	return &this.CaptureFilter.CaptureFilterView
+}
+
+// RedactionsView returns the views of the redaction rules
+func (this ModeArguments) RedactionsView() []v2.RedactionRuleView {
+	return v2.RedactionRuleViews(this.Redactions)
+}
+
+// DiffRulesView returns the view of the diff rules, or nil when there are none
+func (this ModeArguments) DiffRulesView() *v2.DiffRulesView {
+	if this.DiffRules == nil {
+		return nil
+	}
+
+	return &this.DiffRules.DiffRulesView
 }
 
 // ReconstructRequest replaces original request with details provided in Constructor Payload.RequestMatcher
@@ -96,6 +157,9 @@ func ReconstructRequestForPassThrough(pair models.RequestResponsePair) (*http.Re
 func ReconstructResponse(request *http.Request, pair models.RequestResponsePair) *http.Response {
 	response := &http.Response{}
 	response.Request = request
+	if request != nil && pair.Response.PairId != "" {
+		response.Request = util.WithPairId(request, pair.Response.PairId)
+	}
 
 	// adding body, length, status code
 	buf := bytes.NewBufferString(pair.Response.Body)
@@ -8,6 +8,7 @@ import (
 
 	"github.com/SpectoLabs/hoverfly/core/models"
 	"github.com/SpectoLabs/hoverfly/core/modes"
+	"github.com/SpectoLabs/hoverfly/core/util"
 	. "github.com/onsi/gomega"
 )
 
@@ -119,6 +120,24 @@ func Test_ReconstructResponse_ReturnsAResponseWithCorrectStatus(t *testing.T) {
 	Expect(response.StatusCode).To(Equal(404))
 }
 
+func Test_ReconstructResponse_RecordsThePairIdOnTheRequest(t *testing.T) {
+	RegisterTestingT(t)
+
+	req, _ := http.NewRequest("GET", "http://example.com", nil)
+
+	pair := models.RequestResponsePair{
+		Response: models.ResponseDetails{
+			Status: 200,
+			PairId: "get-payment",
+		},
+	}
+
+	response := modes.ReconstructResponse(req, pair)
+
+	Expect(util.GetPairId(response)).To(Equal("get-payment"))
+	Expect(response.Request.URL.String()).To(Equal("http://example.com"))
+}
+
 func Test_ReconstructResponse_ReturnsAResponseWithBody(t *testing.T) {
 	RegisterTestingT(t)
 
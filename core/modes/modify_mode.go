@@ -11,6 +11,8 @@ import (
 type HoverflyModify interface {
 	ApplyMiddleware(models.RequestResponsePair) (models.RequestResponsePair, error)
 	DoRequest(*http.Request) (*http.Response, error)
+	TransformRequest(models.RequestDetails) models.RequestDetails
+	TransformResponse(models.RequestDetails, models.ResponseDetails) (models.ResponseDetails, bool)
 }
 
 type ModifyMode struct {
@@ -26,7 +28,7 @@ func (this *ModifyMode) View() v2.ModeView {
 func (this *ModifyMode) SetArguments(arguments ModeArguments) {}
 
 func (this ModifyMode) Process(request *http.Request, details models.RequestDetails) (*http.Response, error) {
-	pair, err := this.Hoverfly.ApplyMiddleware(models.RequestResponsePair{Request: details})
+	pair, err := this.Hoverfly.ApplyMiddleware(models.RequestResponsePair{Request: this.Hoverfly.TransformRequest(details)})
 	if err != nil {
 		return ReturnErrorAndLog(request, err, &pair, "There was an error when executing middleware", Modify)
 	}
@@ -57,5 +59,7 @@ func (this ModifyMode) Process(request *http.Request, details models.RequestDeta
 		return ReturnErrorAndLog(request, err, &pair, "There was an error when executing middleware", Modify)
 	}
 
+	pair.Response, _ = this.Hoverfly.TransformResponse(details, pair.Response)
+
 	return ReconstructResponse(modifiedRequest, pair), nil
 }
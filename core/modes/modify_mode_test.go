@@ -36,6 +36,21 @@ func (this hoverflyModifyStub) ApplyMiddleware(pair models.RequestResponsePair)
 	return pair, nil
 }
 
+func (this hoverflyModifyStub) TransformRequest(request models.RequestDetails) models.RequestDetails {
+	if request.Destination == "transform.com" {
+		request.Path = "/transformed"
+	}
+	return request
+}
+
+func (this hoverflyModifyStub) TransformResponse(request models.RequestDetails, response models.ResponseDetails) (models.ResponseDetails, bool) {
+	if request.Destination != "transform.com" {
+		return response, false
+	}
+	response.Body = "transformed " + response.Body
+	return response, true
+}
+
 func Test_ModifyMode_WhenGivenARequestItWillModifyTheRequestAndExecuteIt(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -65,6 +80,33 @@ func Test_ModifyMode_WhenGivenARequestItWillModifyTheRequestAndExecuteIt(t *test
 	Expect(string(responseBody)).To(Equal("modified by test middleware"))
 }
 
+func Test_ModifyMode_TransformsTheRequestBeforeMiddlewareAndTheResponseAfterIt(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &modes.ModifyMode{
+		Hoverfly: &hoverflyModifyStub{},
+	}
+
+	requestDetails := models.RequestDetails{
+		Scheme:      "http",
+		Destination: "transform.com",
+		Path:        "/original",
+	}
+
+	request, err := http.NewRequest("GET", "http://transform.com/original", nil)
+	Expect(err).To(BeNil())
+
+	response, err := unit.Process(request, requestDetails)
+	Expect(err).To(BeNil())
+
+	Expect(response.Request.URL.Path).To(Equal("/transformed"))
+
+	responseBody, err := ioutil.ReadAll(response.Body)
+	Expect(err).To(BeNil())
+
+	Expect(string(responseBody)).To(Equal("transformed modified by test middleware"))
+}
+
 func Test_ModifyMode_WhenGivenABadRequestItWillError(t *testing.T) {
 	RegisterTestingT(t)
 
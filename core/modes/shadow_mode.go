@@ -0,0 +1,217 @@
+package modes
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/SpectoLabs/hoverfly/core/errors"
+	"github.com/SpectoLabs/hoverfly/core/util"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/models"
+)
+
+const (
+	DefaultShadowWorkers   = 4
+	DefaultShadowQueueSize = 100
+)
+
+type HoverflyShadow interface {
+	GetResponse(models.RequestDetails) (*models.ResponseDetails, *errors.HoverflyError)
+	ApplyMiddleware(models.RequestResponsePair) (models.RequestResponsePair, error)
+	DoRequest(*http.Request) (*http.Response, error)
+	AddDiff(requestView v2.SimpleRequestDefinitionView, diffReport v2.DiffReport)
+}
+
+// shadowRequest is a request which was answered from the simulation, waiting to be sent to the real service
+type shadowRequest struct {
+	request   models.RequestDetails
+	simulated models.ResponseDetails
+	arguments ModeArguments
+	timestamp string
+}
+
+// ShadowMode responds from the simulation like simulate mode, then calls the real service in the
+// background and records where its response differs as diff mode does. The real service is called by
+// a fixed number of workers from a bounded queue, and requests are not shadowed while the queue is full.
+type ShadowMode struct {
+	Hoverfly  HoverflyShadow
+	Arguments ModeArguments
+	Workers   int
+	QueueSize int
+
+	queue      chan shadowRequest
+	queueMutex sync.RWMutex
+	stopped    bool
+	startOnce  sync.Once
+	stats      v2.ShadowStatsView
+	statsMutex sync.Mutex
+}
+
+func (this *ShadowMode) View() v2.ModeView {
+	this.statsMutex.Lock()
+	stats := this.stats
+	stats.Pending = len(this.queue)
+	this.statsMutex.Unlock()
+
+	return v2.ModeView{
+		Mode:     Shadow,
+		Shadowed: &stats,
+		Arguments: v2.ModeArgumentsView{
+			Headers:          this.Arguments.Headers,
+			MatchingStrategy: this.Arguments.MatchingStrategy,
+			DiffRules:        this.Arguments.DiffRulesView(),
+		},
+	}
+}
+
+func (this *ShadowMode) SetArguments(arguments ModeArguments) {
+	if arguments.MatchingStrategy == nil {
+		arguments.MatchingStrategy = util.StringToPointer("strongest")
+	}
+
+	this.Arguments = arguments
+
+	this.statsMutex.Lock()
+	this.stats = v2.ShadowStatsView{}
+	this.statsMutex.Unlock()
+}
+
+func (this *ShadowMode) Process(request *http.Request, details models.RequestDetails) (*http.Response, error) {
+	pair := models.RequestResponsePair{
+		Request: details,
+	}
+
+	response, matchingErr := this.Hoverfly.GetResponse(details)
+	if matchingErr != nil {
+		return ReturnErrorAndLog(request, matchingErr, &pair, "There was an error when matching", Shadow)
+	}
+
+	this.enqueue(shadowRequest{
+		request:   details,
+		simulated: *response,
+		arguments: this.Arguments,
+		timestamp: time.Now().Format(time.RFC3339),
+	})
+
+	pair.Response = *response
+
+	if pair, err := this.Hoverfly.ApplyMiddleware(pair); err == nil {
+		return ReconstructResponse(request, pair), nil
+	} else {
+		return ReturnErrorAndLog(request, err, &pair, "There was an error when executing middleware", Shadow)
+	}
+}
+
+// enqueue hands a request to the workers without waiting, dropping it when they are too far behind
+func (this *ShadowMode) enqueue(shadowed shadowRequest) {
+	this.queueMutex.RLock()
+	defer this.queueMutex.RUnlock()
+
+	if this.stopped {
+		return
+	}
+
+	this.startOnce.Do(this.startWorkers)
+
+	select {
+	case this.queue <- shadowed:
+	default:
+		this.statsMutex.Lock()
+		this.stats.Dropped++
+		this.statsMutex.Unlock()
+
+		log.WithFields(log.Fields{
+			"mode":    Shadow,
+			"request": GetRequestLogFields(&shadowed.request),
+		}).Warn("Shadow queue is full, the request will not be sent to the real server")
+	}
+}
+
+func (this *ShadowMode) startWorkers() {
+	workers := this.Workers
+	if workers <= 0 {
+		workers = DefaultShadowWorkers
+	}
+	queueSize := this.QueueSize
+	if queueSize <= 0 {
+		queueSize = DefaultShadowQueueSize
+	}
+
+	this.statsMutex.Lock()
+	this.queue = make(chan shadowRequest, queueSize)
+	this.statsMutex.Unlock()
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			for shadowed := range this.queue {
+				this.shadow(shadowed)
+			}
+		}()
+	}
+}
+
+// Stop closes the queue, so the workers exit once they have shadowed the requests already in it.
+// Requests processed after it is stopped are still answered from the simulation but not shadowed.
+func (this *ShadowMode) Stop() {
+	this.queueMutex.Lock()
+	defer this.queueMutex.Unlock()
+
+	if this.stopped {
+		return
+	}
+	this.stopped = true
+
+	if this.queue != nil {
+		close(this.queue)
+	}
+}
+
+func (this *ShadowMode) shadow(shadowed shadowRequest) {
+	pair := models.RequestResponsePair{
+		Request: shadowed.request,
+	}
+
+	modifiedRequest, err := ReconstructRequestForPassThrough(pair)
+	if err == nil {
+		var actualResponse *http.Response
+		actualResponse, err = this.Hoverfly.DoRequest(modifiedRequest)
+		if err == nil {
+			respBody, _ := util.GetResponseBody(actualResponse)
+
+			diffReport := DiffResponses(&shadowed.simulated, &models.ResponseDetails{
+				Status:  actualResponse.StatusCode,
+				Body:    respBody,
+				Headers: actualResponse.Header,
+			}, shadowed.arguments, shadowed.timestamp)
+
+			this.Hoverfly.AddDiff(v2.SimpleRequestDefinitionView{
+				Method: modifiedRequest.Method,
+				Host:   modifiedRequest.URL.Host,
+				Path:   modifiedRequest.URL.Path,
+				Query:  modifiedRequest.URL.RawQuery,
+			}, diffReport)
+
+			this.statsMutex.Lock()
+			this.stats.Compared++
+			if len(diffReport.DiffEntries) > 0 {
+				this.stats.Diverged++
+			}
+			this.statsMutex.Unlock()
+			return
+		}
+	}
+
+	this.statsMutex.Lock()
+	this.stats.Failed++
+	this.statsMutex.Unlock()
+
+	log.WithFields(log.Fields{
+		"error":   err.Error(),
+		"mode":    Shadow,
+		"request": GetRequestLogFields(&pair.Request),
+	}).Warn("There was an error when shadowing the request to the real server")
+}
@@ -0,0 +1,243 @@
+package modes_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/errors"
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/SpectoLabs/hoverfly/core/modes"
+	. "github.com/onsi/gomega"
+)
+
+type hoverflyShadowStub struct {
+	// Upstream blocks calls to the real server until it is closed, when it is set
+	Upstream chan struct{}
+
+	diffs      map[v2.SimpleRequestDefinitionView][]v2.DiffReport
+	diffsMutex sync.Mutex
+}
+
+// GetResponse - Stub implementation of modes.HoverflyShadow interface
+func (this *hoverflyShadowStub) GetResponse(requestDetails models.RequestDetails) (*models.ResponseDetails, *errors.HoverflyError) {
+	if requestDetails.Destination == "negative-match.com" {
+		return nil, &errors.HoverflyError{
+			Message: "matching-error",
+		}
+	}
+
+	return &models.ResponseDetails{
+		Status: 200,
+		Body:   "simulated",
+	}, nil
+}
+
+// ApplyMiddleware - Stub implementation of modes.HoverflyShadow interface
+func (this *hoverflyShadowStub) ApplyMiddleware(pair models.RequestResponsePair) (models.RequestResponsePair, error) {
+	return pair, nil
+}
+
+// DoRequest - Stub implementation of modes.HoverflyShadow interface
+func (this *hoverflyShadowStub) DoRequest(request *http.Request) (*http.Response, error) {
+	if this.Upstream != nil {
+		<-this.Upstream
+	}
+
+	if request.Host == "error.com" {
+		return nil, fmt.Errorf("Could not reach error.com")
+	}
+
+	body := "simulated"
+	if request.Host == "changed.com" {
+		body = "real"
+	}
+
+	return &http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+	}, nil
+}
+
+// AddDiff - Stub implementation of modes.HoverflyShadow interface
+func (this *hoverflyShadowStub) AddDiff(requestView v2.SimpleRequestDefinitionView, diffReport v2.DiffReport) {
+	this.diffsMutex.Lock()
+	defer this.diffsMutex.Unlock()
+
+	if this.diffs == nil {
+		this.diffs = map[v2.SimpleRequestDefinitionView][]v2.DiffReport{}
+	}
+	if len(diffReport.DiffEntries) > 0 {
+		this.diffs[requestView] = append(this.diffs[requestView], diffReport)
+	}
+}
+
+func (this *hoverflyShadowStub) GetDiffs() map[v2.SimpleRequestDefinitionView][]v2.DiffReport {
+	this.diffsMutex.Lock()
+	defer this.diffsMutex.Unlock()
+
+	diffs := map[v2.SimpleRequestDefinitionView][]v2.DiffReport{}
+	for request, reports := range this.diffs {
+		diffs[request] = reports
+	}
+
+	return diffs
+}
+
+func shadowedRequest(destination string) (*http.Request, models.RequestDetails) {
+	request, err := http.NewRequest("GET", "http://"+destination+"/path", nil)
+	Expect(err).To(BeNil())
+
+	return request, models.RequestDetails{
+		Method:      "GET",
+		Scheme:      "http",
+		Destination: destination,
+		Path:        "/path",
+	}
+}
+
+func Test_ShadowMode_ReturnsTheSimulatedResponseWithoutWaitingForTheRealServer(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverflyStub := &hoverflyShadowStub{
+		Upstream: make(chan struct{}),
+	}
+	defer close(hoverflyStub.Upstream)
+
+	unit := &modes.ShadowMode{
+		Hoverfly: hoverflyStub,
+	}
+
+	response, err := unit.Process(shadowedRequest("changed.com"))
+	Expect(err).To(BeNil())
+
+	Expect(response.StatusCode).To(Equal(200))
+
+	responseBody, err := ioutil.ReadAll(response.Body)
+	Expect(err).To(BeNil())
+	Expect(string(responseBody)).To(Equal("simulated"))
+}
+
+func Test_ShadowMode_RecordsDifferencesFromTheRealServer(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverflyStub := &hoverflyShadowStub{}
+	unit := &modes.ShadowMode{
+		Hoverfly: hoverflyStub,
+	}
+	unit.SetArguments(modes.ModeArguments{})
+
+	_, err := unit.Process(shadowedRequest("changed.com"))
+	Expect(err).To(BeNil())
+	_, err = unit.Process(shadowedRequest("unchanged.com"))
+	Expect(err).To(BeNil())
+
+	Eventually(func() int { return unit.View().Shadowed.Compared }).Should(Equal(2))
+
+	Expect(unit.View().Shadowed.Diverged).To(Equal(1))
+	Expect(hoverflyStub.GetDiffs()).To(HaveLen(1))
+
+	diffReports := hoverflyStub.GetDiffs()[v2.SimpleRequestDefinitionView{
+		Method: "GET",
+		Host:   "changed.com",
+		Path:   "/path",
+	}]
+	Expect(diffReports).To(HaveLen(1))
+	Expect(diffReports[0].Timestamp).ToNot(BeEmpty())
+	Expect(diffReports[0].DiffEntries).To(ConsistOf(v2.DiffReportEntry{
+		Field:    "body",
+		Expected: "simulated",
+		Actual:   "real",
+	}))
+}
+
+func Test_ShadowMode_CountsRequestsTheRealServerFailedFor(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &modes.ShadowMode{
+		Hoverfly: &hoverflyShadowStub{},
+	}
+
+	_, err := unit.Process(shadowedRequest("error.com"))
+	Expect(err).To(BeNil())
+
+	Eventually(func() int { return unit.View().Shadowed.Failed }).Should(Equal(1))
+	Expect(unit.View().Shadowed.Compared).To(Equal(0))
+}
+
+func Test_ShadowMode_DropsRequestsWhenTheQueueIsFull(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverflyStub := &hoverflyShadowStub{
+		Upstream: make(chan struct{}),
+	}
+
+	unit := &modes.ShadowMode{
+		Hoverfly:  hoverflyStub,
+		Workers:   1,
+		QueueSize: 1,
+	}
+
+	// the first request is taken by the worker, the second one waits in the queue
+	unit.Process(shadowedRequest("changed.com"))
+	Eventually(func() int { return unit.View().Shadowed.Pending }).Should(Equal(0))
+	unit.Process(shadowedRequest("changed.com"))
+
+	response, err := unit.Process(shadowedRequest("changed.com"))
+	Expect(err).To(BeNil())
+	Expect(response.StatusCode).To(Equal(200))
+
+	Expect(unit.View().Shadowed.Pending).To(Equal(1))
+	Expect(unit.View().Shadowed.Dropped).To(Equal(1))
+
+	close(hoverflyStub.Upstream)
+
+	Eventually(func() int { return unit.View().Shadowed.Compared }).Should(Equal(2))
+}
+
+func Test_ShadowMode_DoesNotShadowRequestsWithoutAMatch(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &modes.ShadowMode{
+		Hoverfly: &hoverflyShadowStub{},
+	}
+
+	response, err := unit.Process(shadowedRequest("negative-match.com"))
+	Expect(err).ToNot(BeNil())
+
+	Expect(response.StatusCode).To(Equal(http.StatusBadGateway))
+	Expect(unit.View().Shadowed).To(Equal(&v2.ShadowStatsView{}))
+}
+
+func Test_ShadowMode_Stop_ShadowsQueuedRequestsButNoNewOnes(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverflyStub := &hoverflyShadowStub{
+		Upstream: make(chan struct{}),
+	}
+
+	unit := &modes.ShadowMode{
+		Hoverfly: hoverflyStub,
+		Workers:  1,
+	}
+
+	unit.Process(shadowedRequest("changed.com"))
+	unit.Process(shadowedRequest("changed.com"))
+
+	unit.Stop()
+	unit.Stop()
+	close(hoverflyStub.Upstream)
+
+	Eventually(func() int { return unit.View().Shadowed.Compared }).Should(Equal(2))
+
+	response, err := unit.Process(shadowedRequest("changed.com"))
+	Expect(err).To(BeNil())
+	Expect(response.StatusCode).To(Equal(200))
+
+	Consistently(func() int { return unit.View().Shadowed.Compared }).Should(Equal(2))
+	Expect(unit.View().Shadowed.Pending).To(Equal(0))
+}
@@ -9,12 +9,16 @@ import (
 
 	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/SpectoLabs/hoverfly/core/util"
 )
 
 type HoverflySpy interface {
 	GetResponse(models.RequestDetails) (*models.ResponseDetails, *errors.HoverflyError)
 	ApplyMiddleware(models.RequestResponsePair) (models.RequestResponsePair, error)
 	DoRequest(*http.Request) (*http.Response, error)
+	TransformRequest(models.RequestDetails) models.RequestDetails
+	TransformResponse(models.RequestDetails, models.ResponseDetails) (models.ResponseDetails, bool)
+	TransformsResponse(models.RequestDetails) bool
 }
 
 type SpyMode struct {
@@ -49,12 +53,16 @@ func (this SpyMode) Process(request *http.Request, details models.RequestDetails
 
 	if matchingErr != nil {
 		log.Info("Going to call real server")
-		modifiedRequest, err := ReconstructRequestForPassThrough(pair)
+		// transformation rules only change the requests to the real server and its responses,
+		// simulated responses are returned as they are
+		modifiedRequest, err := ReconstructRequestForPassThrough(models.RequestResponsePair{
+			Request: this.Hoverfly.TransformRequest(details),
+		})
 		if err == nil {
 			response, err := this.Hoverfly.DoRequest(modifiedRequest)
 			if err == nil {
 				log.Info("Going to return response from real server")
-				return response, nil
+				return this.transformResponse(details, modifiedRequest, response), nil
 			}
 		}
 	}
@@ -71,3 +79,27 @@ func (this SpyMode) Process(request *http.Request, details models.RequestDetails
 		return ReturnErrorAndLog(request, err, &pair, "There was an error when executing middleware", Simulate)
 	}
 }
+
+// transformResponse applies the transformation rules to a response from the real server, which is
+// passed through without reading its body when no rule changes it
+func (this SpyMode) transformResponse(details models.RequestDetails, request *http.Request, response *http.Response) *http.Response {
+	if !this.Hoverfly.TransformsResponse(details) {
+		return response
+	}
+
+	body, err := util.GetResponseBody(response)
+	if err != nil {
+		return response
+	}
+
+	transformed, ok := this.Hoverfly.TransformResponse(details, models.ResponseDetails{
+		Status:  response.StatusCode,
+		Body:    body,
+		Headers: response.Header,
+	})
+	if !ok {
+		return response
+	}
+
+	return ReconstructResponse(request, models.RequestResponsePair{Response: transformed})
+}
@@ -3,6 +3,7 @@ package modes_test
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"testing"
@@ -13,7 +14,10 @@ import (
 	. "github.com/onsi/gomega"
 )
 
-type hoverflySpyStub struct{}
+type hoverflySpyStub struct {
+	// Body is the body of the responses from the real server, when it is set
+	Body io.ReadCloser
+}
 
 // DoRequest - Stub implementation of modes.HoverflySpy interface
 func (this hoverflySpyStub) DoRequest(request *http.Request) (*http.Response, error) {
@@ -24,6 +28,9 @@ func (this hoverflySpyStub) DoRequest(request *http.Request) (*http.Response, er
 
 	response.StatusCode = 200
 	response.Body = ioutil.NopCloser(bytes.NewBufferString("test"))
+	if this.Body != nil {
+		response.Body = this.Body
+	}
 
 	return response, nil
 }
@@ -47,6 +54,28 @@ func (this hoverflySpyStub) ApplyMiddleware(pair models.RequestResponsePair) (mo
 	return pair, nil
 }
 
+// TransformRequest - Stub implementation of modes.HoverflySpy interface
+func (this hoverflySpyStub) TransformRequest(request models.RequestDetails) models.RequestDetails {
+	if request.Destination == "transform.com" {
+		request.Path = "/transformed"
+	}
+	return request
+}
+
+// TransformResponse - Stub implementation of modes.HoverflySpy interface
+func (this hoverflySpyStub) TransformResponse(request models.RequestDetails, response models.ResponseDetails) (models.ResponseDetails, bool) {
+	if request.Destination != "transform.com" {
+		return response, false
+	}
+	response.Body = "transformed " + response.Body
+	return response, true
+}
+
+// TransformsResponse - Stub implementation of modes.HoverflySpy interface
+func (this hoverflySpyStub) TransformsResponse(request models.RequestDetails) bool {
+	return request.Destination == "transform.com"
+}
+
 func Test_SpyMode_WhenGivenAMatchingRequestItReturnsTheCorrectResponse(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -90,6 +119,53 @@ func Test_SpyMode_WhenGivenANonMatchingRequestItWillMakeTheRequestAndReturnIt(t
 	Expect(string(responseBody)).To(Equal("test"))
 }
 
+func Test_SpyMode_TransformsRequestsToTheRealServerAndItsResponses(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := &modes.SpyMode{
+		Hoverfly: hoverflySpyStub{},
+	}
+
+	requestDetails := models.RequestDetails{
+		Scheme:      "http",
+		Destination: "transform.com",
+		Path:        "/original",
+	}
+
+	request, err := http.NewRequest("GET", "http://transform.com/original", nil)
+	Expect(err).To(BeNil())
+
+	response, err := unit.Process(request, requestDetails)
+	Expect(err).To(BeNil())
+
+	Expect(response.Request.URL.Path).To(Equal("/transformed"))
+
+	responseBody, err := ioutil.ReadAll(response.Body)
+	Expect(err).To(BeNil())
+
+	Expect(string(responseBody)).To(Equal("transformed test"))
+}
+
+func Test_SpyMode_DoesNotReadResponsesNoRuleTransforms(t *testing.T) {
+	RegisterTestingT(t)
+
+	body := ioutil.NopCloser(bytes.NewBufferString("test"))
+	unit := &modes.SpyMode{
+		Hoverfly: hoverflySpyStub{Body: body},
+	}
+
+	request, err := http.NewRequest("GET", "http://negative-match.com", nil)
+	Expect(err).To(BeNil())
+
+	response, err := unit.Process(request, models.RequestDetails{
+		Scheme:      "http",
+		Destination: "negative-match.com",
+	})
+	Expect(err).To(BeNil())
+
+	Expect(response.Body).To(BeIdenticalTo(body))
+}
+
 func Test_SpyMode_WhenGivenAMatchingRequesAndMiddlewareFaislItReturnsAnError(t *testing.T) {
 	RegisterTestingT(t)
 
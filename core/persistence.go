@@ -0,0 +1,290 @@
+package hoverfly
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+)
+
+// SimulationBucketName - BoltDB bucket used to persist the simulation and state
+const SimulationBucketName = "simulationBucket"
+
+var (
+	simulationPersistenceKey       = []byte("simulation")
+	statePersistenceKey            = []byte("state")
+	namedSimulationsPersistenceKey = []byte("simulations")
+	middlewarePersistenceKey       = []byte("middleware")
+)
+
+// PersistenceInterval is how long changes are collected before they are written
+// together, so a burst of captured requests results in a single write
+var PersistenceInterval = 100 * time.Millisecond
+
+// The parts of Hoverfly which are persisted, each written when it has changed
+const (
+	persistedSimulation = 1 << iota
+	persistedState
+	persistedNamedSimulations
+	persistedMiddleware
+)
+
+type persistedSimulations struct {
+	Simulations map[string]v2.SimulationViewV6 `json:"simulations"`
+	Active      []string                       `json:"active"`
+}
+
+// pendingPersistence holds the parts which have changed since they were last written,
+// whether a write of them has been scheduled, and whether persistence has been closed
+type pendingPersistence struct {
+	changes   int
+	scheduled bool
+	closed    bool
+	mutex     sync.Mutex
+}
+
+// persistSimulation schedules a write of the current simulation, including its delays
+func (hf *Hoverfly) persistSimulation() {
+	hf.schedulePersistence(persistedSimulation)
+}
+
+// persistState schedules a write of the current state, along with any transitions
+// still pending
+func (hf *Hoverfly) persistState() {
+	hf.schedulePersistence(persistedState)
+}
+
+// persistNamedSimulations schedules a write of the named simulations and the names
+// of those which are active
+func (hf *Hoverfly) persistNamedSimulations() {
+	hf.schedulePersistence(persistedNamedSimulations)
+}
+
+// persistMiddleware schedules a write of the middleware
+func (hf *Hoverfly) persistMiddleware() {
+	hf.schedulePersistence(persistedMiddleware)
+}
+
+// schedulePersistence marks the parts as changed, writing them in the background once
+// PersistenceInterval has passed if a persistence store has been configured and not closed
+func (hf *Hoverfly) schedulePersistence(changes int) {
+	if hf.Persistence == nil {
+		return
+	}
+
+	hf.pendingPersistence.mutex.Lock()
+	defer hf.pendingPersistence.mutex.Unlock()
+
+	if hf.pendingPersistence.closed {
+		return
+	}
+
+	hf.pendingPersistence.changes |= changes
+	if !hf.pendingPersistence.scheduled {
+		hf.pendingPersistence.scheduled = true
+		time.AfterFunc(PersistenceInterval, hf.flushPersistence)
+	}
+}
+
+// flushPersistence writes every part which has changed since it was last written
+func (hf *Hoverfly) flushPersistence() {
+	hf.persistenceMutex.Lock()
+	defer hf.persistenceMutex.Unlock()
+
+	hf.pendingPersistence.mutex.Lock()
+	changes := hf.pendingPersistence.changes
+	hf.pendingPersistence.changes = 0
+	hf.pendingPersistence.scheduled = false
+	hf.pendingPersistence.mutex.Unlock()
+
+	if hf.Persistence == nil {
+		return
+	}
+
+	if changes&persistedSimulation != 0 {
+		hf.writeSimulation()
+	}
+
+	if changes&persistedState != 0 {
+		hf.writeState()
+	}
+
+	if changes&persistedNamedSimulations != 0 {
+		hf.writeNamedSimulations()
+	}
+
+	if changes&persistedMiddleware != 0 {
+		hf.writeMiddleware()
+	}
+}
+
+// ClosePersistence writes the changes which are still pending and stops anything else from being
+// written, so the persistence store can be closed when Hoverfly is stopped without losing them
+func (hf *Hoverfly) ClosePersistence() {
+	hf.pendingPersistence.mutex.Lock()
+	hf.pendingPersistence.closed = true
+	hf.pendingPersistence.mutex.Unlock()
+
+	hf.flushPersistence()
+}
+
+func (hf *Hoverfly) writeSimulation() {
+	simulation, err := hf.GetSimulation()
+	if err == nil {
+		var simulationBytes []byte
+		simulationBytes, err = json.Marshal(simulation)
+		if err == nil {
+			err = hf.Persistence.Set(simulationPersistenceKey, simulationBytes)
+		}
+	}
+
+	if err != nil {
+		log.WithFields(log.Fields{
+			"error": err.Error(),
+		}).Warn("Failed to persist simulation")
+	}
+}
+
+func (hf *Hoverfly) writeState() {
+	stateBytes, err := json.Marshal(v2.StateView{
+		State:     hf.GetState(),
+		Scheduled: hf.GetScheduledStateTransitions(),
+	})
+	if err == nil {
+		err = hf.Persistence.Set(statePersistenceKey, stateBytes)
+	}
+
+	if err != nil {
+		log.WithFields(log.Fields{
+			"error": err.Error(),
+		}).Warn("Failed to persist state")
+	}
+}
+
+func (hf *Hoverfly) writeNamedSimulations() {
+	hf.simulationsMutex.RLock()
+	simulationsBytes, err := json.Marshal(persistedSimulations{
+		Simulations: hf.simulations,
+		Active:      hf.activeSimulations,
+	})
+	hf.simulationsMutex.RUnlock()
+
+	if err == nil {
+		err = hf.Persistence.Set(namedSimulationsPersistenceKey, simulationsBytes)
+	}
+
+	if err != nil {
+		log.WithFields(log.Fields{
+			"error": err.Error(),
+		}).Warn("Failed to persist named simulations")
+	}
+}
+
+func (hf *Hoverfly) writeMiddleware() {
+	binary, script, remote := hf.GetMiddleware()
+
+	middlewareBytes, err := json.Marshal(v2.MiddlewareView{
+		Binary: binary,
+		Script: script,
+		Remote: remote,
+	})
+	if err == nil {
+		err = hf.Persistence.Set(middlewarePersistenceKey, middlewareBytes)
+	}
+
+	if err != nil {
+		log.WithFields(log.Fields{
+			"error": err.Error(),
+		}).Warn("Failed to persist middleware")
+	}
+}
+
+// LoadPersistedSimulation restores the simulation, state and middleware last written
+// to the persistence store. Nothing is loaded when the store is empty, and middleware
+// given on the command line is kept. Anything imported afterwards replaces the simulation.
+func (hf *Hoverfly) LoadPersistedSimulation() error {
+	if hf.Persistence == nil {
+		return nil
+	}
+
+	// Everything is read up front, as restoring the simulation persists the state
+	simulationBytes, simulationErr := hf.Persistence.Get(simulationPersistenceKey)
+	stateBytes, stateErr := hf.Persistence.Get(statePersistenceKey)
+	simulationsBytes, simulationsErr := hf.Persistence.Get(namedSimulationsPersistenceKey)
+	middlewareBytes, middlewareErr := hf.Persistence.Get(middlewarePersistenceKey)
+
+	if simulationsErr == nil {
+		var simulations persistedSimulations
+
+		err := json.Unmarshal(simulationsBytes, &simulations)
+		if err != nil {
+			return fmt.Errorf("Failed to load persisted simulations, error %s", err.Error())
+		}
+
+		hf.simulationsMutex.Lock()
+		hf.simulations = simulations.Simulations
+		if hf.simulations == nil {
+			hf.simulations = map[string]v2.SimulationViewV6{}
+		}
+		hf.activeSimulations = simulations.Active
+		hf.simulationsMutex.Unlock()
+	}
+
+	if simulationErr == nil {
+		var simulation v2.SimulationViewV6
+
+		err := json.Unmarshal(simulationBytes, &simulation)
+		if err != nil {
+			return fmt.Errorf("Failed to load persisted simulation, error %s", err.Error())
+		}
+
+		err = hf.replaceSimulation(simulation).GetError()
+		if err != nil {
+			return fmt.Errorf("Failed to load persisted simulation, error %s", err.Error())
+		}
+	}
+
+	if stateErr == nil {
+		var stateView v2.StateView
+
+		err := json.Unmarshal(stateBytes, &stateView)
+		if err != nil {
+			return fmt.Errorf("Failed to load persisted state, error %s", err.Error())
+		}
+
+		hf.state.SetState(stateView.State)
+
+		// Transitions due while Hoverfly was stopped are applied on the first tick
+		for key, transition := range stateView.Scheduled {
+			at, err := time.Parse(time.RFC3339Nano, transition.At)
+			if err != nil {
+				return fmt.Errorf("Failed to load persisted state, error %s", err.Error())
+			}
+			hf.state.ScheduleTransition(key, transition.Value, at.Sub(time.Now()))
+		}
+
+		hf.persistState()
+	}
+
+	if middlewareErr == nil && !hf.Cfg.Middleware.IsSet() {
+		var middlewareView v2.MiddlewareView
+
+		err := json.Unmarshal(middlewareBytes, &middlewareView)
+		if err != nil {
+			return fmt.Errorf("Failed to load persisted middleware, error %s", err.Error())
+		}
+
+		// Middleware which no longer runs should not stop Hoverfly from starting
+		err = hf.SetMiddleware(middlewareView.Binary, middlewareView.Script, middlewareView.Remote)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"error": err.Error(),
+			}).Warn("Failed to restore persisted middleware")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,271 @@
+package hoverfly
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/cache"
+	"github.com/SpectoLabs/hoverfly/core/handlers/v1"
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/SpectoLabs/hoverfly/core/modes"
+	. "github.com/onsi/gomega"
+)
+
+func Test_Hoverfly_LoadPersistedSimulation_RestoresSimulationAndState(t *testing.T) {
+	RegisterTestingT(t)
+
+	persistence := cache.NewInMemoryCache()
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.Persistence = persistence
+
+	unit.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{pairOne},
+			GlobalActions: v2.GlobalActionsView{
+				Delays: []v1.ResponseDelayView{
+					{
+						UrlPattern: "test.com",
+						Delay:      100,
+					},
+				},
+			},
+		},
+		v2.MetaView{},
+	})
+	unit.SetState(map[string]string{"basket": "full"})
+	unit.ScheduleStateTransitions(map[string]v2.ScheduledStateTransitionView{
+		"order": {Value: "shipped", Delay: 60000},
+	})
+	unit.flushPersistence()
+
+	restarted := NewHoverflyWithConfiguration(&Configuration{})
+	restarted.Persistence = persistence
+
+	Expect(restarted.LoadPersistedSimulation()).To(Succeed())
+
+	simulation, err := restarted.GetSimulation()
+	Expect(err).To(BeNil())
+
+	Expect(simulation.RequestResponsePairs).To(HaveLen(1))
+	Expect(simulation.RequestResponsePairs[0].Response.Body).To(Equal("test-body"))
+	Expect(simulation.GlobalActions.Delays).To(HaveLen(1))
+	Expect(simulation.GlobalActions.Delays[0].UrlPattern).To(Equal("test.com"))
+
+	Expect(restarted.GetState()).To(Equal(map[string]string{"basket": "full"}))
+	Expect(restarted.GetScheduledStateTransitions()).To(HaveKey("order"))
+	Expect(restarted.GetScheduledStateTransitions()["order"].Value).To(Equal("shipped"))
+}
+
+func Test_Hoverfly_LoadPersistedSimulation_DoesNothingWhenStoreIsEmpty(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.Persistence = cache.NewInMemoryCache()
+
+	Expect(unit.LoadPersistedSimulation()).To(Succeed())
+
+	Expect(unit.Simulation.GetMatchingPairs()).To(BeEmpty())
+	Expect(unit.GetState()).To(BeEmpty())
+}
+
+func Test_Hoverfly_LoadPersistedSimulation_ErrorsOnCorruptSimulation(t *testing.T) {
+	RegisterTestingT(t)
+
+	persistence := cache.NewInMemoryCache()
+	persistence.Set(simulationPersistenceKey, []byte("not json"))
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.Persistence = persistence
+
+	err := unit.LoadPersistedSimulation()
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("Failed to load persisted simulation"))
+}
+
+func Test_Hoverfly_Save_PersistsCapturedPairs(t *testing.T) {
+	RegisterTestingT(t)
+
+	persistence := cache.NewInMemoryCache()
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.Persistence = persistence
+
+	unit.Save(&models.RequestDetails{
+		Method:      "GET",
+		Scheme:      "http",
+		Destination: "test.com",
+		Path:        "/captured",
+	}, &models.ResponseDetails{
+		Status: 200,
+		Body:   "captured body",
+	}, &modes.ModeArguments{})
+
+	unit.DeleteSimulation()
+	Expect(unit.Simulation.GetMatchingPairs()).To(BeEmpty())
+	unit.flushPersistence()
+
+	persisted, err := persistence.Get(simulationPersistenceKey)
+	Expect(err).To(BeNil())
+	Expect(string(persisted)).ToNot(ContainSubstring("captured body"))
+
+	unit.Save(&models.RequestDetails{
+		Method:      "GET",
+		Scheme:      "http",
+		Destination: "test.com",
+		Path:        "/captured",
+	}, &models.ResponseDetails{
+		Status: 200,
+		Body:   "captured body",
+	}, &modes.ModeArguments{})
+
+	_, err = persistence.Get(simulationPersistenceKey)
+	Expect(err).To(BeNil())
+
+	Eventually(func() string {
+		persisted, _ := persistence.Get(simulationPersistenceKey)
+		return string(persisted)
+	}).Should(ContainSubstring("captured body"))
+}
+
+func Test_Hoverfly_Save_DoesNotWriteEachCapturedPairImmediately(t *testing.T) {
+	RegisterTestingT(t)
+
+	persistence := cache.NewInMemoryCache()
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.Persistence = persistence
+
+	unit.Save(&models.RequestDetails{
+		Method:      "GET",
+		Scheme:      "http",
+		Destination: "test.com",
+		Path:        "/captured",
+	}, &models.ResponseDetails{
+		Status: 200,
+		Body:   "captured body",
+	}, &modes.ModeArguments{})
+
+	_, err := persistence.Get(simulationPersistenceKey)
+	Expect(err).ToNot(BeNil())
+
+	unit.flushPersistence()
+
+	persisted, err := persistence.Get(simulationPersistenceKey)
+	Expect(err).To(BeNil())
+	Expect(string(persisted)).To(ContainSubstring("captured body"))
+}
+
+func Test_Hoverfly_LoadPersistedSimulation_IsReplacedByAChangedImportOnRestart(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "persistence")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "simulation.yaml")
+	Expect(ioutil.WriteFile(file, []byte(yamlSimulation("/deleted")), 0644)).To(Succeed())
+
+	persistence := cache.NewInMemoryCache()
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.Persistence = persistence
+
+	Expect(unit.Import(file)).To(Succeed())
+	unit.ClosePersistence()
+
+	Expect(ioutil.WriteFile(file, []byte(yamlSimulation("/added")), 0644)).To(Succeed())
+
+	restarted := NewHoverflyWithConfiguration(&Configuration{})
+	restarted.Persistence = persistence
+
+	Expect(restarted.LoadPersistedSimulation()).To(Succeed())
+	Expect(restarted.Import(file)).To(Succeed())
+
+	pairs := restarted.Simulation.GetMatchingPairs()
+	Expect(pairs).To(HaveLen(1))
+	Expect(pairs[0].RequestMatcher.Path[0].Value).To(Equal("/added"))
+}
+
+func Test_Hoverfly_ClosePersistence_WritesPendingChangesAndStopsWriting(t *testing.T) {
+	RegisterTestingT(t)
+
+	persistence := cache.NewInMemoryCache()
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.Persistence = persistence
+
+	unit.Save(&models.RequestDetails{
+		Method:      "GET",
+		Scheme:      "http",
+		Destination: "test.com",
+		Path:        "/before",
+	}, &models.ResponseDetails{
+		Status: 200,
+		Body:   "before close",
+	}, &modes.ModeArguments{})
+
+	unit.ClosePersistence()
+
+	persisted, err := persistence.Get(simulationPersistenceKey)
+	Expect(err).To(BeNil())
+	Expect(string(persisted)).To(ContainSubstring("before close"))
+
+	unit.Save(&models.RequestDetails{
+		Method:      "GET",
+		Scheme:      "http",
+		Destination: "test.com",
+		Path:        "/after",
+	}, &models.ResponseDetails{
+		Status: 200,
+		Body:   "after close",
+	}, &modes.ModeArguments{})
+
+	Consistently(func() string {
+		persisted, _ := persistence.Get(simulationPersistenceKey)
+		return string(persisted)
+	}, 3*PersistenceInterval).ShouldNot(ContainSubstring("after close"))
+}
+
+func Test_Hoverfly_LoadPersistedSimulation_RestoresMiddleware(t *testing.T) {
+	RegisterTestingT(t)
+
+	persistence := cache.NewInMemoryCache()
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.Persistence = persistence
+
+	Expect(unit.SetMiddleware("python", pythonMiddlewareBasic, "")).To(Succeed())
+	unit.flushPersistence()
+
+	restarted := NewHoverflyWithConfiguration(&Configuration{})
+	restarted.Persistence = persistence
+
+	Expect(restarted.LoadPersistedSimulation()).To(Succeed())
+
+	binary, script, remote := restarted.GetMiddleware()
+	Expect(binary).To(Equal("python"))
+	Expect(script).To(Equal(pythonMiddlewareBasic))
+	Expect(remote).To(BeEmpty())
+}
+
+func Test_Hoverfly_PersistsTransitionsAppliedByTheScheduler(t *testing.T) {
+	RegisterTestingT(t)
+
+	persistence := cache.NewInMemoryCache()
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.Persistence = persistence
+
+	unit.ScheduleStateTransitions(map[string]v2.ScheduledStateTransitionView{
+		"order": {Value: "shipped", Delay: 10},
+	})
+
+	Eventually(func() string {
+		persisted, _ := persistence.Get(statePersistenceKey)
+		return string(persisted)
+	}).Should(ContainSubstring(`"order":"shipped"`))
+}
@@ -64,8 +64,10 @@ func NewProxy(hoverfly *Hoverfly) *goproxy.ProxyHttpServer {
 	proxy.OnRequest(matchesFilter(hoverfly.Cfg.Destination)).DoFunc(
 		func(r *http.Request, ctx *goproxy.ProxyCtx) (*http.Request, *http.Response) {
 			startTime := time.Now()
-			resp := hoverfly.processRequest(r)
-			hoverfly.Journal.NewEntry(r, resp, hoverfly.Cfg.Mode, startTime)
+			resp, mode := hoverfly.processRequestWithMode(r)
+			// the mode is kept for the response handlers, as routes may differ from the mode of Hoverfly
+			ctx.UserData = mode
+			hoverfly.Journal.NewEntry(r, resp, mode, startTime)
 			return r, resp
 		})
 
@@ -96,7 +98,11 @@ func NewProxy(hoverfly *Hoverfly) *goproxy.ProxyHttpServer {
 	// intercepts response
 	proxy.OnResponse(matchesFilter(hoverfly.Cfg.Destination)).DoFunc(
 		func(resp *http.Response, ctx *goproxy.ProxyCtx) *http.Response {
-			hoverfly.Counter.Count(hoverfly.Cfg.GetMode())
+			mode, ok := ctx.UserData.(string)
+			if !ok {
+				mode = hoverfly.Cfg.GetMode()
+			}
+			hoverfly.Counter.Count(mode)
 			return resp
 		})
 
@@ -120,8 +126,8 @@ func NewWebserverProxy(hoverfly *Hoverfly) *goproxy.ProxyHttpServer {
 		log.Warn("NonproxyHandler")
 		startTime := time.Now()
 		r.URL.Scheme = "http"
-		resp := hoverfly.processRequest(r)
-		hoverfly.Journal.NewEntry(r, resp, hoverfly.Cfg.Mode, startTime)
+		resp, mode := hoverfly.processRequestWithMode(r)
+		hoverfly.Journal.NewEntry(r, resp, mode, startTime)
 		body, err := util.GetResponseBody(resp)
 
 		if err != nil {
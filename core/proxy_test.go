@@ -1,13 +1,18 @@
 package hoverfly
 
 import (
+	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/url"
+	"sync"
 	"testing"
 
 	"bufio"
 	"net"
 
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
 	. "github.com/onsi/gomega"
 
 	"net/http/httptest"
@@ -283,3 +288,118 @@ func Test_matchesFilter_ShoulRemovePathFromFilterIfConnectRequest(t *testing.T)
 	}, nil)
 	Expect(removedPathResult).To(BeTrue())
 }
+
+func Test_NewProxy_CanHandleConcurrentRequestsInCaptureMode(t *testing.T) {
+	RegisterTestingT(t)
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(r.URL.Path))
+	}))
+	defer upstream.Close()
+
+	testHoverfly := NewHoverflyWithConfiguration(&Configuration{Mode: "capture"})
+
+	proxyServer := httptest.NewServer(NewProxy(testHoverfly))
+	defer proxyServer.Close()
+
+	proxyURL, _ := url.Parse(proxyServer.URL)
+	client := &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxyURL)}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 5; j++ {
+				response, err := client.Get(fmt.Sprintf("%s/path/%d/%d", upstream.URL, i, j))
+				if err == nil {
+					ioutil.ReadAll(response.Body)
+					response.Body.Close()
+				}
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 5; j++ {
+				testHoverfly.GetSimulation()
+				testHoverfly.GetState()
+				testHoverfly.Journal.GetEntries(0, 25, nil, nil, "")
+			}
+		}()
+	}
+	wg.Wait()
+
+	Expect(testHoverfly.Simulation.GetMatchingPairs()).To(HaveLen(100))
+
+	journalView, err := testHoverfly.Journal.GetEntries(0, 1000, nil, nil, "")
+	Expect(err).To(BeNil())
+	Expect(journalView.Total).To(Equal(100))
+}
+
+func Test_NewProxy_CanHandleConcurrentRequestsInSimulateModeWithStateTransitions(t *testing.T) {
+	RegisterTestingT(t)
+
+	testHoverfly := NewHoverflyWithConfiguration(&Configuration{Mode: "simulate"})
+	testHoverfly.PutSimulation(v2.SimulationViewV6{
+		DataViewV6: v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Path: []v2.MatcherViewV5{
+							v2.NewMatcherView(matchers.Glob, "/basket/*"),
+						},
+					},
+					Response: v2.ResponseDetailsViewV6{
+						Status:           200,
+						Body:             "{{ State.basket }}",
+						Templated:        true,
+						TransitionsState: map[string]string{"basket": "full"},
+					},
+				},
+			},
+		},
+	})
+
+	proxyServer := httptest.NewServer(NewProxy(testHoverfly))
+	defer proxyServer.Close()
+
+	proxyURL, _ := url.Parse(proxyServer.URL)
+	client := &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxyURL)}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 5; j++ {
+				response, err := client.Get(fmt.Sprintf("http://test.com/basket/%d", i))
+				if err == nil {
+					ioutil.ReadAll(response.Body)
+					response.Body.Close()
+				}
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 5; j++ {
+				testHoverfly.PatchState(map[string]string{"other": "value"})
+				testHoverfly.GetState()
+				testHoverfly.Journal.GetFilteredEntries(v2.JournalEntryFilterView{
+					Request: &v2.RequestMatcherViewV5{
+						Path: []v2.MatcherViewV5{
+							v2.NewMatcherView(matchers.Glob, "/basket/*"),
+						},
+					},
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	Expect(testHoverfly.GetState()).To(HaveKeyWithValue("basket", "full"))
+
+	journalView, err := testHoverfly.Journal.GetEntries(0, 1000, nil, nil, "")
+	Expect(err).To(BeNil())
+	Expect(journalView.Total).To(Equal(100))
+}
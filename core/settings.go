@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	log "github.com/Sirupsen/logrus"
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/core/middleware"
 )
 
@@ -41,6 +42,8 @@ type Configuration struct {
 
 	PlainHttpTunneling bool
 
+	Redactions []*v2.RedactionRule
+
 	ProxyControlWG sync.WaitGroup
 
 	mu sync.Mutex
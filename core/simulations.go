@@ -0,0 +1,141 @@
+package hoverfly
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/modes"
+)
+
+func (hf *Hoverfly) GetSimulationNames() []string {
+	hf.simulationsMutex.RLock()
+	defer hf.simulationsMutex.RUnlock()
+
+	names := make([]string, 0, len(hf.simulations))
+	for name := range hf.simulations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func (hf *Hoverfly) GetActiveSimulations() []string {
+	hf.simulationsMutex.RLock()
+	defer hf.simulationsMutex.RUnlock()
+
+	return append([]string{}, hf.activeSimulations...)
+}
+
+func (hf *Hoverfly) GetNamedSimulation(name string) (v2.SimulationViewV6, error) {
+	hf.simulationsMutex.RLock()
+	defer hf.simulationsMutex.RUnlock()
+
+	simulation, ok := hf.simulations[name]
+	if !ok {
+		return v2.SimulationViewV6{}, fmt.Errorf("Simulation %s does not exist", name)
+	}
+
+	return simulation, nil
+}
+
+// PutNamedSimulation stores a simulation under the given name, replacing any simulation
+// already stored with that name. If the simulation is active it is applied straight away.
+func (hf *Hoverfly) PutNamedSimulation(name string, simulationView v2.SimulationViewV6) v2.SimulationImportResult {
+	hf.simulationsMutex.Lock()
+	defer hf.simulationsMutex.Unlock()
+
+	if hf.simulations == nil {
+		hf.simulations = map[string]v2.SimulationViewV6{}
+	}
+	hf.simulations[name] = simulationView
+	hf.persistNamedSimulations()
+
+	for _, active := range hf.activeSimulations {
+		if active == name {
+			return hf.applyActiveSimulations()
+		}
+	}
+
+	return v2.SimulationImportResult{}
+}
+
+func (hf *Hoverfly) DeleteNamedSimulation(name string) error {
+	hf.simulationsMutex.Lock()
+	defer hf.simulationsMutex.Unlock()
+
+	if _, ok := hf.simulations[name]; !ok {
+		return fmt.Errorf("Simulation %s does not exist", name)
+	}
+
+	for _, active := range hf.activeSimulations {
+		if active == name {
+			return fmt.Errorf("Simulation %s is active and cannot be deleted", name)
+		}
+	}
+
+	delete(hf.simulations, name)
+	hf.persistNamedSimulations()
+
+	return nil
+}
+
+// UseSimulations replaces the current simulation with the named simulations. When more than
+// one is given they are layered in priority order, so where pairs share the same request
+// matcher the pair from the earlier simulation is kept.
+func (hf *Hoverfly) UseSimulations(names []string) error {
+	hf.simulationsMutex.Lock()
+	defer hf.simulationsMutex.Unlock()
+
+	if len(names) == 0 {
+		return fmt.Errorf("At least one simulation must be given")
+	}
+
+	for _, name := range names {
+		if _, ok := hf.simulations[name]; !ok {
+			return fmt.Errorf("Simulation %s does not exist", name)
+		}
+	}
+
+	hf.activeSimulations = append([]string{}, names...)
+	hf.persistNamedSimulations()
+
+	return hf.applyActiveSimulations().GetError()
+}
+
+func (hf *Hoverfly) clearActiveSimulations() {
+	hf.simulationsMutex.Lock()
+	defer hf.simulationsMutex.Unlock()
+
+	if len(hf.activeSimulations) == 0 {
+		return
+	}
+
+	hf.activeSimulations = nil
+	hf.persistNamedSimulations()
+}
+
+// applyActiveSimulations must be called with simulationsMutex held
+func (hf *Hoverfly) applyActiveSimulations() v2.SimulationImportResult {
+	layered := v2.SimulationViewV6{}
+
+	for _, name := range hf.activeSimulations {
+		simulation := hf.simulations[name]
+
+		layered.RequestResponsePairs = append(layered.RequestResponsePairs, simulation.RequestResponsePairs...)
+		layered.GlobalActions.Delays = append(layered.GlobalActions.Delays, simulation.GlobalActions.Delays...)
+	}
+
+	return hf.replaceSimulation(layered)
+}
+
+// reloadCacheMatcher throws away the responses cached from the previous simulation,
+// caching the new one straight away when it is being used to respond to requests
+func (hf *Hoverfly) reloadCacheMatcher() {
+	if mode := hf.Cfg.GetMode(); mode == modes.Simulate || mode == modes.Spy || mode == modes.Hybrid || mode == modes.Shadow {
+		hf.CacheMatcher.ReloadCache(hf.Simulation)
+	} else {
+		hf.CacheMatcher.FlushCache()
+	}
+}
@@ -0,0 +1,245 @@
+package hoverfly
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/cache"
+	"github.com/SpectoLabs/hoverfly/core/handlers/v1"
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	"github.com/SpectoLabs/hoverfly/core/models"
+	. "github.com/onsi/gomega"
+)
+
+func namedSimulation(path, body string) v2.SimulationViewV6 {
+	return v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Path: []v2.MatcherViewV5{
+							v2.NewMatcherView(matchers.Exact, path),
+						},
+					},
+					Response: v2.ResponseDetailsViewV6{
+						Status: 200,
+						Body:   body,
+					},
+				},
+			},
+		},
+		v2.MetaView{},
+	}
+}
+
+func Test_Hoverfly_PutNamedSimulation_StoresSimulationWithoutActivatingIt(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.PutNamedSimulation("happy", namedSimulation("/orders", "happy"))
+
+	Expect(unit.GetSimulationNames()).To(ConsistOf("happy"))
+	Expect(unit.GetActiveSimulations()).To(BeEmpty())
+	Expect(unit.Simulation.GetMatchingPairs()).To(BeEmpty())
+
+	simulation, err := unit.GetNamedSimulation("happy")
+	Expect(err).To(BeNil())
+	Expect(simulation.RequestResponsePairs[0].Response.Body).To(Equal("happy"))
+}
+
+func Test_Hoverfly_GetNamedSimulation_ErrorsWhenSimulationDoesNotExist(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	_, err := unit.GetNamedSimulation("missing")
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Simulation missing does not exist"))
+}
+
+func Test_Hoverfly_UseSimulations_ReplacesSimulation(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.SetModeWithArguments(v2.ModeView{Mode: "simulate"})
+
+	unit.PutNamedSimulation("happy", namedSimulation("/orders", "happy"))
+	unit.PutNamedSimulation("outage", namedSimulation("/orders", "outage"))
+
+	Expect(unit.UseSimulations([]string{"happy"})).To(Succeed())
+
+	response, err := unit.GetResponse(models.RequestDetails{Path: "/orders"})
+	Expect(err).To(BeNil())
+	Expect(response.Body).To(Equal("happy"))
+
+	Expect(unit.UseSimulations([]string{"outage"})).To(Succeed())
+	Expect(unit.GetActiveSimulations()).To(Equal([]string{"outage"}))
+
+	response, err = unit.GetResponse(models.RequestDetails{Path: "/orders"})
+	Expect(err).To(BeNil())
+	Expect(response.Body).To(Equal("outage"))
+}
+
+func Test_Hoverfly_UseSimulations_ReplacesDelaysOfPreviousSimulation(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	slow := namedSimulation("/orders", "slow")
+	slow.GlobalActions.Delays = []v1.ResponseDelayView{
+		{
+			UrlPattern: "test.com",
+			Delay:      100,
+		},
+	}
+
+	unit.PutNamedSimulation("slow", slow)
+	unit.PutNamedSimulation("happy", namedSimulation("/orders", "happy"))
+
+	Expect(unit.UseSimulations([]string{"slow"})).To(Succeed())
+	Expect(unit.Simulation.GetResponseDelays().ConvertToResponseDelayPayloadView().Data).To(HaveLen(1))
+
+	Expect(unit.UseSimulations([]string{"happy"})).To(Succeed())
+	Expect(unit.GetActiveSimulations()).To(Equal([]string{"happy"}))
+
+	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(1))
+	Expect(unit.Simulation.GetMatchingPairs()[0].Response.Body).To(Equal("happy"))
+	Expect(unit.Simulation.GetResponseDelays().ConvertToResponseDelayPayloadView().Data).To(BeEmpty())
+}
+
+func Test_Hoverfly_UseSimulations_LayersSimulationsInPriorityOrder(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	happy := namedSimulation("/orders", "happy")
+	happy.RequestResponsePairs = append(happy.RequestResponsePairs, namedSimulation("/users", "users").RequestResponsePairs...)
+	happy.GlobalActions.Delays = []v1.ResponseDelayView{{UrlPattern: "happy.com", Delay: 10}}
+
+	degraded := namedSimulation("/orders", "degraded")
+	degraded.GlobalActions.Delays = []v1.ResponseDelayView{{UrlPattern: "degraded.com", Delay: 20}}
+
+	unit.PutNamedSimulation("happy", happy)
+	unit.PutNamedSimulation("degraded", degraded)
+
+	Expect(unit.UseSimulations([]string{"degraded", "happy"})).To(Succeed())
+
+	pairs := unit.Simulation.GetMatchingPairs()
+	Expect(pairs).To(HaveLen(2))
+	Expect(pairs[0].Response.Body).To(Equal("degraded"))
+	Expect(pairs[1].Response.Body).To(Equal("users"))
+
+	delays := unit.Simulation.GetResponseDelays().ConvertToResponseDelayPayloadView()
+	Expect(delays.Data).To(HaveLen(2))
+	Expect(delays.Data[0].UrlPattern).To(Equal("degraded.com"))
+}
+
+func Test_Hoverfly_UseSimulations_ErrorsWhenSimulationDoesNotExist(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.PutNamedSimulation("happy", namedSimulation("/orders", "happy"))
+
+	err := unit.UseSimulations([]string{"happy", "missing"})
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Simulation missing does not exist"))
+
+	Expect(unit.GetActiveSimulations()).To(BeEmpty())
+}
+
+func Test_Hoverfly_UseSimulations_InvalidatesCachedResponses(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.SetModeWithArguments(v2.ModeView{Mode: "simulate"})
+
+	unit.PutNamedSimulation("happy", namedSimulation("/orders", "happy"))
+	unit.PutNamedSimulation("other", namedSimulation("/other", "other"))
+
+	Expect(unit.UseSimulations([]string{"other"})).To(Succeed())
+
+	_, err := unit.GetResponse(models.RequestDetails{Path: "/orders"})
+	Expect(err).ToNot(BeNil())
+
+	cached, _ := unit.GetCache()
+	Expect(cached.Cache).To(HaveLen(1))
+
+	Expect(unit.UseSimulations([]string{"happy"})).To(Succeed())
+
+	cached, _ = unit.GetCache()
+	Expect(cached.Cache).To(BeEmpty())
+
+	response, err := unit.GetResponse(models.RequestDetails{Path: "/orders"})
+	Expect(err).To(BeNil())
+	Expect(response.Body).To(Equal("happy"))
+}
+
+func Test_Hoverfly_PutNamedSimulation_ReappliesActiveSimulation(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.PutNamedSimulation("happy", namedSimulation("/orders", "happy"))
+	unit.UseSimulations([]string{"happy"})
+
+	unit.PutNamedSimulation("happy", namedSimulation("/orders", "updated"))
+
+	pairs := unit.Simulation.GetMatchingPairs()
+	Expect(pairs).To(HaveLen(1))
+	Expect(pairs[0].Response.Body).To(Equal("updated"))
+}
+
+func Test_Hoverfly_DeleteNamedSimulation_CannotDeleteActiveSimulation(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.PutNamedSimulation("happy", namedSimulation("/orders", "happy"))
+	unit.PutNamedSimulation("outage", namedSimulation("/orders", "outage"))
+	unit.UseSimulations([]string{"happy"})
+
+	err := unit.DeleteNamedSimulation("happy")
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Simulation happy is active and cannot be deleted"))
+
+	Expect(unit.DeleteNamedSimulation("outage")).To(Succeed())
+	Expect(unit.GetSimulationNames()).To(ConsistOf("happy"))
+}
+
+func Test_Hoverfly_DeleteSimulation_ClearsActiveSimulations(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	unit.PutNamedSimulation("happy", namedSimulation("/orders", "happy"))
+	unit.UseSimulations([]string{"happy"})
+
+	unit.DeleteSimulation()
+
+	Expect(unit.GetActiveSimulations()).To(BeEmpty())
+	Expect(unit.GetSimulationNames()).To(ConsistOf("happy"))
+}
+
+func Test_Hoverfly_LoadPersistedSimulation_RestoresNamedSimulations(t *testing.T) {
+	RegisterTestingT(t)
+
+	persistence := cache.NewInMemoryCache()
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.Persistence = persistence
+
+	unit.PutNamedSimulation("happy", namedSimulation("/orders", "happy"))
+	unit.PutNamedSimulation("outage", namedSimulation("/orders", "outage"))
+	unit.UseSimulations([]string{"outage"})
+	unit.flushPersistence()
+
+	restarted := NewHoverflyWithConfiguration(&Configuration{})
+	restarted.Persistence = persistence
+
+	Expect(restarted.LoadPersistedSimulation()).To(Succeed())
+
+	Expect(restarted.GetSimulationNames()).To(Equal([]string{"happy", "outage"}))
+	Expect(restarted.GetActiveSimulations()).To(Equal([]string{"outage"}))
+	Expect(restarted.Simulation.GetMatchingPairs()[0].Response.Body).To(Equal("outage"))
+}
@@ -3,53 +3,255 @@ package state
 import (
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 )
 
+// SchedulerInterval is how often pending transitions are checked
+// while at least one of them is waiting to be applied
+var SchedulerInterval = 100 * time.Millisecond
+
 type State struct {
-	State map[string]string
+	State     map[string]string
+	scheduled map[string]ScheduledTransition
+	ticking   bool
+	onApplied func()
+	mutex     sync.RWMutex
+}
+
+// ScheduledTransition moves a state key to Value once At has passed.
+// An empty Value expires the key, removing it from the state.
+type ScheduledTransition struct {
+	Value string
+	At    time.Time
 }
 
 func NewState() *State {
 	return &State{
-		State: map[string]string{},
+		State:     map[string]string{},
+		scheduled: map[string]ScheduledTransition{},
 	}
 }
 
 func NewStateFromState(incomingState map[string]string) *State {
-	state := &State{
-		State: map[string]string{},
-	}
+	state := NewState()
+	state.InitializeSequences(incomingState)
+
+	return state
+}
+
+// InitializeSequences replaces the current state with the sequence
+// keys found in the given state, each of them reset to their first step
+func (s *State) InitializeSequences(incomingState map[string]string) {
+	sequences := map[string]string{}
 
 	for stateKey, _ := range incomingState {
 		if strings.Contains(stateKey, "sequence:") {
-			state.State[stateKey] = "1"
+			sequences[stateKey] = "1"
 		}
 	}
 
-	return state
+	s.mutex.Lock()
+	s.State = sequences
+	s.scheduled = map[string]ScheduledTransition{}
+	s.mutex.Unlock()
+}
+
+// AddSequences sets any sequence keys found in the given state which are
+// not already set to their first step, leaving the rest of the state as it is
+func (s *State) AddSequences(incomingState map[string]string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for stateKey, _ := range incomingState {
+		if !strings.Contains(stateKey, "sequence:") {
+			continue
+		}
+		if _, ok := s.State[stateKey]; !ok {
+			s.State[stateKey] = "1"
+		}
+	}
 }
 
 func (s *State) GetState(key string) string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
 	return s.State[key]
 }
 
+// GetValue returns the value of a state key and whether the key is set
+func (s *State) GetValue(key string) (string, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	value, ok := s.State[key]
+	return value, ok
+}
+
+// Copy returns a snapshot of the state which is safe to read
+// while the state continues to be modified by other requests
+func (s *State) Copy() map[string]string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	copied := make(map[string]string, len(s.State))
+	for k, v := range s.State {
+		copied[k] = v
+	}
+
+	return copied
+}
+
 func (s *State) SetState(state map[string]string) {
-	s.State = state
+	newState := make(map[string]string, len(state))
+	for k, v := range state {
+		newState[k] = v
+	}
+
+	s.mutex.Lock()
+	s.State = newState
+	s.scheduled = map[string]ScheduledTransition{}
+	s.mutex.Unlock()
 }
 
+// PatchState sets the given keys, cancelling any transition
+// which was still pending for them
 func (s *State) PatchState(toPatch map[string]string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
 	for k, v := range toPatch {
 		s.State[k] = v
+		delete(s.scheduled, k)
 	}
 }
 
 func (s *State) RemoveState(toRemove []string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
 	for _, key := range toRemove {
 		delete(s.State, key)
+		delete(s.scheduled, key)
+	}
+}
+
+// ClearState removes every key from the state along with
+// any pending transitions
+func (s *State) ClearState() {
+	s.mutex.Lock()
+	s.State = map[string]string{}
+	s.scheduled = map[string]ScheduledTransition{}
+	s.mutex.Unlock()
+}
+
+// ScheduleTransition moves key to value once delay has elapsed, or removes
+// it when value is empty, replacing any transition already pending for the key
+func (s *State) ScheduleTransition(key, value string, delay time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.scheduled == nil {
+		s.scheduled = map[string]ScheduledTransition{}
+	}
+
+	s.scheduled[key] = ScheduledTransition{
+		Value: value,
+		At:    time.Now().Add(delay),
+	}
+
+	if !s.ticking {
+		s.ticking = true
+		go s.tick()
+	}
+}
+
+// GetScheduledTransitions returns a copy of the transitions
+// which have not yet been applied
+func (s *State) GetScheduledTransitions() map[string]ScheduledTransition {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	scheduled := make(map[string]ScheduledTransition, len(s.scheduled))
+	for k, v := range s.scheduled {
+		scheduled[k] = v
+	}
+
+	return scheduled
+}
+
+// OnScheduledTransitionsApplied sets a function which is called whenever
+// the scheduler has applied at least one transition
+func (s *State) OnScheduledTransitionsApplied(onApplied func()) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.onApplied = onApplied
+}
+
+// ApplyScheduledTransitions applies every transition due at or before now
+// and returns the number of transitions still pending
+func (s *State) ApplyScheduledTransitions(now time.Time) int {
+	_, pending := s.applyScheduledTransitions(now)
+	return pending
+}
+
+func (s *State) applyScheduledTransitions(now time.Time) (int, int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	applied := 0
+	for key, transition := range s.scheduled {
+		if transition.At.After(now) {
+			continue
+		}
+
+		if transition.Value == "" {
+			delete(s.State, key)
+		} else {
+			s.State[key] = transition.Value
+		}
+		delete(s.scheduled, key)
+		applied++
+	}
+
+	return applied, len(s.scheduled)
+}
+
+func (s *State) tick() {
+	ticker := time.NewTicker(SchedulerInterval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		applied, pending := s.applyScheduledTransitions(now)
+
+		s.mutex.RLock()
+		onApplied := s.onApplied
+		s.mutex.RUnlock()
+
+		if applied > 0 && onApplied != nil {
+			onApplied()
+		}
+
+		if pending > 0 {
+			continue
+		}
+
+		s.mutex.Lock()
+		if len(s.scheduled) == 0 {
+			s.ticking = false
+			s.mutex.Unlock()
+			return
+		}
+		s.mutex.Unlock()
 	}
 }
 
 func (s *State) GetNewSequenceKey() string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
 	returnKey := ""
 	i := 1
 	for returnKey == "" {
@@ -1,7 +1,10 @@
 package state_test
 
 import (
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/SpectoLabs/hoverfly/core/state"
 	. "github.com/onsi/gomega"
@@ -128,3 +131,156 @@ func Test_State_GetNewSequenceKey_ReturnsFirstFreeInSequence(t *testing.T) {
 	})
 	Expect(s.GetNewSequenceKey()).To(Equal("sequence:4"))
 }
+
+func Test_State_CanBeModifiedConcurrently(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := state.NewState()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			s.PatchState(map[string]string{fmt.Sprintf("key%v", i): "value"})
+			s.RemoveState([]string{"removed"})
+		}(i)
+		go func() {
+			defer wg.Done()
+			s.Copy()
+			s.GetState("key1")
+			s.GetNewSequenceKey()
+		}()
+	}
+	wg.Wait()
+
+	Expect(s.Copy()).To(HaveLen(50))
+}
+
+func Test_State_Copy_IsNotAffectedByLaterChanges(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := state.NewState()
+	s.SetState(map[string]string{"test": "1"})
+
+	copied := s.Copy()
+	s.PatchState(map[string]string{"test": "2"})
+
+	Expect(copied).To(Equal(map[string]string{"test": "1"}))
+	Expect(s.GetState("test")).To(Equal("2"))
+}
+
+func Test_State_ClearState_RemovesAllKeys(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := state.NewState()
+	s.SetState(map[string]string{"test": "1"})
+
+	s.ClearState()
+
+	Expect(s.Copy()).To(BeEmpty())
+}
+
+func Test_State_ApplyScheduledTransitions_AppliesDueTransitions(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := state.NewState()
+	s.SetState(map[string]string{"order": "created", "session": "active"})
+
+	s.ScheduleTransition("order", "shipped", time.Minute)
+	s.ScheduleTransition("session", "", time.Minute)
+
+	Expect(s.ApplyScheduledTransitions(time.Now())).To(Equal(2))
+	Expect(s.Copy()).To(Equal(map[string]string{"order": "created", "session": "active"}))
+
+	Expect(s.ApplyScheduledTransitions(time.Now().Add(2 * time.Minute))).To(Equal(0))
+	Expect(s.Copy()).To(Equal(map[string]string{"order": "shipped"}))
+	Expect(s.GetScheduledTransitions()).To(BeEmpty())
+}
+
+func Test_State_ScheduleTransition_IsAppliedByTicker(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := state.NewState()
+	s.SetState(map[string]string{"order": "created"})
+
+	s.ScheduleTransition("order", "shipped", 10*time.Millisecond)
+
+	Expect(s.GetScheduledTransitions()).To(HaveKey("order"))
+	Eventually(func() string {
+		return s.GetState("order")
+	}).Should(Equal("shipped"))
+	Expect(s.GetScheduledTransitions()).To(BeEmpty())
+}
+
+func Test_State_OnScheduledTransitionsApplied_IsCalledByTicker(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := state.NewState()
+
+	applied := make(chan string, 1)
+	s.OnScheduledTransitionsApplied(func() {
+		applied <- s.GetState("order")
+	})
+
+	s.ScheduleTransition("order", "shipped", 10*time.Millisecond)
+
+	Eventually(applied).Should(Receive(Equal("shipped")))
+}
+
+func Test_State_ScheduleTransition_RemovesKeyWithoutValueOnceDelayHasPassed(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := state.NewState()
+	s.SetState(map[string]string{"session": "active"})
+
+	s.ScheduleTransition("session", "", 10*time.Millisecond)
+
+	Eventually(s.Copy).Should(BeEmpty())
+}
+
+func Test_State_PatchState_CancelsPendingTransition(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := state.NewState()
+	s.ScheduleTransition("order", "shipped", time.Minute)
+	s.ScheduleTransition("basket", "empty", time.Minute)
+
+	s.PatchState(map[string]string{"order": "cancelled"})
+
+	Expect(s.GetScheduledTransitions()).To(HaveLen(1))
+	Expect(s.GetScheduledTransitions()).To(HaveKey("basket"))
+}
+
+func Test_State_ClearState_RemovesPendingTransitions(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := state.NewState()
+	s.ScheduleTransition("order", "shipped", time.Minute)
+
+	s.ClearState()
+
+	Expect(s.GetScheduledTransitions()).To(BeEmpty())
+}
+
+func Test_State_AddSequences_OnlyInitializesNewSequenceKeys(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := state.NewState()
+	s.SetState(map[string]string{
+		"sequence:1": "3",
+		"test":       "value",
+	})
+
+	s.AddSequences(map[string]string{
+		"sequence:1": "1",
+		"sequence:2": "2",
+		"other":      "true",
+	})
+
+	Expect(s.Copy()).To(Equal(map[string]string{
+		"sequence:1": "3",
+		"sequence:2": "1",
+		"test":       "value",
+	}))
+}
@@ -69,6 +69,12 @@ func (*Templator) ApplyTemplate(requestDetails *models.RequestDetails, state map
 	}
 }
 
+// ValidateTemplate checks that a response body can be parsed as a template, without rendering it
+func (*Templator) ValidateTemplate(responseBody string) error {
+	_, err := raymond.Parse(responseBody)
+	return err
+}
+
 func NewTemplatingDataFromRequest(requestDetails *models.RequestDetails, state map[string]string) *TemplatingData {
 	return &TemplatingData{
 		Request: Request{
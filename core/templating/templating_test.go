@@ -356,3 +356,13 @@ func Test_ApplyTemplate_Request_Body(t *testing.T) {
 
 	Expect(template).To(Not(Equal(ContainSubstring(`{{Request.Body jsonPath \"$.test\"}}`))))
 }
+
+func Test_ValidateTemplate_ReturnsErrorIfTemplateCannotBeParsed(t *testing.T) {
+	RegisterTestingT(t)
+
+	templator := templating.NewTemplator()
+
+	Expect(templator.ValidateTemplate("{{ Request.Path.[0] }}")).To(BeNil())
+	Expect(templator.ValidateTemplate("{{ Request.Path.[0] ")).ToNot(BeNil())
+	Expect(templator.ValidateTemplate("{{#if State.basket}}full")).ToNot(BeNil())
+}
@@ -0,0 +1,188 @@
+package hoverfly
+
+import (
+	"fmt"
+	"regexp"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/models"
+)
+
+// transformationRule changes requests and responses in process, for changes too simple to need
+// middleware. Rules are matched against the request as Hoverfly received it.
+type transformationRule struct {
+	destination *regexp.Regexp
+	path        *regexp.Regexp
+	pathRegex   *regexp.Regexp
+	request     *v2.MessageTransformation
+	response    *v2.MessageTransformation
+	view        v2.TransformationRuleView
+}
+
+func newTransformationRule(ruleView v2.TransformationRuleView) (transformationRule, error) {
+	if err := ruleView.Validate(); err != nil {
+		return transformationRule{}, err
+	}
+
+	rule := transformationRule{
+		destination: regexp.MustCompile(ruleView.Destination),
+		path:        regexp.MustCompile(ruleView.Path),
+		view:        ruleView,
+	}
+	if ruleView.Request != nil {
+		if ruleView.Request.PathRegex != "" {
+			rule.pathRegex = regexp.MustCompile(ruleView.Request.PathRegex)
+		}
+		rule.request, _ = v2.NewMessageTransformation(ruleView.Request.MessageTransformationView)
+	}
+	if ruleView.Response != nil {
+		rule.response, _ = v2.NewMessageTransformation(ruleView.Response.MessageTransformationView)
+	}
+
+	return rule, nil
+}
+
+func (this transformationRule) matches(requestDetails models.RequestDetails) bool {
+	return this.destination.MatchString(requestDetails.Destination) && this.path.MatchString(requestDetails.Path)
+}
+
+func (this transformationRule) transformRequest(requestDetails models.RequestDetails) models.RequestDetails {
+	transformation := this.view.Request
+
+	if transformation.Destination != "" {
+		requestDetails.Destination = transformation.Destination
+	}
+	if this.pathRegex != nil {
+		requestDetails.Path = this.pathRegex.ReplaceAllString(requestDetails.Path, transformation.PathReplacement)
+	}
+
+	requestDetails.Headers = this.request.TransformHeaders(requestDetails.Headers)
+	requestDetails.Body = this.request.TransformBody(requestDetails.Body)
+
+	return requestDetails
+}
+
+func (this transformationRule) transformResponse(responseDetails models.ResponseDetails) models.ResponseDetails {
+	transformation := this.view.Response
+
+	if transformation.Status != 0 {
+		responseDetails.Status = transformation.Status
+	}
+
+	responseDetails.Headers = this.response.TransformHeaders(responseDetails.Headers)
+
+	body := this.response.TransformBody(responseDetails.Body)
+	if body != responseDetails.Body {
+		// the length of the body is worked out again when it is written
+		delete(responseDetails.Headers, "Content-Length")
+		responseDetails.Body = body
+	}
+
+	return responseDetails
+}
+
+// TransformRequest applies the request part of every rule matching the request, in order
+func (hf *Hoverfly) TransformRequest(requestDetails models.RequestDetails) models.RequestDetails {
+	hf.transformationRulesMutex.RLock()
+	defer hf.transformationRulesMutex.RUnlock()
+
+	transformed := requestDetails
+	for _, rule := range hf.transformationRules {
+		if rule.view.Request != nil && rule.matches(requestDetails) {
+			transformed = rule.transformRequest(transformed)
+		}
+	}
+
+	return transformed
+}
+
+// TransformResponse applies the response part of every rule matching the request to its response,
+// in order, returning whether any rule was applied
+func (hf *Hoverfly) TransformResponse(requestDetails models.RequestDetails, responseDetails models.ResponseDetails) (models.ResponseDetails, bool) {
+	hf.transformationRulesMutex.RLock()
+	defer hf.transformationRulesMutex.RUnlock()
+
+	transformed := false
+	for _, rule := range hf.transformationRules {
+		if rule.view.Response != nil && rule.matches(requestDetails) {
+			responseDetails = rule.transformResponse(responseDetails)
+			transformed = true
+		}
+	}
+
+	return responseDetails, transformed
+}
+
+// TransformsResponse returns whether any rule changes the response to the request, so that the
+// response only has to be read when one does
+func (hf *Hoverfly) TransformsResponse(requestDetails models.RequestDetails) bool {
+	hf.transformationRulesMutex.RLock()
+	defer hf.transformationRulesMutex.RUnlock()
+
+	for _, rule := range hf.transformationRules {
+		if rule.view.Response != nil && rule.matches(requestDetails) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (hf *Hoverfly) GetTransformationRules() v2.TransformationRulesView {
+	hf.transformationRulesMutex.RLock()
+	defer hf.transformationRulesMutex.RUnlock()
+
+	rulesView := v2.TransformationRulesView{Rules: []v2.TransformationRuleView{}}
+	for _, rule := range hf.transformationRules {
+		rulesView.Rules = append(rulesView.Rules, rule.view)
+	}
+
+	return rulesView
+}
+
+// SetTransformationRules replaces every rule, leaving them as they were when any of the new ones is not valid
+func (hf *Hoverfly) SetTransformationRules(rulesView v2.TransformationRulesView) error {
+	rules := []transformationRule{}
+	for i, ruleView := range rulesView.Rules {
+		rule, err := newTransformationRule(ruleView)
+		if err != nil {
+			return fmt.Errorf("Transformation rule %d is not valid: %s", i, err.Error())
+		}
+		rules = append(rules, rule)
+	}
+
+	hf.transformationRulesMutex.Lock()
+	hf.transformationRules = rules
+	hf.transformationRulesMutex.Unlock()
+
+	log.WithFields(log.Fields{
+		"rules": len(rules),
+	}).Info("Transformation rules have been changed")
+
+	return nil
+}
+
+func (hf *Hoverfly) AddTransformationRule(ruleView v2.TransformationRuleView) error {
+	rule, err := newTransformationRule(ruleView)
+	if err != nil {
+		return err
+	}
+
+	hf.transformationRulesMutex.Lock()
+	hf.transformationRules = append(hf.transformationRules, rule)
+	hf.transformationRulesMutex.Unlock()
+
+	log.WithFields(log.Fields{
+		"destination": ruleView.Destination,
+		"path":        ruleView.Path,
+	}).Info("Transformation rule has been added")
+
+	return nil
+}
+
+func (hf *Hoverfly) DeleteTransformationRules() {
+	hf.transformationRulesMutex.Lock()
+	hf.transformationRules = nil
+	hf.transformationRulesMutex.Unlock()
+}
@@ -0,0 +1,195 @@
+package hoverfly
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/models"
+	. "github.com/onsi/gomega"
+)
+
+func Test_Hoverfly_TransformRequest_AppliesEveryMatchingRuleInOrder(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	Expect(unit.SetTransformationRules(v2.TransformationRulesView{
+		Rules: []v2.TransformationRuleView{
+			{
+				Destination: `^catalog\.com$`,
+				Request: &v2.RequestTransformationView{
+					Destination:     "catalog.internal:8080",
+					PathRegex:       "^/v1/(.*)",
+					PathReplacement: "/api/$1",
+				},
+			},
+			{
+				Path: "^/v1",
+				Request: &v2.RequestTransformationView{
+					MessageTransformationView: v2.MessageTransformationView{
+						SetHeaders:    map[string][]string{"X-Api-Key": {"key"}},
+						RemoveHeaders: []string{"cookie"},
+						SetJsonPaths:  []v2.JsonPathValueView{{Path: "$.user", Value: "test"}},
+					},
+				},
+			},
+			{
+				Destination: "payments.com",
+				Request:     &v2.RequestTransformationView{Destination: "payments.internal"},
+			},
+		},
+	})).To(Succeed())
+
+	transformed := unit.TransformRequest(models.RequestDetails{
+		Destination: "catalog.com",
+		Path:        "/v1/items",
+		Body:        `{"user": "jane"}`,
+		Headers: map[string][]string{
+			"Cookie": {"session=1"},
+			"Accept": {"application/json"},
+		},
+	})
+
+	Expect(transformed.Destination).To(Equal("catalog.internal:8080"))
+	Expect(transformed.Path).To(Equal("/api/items"))
+	Expect(transformed.Body).To(Equal(`{"user":"test"}`))
+	Expect(transformed.Headers).To(Equal(map[string][]string{
+		"Accept":    {"application/json"},
+		"X-Api-Key": {"key"},
+	}))
+}
+
+func Test_Hoverfly_TransformRequest_LeavesRequestsNoRuleMatches(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	Expect(unit.AddTransformationRule(v2.TransformationRuleView{
+		Destination: "catalog.com",
+		Request:     &v2.RequestTransformationView{Destination: "catalog.internal"},
+	})).To(Succeed())
+
+	request := models.RequestDetails{
+		Destination: "payments.com",
+		Path:        "/payments",
+		Headers:     map[string][]string{"Accept": {"application/json"}},
+	}
+
+	Expect(unit.TransformRequest(request)).To(Equal(request))
+}
+
+func Test_Hoverfly_TransformResponse_ChangesStatusHeadersAndBody(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	Expect(unit.AddTransformationRule(v2.TransformationRuleView{
+		Destination: "catalog.com",
+		Response: &v2.ResponseTransformationView{
+			Status: http.StatusServiceUnavailable,
+			MessageTransformationView: v2.MessageTransformationView{
+				SetHeaders:    map[string][]string{"Retry-After": {"10"}},
+				RemoveHeaders: []string{"Server"},
+				SetJsonPaths:  []v2.JsonPathValueView{{Path: "$.stock", Value: 0}},
+			},
+		},
+	})).To(Succeed())
+
+	transformed, ok := unit.TransformResponse(models.RequestDetails{Destination: "catalog.com"}, models.ResponseDetails{
+		Status: http.StatusOK,
+		Body:   `{"stock": 12}`,
+		Headers: map[string][]string{
+			"Server":         {"nginx"},
+			"Content-Length": {"13"},
+			"Content-Type":   {"application/json"},
+		},
+	})
+
+	Expect(ok).To(BeTrue())
+	Expect(transformed.Status).To(Equal(http.StatusServiceUnavailable))
+	Expect(transformed.Body).To(Equal(`{"stock":0}`))
+	Expect(transformed.Headers).To(Equal(map[string][]string{
+		"Content-Type": {"application/json"},
+		"Retry-After":  {"10"},
+	}))
+
+	_, ok = unit.TransformResponse(models.RequestDetails{Destination: "payments.com"}, models.ResponseDetails{Status: http.StatusOK})
+	Expect(ok).To(BeFalse())
+}
+
+func Test_Hoverfly_TransformsResponse_IsTrueOnlyWhenAResponseRuleMatches(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	Expect(unit.AddTransformationRule(v2.TransformationRuleView{
+		Destination: "payments.com",
+		Request:     &v2.RequestTransformationView{Destination: "payments.internal"},
+	})).To(Succeed())
+	Expect(unit.AddTransformationRule(v2.TransformationRuleView{
+		Destination: "catalog.com",
+		Response:    &v2.ResponseTransformationView{Status: http.StatusServiceUnavailable},
+	})).To(Succeed())
+
+	Expect(unit.TransformsResponse(models.RequestDetails{Destination: "catalog.com"})).To(BeTrue())
+	Expect(unit.TransformsResponse(models.RequestDetails{Destination: "payments.com"})).To(BeFalse())
+	Expect(unit.TransformsResponse(models.RequestDetails{Destination: "orders.com"})).To(BeFalse())
+}
+
+func Test_Hoverfly_SetTransformationRules_KeepsRulesWhenANewRuleIsNotValid(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	rule := v2.TransformationRuleView{
+		Destination: "catalog.com",
+		Response:    &v2.ResponseTransformationView{Status: http.StatusServiceUnavailable},
+	}
+	Expect(unit.AddTransformationRule(rule)).To(Succeed())
+
+	err := unit.SetTransformationRules(v2.TransformationRulesView{
+		Rules: []v2.TransformationRuleView{rule, {Path: "[v1", Response: rule.Response}},
+	})
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(ContainSubstring("Transformation rule 1 is not valid"))
+
+	Expect(unit.GetTransformationRules().Rules).To(Equal([]v2.TransformationRuleView{rule}))
+
+	unit.DeleteTransformationRules()
+	Expect(unit.GetTransformationRules().Rules).To(BeEmpty())
+}
+
+func Test_Hoverfly_processRequest_CapturesTransformedRequestsAndResponses(t *testing.T) {
+	RegisterTestingT(t)
+
+	server, unit := testTools(200, `{"stock": 12}`)
+	defer server.Close()
+
+	Expect(unit.SetMode("capture")).To(Succeed())
+	Expect(unit.AddTransformationRule(v2.TransformationRuleView{
+		Destination: "catalog.com",
+		Request: &v2.RequestTransformationView{
+			PathRegex:       "^/v1",
+			PathReplacement: "/v2",
+		},
+		Response: &v2.ResponseTransformationView{
+			Status: http.StatusCreated,
+			MessageTransformationView: v2.MessageTransformationView{
+				SetJsonPaths: []v2.JsonPathValueView{{Path: "$.stock", Value: 0}},
+			},
+		},
+	})).To(Succeed())
+
+	request, err := http.NewRequest("GET", "http://catalog.com/v1/items", nil)
+	Expect(err).To(BeNil())
+
+	response := unit.processRequest(request)
+	Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+	pairs := unit.Simulation.GetMatchingPairs()
+	Expect(pairs).To(HaveLen(1))
+	Expect(pairs[0].RequestMatcher.Path[0].Value).To(Equal("/v2/items"))
+	Expect(pairs[0].Response.Status).To(Equal(http.StatusCreated))
+	Expect(pairs[0].Response.Body).To(Equal(`{"stock":0}`))
+}
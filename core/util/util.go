@@ -2,6 +2,7 @@ package util
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
@@ -10,9 +11,11 @@ import (
 	"sort"
 	"strings"
 
+	"fmt"
 	"github.com/tdewolff/minify"
 	mjson "github.com/tdewolff/minify/json"
 	"github.com/tdewolff/minify/xml"
+	"gopkg.in/yaml.v2"
 	"strconv"
 	"time"
 )
@@ -45,6 +48,25 @@ func GetResponseBody(response *http.Response) (string, error) {
 	return string(bodyBytes), nil
 }
 
+type pairIdKey struct{}
+
+// WithPairId returns a copy of the request which records the id of the simulated pair
+// the request was answered with, so the id can be read back from the response
+func WithPairId(request *http.Request, pairId string) *http.Request {
+	return request.WithContext(context.WithValue(request.Context(), pairIdKey{}, pairId))
+}
+
+// GetPairId returns the id of the simulated pair a response was served from, or an
+// empty string if it was not served from the simulation
+func GetPairId(response *http.Response) string {
+	if response == nil || response.Request == nil {
+		return ""
+	}
+
+	pairId, _ := response.Request.Context().Value(pairIdKey{}).(string)
+	return pairId
+}
+
 func GetUnixTimeQueryParam(request *http.Request, paramName string) *time.Time {
 	var timeQuery *time.Time
 	epochValue, _ := strconv.Atoi(request.URL.Query().Get(paramName))
@@ -133,6 +155,37 @@ func JSONMarshal(t interface{}) ([]byte, error) {
 	return buffer.Bytes(), err
 }
 
+// YAMLToJSON converts a YAML document into JSON so that it can be read in the same
+// way as JSON, including validation against the JSON schemas of simulations
+func YAMLToJSON(body []byte) ([]byte, error) {
+	var document interface{}
+	if err := yaml.Unmarshal(body, &document); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(jsonCompatibleValue(document))
+}
+
+// yaml.v2 decodes maps with interface{} keys, which encoding/json cannot handle
+func jsonCompatibleValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[interface{}]interface{}:
+		converted := map[string]interface{}{}
+		for key, child := range value {
+			converted[fmt.Sprint(key)] = jsonCompatibleValue(child)
+		}
+		return converted
+	case []interface{}:
+		converted := make([]interface{}, len(value))
+		for i, child := range value {
+			converted[i] = jsonCompatibleValue(child)
+		}
+		return converted
+	}
+
+	return value
+}
+
 var minifier *minify.M
 
 func GetMinifier() *minify.M {
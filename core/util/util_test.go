@@ -64,6 +64,16 @@ func Test_GetResponseBody_GettingTheResponseBodySetsTheSameBodyAgain(t *testing.
 	Expect(string(newResponseBody)).To(Equal("test-preserve"))
 }
 
+func Test_GetPairId_ReturnsPairIdRecordedOnRequest(t *testing.T) {
+	RegisterTestingT(t)
+
+	request, _ := http.NewRequest("GET", "http://hoverfly.io", nil)
+
+	Expect(GetPairId(&http.Response{Request: request})).To(Equal(""))
+	Expect(GetPairId(&http.Response{Request: WithPairId(request, "get-payment")})).To(Equal("get-payment"))
+	Expect(GetPairId(&http.Response{})).To(Equal(""))
+}
+
 func Test_SortQueryString_ReordersQueryStringAlphabetically(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -192,3 +202,27 @@ func Test_MinifyXml_SimplifiesXmlString(t *testing.T) {
 		<document></document>
 	</xml>`)).To(Equal(`<xml><document/></xml>`))
 }
+
+func Test_YAMLToJSON_ConvertsYamlIntoJson(t *testing.T) {
+	RegisterTestingT(t)
+
+	jsonBytes, err := YAMLToJSON([]byte(`
+name: Rex
+age: 3
+tags:
+- dog
+owner:
+  name: Sam
+`))
+
+	Expect(err).To(BeNil())
+	Expect(string(jsonBytes)).To(MatchJSON(`{"name": "Rex", "age": 3, "tags": ["dog"], "owner": {"name": "Sam"}}`))
+}
+
+func Test_YAMLToJSON_ErrorsOnInvalidYaml(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := YAMLToJSON([]byte("tags: [\n"))
+
+	Expect(err).ToNot(BeNil())
+}
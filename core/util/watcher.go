@@ -0,0 +1,112 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/fsnotify/fsnotify"
+)
+
+// FileWatcher calls a function once the files or directories it watches have
+// stopped changing for the given delay, so a save which is made up of several
+// writes results in a single call.
+type FileWatcher struct {
+	watcher     *fsnotify.Watcher
+	files       map[string]bool
+	directories map[string]bool
+	delay       time.Duration
+	onChange    func()
+	done        chan struct{}
+}
+
+// NewFileWatcher starts watching the given paths. The directory containing
+// each file is watched rather than the file itself, as many editors save a
+// file by replacing it, which would otherwise end the watch. Only the top
+// level of a directory is watched.
+func NewFileWatcher(paths []string, delay time.Duration, onChange func()) (*FileWatcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	fileWatcher := &FileWatcher{
+		watcher:     watcher,
+		files:       map[string]bool{},
+		directories: map[string]bool{},
+		delay:       delay,
+		onChange:    onChange,
+		done:        make(chan struct{}),
+	}
+
+	for _, path := range paths {
+		path, err = filepath.Abs(path)
+		if err != nil {
+			watcher.Close()
+			return nil, err
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			watcher.Close()
+			return nil, err
+		}
+
+		watched := path
+		if info.IsDir() {
+			fileWatcher.directories[path] = true
+		} else {
+			fileWatcher.files[path] = true
+			watched = filepath.Dir(path)
+		}
+
+		if err := watcher.Add(watched); err != nil {
+			watcher.Close()
+			return nil, err
+		}
+	}
+
+	go fileWatcher.run()
+
+	return fileWatcher, nil
+}
+
+// Close stops watching, after which the function is no longer called
+func (this *FileWatcher) Close() error {
+	close(this.done)
+	return this.watcher.Close()
+}
+
+func (this *FileWatcher) run() {
+	var changed <-chan time.Time
+
+	for {
+		select {
+		case event, ok := <-this.watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op == fsnotify.Chmod || !this.isWatched(event.Name) {
+				continue
+			}
+			changed = time.After(this.delay)
+		case err, ok := <-this.watcher.Errors:
+			if !ok {
+				return
+			}
+			log.WithFields(log.Fields{
+				"error": err.Error(),
+			}).Warn("Error while watching files")
+		case <-changed:
+			changed = nil
+			this.onChange()
+		case <-this.done:
+			return
+		}
+	}
+}
+
+func (this *FileWatcher) isWatched(path string) bool {
+	return this.files[path] || this.directories[filepath.Dir(path)]
+}
@@ -0,0 +1,66 @@
+package util
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func Test_FileWatcher_CallsFunctionOnceWhenFileChanges(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "watcher")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "simulation.json")
+	Expect(ioutil.WriteFile(file, []byte("1"), 0644)).To(Succeed())
+	Expect(ioutil.WriteFile(filepath.Join(dir, "other.json"), []byte("1"), 0644)).To(Succeed())
+
+	changes := make(chan bool, 10)
+	unit, err := NewFileWatcher([]string{file}, 50*time.Millisecond, func() {
+		changes <- true
+	})
+	Expect(err).To(BeNil())
+	defer unit.Close()
+
+	Expect(ioutil.WriteFile(filepath.Join(dir, "other.json"), []byte("2"), 0644)).To(Succeed())
+	Consistently(changes, 200*time.Millisecond).ShouldNot(Receive())
+
+	Expect(ioutil.WriteFile(file, []byte("2"), 0644)).To(Succeed())
+	Expect(ioutil.WriteFile(file, []byte("3"), 0644)).To(Succeed())
+
+	Eventually(changes, time.Second).Should(Receive())
+	Consistently(changes, 200*time.Millisecond).ShouldNot(Receive())
+}
+
+func Test_FileWatcher_CallsFunctionWhenFileInDirectoryIsReplaced(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "watcher")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+
+	changes := make(chan bool, 10)
+	unit, err := NewFileWatcher([]string{dir}, 50*time.Millisecond, func() {
+		changes <- true
+	})
+	Expect(err).To(BeNil())
+	defer unit.Close()
+
+	Expect(ioutil.WriteFile(filepath.Join(dir, "simulation.json.tmp"), []byte("1"), 0644)).To(Succeed())
+	Expect(os.Rename(filepath.Join(dir, "simulation.json.tmp"), filepath.Join(dir, "simulation.json"))).To(Succeed())
+
+	Eventually(changes, time.Second).Should(Receive())
+}
+
+func Test_NewFileWatcher_ErrorsWhenPathDoesNotExist(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := NewFileWatcher([]string{"does-not-exist.json"}, time.Millisecond, func() {})
+	Expect(err).ToNot(BeNil())
+}
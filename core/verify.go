@@ -0,0 +1,125 @@
+package hoverfly
+
+import (
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/models"
+	"github.com/SpectoLabs/hoverfly/core/modes"
+	"github.com/SpectoLabs/hoverfly/core/util"
+)
+
+// VerifySimulation checks the simulation is still true for the real service. The request each pair
+// with only exact matchers matches is sent to the service, and its response is compared with the
+// response of the pair as diff mode would compare them. Nothing is recorded while verifying.
+func (hf *Hoverfly) VerifySimulation(diffRules *v2.DiffRulesView) (v2.SimulationVerificationView, error) {
+	verification := v2.SimulationVerificationView{
+		Pairs: []v2.PairVerificationView{},
+	}
+
+	var rules *v2.DiffRules
+	if diffRules != nil {
+		var err error
+		if rules, err = v2.NewDiffRules(*diffRules); err != nil {
+			return verification, err
+		}
+	}
+
+	simulationView, err := hf.GetSimulation()
+	if err != nil {
+		return verification, err
+	}
+
+	for i, pairView := range simulationView.RequestResponsePairs {
+		pairVerification := hf.verifyPair(pairView, rules)
+		pairVerification.Pair = i
+		pairVerification.PairId = pairView.Id
+
+		switch pairVerification.Result {
+		case v2.VerificationPassed:
+			verification.Passed++
+		case v2.VerificationFailed:
+			verification.Failed++
+		case v2.VerificationSkipped:
+			verification.Skipped++
+		}
+
+		verification.Pairs = append(verification.Pairs, pairVerification)
+	}
+
+	return verification, nil
+}
+
+func (hf *Hoverfly) verifyPair(pairView v2.RequestMatcherResponsePairViewV6, diffRules *v2.DiffRules) v2.PairVerificationView {
+	skip := func(message string) v2.PairVerificationView {
+		return v2.PairVerificationView{Result: v2.VerificationSkipped, Message: message}
+	}
+
+	if !v2.IsExactRequestMatcher(pairView.RequestMatcher) {
+		return skip("The request matcher uses matchers other than exact")
+	}
+
+	if pairView.Response.Templated {
+		return skip("The response is templated")
+	}
+
+	requestView := v2.NewExactRequestDetailsView(pairView.RequestMatcher)
+	if requestView.Destination == nil || *requestView.Destination == "" {
+		return skip("The request matcher has no destination")
+	}
+	if requestView.Scheme == nil || *requestView.Scheme == "" {
+		requestView.Scheme = util.StringToPointer("http")
+	}
+	if requestView.Method == nil || *requestView.Method == "" {
+		requestView.Method = util.StringToPointer("GET")
+	}
+
+	pair := models.RequestResponsePair{
+		Request:  models.NewRequestDetailsFromRequest(requestView),
+		Response: models.NewResponseDetailsFromResponse(pairView.Response),
+	}
+
+	verification := v2.PairVerificationView{
+		Result: v2.VerificationFailed,
+		Request: &v2.SimpleRequestDefinitionView{
+			Method: pair.Request.Method,
+			Host:   pair.Request.Destination,
+			Path:   pair.Request.Path,
+			Query:  pair.Request.QueryString(),
+		},
+	}
+
+	request, err := modes.ReconstructRequest(pair)
+	if err != nil {
+		verification.Message = "The request could not be built: " + err.Error()
+		return verification
+	}
+
+	response, err := hf.DoRequest(request)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"error":   err.Error(),
+			"request": modes.GetRequestLogFields(&pair.Request),
+		}).Warn("There was an error when verifying a pair against the real service")
+
+		verification.Message = "The request could not be sent: " + err.Error()
+		return verification
+	}
+
+	responseBody, _ := util.GetResponseBody(response)
+
+	diffReport := modes.DiffResponses(&pair.Response, &models.ResponseDetails{
+		Status:  response.StatusCode,
+		Body:    responseBody,
+		Headers: response.Header,
+	}, modes.ModeArguments{DiffRules: diffRules}, time.Now().Format(time.RFC3339))
+
+	if len(diffReport.DiffEntries) > 0 {
+		verification.DiffEntries = diffReport.DiffEntries
+		return verification
+	}
+
+	verification.Result = v2.VerificationPassed
+	return verification
+}
@@ -0,0 +1,100 @@
+package hoverfly
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	. "github.com/onsi/gomega"
+)
+
+func putVerifiedPairs(unit *Hoverfly, pairs string) {
+	simulationView, err := v2.NewSimulationViewFromResponseBody([]byte(`{
+		"data": {
+			"pairs": [` + pairs + `],
+			"globalActions": {"delays": []}
+		},
+		"meta": {"schemaVersion": "v6"}
+	}`))
+	Expect(err).To(BeNil())
+	Expect(unit.PutSimulation(simulationView).GetError()).To(BeNil())
+}
+
+func Test_Hoverfly_VerifySimulation_ComparesResponsesOfExactPairsWithTheRealService(t *testing.T) {
+	RegisterTestingT(t)
+
+	server, unit := testTools(200, `{"message": "actual", "count": 1}`)
+	defer server.Close()
+
+	putVerifiedPairs(unit, `
+		{"request": {"destination": [{"matcher": "exact", "value": "somehost.com"}], "path": [{"matcher": "exact", "value": "/same"}]}, "response": {"status": 200, "body": "{\"message\": \"actual\", \"count\": 1}"}},
+		{"id": "changed", "request": {"method": [{"matcher": "exact", "value": "POST"}], "destination": [{"matcher": "exact", "value": "somehost.com"}], "path": [{"matcher": "exact", "value": "/changed"}]}, "response": {"status": 201, "body": "{\"message\": \"expected\", \"count\": 1}"}},
+		{"request": {"destination": [{"matcher": "exact", "value": "somehost.com"}], "path": [{"matcher": "glob", "value": "/any/*"}]}, "response": {"status": 200}},
+		{"request": {"path": [{"matcher": "exact", "value": "/nowhere"}]}, "response": {"status": 200}},
+		{"request": {"destination": [{"matcher": "exact", "value": "somehost.com"}], "path": [{"matcher": "exact", "value": "/templated"}]}, "response": {"status": 200, "body": "{{ Request.Path }}", "templated": true}}
+	`)
+
+	verification, err := unit.VerifySimulation(nil)
+	Expect(err).To(BeNil())
+
+	Expect(verification.Passed).To(Equal(1))
+	Expect(verification.Failed).To(Equal(1))
+	Expect(verification.Skipped).To(Equal(3))
+	Expect(verification.Pairs).To(HaveLen(5))
+
+	Expect(verification.Pairs[0].Result).To(Equal(v2.VerificationPassed))
+	Expect(*verification.Pairs[0].Request).To(Equal(v2.SimpleRequestDefinitionView{
+		Method: "GET",
+		Host:   "somehost.com",
+		Path:   "/same",
+	}))
+
+	Expect(verification.Pairs[1].Result).To(Equal(v2.VerificationFailed))
+	Expect(verification.Pairs[1].Pair).To(Equal(1))
+	Expect(verification.Pairs[1].PairId).To(Equal("changed"))
+	Expect(verification.Pairs[1].Request.Method).To(Equal("POST"))
+	Expect(verification.Pairs[1].DiffEntries).To(ConsistOf(
+		v2.DiffReportEntry{Field: "status", Expected: "201", Actual: "200"},
+		v2.DiffReportEntry{Field: "body/message", Expected: "expected", Actual: "actual"}))
+
+	Expect(verification.Pairs[2].Result).To(Equal(v2.VerificationSkipped))
+	Expect(verification.Pairs[2].Message).To(Equal("The request matcher uses matchers other than exact"))
+	Expect(verification.Pairs[3].Message).To(Equal("The request matcher has no destination"))
+	Expect(verification.Pairs[4].Message).To(Equal("The response is templated"))
+}
+
+func Test_Hoverfly_VerifySimulation_UsesDiffRules(t *testing.T) {
+	RegisterTestingT(t)
+
+	server, unit := testTools(200, `{"message": "actual", "count": 1.2}`)
+	defer server.Close()
+
+	putVerifiedPairs(unit, `
+		{"request": {"destination": [{"matcher": "exact", "value": "somehost.com"}]}, "response": {"status": 200, "body": "{\"message\": \"expected\", \"count\": 1}"}}
+	`)
+
+	verification, err := unit.VerifySimulation(&v2.DiffRulesView{
+		IgnoreJsonPaths: []string{"$.message"},
+		Tolerance:       0.5,
+	})
+	Expect(err).To(BeNil())
+
+	Expect(verification.Passed).To(Equal(1))
+	Expect(verification.Pairs[0].DiffEntries).To(BeEmpty())
+}
+
+func Test_Hoverfly_VerifySimulation_FailsPairsWhenTheRealServiceCannotBeReached(t *testing.T) {
+	RegisterTestingT(t)
+
+	server, unit := testTools(200, "")
+	server.Close()
+
+	putVerifiedPairs(unit, `
+		{"request": {"destination": [{"matcher": "exact", "value": "somehost.com"}]}, "response": {"status": 200}}
+	`)
+
+	verification, err := unit.VerifySimulation(nil)
+	Expect(err).To(BeNil())
+
+	Expect(verification.Failed).To(Equal(1))
+	Expect(verification.Pairs[0].Message).To(HavePrefix("The request could not be sent: "))
+}
@@ -0,0 +1,39 @@
+package hoverfly
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/SpectoLabs/hoverfly/core/util"
+)
+
+// importWatchDelay is how long the watched files must go unchanged before they are
+// reimported, long enough for an editor to finish saving
+const importWatchDelay = 200 * time.Millisecond
+
+// WatchImports reimports the given files and directories whenever they change, replacing the
+// simulation with them. A change which is not a valid simulation is logged, and the simulation
+// which was last imported is kept until it is fixed. URLs cannot be watched.
+func (hf *Hoverfly) WatchImports(uris []string) (*util.FileWatcher, error) {
+	for _, uri := range uris {
+		if isURL(uri) {
+			return nil, fmt.Errorf("Cannot watch %s, only files and directories can be watched", uri)
+		}
+	}
+
+	return util.NewFileWatcher(uris, importWatchDelay, func() {
+		err := hf.Import(uris...)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"error":  err.Error(),
+				"import": uris,
+			}).Error("Failed to reimport changed simulation, keeping the previous simulation")
+			return
+		}
+
+		log.WithFields(log.Fields{
+			"import": uris,
+		}).Info("Reimported changed simulation")
+	})
+}
@@ -0,0 +1,68 @@
+package hoverfly
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	. "github.com/onsi/gomega"
+)
+
+func newWatchedPairView(path, body string) v2.RequestMatcherResponsePairViewV6 {
+	return v2.RequestMatcherResponsePairViewV6{
+		RequestMatcher: v2.RequestMatcherViewV5{
+			Path: []v2.MatcherViewV5{
+				{
+					Matcher: matchers.Exact,
+					Value:   path,
+				},
+			},
+		},
+		Response: v2.ResponseDetailsViewV6{
+			Status: 200,
+			Body:   body,
+		},
+	}
+}
+
+func Test_Hoverfly_WatchImports_ReimportsChangedFile(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "watch")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "simulation.yaml")
+	Expect(ioutil.WriteFile(file, []byte(yamlSimulation("/a")), 0644)).To(Succeed())
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	Expect(unit.Import(file)).To(Succeed())
+
+	watcher, err := unit.WatchImports([]string{file})
+	Expect(err).To(BeNil())
+	defer watcher.Close()
+
+	Expect(ioutil.WriteFile(file, []byte("data: [\n"), 0644)).To(Succeed())
+	Consistently(func() interface{} {
+		return unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Path[0].Value
+	}, 500*time.Millisecond).Should(Equal("/a"))
+
+	Expect(ioutil.WriteFile(file, []byte(yamlSimulation("/b")), 0644)).To(Succeed())
+	Eventually(func() interface{} {
+		return unit.Simulation.GetMatchingPairs()[0].RequestMatcher.Path[0].Value
+	}, 2*time.Second).Should(Equal("/b"))
+}
+
+func Test_Hoverfly_WatchImports_ErrorsForUrls(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	_, err := unit.WatchImports([]string{"http://test.com/simulation.json"})
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Cannot watch http://test.com/simulation.json, only files and directories can be watched"))
+}
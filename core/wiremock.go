@@ -0,0 +1,43 @@
+package hoverfly
+
+import (
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+)
+
+// PutWireMock replaces the simulation with one converted from WireMock mappings. Each scenario
+// is put in its Started state, as the mappings for the first step of a scenario require it.
+func (hf *Hoverfly) PutWireMock(mappings v2.WireMockMappingsView) v2.SimulationImportResult {
+	simulationView, scenarios, warnings := v2.NewSimulationViewFromWireMock(mappings)
+
+	result := hf.ReplaceSimulation(simulationView)
+	if result.GetError() != nil {
+		return result
+	}
+
+	hf.PatchState(scenarios)
+
+	result.WarningMessages = append(warnings, result.WarningMessages...)
+	return result
+}
+
+// AppendWireMock adds WireMock mappings to the simulation. Only scenarios which
+// are not already in the state are put in their Started state.
+func (hf *Hoverfly) AppendWireMock(mappings v2.WireMockMappingsView) v2.SimulationImportResult {
+	simulationView, scenarios, warnings := v2.NewSimulationViewFromWireMock(mappings)
+
+	result := hf.AppendSimulation(simulationView)
+	if result.GetError() != nil {
+		return result
+	}
+
+	newScenarios := map[string]string{}
+	for scenario, started := range scenarios {
+		if _, ok := hf.state.GetValue(scenario); !ok {
+			newScenarios[scenario] = started
+		}
+	}
+	hf.PatchState(newScenarios)
+
+	result.WarningMessages = append(warnings, result.WarningMessages...)
+	return result
+}
@@ -0,0 +1,91 @@
+package hoverfly
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	. "github.com/onsi/gomega"
+)
+
+func newWireMockScenarioMapping(path, requiredState, newState string) v2.WireMockMappingView {
+	return v2.WireMockMappingView{
+		ScenarioName:          "scenario",
+		RequiredScenarioState: requiredState,
+		NewScenarioState:      newState,
+		Request: v2.WireMockRequestView{
+			Method:  "GET",
+			UrlPath: &path,
+		},
+		Response: v2.WireMockResponseView{
+			Status: 200,
+			Body:   path,
+		},
+	}
+}
+
+func Test_Hoverfly_PutWireMock_StartsScenarios(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	result := unit.PutWireMock(v2.WireMockMappingsView{
+		Mappings: []v2.WireMockMappingView{
+			newWireMockScenarioMapping("/first", "Started", "Second"),
+			newWireMockScenarioMapping("/second", "Second", ""),
+		},
+	})
+	Expect(result.GetError()).To(BeNil())
+	Expect(result.WarningMessages).To(BeEmpty())
+
+	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(2))
+	Expect(unit.GetState()).To(Equal(map[string]string{"scenario": "Started"}))
+}
+
+func Test_Hoverfly_AppendWireMock_KeepsStateOfExistingScenarios(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+	unit.PutWireMock(v2.WireMockMappingsView{
+		Mappings: []v2.WireMockMappingView{
+			newWireMockScenarioMapping("/first", "Started", "Second"),
+		},
+	})
+	unit.PatchState(map[string]string{"scenario": "Second"})
+
+	otherMapping := newWireMockScenarioMapping("/other", "Started", "")
+	otherMapping.ScenarioName = "other"
+
+	unit.AppendWireMock(v2.WireMockMappingsView{
+		Mappings: []v2.WireMockMappingView{
+			newWireMockScenarioMapping("/second", "Second", ""),
+			otherMapping,
+		},
+	})
+
+	Expect(unit.Simulation.GetMatchingPairs()).To(HaveLen(3))
+	Expect(unit.GetState()).To(Equal(map[string]string{
+		"scenario": "Second",
+		"other":    "Started",
+	}))
+}
+
+func Test_Hoverfly_PutWireMock_ReturnsWarningsForUnsupportedMappings(t *testing.T) {
+	RegisterTestingT(t)
+
+	unit := NewHoverflyWithConfiguration(&Configuration{})
+
+	path := "/faulty"
+	result := unit.PutWireMock(v2.WireMockMappingsView{
+		Mappings: []v2.WireMockMappingView{
+			{
+				Request:  v2.WireMockRequestView{UrlPath: &path},
+				Response: v2.WireMockResponseView{Fault: "CONNECTION_RESET_BY_PEER"},
+			},
+		},
+	})
+
+	Expect(result.GetError()).To(BeNil())
+	Expect(result.WarningMessages).To(HaveLen(1))
+	Expect(result.WarningMessages[0].Message).To(Equal("WARNING: mappings[0].response.fault is not supported, the mapping was not imported"))
+	Expect(unit.Simulation.GetMatchingPairs()).To(BeEmpty())
+}
@@ -46,7 +46,7 @@ var _ = Describe("/api/v2/simulation", func() {
 			Expect(err).To(BeNil())
 			schemaVersion, err := metaObject.GetString("schemaVersion")
 			Expect(err).To(BeNil())
-			Expect(schemaVersion).To(Equal("v5"))
+			Expect(schemaVersion).To(Equal("v6"))
 			hoverflyVersion, err := metaObject.GetString("hoverflyVersion")
 			Expect(err).To(BeNil())
 			Expect(hoverflyVersion).ToNot(BeNil())
@@ -173,7 +173,7 @@ var _ = Describe("/api/v2/simulation", func() {
 			Expect(err).To(BeNil())
 			schemaVersion, err := metaObject.GetString("schemaVersion")
 			Expect(err).To(BeNil())
-			Expect(schemaVersion).To(Equal("v5"))
+			Expect(schemaVersion).To(Equal("v6"))
 			hoverflyVersion, err := metaObject.GetString("hoverflyVersion")
 			Expect(err).To(BeNil())
 			Expect(hoverflyVersion).ToNot(BeNil())
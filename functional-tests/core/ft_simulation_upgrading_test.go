@@ -32,11 +32,11 @@ var _ = Describe("Running Hoverfly with older simulations", func() {
 			hoverfly.ImportSimulation(testdata.V1JsonPayload)
 			upgradedSimulation := hoverfly.ExportSimulation()
 
-			simulation := v2.SimulationViewV5{}
+			simulation := v2.SimulationViewV6{}
 
 			functional_tests.Unmarshal([]byte(testdata.V5JsonPayload), &simulation)
 
-			Expect(upgradedSimulation.DataViewV5).To(Equal(simulation.DataViewV5))
+			Expect(withoutPairIds(upgradedSimulation.DataViewV6)).To(Equal(simulation.DataViewV6))
 		})
 	})
 
@@ -50,66 +50,66 @@ var _ = Describe("Running Hoverfly with older simulations", func() {
 			hoverfly.ImportSimulation(testdata.V3Delays)
 			upgradedSimulation := hoverfly.ExportSimulation()
 
-			simulation := v2.SimulationViewV5{}
+			simulation := v2.SimulationViewV6{}
 
 			functional_tests.Unmarshal([]byte(testdata.Delays), &simulation)
 
-			Expect(upgradedSimulation.DataViewV5).To(Equal(simulation.DataViewV5))
+			Expect(withoutPairIds(upgradedSimulation.DataViewV6)).To(Equal(simulation.DataViewV6))
 		})
 
 		It("should upgrade it to the latest simulation", func() {
 			hoverfly.ImportSimulation(testdata.V3ClosestMissProof)
 			upgradedSimulation := hoverfly.ExportSimulation()
 
-			simulation := v2.SimulationViewV5{}
+			simulation := v2.SimulationViewV6{}
 
 			functional_tests.Unmarshal([]byte(testdata.ClosestMissProof), &simulation)
 
-			Expect(upgradedSimulation.DataViewV5).To(Equal(simulation.DataViewV5))
+			Expect(withoutPairIds(upgradedSimulation.DataViewV6)).To(Equal(simulation.DataViewV6))
 		})
 
 		It("should upgrade it to the latest simulation", func() {
 			hoverfly.ImportSimulation(testdata.V3ExactMatch)
 			upgradedSimulation := hoverfly.ExportSimulation()
 
-			simulation := v2.SimulationViewV5{}
+			simulation := v2.SimulationViewV6{}
 
 			functional_tests.Unmarshal([]byte(testdata.ExactMatch), &simulation)
 
-			Expect(upgradedSimulation.DataViewV5).To(Equal(simulation.DataViewV5))
+			Expect(withoutPairIds(upgradedSimulation.DataViewV6)).To(Equal(simulation.DataViewV6))
 		})
 
 		It("should upgrade it to the latest simulation", func() {
 			hoverfly.ImportSimulation(testdata.V3GlobMatch)
 			upgradedSimulation := hoverfly.ExportSimulation()
 
-			simulation := v2.SimulationViewV5{}
+			simulation := v2.SimulationViewV6{}
 
 			functional_tests.Unmarshal([]byte(testdata.GlobMatch), &simulation)
 
-			Expect(upgradedSimulation.DataViewV5).To(Equal(simulation.DataViewV5))
+			Expect(withoutPairIds(upgradedSimulation.DataViewV6)).To(Equal(simulation.DataViewV6))
 		})
 
 		It("should upgrade it to the latest simulation", func() {
 			hoverfly.ImportSimulation(testdata.V3XmlMatch)
 			upgradedSimulation := hoverfly.ExportSimulation()
 
-			simulation := v2.SimulationViewV5{}
+			simulation := v2.SimulationViewV6{}
 
 			functional_tests.Unmarshal([]byte(testdata.XmlMatch), &simulation)
 
-			Expect(upgradedSimulation.DataViewV5).To(Equal(simulation.DataViewV5))
+			Expect(withoutPairIds(upgradedSimulation.DataViewV6)).To(Equal(simulation.DataViewV6))
 		})
 
 		It("should upgrade it to the latest simulation", func() {
 			hoverfly.ImportSimulation(testdata.V3XpathMatch)
 			upgradedSimulation := hoverfly.ExportSimulation()
 
-			simulation := v2.SimulationViewV5{}
+			simulation := v2.SimulationViewV6{}
 
 			functional_tests.Unmarshal([]byte(testdata.XpathMatch), &simulation)
 
-			Expect(upgradedSimulation.DataViewV5).To(Equal(simulation.DataViewV5))
+			Expect(withoutPairIds(upgradedSimulation.DataViewV6)).To(Equal(simulation.DataViewV6))
 		})
 	})
 
@@ -123,22 +123,31 @@ var _ = Describe("Running Hoverfly with older simulations", func() {
 			hoverfly.ImportSimulation(testdata.V4QueryMatchers)
 			upgradedSimulation := hoverfly.ExportSimulation()
 
-			simulation := v2.SimulationViewV5{}
+			simulation := v2.SimulationViewV6{}
 
 			functional_tests.Unmarshal([]byte(testdata.QueryMatchers), &simulation)
 
-			Expect(upgradedSimulation.DataViewV5).To(Equal(simulation.DataViewV5))
+			Expect(withoutPairIds(upgradedSimulation.DataViewV6)).To(Equal(simulation.DataViewV6))
 		})
 
 		It("should upgrade it to the latest simulation", func() {
 			hoverfly.ImportSimulation(testdata.V4HeaderMatchers)
 			upgradedSimulation := hoverfly.ExportSimulation()
 
-			simulation := v2.SimulationViewV5{}
+			simulation := v2.SimulationViewV6{}
 
 			functional_tests.Unmarshal([]byte(testdata.HeaderMatchers), &simulation)
 
-			Expect(upgradedSimulation.DataViewV5).To(Equal(simulation.DataViewV5))
+			Expect(withoutPairIds(upgradedSimulation.DataViewV6)).To(Equal(simulation.DataViewV6))
 		})
 	})
 })
+
+// withoutPairIds clears the ids generated for pairs imported without one
+func withoutPairIds(data v2.DataViewV6) v2.DataViewV6 {
+	for i := range data.RequestResponsePairs {
+		data.RequestResponsePairs[i].Id = ""
+	}
+
+	return data
+}
@@ -177,12 +177,12 @@ func (this Hoverfly) WriteLogsIfError() {
 	ginkgo.GinkgoWriter.Write(logs) // Only writes when test fails
 }
 
-func (this Hoverfly) ExportSimulation() v2.SimulationViewV5 {
+func (this Hoverfly) ExportSimulation() v2.SimulationViewV6 {
 	reader := this.GetSimulation()
 	simulationBytes, err := ioutil.ReadAll(reader)
 	Expect(err).To(BeNil())
 
-	var simulation v2.SimulationViewV5
+	var simulation v2.SimulationViewV6
 
 	err = json.Unmarshal(simulationBytes, &simulation)
 	Expect(err).To(BeNil())
@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 
 	"bytes"
 
@@ -21,6 +23,9 @@ as lists of strings grouped by the same requests.
 	`,
 }
 
+var diffFormat string
+var failOnDiff bool
+
 const errorMsgTemplate = "\"%s\"\nthe expected value was [%s], but actual value was [%s]\n\n"
 
 var getAllDiffCmd = &cobra.Command{
@@ -28,6 +33,13 @@ var getAllDiffCmd = &cobra.Command{
 	Short: "Gets all diffs stored in Hoverfly",
 	Long: `
 Returns all differences between expected and actual responses from Hoverfly.
+
+With --format junit or markdown a report is written with
+a test case for each endpoint of the simulation, which
+fails when differences were found in the requests to it.
+With --fail-on-diff hoverctl exits with a non-zero status
+when any differences were found, so CI pipelines can
+fail on them.
 	`,
 	Run: func(cmd *cobra.Command, args []string) {
 
@@ -36,40 +48,65 @@ Returns all differences between expected and actual responses from Hoverfly.
 		if len(args) == 0 {
 			diffs, err := wrapper.GetAllDiffs(*target)
 			handleIfError(err)
-			var output bytes.Buffer
 
-			for _, diffsWithRequest := range diffs {
-
-				diffString := "diff"
-				if len(diffsWithRequest.DiffReport) > 1 {
-					diffString = "diffs"
-				}
-				output.WriteString(
-					fmt.Sprintf("For request:\n"+
-						"\n Method: %s \n Host: %s \n Path: %s \n Query:  %s \n\n%s %s recorded:\n",
-						diffsWithRequest.Request.Method,
-						diffsWithRequest.Request.Host,
-						diffsWithRequest.Request.Path,
-						diffsWithRequest.Request.Query,
-						fmt.Sprint(len(diffsWithRequest.DiffReport)),
-						diffString,
-					))
-
-				for index, diff := range diffsWithRequest.DiffReport {
-					output.WriteString(fmt.Sprintf("\n%s. %s\n%s\n",
-						fmt.Sprint(index+1), diff.Timestamp, diffReportMessage(diff)))
+			switch diffFormat {
+			case "text":
+				printDiffs(diffs)
+			case "json":
+				if diffs == nil {
+					diffs = []v2.ResponseDiffForRequestView{}
 				}
+				diffsJson, err := json.MarshalIndent(v2.DiffView{Diff: diffs}, "", "\t")
+				handleIfError(err)
+				fmt.Println(string(diffsJson))
+			case "junit", "markdown":
+				report, err := wrapper.GetDiffReport(*target, diffFormat)
+				handleIfError(err)
+				fmt.Print(string(report))
+			default:
+				handleIfError(fmt.Errorf("Unknown format %s, expected text, json, junit or markdown", diffFormat))
 			}
 
-			if len(output.Bytes()) == 0 {
-				fmt.Println("There are no diffs stored in Hoverfly")
-			} else {
-				fmt.Println(output.String())
+			if failOnDiff && len(diffs) > 0 {
+				os.Exit(1)
 			}
 		}
 	},
 }
 
+func printDiffs(diffs []v2.ResponseDiffForRequestView) {
+	var output bytes.Buffer
+
+	for _, diffsWithRequest := range diffs {
+
+		diffString := "diff"
+		if len(diffsWithRequest.DiffReport) > 1 {
+			diffString = "diffs"
+		}
+		output.WriteString(
+			fmt.Sprintf("For request:\n"+
+				"\n Method: %s \n Host: %s \n Path: %s \n Query:  %s \n\n%s %s recorded:\n",
+				diffsWithRequest.Request.Method,
+				diffsWithRequest.Request.Host,
+				diffsWithRequest.Request.Path,
+				diffsWithRequest.Request.Query,
+				fmt.Sprint(len(diffsWithRequest.DiffReport)),
+				diffString,
+			))
+
+		for index, diff := range diffsWithRequest.DiffReport {
+			output.WriteString(fmt.Sprintf("\n%s. %s\n%s\n",
+				fmt.Sprint(index+1), diff.Timestamp, diffReportMessage(diff)))
+		}
+	}
+
+	if len(output.Bytes()) == 0 {
+		fmt.Println("There are no diffs stored in Hoverfly")
+	} else {
+		fmt.Println(output.String())
+	}
+}
+
 var deleteDiffsCmd = &cobra.Command{
 	Use:   "delete",
 	Short: "Deletes all diffs",
@@ -98,4 +135,7 @@ func init() {
 	RootCmd.AddCommand(diffCmd)
 	diffCmd.AddCommand(getAllDiffCmd)
 	diffCmd.AddCommand(deleteDiffsCmd)
+
+	getAllDiffCmd.Flags().StringVar(&diffFormat, "format", "text", "The format to write the diffs in - 'text | json | junit | markdown'")
+	getAllDiffCmd.Flags().BoolVar(&failOnDiff, "fail-on-diff", false, "Exit with a non-zero status when any diffs are stored in Hoverfly")
 }
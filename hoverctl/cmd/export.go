@@ -9,12 +9,33 @@ import (
 )
 
 var urlPattern string
+var exportFormat string
+var exportJournal bool
+var exportPackage string
 var exportCmd = &cobra.Command{
 	Use:   "export [path to simulation]",
 	Short: "Export a simulation from Hoverfly",
 	Long: `
 Exports a simulation from Hoverfly. The simulation JSON
 will be written to the file path provided.
+
+With --format har the simulation is written as a HAR file,
+and with --journal the journal is written as a HAR file
+instead of the simulation.
+
+With --format wiremock the pairs are written as WireMock
+stub mappings. WireMock does not match on the destination
+or scheme, so these matchers are left out.
+
+With --format curl a shell script is written with a curl
+command for each pair which only uses exact matchers,
+sending its request through the proxy of the target.
+
+With --format go-httptest a Go file is written with an
+httptest.Server which responds as the pairs do, leaving
+out pairs which are templated, require a state or use
+matchers other than exact. The package of the file can
+be set with --package.
 	`,
 
 	Run: func(cmd *cobra.Command, args []string) {
@@ -22,13 +43,37 @@ will be written to the file path provided.
 
 		checkArgAndExit(args, "You have not provided a path to simulation", "export")
 
-		simulationData, err := wrapper.ExportSimulation(*target, urlPattern)
+		var simulationData []byte
+		var err error
+
+		switch {
+		case exportJournal && exportFormat != "har":
+			err = fmt.Errorf("The journal can only be exported with --format har")
+		case exportJournal:
+			simulationData, err = wrapper.ExportJournalAsHar(*target)
+		case exportFormat == "har":
+			simulationData, err = wrapper.ExportSimulationAsHar(*target, urlPattern)
+		case exportFormat == "wiremock":
+			simulationData, err = wrapper.ExportSimulationAsWireMock(*target, urlPattern)
+		case exportFormat == "curl":
+			simulationData, err = wrapper.ExportSimulationAsCurl(*target, urlPattern)
+		case exportFormat == "go-httptest":
+			simulationData, err = wrapper.ExportSimulationAsGoHttpTest(*target, urlPattern, exportPackage)
+		case exportFormat != "json":
+			err = fmt.Errorf("Unknown format %s, expected json, har, wiremock, curl or go-httptest", exportFormat)
+		default:
+			simulationData, err = wrapper.ExportSimulation(*target, urlPattern)
+		}
 		handleIfError(err)
 
 		err = configuration.WriteFile(args[0], simulationData)
 		handleIfError(err)
 
-		fmt.Println("Successfully exported simulation to", args[0])
+		if exportJournal {
+			fmt.Println("Successfully exported journal to", args[0])
+		} else {
+			fmt.Println("Successfully exported simulation to", args[0])
+		}
 	},
 }
 
@@ -36,4 +81,7 @@ func init() {
 	RootCmd.AddCommand(exportCmd)
 
 	exportCmd.Flags().StringVar(&urlPattern, "url-pattern", "", "Export simulation for the urls that matches a pattern, eg. foo.com/api/v(.+)")
+	exportCmd.Flags().StringVar(&exportFormat, "format", "json", "The format to export in - 'json | har | wiremock | curl | go-httptest'")
+	exportCmd.Flags().StringVar(&exportPackage, "package", "", "The package of the Go file written with --format go-httptest, defaults to simulation")
+	exportCmd.Flags().BoolVar(&exportJournal, "journal", false, "Export the journal instead of the simulation, requires --format har")
 }
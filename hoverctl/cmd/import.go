@@ -1,14 +1,29 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/util"
 	"github.com/SpectoLabs/hoverfly/hoverctl/configuration"
 	"github.com/SpectoLabs/hoverfly/hoverctl/wrapper"
 	"github.com/spf13/cobra"
 )
 
 var importV1 bool
+var importAppend bool
+var importFormat string
+var importHeaders string
+var importAllHeaders bool
+var importStateful bool
+var importOpenApi bool
+var importWatch bool
 
 // importCmd represents the import command
 var importCmd = &cobra.Command{
@@ -16,24 +31,169 @@ var importCmd = &cobra.Command{
 	Short: "Import a simulation into Hoverfly",
 	Long: `
 Imports a simulation into Hoverfly. An absolute or
-relative path to a Hoverfly simulation JSON file
-must be provided.
+relative path to a Hoverfly simulation JSON or YAML
+file must be provided. Given a directory, the JSON and
+YAML simulations within it are merged in order of their
+file names and imported as one simulation.
+
+By default the simulation replaces the one in Hoverfly.
+With --append its pairs are added to the existing
+simulation, skipping any with an identical request.
+
+With --format har a HAR file is imported instead. Its
+entries are turned into pairs in the same way as capture
+mode, so --headers, --all-headers and --stateful can be
+used to choose how strictly requests are matched.
+
+With --openapi an OpenAPI 3 or Swagger 2 specification,
+in JSON or YAML, is imported. Each operation becomes a
+pair, and any operation which could not be modelled is
+listed as a warning.
+
+With --format wiremock WireMock stub mappings are imported.
+The path can be a file of mappings or a directory, such as
+the mappings directory of WireMock. Scenarios become state,
+and any part of a mapping which is not supported is listed
+as a warning.
+
+With --watch hoverctl keeps running after the import and
+reimports the file or directory each time it changes. A
+change which cannot be imported is reported, and Hoverfly
+keeps the simulation last imported. Each reimport replaces
+the whole simulation, so --watch cannot be used with
+--append.
 	`,
 
 	Run: func(cmd *cobra.Command, args []string) {
 		checkTargetAndExit(target)
 
 		checkArgAndExit(args, "You have not provided a path to simulation", "import")
-		simulationData, err := configuration.ReadFile(args[0])
-		handleIfError(err)
 
-		err = wrapper.ImportSimulation(*target, string(simulationData))
+		if importWatch && importAppend {
+			handleIfError(errors.New("Cannot use --watch with --append, each change would be added to the simulation again"))
+		}
+
+		err := importFrom(args[0])
 		handleIfError(err)
 
 		fmt.Println("Successfully imported simulation from", args[0])
+
+		if importWatch {
+			watchImport(args[0])
+		}
 	},
 }
 
+func importFrom(path string) error {
+	format := importFormat
+	if importOpenApi {
+		format = "openapi"
+	}
+
+	// A directory of JSON and YAML simulations is merged into a single JSON simulation
+	if (format == "json" || format == "yaml") && configuration.IsDirectory(path) {
+		simulationData, err := configuration.ReadSimulationDirectory(path)
+		if err != nil {
+			return err
+		}
+
+		if importAppend {
+			return wrapper.AppendSimulation(*target, string(simulationData))
+		}
+		return wrapper.ImportSimulation(*target, string(simulationData))
+	}
+
+	var simulationData []byte
+	var err error
+	if format == "wiremock" {
+		simulationData, err = configuration.ReadWireMockMappings(path)
+	} else {
+		simulationData, err = configuration.ReadFile(path)
+	}
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case format == "openapi":
+		if importAppend {
+			err = wrapper.AppendOpenApi(*target, string(simulationData))
+		} else {
+			err = wrapper.ImportOpenApi(*target, string(simulationData))
+		}
+	case format == "wiremock":
+		if importAppend {
+			err = wrapper.AppendWireMock(*target, string(simulationData))
+		} else {
+			err = wrapper.ImportWireMock(*target, string(simulationData))
+		}
+	case format == "har":
+		arguments := v2.ModeArgumentsView{Stateful: importStateful}
+		if importAllHeaders {
+			arguments.Headers = []string{"*"}
+		} else if len(importHeaders) > 0 {
+			arguments.Headers = strings.Split(importHeaders, ",")
+		}
+
+		if importAppend {
+			err = wrapper.AppendHar(*target, string(simulationData), arguments)
+		} else {
+			err = wrapper.ImportHar(*target, string(simulationData), arguments)
+		}
+	case format == "yaml" || (format == "json" && v2.IsYamlFile(path)):
+		if importAppend {
+			err = wrapper.AppendYamlSimulation(*target, string(simulationData))
+		} else {
+			err = wrapper.ImportYamlSimulation(*target, string(simulationData))
+		}
+	case format != "json":
+		err = fmt.Errorf("Unknown format %s, expected json, yaml, har, openapi or wiremock", format)
+	case importAppend:
+		err = wrapper.AppendSimulation(*target, string(simulationData))
+	default:
+		err = wrapper.ImportSimulation(*target, string(simulationData))
+	}
+
+	return err
+}
+
+// watchImport reimports the simulation each time it changes until hoverctl is interrupted. An
+// invalid change is reported without stopping, leaving Hoverfly with the last valid simulation.
+// Reimports are PUT, which Hoverfly swaps in whole, so requests made while a change is being
+// imported are matched against either the old simulation or the new one.
+func watchImport(path string) {
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		handleIfError(errors.New("Cannot watch " + path + ", only files and directories can be watched"))
+	}
+
+	watcher, err := util.NewFileWatcher([]string{path}, 200*time.Millisecond, func() {
+		err := importFrom(path)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Could not reimport simulation from", path+"\n\n"+err.Error())
+			return
+		}
+
+		fmt.Println("Successfully reimported simulation from", path)
+	})
+	handleIfError(err)
+	defer watcher.Close()
+
+	fmt.Println("Watching", path, "for changes, press Ctrl+C to stop")
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	<-interrupt
+}
+
 func init() {
 	RootCmd.AddCommand(importCmd)
+
+	importCmd.Flags().BoolVar(&importAppend, "append", false, "Add the simulation to the one already in Hoverfly instead of replacing it")
+	importCmd.Flags().StringVar(&importFormat, "format", "json", "The format of the file being imported - 'json | yaml | har | openapi | wiremock'")
+	importCmd.Flags().BoolVar(&importWatch, "watch", false, "Keep running, reimporting the simulation whenever the file or directory changes")
+	importCmd.Flags().BoolVar(&importOpenApi, "openapi", false, "Import an OpenAPI or Swagger specification, the same as --format openapi")
+	importCmd.Flags().StringVar(&importHeaders, "headers", "",
+		"A comma separated list of headers to match on when importing a HAR file `Content-Type,Authorization`")
+	importCmd.Flags().BoolVar(&importAllHeaders, "all-headers", false, "Match on all headers when importing a HAR file")
+	importCmd.Flags().BoolVar(&importStateful, "stateful", false, "Import repeated requests in a HAR file as a sequence")
 }
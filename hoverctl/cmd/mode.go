@@ -2,21 +2,63 @@ package cmd
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/core/modes"
 	"github.com/SpectoLabs/hoverfly/hoverctl/wrapper"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var specficHeaders string
 var allHeaders bool
 var stateful bool
 var matchingStrategy string
+var redactions stringArrayFlag
+var anyValueRedactions stringArrayFlag
+var ignoreFields []string
+var ignoreQuery []string
+var globPathSegments stringArrayFlag
+var bodyJsonPaths stringArrayFlag
+var capturePolicy string
+var captureStatuses []string
+var captureContentTypes []string
+var captureMethods []string
+var includeDestination string
+var excludeDestination string
+var includePath string
+var excludePath string
+var recordMatchers string
+var diffIgnoreHeaders []string
+var diffIgnoreJsonPaths stringArrayFlag
+var diffIgnoreXPaths stringArrayFlag
+var diffIgnoreRegexes stringArrayFlag
+var diffTolerance float64
+var diffRelativeTolerance float64
+var routeDestination string
+var routePath string
+
+// stringArrayFlag collects the values of a flag which can be given more than once. The values
+// are not split on commas, as regexes and JSONPaths may contain them.
+type stringArrayFlag []string
+
+func (this *stringArrayFlag) String() string {
+	return strings.Join(*this, " ")
+}
+
+func (this *stringArrayFlag) Set(value string) error {
+	*this = append(*this, value)
+	return nil
+}
+
+func (this *stringArrayFlag) Type() string {
+	return "value"
+}
 
 var modeCmd = &cobra.Command{
-	Use:   "mode [capture|simulate|spy|modify|synthesize (optional)]",
+	Use:   "mode [capture|simulate|spy|hybrid|shadow|modify|synthesize (optional)]",
 	Short: "Get and set the Hoverfly mode",
 	Long: `
 Sets Hoverfly to the mode specified. The mode
@@ -37,32 +79,16 @@ mode is shown.
 
 			if mode.Mode == modes.Simulate {
 				extraInformation = fmt.Sprintf("with a matching strategy of '%s'", *mode.Arguments.MatchingStrategy)
+			} else if mode.Skipped != nil {
+				extraInformation = fmt.Sprintf("and has filtered out %v requests", mode.Skipped.Total)
+			} else if mode.Shadowed != nil {
+				extraInformation = fmt.Sprintf("and has found differences in %v of %v shadowed requests", mode.Shadowed.Diverged, mode.Shadowed.Compared)
 			}
 
 			fmt.Println("Hoverfly is currently set to", mode.Mode, "mode", extraInformation)
 
 		} else {
-			modeView := v2.ModeView{
-				Mode: args[0],
-			}
-
-			var extraInformation string
-
-			//TODO: For @benji, convert this whole thing to a switch case for each mode, only allowing the correct functionality for each one
-			if modeView.Mode == modes.Simulate && len(matchingStrategy) > 0 {
-				extraInformation = fmt.Sprintf("with a matching strategy of '%s'", matchingStrategy)
-				modeView.Arguments.MatchingStrategy = &matchingStrategy
-			} else if allHeaders {
-				modeView.Arguments.Headers = append(modeView.Arguments.Headers, "*")
-				extraInformation = "and will capture all request headers"
-			} else if len(specficHeaders) > 0 {
-				splitHeaders := strings.Split(specficHeaders, ",")
-				modeView.Arguments.Headers = append(modeView.Arguments.Headers, splitHeaders...)
-
-				extraInformation = fmt.Sprintln("and will capture the following request headers:", splitHeaders)
-			}
-
-			modeView.Arguments.Stateful = stateful
+			modeView, extraInformation := modeViewFromFlags(args[0])
 
 			mode, err := wrapper.SetModeWithArguments(*target, modeView)
 			handleIfError(err)
@@ -72,9 +98,207 @@ mode is shown.
 	},
 }
 
+var modeRouteCmd = &cobra.Command{
+	Use:   "route",
+	Short: "Manage the mode routes of Hoverfly",
+	Long: `
+Routes process the requests to a destination
+and path in a mode of their own. Requests which
+do not match any route are processed in the
+mode of Hoverfly.
+`,
+}
+
+var addModeRouteCmd = &cobra.Command{
+	Use:   "add [capture|simulate|spy|hybrid|shadow|modify|synthesize]",
+	Short: "Add a mode route to Hoverfly",
+	Long: `
+Adds a route which processes the requests whose
+destination and path match the given regexes in
+the mode specified. The mode flags set the
+arguments of the route's mode. Routes are tried
+in the order they were added.
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkTargetAndExit(target)
+
+		if len(args) == 0 {
+			handleIfError(fmt.Errorf("You have not specified a mode"))
+		}
+
+		modeView, extraInformation := modeViewFromFlags(args[0])
+
+		_, err := wrapper.AddModeRoute(*target, v2.ModeRouteView{
+			Destination: routeDestination,
+			Path:        routePath,
+			ModeView:    modeView,
+		})
+		handleIfError(err)
+
+		fmt.Println("Requests to", describeModeRoute(routeDestination, routePath), "will be processed in", modeView.Mode, "mode", extraInformation)
+	},
+}
+
+var listModeRoutesCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the mode routes of Hoverfly",
+	Run: func(cmd *cobra.Command, args []string) {
+		checkTargetAndExit(target)
+
+		routesView, err := wrapper.GetModeRoutes(*target)
+		handleIfError(err)
+
+		if len(routesView.Routes) == 0 {
+			fmt.Println("Hoverfly has no mode routes")
+			return
+		}
+
+		data := [][]string{{"DESTINATION", "PATH", "MODE"}}
+		for _, route := range routesView.Routes {
+			data = append(data, []string{route.Destination, route.Path, route.Mode})
+		}
+
+		drawTable(data, true)
+	},
+}
+
+var deleteModeRoutesCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete every mode route",
+	Run: func(cmd *cobra.Command, args []string) {
+		checkTargetAndExit(target)
+
+		err := wrapper.DeleteModeRoutes(*target)
+		handleIfError(err)
+
+		fmt.Println("All mode routes have been deleted")
+	},
+}
+
+func describeModeRoute(destination, path string) string {
+	if destination == "" && path == "" {
+		return "any destination"
+	}
+	if path == "" {
+		return fmt.Sprintf("destinations matching '%s'", destination)
+	}
+	if destination == "" {
+		return fmt.Sprintf("paths matching '%s'", path)
+	}
+
+	return fmt.Sprintf("destinations matching '%s' with paths matching '%s'", destination, path)
+}
+
+// modeViewFromFlags builds the arguments of a mode from the flags of the mode command, returning
+// a description of them along with the mode view
+func modeViewFromFlags(modeName string) (v2.ModeView, string) {
+	modeView := v2.ModeView{
+		Mode: modeName,
+	}
+
+	var extraInformation string
+
+			//TODO: For @benji, convert this whole thing to a switch case for each mode, only allowing the correct functionality for each one
+	if modeView.Mode == modes.Simulate && len(matchingStrategy) > 0 {
+		extraInformation = fmt.Sprintf("with a matching strategy of '%s'", matchingStrategy)
+		modeView.Arguments.MatchingStrategy = &matchingStrategy
+	} else if allHeaders {
+		modeView.Arguments.Headers = append(modeView.Arguments.Headers, "*")
+		extraInformation = "and will capture all request headers"
+	} else if len(specficHeaders) > 0 {
+		splitHeaders := strings.Split(specficHeaders, ",")
+		modeView.Arguments.Headers = append(modeView.Arguments.Headers, splitHeaders...)
+
+		extraInformation = fmt.Sprintln("and will capture the following request headers:", splitHeaders)
+	}
+
+	modeView.Arguments.Stateful = stateful
+	modeView.Arguments.CapturePolicy = capturePolicy
+	modeView.Arguments.RecordMatchers = recordMatchers
+
+	captureFilter := v2.CaptureFilterView{
+		Statuses:           captureStatuses,
+		ContentTypes:       captureContentTypes,
+		Methods:            captureMethods,
+		IncludeDestination: includeDestination,
+		ExcludeDestination: excludeDestination,
+		IncludePath:        includePath,
+		ExcludePath:        excludePath,
+	}
+	if !reflect.DeepEqual(captureFilter, v2.CaptureFilterView{}) {
+		modeView.Arguments.CaptureFilter = &captureFilter
+	}
+
+	for _, rule := range redactions {
+		redaction, err := v2.NewRedactionRuleViewFromString(rule, false)
+		handleIfError(err)
+		modeView.Arguments.Redactions = append(modeView.Arguments.Redactions, redaction)
+	}
+	for _, rule := range anyValueRedactions {
+		redaction, err := v2.NewRedactionRuleViewFromString(rule, true)
+		handleIfError(err)
+		modeView.Arguments.Redactions = append(modeView.Arguments.Redactions, redaction)
+	}
+
+	if len(ignoreFields) > 0 || len(ignoreQuery) > 0 || len(globPathSegments) > 0 || len(bodyJsonPaths) > 0 {
+		modeView.Arguments.MatcherPolicy = &v2.MatcherPolicyView{
+			IgnoreFields:     ignoreFields,
+			IgnoreQuery:      ignoreQuery,
+			GlobPathSegments: globPathSegments,
+			BodyJsonPaths:    bodyJsonPaths,
+		}
+	}
+
+	modeView.Arguments.DiffRules = diffRulesFromFlags()
+
+	return modeView, extraInformation
+}
+
+// diffRulesFromFlags returns the diff rules given with the diff flags, or nil when none were given
+func diffRulesFromFlags() *v2.DiffRulesView {
+	diffRules := v2.DiffRulesView{
+		IgnoreHeaders:     diffIgnoreHeaders,
+		IgnoreJsonPaths:   diffIgnoreJsonPaths,
+		IgnoreXPaths:      diffIgnoreXPaths,
+		IgnoreRegexes:     diffIgnoreRegexes,
+		Tolerance:         diffTolerance,
+		RelativeTolerance: diffRelativeTolerance,
+	}
+	if reflect.DeepEqual(diffRules, v2.DiffRulesView{}) {
+		return nil
+	}
+
+	return &diffRules
+}
+
+// addDiffRulesFlags adds the diff flags to a command, describing them as applying to what it does
+func addDiffRulesFlags(flags *pflag.FlagSet, applies string) {
+	flags.StringSliceVar(&diffIgnoreHeaders, "diff-ignore-headers", nil,
+		"A comma separated list of headers not to compare "+applies+" `Date,X-Request-Id`")
+	flags.Var(&diffIgnoreJsonPaths, "diff-ignore-jsonpath",
+		"Do not compare the values at a JSONPath of JSON bodies "+applies+", can be given more than once `$..timestamp`")
+	flags.Var(&diffIgnoreXPaths, "diff-ignore-xpath",
+		"Do not compare the values at an XPath of XML bodies "+applies+", can be given more than once `//order/@id`")
+	flags.Var(&diffIgnoreRegexes, "diff-ignore-regex",
+		"Do not compare the parts of bodies and header values matching a regex "+applies+", can be given more than once")
+	flags.Float64Var(&diffTolerance, "diff-tolerance", 0,
+		"Treat numbers which differ by no more than this as the same "+applies)
+	flags.Float64Var(&diffRelativeTolerance, "diff-relative-tolerance", 0,
+		"Treat numbers which differ by no more than this fraction of the expected number as the same "+applies)
+}
+
 func init() {
 
 	RootCmd.AddCommand(modeCmd)
+	modeCmd.AddCommand(modeRouteCmd)
+	modeRouteCmd.AddCommand(addModeRouteCmd)
+	modeRouteCmd.AddCommand(listModeRoutesCmd)
+	modeRouteCmd.AddCommand(deleteModeRoutesCmd)
+
+	addModeRouteCmd.Flags().StringVar(&routeDestination, "destination", "",
+		"Only route requests to destinations matching a regex `payments\\.example\\.com`")
+	addModeRouteCmd.Flags().StringVar(&routePath, "path", "",
+		"Only route requests to paths matching a regex `^/v1/charges`")
 	modeCmd.PersistentFlags().StringVar(&specficHeaders, "headers", "",
 		"A comma separated list of headers to record in capture mode `Content-Type,Authorization`")
 	modeCmd.PersistentFlags().BoolVar(&allHeaders, "all-headers", false,
@@ -83,4 +307,35 @@ func init() {
 		"Sets the matching strategy - 'strongest | first'")
 	modeCmd.PersistentFlags().BoolVar(&stateful, "stateful", false,
 		"Record stateful responses as a sequence in capture mode")
+	modeCmd.PersistentFlags().StringVar(&capturePolicy, "policy", "",
+		"Sets what is recorded when a request is captured more than once - 'appendAll | firstWins | lastWins | sequenceOnlyIfDifferent'")
+	modeCmd.PersistentFlags().StringVar(&recordMatchers, "record-matchers", "",
+		"Sets the request matchers of the pairs recorded in hybrid mode - 'exact | relaxed'")
+	modeCmd.PersistentFlags().StringSliceVar(&captureStatuses, "capture-statuses", nil,
+		"A comma separated list of response statuses to record in capture mode `2xx,304,400-404`")
+	modeCmd.PersistentFlags().StringSliceVar(&captureContentTypes, "capture-content-types", nil,
+		"A comma separated list of response content types to record in capture mode `application/json,text/*`")
+	modeCmd.PersistentFlags().StringSliceVar(&captureMethods, "capture-methods", nil,
+		"A comma separated list of request methods to record in capture mode `GET,POST`")
+	modeCmd.PersistentFlags().StringVar(&includeDestination, "include-destination", "",
+		"Only record requests to destinations matching a regex in capture mode")
+	modeCmd.PersistentFlags().StringVar(&excludeDestination, "exclude-destination", "",
+		"Do not record requests to destinations matching a regex in capture mode")
+	modeCmd.PersistentFlags().StringVar(&includePath, "include-path", "",
+		"Only record requests to paths matching a regex in capture mode")
+	modeCmd.PersistentFlags().StringVar(&excludePath, "exclude-path", "",
+		"Do not record requests to paths matching a regex in capture mode")
+	modeCmd.PersistentFlags().Var(&redactions, "redact",
+		"Redact values before they are saved in capture mode, can be given more than once `header:Authorization | query:token | jsonpath:$.password | xpath://password | regex:[0-9]{16}`")
+	modeCmd.PersistentFlags().Var(&anyValueRedactions, "redact-any-value",
+		"Redact values as --redact does, matching any value of them in request matchers")
+	modeCmd.PersistentFlags().StringSliceVar(&ignoreFields, "ignore-fields", nil,
+		"A comma separated list of request fields not to match on in capture mode `scheme,destination`")
+	modeCmd.PersistentFlags().StringSliceVar(&ignoreQuery, "ignore-query", nil,
+		"A comma separated list of query parameters not to match on in capture mode `timestamp,nonce`")
+	modeCmd.PersistentFlags().Var(&globPathSegments, "glob-path-segments",
+		"Match path segments which are a uuid, a number or match a regex with a glob in capture mode, can be given more than once `uuid | number | regex`")
+	modeCmd.PersistentFlags().Var(&bodyJsonPaths, "body-jsonpath",
+		"Only match on the values at a JSONPath of JSON bodies in capture mode, can be given more than once `$.user.id`")
+	addDiffRulesFlags(modeCmd.PersistentFlags(), "in diff and shadow mode")
 }
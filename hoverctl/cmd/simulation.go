@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v1"
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/hoverctl/configuration"
+	"github.com/SpectoLabs/hoverfly/hoverctl/wrapper"
+	"github.com/spf13/cobra"
+)
+
+var simulationCmd = &cobra.Command{
+	Use:   "simulation",
+	Short: "Manage the simulations in Hoverfly",
+	Long: `
+Hoverfly can hold several named simulations, such as
+one for the happy path and one for an outage. This
+allows you to list them and to choose which of them
+are used, as well as to find problems with a simulation
+and to see which of its pairs are being used.
+	`,
+}
+
+var listSimulationsCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the named simulations",
+	Long: `
+Returns the names of the simulations stored in Hoverfly,
+marking those which are currently active.
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkTargetAndExit(target)
+
+		simulations, err := wrapper.GetSimulations(*target)
+		handleIfError(err)
+
+		if len(simulations.Simulations) == 0 {
+			fmt.Println("There are no named simulations stored in Hoverfly")
+			return
+		}
+
+		active := map[string]bool{}
+		for _, name := range simulations.Active {
+			active[name] = true
+		}
+
+		for _, name := range simulations.Simulations {
+			if active[name] {
+				fmt.Println(name, "(active)")
+			} else {
+				fmt.Println(name)
+			}
+		}
+	},
+}
+
+var useSimulationCmd = &cobra.Command{
+	Use:   "use [name] [name (optional)]...",
+	Short: "Sets the active simulations",
+	Long: `
+Replaces the simulation in Hoverfly with one or more
+of its named simulations.
+
+When more than one name is given the simulations are
+layered in priority order. Where pairs from two
+simulations have the same request matcher, the pair
+from the earlier simulation is used.
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkTargetAndExit(target)
+
+		if len(args) == 0 {
+			fmt.Fprintln(os.Stderr, "You must provide the name of at least one simulation")
+			fmt.Fprintln(os.Stderr, "\nTry hoverctl simulation use --help for more information")
+			os.Exit(1)
+		}
+
+		err := wrapper.UseSimulations(*target, args)
+		handleIfError(err)
+
+		fmt.Println("Hoverfly is now using", strings.Join(args, ", "))
+	},
+}
+
+var lintSimulationCmd = &cobra.Command{
+	Use:   "lint [path to simulation (optional)]",
+	Short: "Finds problems with a simulation",
+	Long: `
+Looks for pairs which are duplicates of an earlier pair,
+which are never used with the first matching strategy as
+an earlier pair matches every request they do, which have
+regex, jsonpath or xpath values that cannot be parsed, or
+which have templates that cannot be parsed. Pairs which
+require a state that no pair ever sets are also listed.
+
+Given a path to a JSON or YAML simulation, or a directory
+of them, that simulation is linted without being imported.
+Otherwise the simulation Hoverfly is using is linted.
+
+Exits with an error if any problems are found.
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkTargetAndExit(target)
+
+		var simulationData []byte
+		var err error
+		isYaml := false
+		if len(args) > 0 {
+			if configuration.IsDirectory(args[0]) {
+				simulationData, err = configuration.ReadSimulationDirectory(args[0])
+			} else {
+				simulationData, err = configuration.ReadFile(args[0])
+				isYaml = v2.IsYamlFile(args[0])
+			}
+			handleIfError(err)
+		}
+
+		lintView, err := wrapper.LintSimulation(*target, string(simulationData), isYaml)
+		handleIfError(err)
+
+		if len(lintView.Issues) == 0 {
+			fmt.Println("No problems found in the simulation")
+			return
+		}
+
+		for _, issue := range lintView.Issues {
+			fmt.Printf("%s: %s\n", issue.Type, issue.Message)
+		}
+
+		os.Exit(1)
+	},
+}
+
+var coverageExportUnused string
+var coverageReset bool
+var coverageSimulationCmd = &cobra.Command{
+	Use:   "coverage",
+	Short: "Shows how often each pair has been used",
+	Long: `
+Lists the pairs in the simulation with the number of
+times each has been used to respond to a request since
+Hoverfly started or the coverage was last reset.
+
+With --export-unused the pairs which have never been
+used are written to the file path provided, so that a
+simulation can be pruned of pairs it no longer needs.
+
+With --reset the hits are set back to zero.
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkTargetAndExit(target)
+
+		if coverageReset {
+			handleIfError(wrapper.ResetCoverage(*target))
+			fmt.Println("Simulation coverage has been reset")
+			return
+		}
+
+		coverageView, err := wrapper.GetCoverage(*target)
+		handleIfError(err)
+
+		if coverageExportUnused != "" {
+			unusedPairs := []v2.RequestMatcherResponsePairViewV6{}
+			for _, pair := range coverageView.Pairs {
+				if pair.Hits == 0 {
+					unusedPairs = append(unusedPairs, pair.RequestMatcherResponsePairViewV6)
+				}
+			}
+
+			unused := v2.BuildSimulationView(unusedPairs, v1.ResponseDelayPayloadView{Data: []v1.ResponseDelayView{}}, version)
+
+			unusedData, err := json.MarshalIndent(unused, "", "\t")
+			handleIfError(err)
+			handleIfError(configuration.WriteFile(coverageExportUnused, unusedData))
+
+			fmt.Printf("Exported %v unused pairs to %s\n", len(unusedPairs), coverageExportUnused)
+			return
+		}
+
+		used := 0
+		data := [][]string{{"HITS", "ID", "DESCRIPTION"}}
+		for _, pair := range coverageView.Pairs {
+			if pair.Hits > 0 {
+				used++
+			}
+			data = append(data, []string{strconv.Itoa(pair.Hits), pair.Id, pair.Description})
+		}
+
+		drawTable(data, true)
+		fmt.Printf("%v of %v pairs used since %s\n", used, len(coverageView.Pairs), coverageView.Since)
+
+		if len(coverageView.Delays) > 0 {
+			fmt.Println("")
+			data = [][]string{{"HITS", "URL PATTERN", "HTTP METHOD", "DELAY"}}
+			for _, delay := range coverageView.Delays {
+				data = append(data, []string{strconv.Itoa(delay.Hits), delay.UrlPattern, delay.HttpMethod, strconv.Itoa(delay.Delay)})
+			}
+
+			drawTable(data, true)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(simulationCmd)
+	simulationCmd.AddCommand(listSimulationsCmd)
+	simulationCmd.AddCommand(useSimulationCmd)
+	simulationCmd.AddCommand(lintSimulationCmd)
+	simulationCmd.AddCommand(coverageSimulationCmd)
+
+	coverageSimulationCmd.Flags().StringVar(&coverageExportUnused, "export-unused", "", "Write the pairs which have never been used to the file path provided")
+	coverageSimulationCmd.Flags().BoolVar(&coverageReset, "reset", false, "Set the hits of every pair and delay back to zero")
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/hoverctl/wrapper"
+	"github.com/spf13/cobra"
+)
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify the simulation against the real service",
+	Long: `
+Checks the simulation Hoverfly is using still describes
+the real service. For each pair which only uses exact
+matchers, the request it matches is sent to the real
+service and the response is compared with the response
+of the pair, as diff mode would compare them. Pairs with
+other matchers or templated responses are skipped.
+
+The --diff flags leave differences out of the comparison
+as they do in diff mode.
+
+Exits with an error if any pair fails.
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkTargetAndExit(target)
+
+		verificationView, err := wrapper.VerifySimulation(*target, diffRulesFromFlags())
+		handleIfError(err)
+
+		if len(verificationView.Pairs) == 0 {
+			fmt.Println("There are no pairs in the simulation to verify")
+			return
+		}
+
+		data := [][]string{{"PAIR", "REQUEST", "RESULT", "DETAILS"}}
+		for _, pair := range verificationView.Pairs {
+			data = append(data, []string{strconv.Itoa(pair.Pair), verifiedRequest(pair), pair.Result, pair.Message})
+		}
+
+		drawTable(data, true)
+
+		for _, pair := range verificationView.Pairs {
+			if len(pair.DiffEntries) == 0 {
+				continue
+			}
+
+			fmt.Printf("\nPair %d differs from the real service:\n%s", pair.Pair, diffReportMessage(v2.DiffReport{DiffEntries: pair.DiffEntries}))
+		}
+
+		fmt.Printf("\n%d passed, %d failed, %d skipped\n", verificationView.Passed, verificationView.Failed, verificationView.Skipped)
+
+		if verificationView.Failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+func verifiedRequest(pair v2.PairVerificationView) string {
+	if pair.Request == nil {
+		return ""
+	}
+
+	request := fmt.Sprintf("%s %s%s", pair.Request.Method, pair.Request.Host, pair.Request.Path)
+	if pair.Request.Query != "" {
+		request += "?" + pair.Request.Query
+	}
+
+	return request
+}
+
+func init() {
+	RootCmd.AddCommand(verifyCmd)
+
+	addDiffRulesFlags(verifyCmd.Flags(), "when verifying")
+}
@@ -1,16 +1,20 @@
 package configuration
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 
 	"strings"
 
 	"net/http"
 
 	log "github.com/Sirupsen/logrus"
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
 )
 
 func WriteFile(filePath string, data []byte) error {
@@ -55,3 +59,51 @@ func DownloadFile(filePath string) ([]byte, error) {
 
 	return body, nil
 }
+
+// IsDirectory returns whether the path is a local directory
+func IsDirectory(path string) bool {
+	info, err := os.Stat(path)
+
+	return err == nil && info.IsDir()
+}
+
+// ReadWireMockMappings reads a file of WireMock mappings. Given a directory, such as the
+// mappings directory of WireMock, the mappings in each JSON file within it are combined.
+func ReadWireMockMappings(path string) ([]byte, error) {
+	if !IsDirectory(path) {
+		return ReadFile(path)
+	}
+
+	files, err := filepath.Glob(filepath.Join(path, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	combined := v2.WireMockMappingsView{Mappings: []v2.WireMockMappingView{}}
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		mappings, err := v2.NewWireMockMappingsViewFromResponseBody(data)
+		if err != nil {
+			return nil, fmt.Errorf("Could not read %s, %s", file, err.Error())
+		}
+		combined.Mappings = append(combined.Mappings, mappings.Mappings...)
+	}
+
+	return json.Marshal(combined)
+}
+
+// ReadSimulationDirectory reads the JSON and YAML simulations in a directory as a single simulation,
+// as Hoverfly does when importing a directory
+func ReadSimulationDirectory(path string) ([]byte, error) {
+	simulation, err := v2.ReadSimulationDirectory(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(simulation)
+}
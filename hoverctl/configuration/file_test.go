@@ -0,0 +1,112 @@
+package configuration
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	. "github.com/onsi/gomega"
+)
+
+func Test_ReadWireMockMappings_CombinesMappingsInDirectory(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "mappings")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+
+	Expect(ioutil.WriteFile(filepath.Join(dir, "a.json"), []byte(`{"request": {"urlPath": "/a"}, "response": {}}`), 0644)).To(Succeed())
+	Expect(ioutil.WriteFile(filepath.Join(dir, "b.json"), []byte(`{"mappings": [{"request": {"urlPath": "/b"}, "response": {}}, {"request": {"urlPath": "/c"}, "response": {}}]}`), 0644)).To(Succeed())
+	Expect(ioutil.WriteFile(filepath.Join(dir, "notes.txt"), []byte(`not a mapping`), 0644)).To(Succeed())
+
+	data, err := ReadWireMockMappings(dir)
+	Expect(err).To(BeNil())
+
+	var mappings v2.WireMockMappingsView
+	Expect(json.Unmarshal(data, &mappings)).To(Succeed())
+	Expect(mappings.Mappings).To(HaveLen(3))
+	Expect(*mappings.Mappings[0].Request.UrlPath).To(Equal("/a"))
+	Expect(*mappings.Mappings[2].Request.UrlPath).To(Equal("/c"))
+}
+
+func Test_ReadWireMockMappings_ErrorsOnInvalidFileInDirectory(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "mappings")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+
+	Expect(ioutil.WriteFile(filepath.Join(dir, "a.json"), []byte(`{"data": {}}`), 0644)).To(Succeed())
+
+	_, err = ReadWireMockMappings(dir)
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not read " + filepath.Join(dir, "a.json") + ", Invalid WireMock mappings, expected a mapping or a list of mappings"))
+}
+
+func Test_ReadWireMockMappings_ReadsSingleFile(t *testing.T) {
+	RegisterTestingT(t)
+
+	file, err := ioutil.TempFile("", "mapping")
+	Expect(err).To(BeNil())
+	defer os.Remove(file.Name())
+
+	file.WriteString(`{"request": {"urlPath": "/a"}, "response": {}}`)
+	file.Close()
+
+	data, err := ReadWireMockMappings(file.Name())
+	Expect(err).To(BeNil())
+	Expect(string(data)).To(Equal(`{"request": {"urlPath": "/a"}, "response": {}}`))
+}
+
+func Test_ReadSimulationDirectory_MergesJsonAndYamlSimulations(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "simulations")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+
+	Expect(ioutil.WriteFile(filepath.Join(dir, "b.json"), []byte(`{"data": {"pairs": [{"request": {"path": [{"matcher": "exact", "value": "/b"}]}, "response": {"status": 200}}]}, "meta": {"schemaVersion": "v5"}}`), 0644)).To(Succeed())
+	Expect(ioutil.WriteFile(filepath.Join(dir, "a.yaml"), []byte("data:\n  pairs:\n  - request:\n      path:\n      - matcher: exact\n        value: /a\n    response:\n      status: 200\nmeta:\n  schemaVersion: v5\n"), 0644)).To(Succeed())
+	Expect(ioutil.WriteFile(filepath.Join(dir, "notes.txt"), []byte(`not a simulation`), 0644)).To(Succeed())
+
+	data, err := ReadSimulationDirectory(dir)
+	Expect(err).To(BeNil())
+
+	simulation, err := v2.NewSimulationViewFromResponseBody(data)
+	Expect(err).To(BeNil())
+	Expect(simulation.RequestResponsePairs).To(HaveLen(2))
+	Expect(simulation.RequestResponsePairs[0].RequestMatcher.Path[0].Value).To(Equal("/a"))
+	Expect(simulation.RequestResponsePairs[1].RequestMatcher.Path[0].Value).To(Equal("/b"))
+}
+
+func Test_ReadSimulationDirectory_ListsEachInvalidFile(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "simulations")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+
+	Expect(ioutil.WriteFile(filepath.Join(dir, "a.json"), []byte(`{{`), 0644)).To(Succeed())
+	Expect(ioutil.WriteFile(filepath.Join(dir, "b.yml"), []byte("data: [\n"), 0644)).To(Succeed())
+
+	_, err = ReadSimulationDirectory(dir)
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not read simulations in " + dir + "\n\n" +
+		filepath.Join(dir, "a.json") + ": Invalid JSON\n" +
+		filepath.Join(dir, "b.yml") + ": Invalid YAML"))
+}
+
+func Test_ReadSimulationDirectory_ErrorsWhenThereAreNoSimulations(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "simulations")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+
+	_, err = ReadSimulationDirectory(dir)
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("No JSON or YAML simulations found in " + dir))
+}
@@ -11,9 +11,9 @@ import (
 func Test_FlushCache_GetsMiddlewareFromHoverfly(t *testing.T) {
 	RegisterTestingT(t)
 	hoverfly.DeleteSimulation()
-	hoverfly.PutSimulation(v2.SimulationViewV5{
-		v2.DataViewV5{
-			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
+	hoverfly.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
 				{
 					RequestMatcher: v2.RequestMatcherViewV5{
 						Method: []v2.MatcherViewV5{
@@ -29,7 +29,7 @@ func Test_FlushCache_GetsMiddlewareFromHoverfly(t *testing.T) {
 							},
 						},
 					},
-					Response: v2.ResponseDetailsViewV5{
+					Response: v2.ResponseDetailsViewV6{
 						Status: 200,
 						Body:   `{"binary": "test-binary", "script": "test.script", "remote": "http://test.com"}`,
 					},
@@ -58,10 +58,10 @@ func Test_FlushCache_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
 	RegisterTestingT(t)
 
 	hoverfly.DeleteSimulation()
-	hoverfly.PutSimulation(v2.SimulationViewV5{
-		v2.DataViewV5{
-			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
-				v2.RequestMatcherResponsePairViewV5{
+	hoverfly.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				v2.RequestMatcherResponsePairViewV6{
 					RequestMatcher: v2.RequestMatcherViewV5{
 						Method: []v2.MatcherViewV5{
 							{
@@ -76,7 +76,7 @@ func Test_FlushCache_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
 							},
 						},
 					},
-					Response: v2.ResponseDetailsViewV5{
+					Response: v2.ResponseDetailsViewV6{
 						Status: 400,
 						Body:   "{\"error\":\"test error\"}",
 					},
@@ -3,6 +3,7 @@ package wrapper
 import (
 	"encoding/json"
 	"io/ioutil"
+	"net/url"
 
 	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
 	"github.com/SpectoLabs/hoverfly/hoverctl/configuration"
@@ -35,6 +36,14 @@ func GetAllDiffs(target configuration.Target) ([]v2.ResponseDiffForRequestView,
 	return diffs.Diff, nil
 }
 
+// GetDiffReport gets the diffs as a junit or markdown report, with a test case for each endpoint
+// of the simulation
+func GetDiffReport(target configuration.Target, format string) ([]byte, error) {
+	query := url.Values{"format": []string{format}}
+
+	return export(target, v2ApiDiff+"?"+query.Encode(), "Could not retrieve diffs")
+}
+
 func DeleteAllDiffs(target configuration.Target) error {
 
 	_, err := doRequest(target, "DELETE", v2ApiDiff, "", nil)
@@ -0,0 +1,96 @@
+package wrapper
+
+import (
+	"testing"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/core/matching/matchers"
+	. "github.com/onsi/gomega"
+)
+
+func Test_GetDiffReport_RequestsReportFormat(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				v2.RequestMatcherResponsePairViewV6{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "GET",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/diff",
+							},
+						},
+						Query: &v2.QueryMatcherViewV5{
+							"format": []v2.MatcherViewV5{
+								{
+									Matcher: matchers.Exact,
+									Value:   "markdown",
+								},
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV6{
+						Status: 200,
+						Body:   "# Hoverfly diff report\n",
+					},
+				},
+			},
+		},
+		v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	report, err := GetDiffReport(target, "markdown")
+	Expect(err).To(BeNil())
+
+	Expect(string(report)).To(Equal("# Hoverfly diff report\n"))
+}
+
+func Test_GetDiffReport_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				v2.RequestMatcherResponsePairViewV6{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "GET",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/diff",
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV6{
+						Status: 400,
+						Body:   "{\"error\":\"Unknown format html, expected json, junit or markdown\"}",
+					},
+				},
+			},
+		},
+		v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	_, err := GetDiffReport(target, "html")
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not retrieve diffs\n\nUnknown format html, expected json, junit or markdown"))
+}
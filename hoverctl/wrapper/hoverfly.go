@@ -23,7 +23,9 @@ import (
 
 const (
 	v2ApiSimulation  = "/api/v2/simulation"
+	v2ApiSimulations = "/api/v2/simulations"
 	v2ApiMode        = "/api/v2/hoverfly/mode"
+	v2ApiModeRoutes  = "/api/v2/hoverfly/mode/routes"
 	v2ApiDestination = "/api/v2/hoverfly/destination"
 	v2ApiState       = "/api/v2/state"
 	v2ApiMiddleware  = "/api/v2/hoverfly/middleware"
@@ -32,6 +34,7 @@ const (
 	v2ApiLogs        = "/api/v2/logs"
 	v2ApiHoverfly    = "/api/v2/hoverfly"
 	v2ApiDiff        = "/api/v2/diff"
+	v2ApiJournal     = "/api/v2/journal"
 
 	v2ApiShutdown = "/api/v2/shutdown"
 	v2ApiHealth   = "/api/health"
@@ -36,7 +36,8 @@ func GetMode(target configuration.Target) (*v2.ModeView, error) {
 func SetModeWithArguments(target configuration.Target, modeView v2.ModeView) (string, error) {
 	if modeView.Mode != "simulate" && modeView.Mode != "capture" &&
 		modeView.Mode != "modify" && modeView.Mode != "synthesize" &&
-		modeView.Mode != "spy" && modeView.Mode != "diff" {
+		modeView.Mode != "spy" && modeView.Mode != "diff" &&
+		modeView.Mode != "hybrid" && modeView.Mode != "shadow" {
 		return "", errors.New(modeView.Mode + " is not a valid mode")
 	}
 	bytes, err := json.Marshal(modeView)
@@ -63,3 +64,64 @@ func SetModeWithArguments(target configuration.Target, modeView v2.ModeView) (st
 
 	return modeViewResponse.Mode, nil
 }
+
+// GetModeRoutes will go the mode routes endpoint in Hoverfly, parse the JSON response and return the routes
+func GetModeRoutes(target configuration.Target) (*v2.ModeRoutesView, error) {
+	response, err := doRequest(target, "GET", v2ApiModeRoutes, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	err = handleResponseError(response, "Could not retrieve mode routes")
+	if err != nil {
+		return nil, err
+	}
+
+	var routesView v2.ModeRoutesView
+
+	err = UnmarshalToInterface(response, &routesView)
+	if err != nil {
+		return nil, err
+	}
+
+	return &routesView, nil
+}
+
+// AddModeRoute will go the mode routes endpoint in Hoverfly, sending JSON that will add a route after the existing ones
+func AddModeRoute(target configuration.Target, routeView v2.ModeRouteView) (*v2.ModeRoutesView, error) {
+	bytes, err := json.Marshal(routeView)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := doRequest(target, "POST", v2ApiModeRoutes, string(bytes), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = handleResponseError(response, "Could not add mode route")
+	if err != nil {
+		return nil, err
+	}
+
+	var routesView v2.ModeRoutesView
+
+	err = UnmarshalToInterface(response, &routesView)
+	if err != nil {
+		return nil, err
+	}
+
+	return &routesView, nil
+}
+
+// DeleteModeRoutes will go the mode routes endpoint in Hoverfly, deleting every route
+func DeleteModeRoutes(target configuration.Target) error {
+	response, err := doRequest(target, "DELETE", v2ApiModeRoutes, "", nil)
+	if err != nil {
+		return err
+	}
+
+	return handleResponseError(response, "Could not delete mode routes")
+}
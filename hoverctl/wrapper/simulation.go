@@ -8,6 +8,7 @@ import (
 
 	"fmt"
 	"net/url"
+	"strings"
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
@@ -20,6 +21,62 @@ func ExportSimulation(target configuration.Target, urlPattern string) ([]byte, e
 	if len(urlPattern) > 0 {
 		requestUrl = fmt.Sprintf("%s?urlPattern=%s", requestUrl, url.QueryEscape(urlPattern))
 	}
+
+	return exportJson(target, requestUrl, "Could not retrieve simulation")
+}
+
+// ExportSimulationAsHar exports the simulation as a HAR file. Only the
+// values of exact matchers are used to build the requests.
+func ExportSimulationAsHar(target configuration.Target, urlPattern string) ([]byte, error) {
+	query := url.Values{"format": []string{"har"}}
+	if len(urlPattern) > 0 {
+		query.Set("urlPattern", urlPattern)
+	}
+
+	return exportJson(target, v2ApiSimulation+"?"+query.Encode(), "Could not retrieve simulation")
+}
+
+// ExportSimulationAsWireMock exports the pairs of the simulation as WireMock mappings
+func ExportSimulationAsWireMock(target configuration.Target, urlPattern string) ([]byte, error) {
+	query := url.Values{"format": []string{"wiremock"}}
+	if len(urlPattern) > 0 {
+		query.Set("urlPattern", urlPattern)
+	}
+
+	return exportJson(target, v2ApiSimulation+"?"+query.Encode(), "Could not retrieve simulation")
+}
+
+// ExportSimulationAsCurl exports a shell script with a curl command for each pair with only exact
+// matchers, sending its request through the proxy of the target
+func ExportSimulationAsCurl(target configuration.Target, urlPattern string) ([]byte, error) {
+	query := url.Values{"format": []string{"curl"}}
+	query.Set("proxy", fmt.Sprintf("http://%s:%v", target.Host, target.ProxyPort))
+	if len(urlPattern) > 0 {
+		query.Set("urlPattern", urlPattern)
+	}
+
+	return export(target, v2ApiSimulation+"?"+query.Encode(), "Could not retrieve simulation")
+}
+
+// ExportSimulationAsGoHttpTest exports a Go file in the given package with an httptest.Server
+// which responds as the simple pairs of the simulation do
+func ExportSimulationAsGoHttpTest(target configuration.Target, urlPattern, packageName string) ([]byte, error) {
+	query := url.Values{"format": []string{"go-httptest"}}
+	if len(packageName) > 0 {
+		query.Set("package", packageName)
+	}
+	if len(urlPattern) > 0 {
+		query.Set("urlPattern", urlPattern)
+	}
+
+	return export(target, v2ApiSimulation+"?"+query.Encode(), "Could not retrieve simulation")
+}
+
+func ExportJournalAsHar(target configuration.Target) ([]byte, error) {
+	return exportJson(target, v2ApiJournal+"?format=har", "Could not retrieve journal")
+}
+
+func export(target configuration.Target, requestUrl, errorMessage string) ([]byte, error) {
 	response, err := doRequest(target, "GET", requestUrl, "", nil)
 	if err != nil {
 		return nil, err
@@ -27,7 +84,7 @@ func ExportSimulation(target configuration.Target, urlPattern string) ([]byte, e
 
 	defer response.Body.Close()
 
-	err = handleResponseError(response, "Could not retrieve simulation")
+	err = handleResponseError(response, errorMessage)
 	if err != nil {
 		return nil, err
 	}
@@ -38,6 +95,15 @@ func ExportSimulation(target configuration.Target, urlPattern string) ([]byte, e
 		return nil, errors.New("Could not export from Hoverfly")
 	}
 
+	return body, nil
+}
+
+func exportJson(target configuration.Target, requestUrl, errorMessage string) ([]byte, error) {
+	body, err := export(target, requestUrl, errorMessage)
+	if err != nil {
+		return nil, err
+	}
+
 	var jsonBytes bytes.Buffer
 	err = json.Indent(&jsonBytes, body, "", "\t")
 	if err != nil {
@@ -49,7 +115,143 @@ func ExportSimulation(target configuration.Target, urlPattern string) ([]byte, e
 }
 
 func ImportSimulation(target configuration.Target, simulationData string) error {
-	response, err := doRequest(target, "PUT", v2ApiSimulation, simulationData, nil)
+	return importSimulation(target, "PUT", v2ApiSimulation, simulationData, nil)
+}
+
+// AppendSimulation adds the pairs and delays of the simulation to those
+// already in Hoverfly rather than replacing them
+func AppendSimulation(target configuration.Target, simulationData string) error {
+	return importSimulation(target, "POST", v2ApiSimulation, simulationData, nil)
+}
+
+// ImportYamlSimulation replaces the simulation with one written as YAML
+// rather than JSON
+func ImportYamlSimulation(target configuration.Target, simulationData string) error {
+	return importSimulation(target, "PUT", v2ApiSimulation, simulationData, yamlHeaders)
+}
+
+func AppendYamlSimulation(target configuration.Target, simulationData string) error {
+	return importSimulation(target, "POST", v2ApiSimulation, simulationData, yamlHeaders)
+}
+
+// ImportHar replaces the simulation with the entries of a HAR file. The
+// headers whitelist and stateful arguments work as they do in capture mode.
+func ImportHar(target configuration.Target, harData string, arguments v2.ModeArgumentsView) error {
+	return importSimulation(target, "PUT", harImportUrl(arguments), harData, nil)
+}
+
+func AppendHar(target configuration.Target, harData string, arguments v2.ModeArgumentsView) error {
+	return importSimulation(target, "POST", harImportUrl(arguments), harData, nil)
+}
+
+// ImportOpenApi replaces the simulation with a pair for each operation in
+// an OpenAPI or Swagger specification, which may be either JSON or YAML
+func ImportOpenApi(target configuration.Target, specification string) error {
+	return importSimulation(target, "PUT", v2ApiSimulation+"?format=openapi", specification, nil)
+}
+
+func AppendOpenApi(target configuration.Target, specification string) error {
+	return importSimulation(target, "POST", v2ApiSimulation+"?format=openapi", specification, nil)
+}
+
+// ImportWireMock replaces the simulation with one converted from WireMock
+// mappings. Any unsupported parts of the mappings are printed as warnings.
+func ImportWireMock(target configuration.Target, mappings string) error {
+	return importSimulation(target, "PUT", v2ApiSimulation+"?format=wiremock", mappings, nil)
+}
+
+func AppendWireMock(target configuration.Target, mappings string) error {
+	return importSimulation(target, "POST", v2ApiSimulation+"?format=wiremock", mappings, nil)
+}
+
+// LintSimulation looks for pairs which are never used or do not behave as intended. Without
+// simulation data the simulation Hoverfly is using is linted, otherwise the given simulation
+// is linted without being imported.
+func LintSimulation(target configuration.Target, simulationData string, yaml bool) (*v2.SimulationLintView, error) {
+	method := "GET"
+	var headers map[string]string
+	if simulationData != "" {
+		method = "POST"
+		if yaml {
+			headers = yamlHeaders
+		}
+	}
+
+	response, err := doRequest(target, method, v2ApiSimulation+"/lint", simulationData, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	err = handleResponseError(response, "Could not lint simulation")
+	if err != nil {
+		return nil, err
+	}
+
+	var lintView v2.SimulationLintView
+
+	err = UnmarshalToInterface(response, &lintView)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lintView, nil
+}
+
+// GetCoverage returns how many times each pair and global delay has been used since Hoverfly
+// started or the coverage was last reset
+func GetCoverage(target configuration.Target) (*v2.SimulationCoverageView, error) {
+	response, err := doRequest(target, "GET", v2ApiSimulation+"/coverage", "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	err = handleResponseError(response, "Could not get simulation coverage")
+	if err != nil {
+		return nil, err
+	}
+
+	var coverageView v2.SimulationCoverageView
+
+	err = UnmarshalToInterface(response, &coverageView)
+	if err != nil {
+		return nil, err
+	}
+
+	return &coverageView, nil
+}
+
+// ResetCoverage sets the hits of every pair and global delay back to zero
+func ResetCoverage(target configuration.Target) error {
+	response, err := doRequest(target, "DELETE", v2ApiSimulation+"/coverage", "", nil)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	return handleResponseError(response, "Could not reset simulation coverage")
+}
+
+var yamlHeaders = map[string]string{"Content-Type": "application/x-yaml"}
+
+func harImportUrl(arguments v2.ModeArgumentsView) string {
+	query := url.Values{"format": []string{"har"}}
+	if len(arguments.Headers) > 0 {
+		query.Set("headersWhitelist", strings.Join(arguments.Headers, ","))
+	}
+	if arguments.Stateful {
+		query.Set("stateful", "true")
+	}
+
+	return v2ApiSimulation + "?" + query.Encode()
+}
+
+func importSimulation(target configuration.Target, method, requestUrl, simulationData string, headers map[string]string) error {
+	response, err := doRequest(target, method, requestUrl, simulationData, headers)
 	if err != nil {
 		return err
 	}
@@ -88,3 +290,33 @@ func DeleteSimulations(target configuration.Target) error {
 
 	return nil
 }
+
+// VerifySimulation sends the request of each pair with exact matchers in the simulation Hoverfly is
+// using to the real service, and compares the response with the response of the pair
+func VerifySimulation(target configuration.Target, diffRules *v2.DiffRulesView) (*v2.SimulationVerificationView, error) {
+	requestBody, err := json.Marshal(v2.VerifySimulationView{DiffRules: diffRules})
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := doRequest(target, "POST", v2ApiSimulation+"/verify", string(requestBody), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	err = handleResponseError(response, "Could not verify simulation")
+	if err != nil {
+		return nil, err
+	}
+
+	var verificationView v2.SimulationVerificationView
+
+	err = UnmarshalToInterface(response, &verificationView)
+	if err != nil {
+		return nil, err
+	}
+
+	return &verificationView, nil
+}
@@ -12,10 +12,10 @@ func Test_ExportSimulation_GetsModeFromHoverfly(t *testing.T) {
 	RegisterTestingT(t)
 
 	hoverfly.DeleteSimulation()
-	hoverfly.PutSimulation(v2.SimulationViewV5{
-		v2.DataViewV5{
-			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
-				v2.RequestMatcherResponsePairViewV5{
+	hoverfly.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				v2.RequestMatcherResponsePairViewV6{
 					RequestMatcher: v2.RequestMatcherViewV5{
 						Method: []v2.MatcherViewV5{
 							{
@@ -30,7 +30,7 @@ func Test_ExportSimulation_GetsModeFromHoverfly(t *testing.T) {
 							},
 						},
 					},
-					Response: v2.ResponseDetailsViewV5{
+					Response: v2.ResponseDetailsViewV6{
 						Status: 200,
 						Body:   `{"simulation": true}`,
 					},
@@ -52,10 +52,10 @@ func Test_ExportSimulation_WithUrlPattern(t *testing.T) {
 	RegisterTestingT(t)
 
 	hoverfly.DeleteSimulation()
-	hoverfly.PutSimulation(v2.SimulationViewV5{
-		v2.DataViewV5{
-			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
-				v2.RequestMatcherResponsePairViewV5{
+	hoverfly.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				v2.RequestMatcherResponsePairViewV6{
 					RequestMatcher: v2.RequestMatcherViewV5{
 						Method: []v2.MatcherViewV5{
 							{
@@ -78,7 +78,7 @@ func Test_ExportSimulation_WithUrlPattern(t *testing.T) {
 							},
 						},
 					},
-					Response: v2.ResponseDetailsViewV5{
+					Response: v2.ResponseDetailsViewV6{
 						Status: 200,
 						Body:   `{"simulation": true}`,
 					},
@@ -109,10 +109,10 @@ func Test_ExportSimulation_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
 	RegisterTestingT(t)
 
 	hoverfly.DeleteSimulation()
-	hoverfly.PutSimulation(v2.SimulationViewV5{
-		v2.DataViewV5{
-			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
-				v2.RequestMatcherResponsePairViewV5{
+	hoverfly.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				v2.RequestMatcherResponsePairViewV6{
 					RequestMatcher: v2.RequestMatcherViewV5{
 						Method: []v2.MatcherViewV5{
 							{
@@ -127,7 +127,7 @@ func Test_ExportSimulation_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
 							},
 						},
 					},
-					Response: v2.ResponseDetailsViewV5{
+					Response: v2.ResponseDetailsViewV6{
 						Status: 400,
 						Body:   "{\"error\":\"test error\"}",
 					},
@@ -148,10 +148,10 @@ func Test_ImportSimulation_SendsCorrectHTTPRequest(t *testing.T) {
 	RegisterTestingT(t)
 
 	hoverfly.DeleteSimulation()
-	hoverfly.PutSimulation(v2.SimulationViewV5{
-		v2.DataViewV5{
-			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
-				v2.RequestMatcherResponsePairViewV5{
+	hoverfly.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				v2.RequestMatcherResponsePairViewV6{
 					RequestMatcher: v2.RequestMatcherViewV5{
 						Method: []v2.MatcherViewV5{
 							{
@@ -172,7 +172,7 @@ func Test_ImportSimulation_SendsCorrectHTTPRequest(t *testing.T) {
 							},
 						},
 					},
-					Response: v2.ResponseDetailsViewV5{
+					Response: v2.ResponseDetailsViewV6{
 						Status: 200,
 						Body:   `{"simulation": true}`,
 					},
@@ -201,10 +201,10 @@ func Test_ImportSimulation_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
 	RegisterTestingT(t)
 
 	hoverfly.DeleteSimulation()
-	hoverfly.PutSimulation(v2.SimulationViewV5{
-		v2.DataViewV5{
-			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
-				v2.RequestMatcherResponsePairViewV5{
+	hoverfly.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				v2.RequestMatcherResponsePairViewV6{
 					RequestMatcher: v2.RequestMatcherViewV5{
 						Method: []v2.MatcherViewV5{
 							{
@@ -219,7 +219,7 @@ func Test_ImportSimulation_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
 							},
 						},
 					},
-					Response: v2.ResponseDetailsViewV5{
+					Response: v2.ResponseDetailsViewV6{
 						Status: 400,
 						Body:   "{\"error\":\"test error\"}",
 					},
@@ -236,14 +236,143 @@ func Test_ImportSimulation_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
 	Expect(err.Error()).To(Equal("Could not import simulation\n\ntest error"))
 }
 
+func Test_ImportYamlSimulation_SendsYamlContentType(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				v2.RequestMatcherResponsePairViewV6{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "PUT",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/simulation",
+							},
+						},
+						Headers: map[string][]v2.MatcherViewV5{
+							"Content-Type": []v2.MatcherViewV5{
+								{
+									Matcher: matchers.Exact,
+									Value:   "application/x-yaml",
+								},
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV6{
+						Status: 200,
+						Body:   `{"simulation": true}`,
+					},
+				},
+			},
+		},
+		v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	err := ImportYamlSimulation(target, "simulation: true")
+	Expect(err).To(BeNil())
+}
+
+func Test_AppendSimulation_SendsCorrectHTTPRequest(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				v2.RequestMatcherResponsePairViewV6{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "POST",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/simulation",
+							},
+						},
+						Body: []v2.MatcherViewV5{
+							{
+								Matcher: "json",
+								Value:   `{"simulation": true}`,
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV6{
+						Status: 200,
+						Body:   `{"simulation": true}`,
+					},
+				},
+			},
+		},
+		v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	err := AppendSimulation(target, `{"simulation": true}`)
+	Expect(err).To(BeNil())
+}
+
+func Test_AppendSimulation_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				v2.RequestMatcherResponsePairViewV6{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "POST",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/simulation",
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV6{
+						Status: 400,
+						Body:   "{\"error\":\"test error\"}",
+					},
+				},
+			},
+		},
+		v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	err := AppendSimulation(target, "")
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not import simulation\n\ntest error"))
+}
+
 func Test_DeleteSimulations_SendsCorrectHTTPRequest(t *testing.T) {
 	RegisterTestingT(t)
 
 	hoverfly.DeleteSimulation()
-	hoverfly.PutSimulation(v2.SimulationViewV5{
-		v2.DataViewV5{
-			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
-				v2.RequestMatcherResponsePairViewV5{
+	hoverfly.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				v2.RequestMatcherResponsePairViewV6{
 					RequestMatcher: v2.RequestMatcherViewV5{
 						Method: []v2.MatcherViewV5{
 							{
@@ -258,7 +387,7 @@ func Test_DeleteSimulations_SendsCorrectHTTPRequest(t *testing.T) {
 							},
 						},
 					},
-					Response: v2.ResponseDetailsViewV5{
+					Response: v2.ResponseDetailsViewV6{
 						Status: 200,
 						Body:   `{"simulation": true}`,
 					},
@@ -287,10 +416,10 @@ func Test_DeleteSimulations_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
 	RegisterTestingT(t)
 
 	hoverfly.DeleteSimulation()
-	hoverfly.PutSimulation(v2.SimulationViewV5{
-		v2.DataViewV5{
-			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV5{
-				v2.RequestMatcherResponsePairViewV5{
+	hoverfly.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				v2.RequestMatcherResponsePairViewV6{
 					RequestMatcher: v2.RequestMatcherViewV5{
 						Method: []v2.MatcherViewV5{
 							{
@@ -305,7 +434,7 @@ func Test_DeleteSimulations_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
 							},
 						},
 					},
-					Response: v2.ResponseDetailsViewV5{
+					Response: v2.ResponseDetailsViewV6{
 						Status: 400,
 						Body:   "{\"error\":\"test error\"}",
 					},
@@ -321,3 +450,926 @@ func Test_DeleteSimulations_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
 	Expect(err).ToNot(BeNil())
 	Expect(err.Error()).To(Equal("Could not delete simulation\n\ntest error"))
 }
+
+func Test_ExportSimulationAsHar_RequestsHarFormat(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				v2.RequestMatcherResponsePairViewV6{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "GET",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/simulation",
+							},
+						},
+						Query: &v2.QueryMatcherViewV5{
+							"format": []v2.MatcherViewV5{
+								{
+									Matcher: matchers.Exact,
+									Value:   "har",
+								},
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV6{
+						Status: 200,
+						Body:   `{"log": true}`,
+					},
+				},
+			},
+		},
+		v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	har, err := ExportSimulationAsHar(target, "")
+	Expect(err).To(BeNil())
+
+	Expect(string(har)).To(Equal("{\n\t\"log\": true\n}"))
+}
+
+func Test_ExportJournalAsHar_RequestsHarFormat(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				v2.RequestMatcherResponsePairViewV6{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "GET",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/journal",
+							},
+						},
+						Query: &v2.QueryMatcherViewV5{
+							"format": []v2.MatcherViewV5{
+								{
+									Matcher: matchers.Exact,
+									Value:   "har",
+								},
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV6{
+						Status: 200,
+						Body:   `{"log": true}`,
+					},
+				},
+			},
+		},
+		v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	har, err := ExportJournalAsHar(target)
+	Expect(err).To(BeNil())
+
+	Expect(string(har)).To(Equal("{\n\t\"log\": true\n}"))
+}
+
+func Test_ImportHar_SendsCorrectHTTPRequest(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				v2.RequestMatcherResponsePairViewV6{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "PUT",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/simulation",
+							},
+						},
+						Query: &v2.QueryMatcherViewV5{
+							"format": []v2.MatcherViewV5{
+								{
+									Matcher: matchers.Exact,
+									Value:   "har",
+								},
+							},
+							"headersWhitelist": []v2.MatcherViewV5{
+								{
+									Matcher: matchers.Exact,
+									Value:   "Accept,Authorization",
+								},
+							},
+							"stateful": []v2.MatcherViewV5{
+								{
+									Matcher: matchers.Exact,
+									Value:   "true",
+								},
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV6{
+						Status: 200,
+						Body:   `{"simulation": true}`,
+					},
+				},
+			},
+		},
+		v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	err := ImportHar(target, `{"log": true}`, v2.ModeArgumentsView{
+		Headers:  []string{"Accept", "Authorization"},
+		Stateful: true,
+	})
+	Expect(err).To(BeNil())
+}
+
+func Test_AppendHar_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				v2.RequestMatcherResponsePairViewV6{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "POST",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/simulation",
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV6{
+						Status: 400,
+						Body:   "{\"error\":\"test error\"}",
+					},
+				},
+			},
+		},
+		v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	err := AppendHar(target, "", v2.ModeArgumentsView{})
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not import simulation\n\ntest error"))
+}
+
+func Test_ImportOpenApi_SendsCorrectHTTPRequest(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				v2.RequestMatcherResponsePairViewV6{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "PUT",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/simulation",
+							},
+						},
+						Query: &v2.QueryMatcherViewV5{
+							"format": []v2.MatcherViewV5{
+								{
+									Matcher: matchers.Exact,
+									Value:   "openapi",
+								},
+							},
+						},
+						Body: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "openapi: 3.0.0",
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV6{
+						Status: 200,
+						Body:   `{"simulation": true}`,
+					},
+				},
+			},
+		},
+		v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	err := ImportOpenApi(target, "openapi: 3.0.0")
+	Expect(err).To(BeNil())
+}
+
+func Test_AppendOpenApi_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				v2.RequestMatcherResponsePairViewV6{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "POST",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/simulation",
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV6{
+						Status: 400,
+						Body:   "{\"error\":\"test error\"}",
+					},
+				},
+			},
+		},
+		v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	err := AppendOpenApi(target, "")
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not import simulation\n\ntest error"))
+}
+
+func Test_ImportWireMock_SendsCorrectHTTPRequest(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				v2.RequestMatcherResponsePairViewV6{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "PUT",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/simulation",
+							},
+						},
+						Query: &v2.QueryMatcherViewV5{
+							"format": []v2.MatcherViewV5{
+								{
+									Matcher: matchers.Exact,
+									Value:   "wiremock",
+								},
+							},
+						},
+						Body: []v2.MatcherViewV5{
+							{
+								Matcher: "json",
+								Value:   `{"mappings": []}`,
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV6{
+						Status: 200,
+						Body:   `{"simulation": true}`,
+					},
+				},
+			},
+		},
+		v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	err := ImportWireMock(target, `{"mappings": []}`)
+	Expect(err).To(BeNil())
+}
+
+func Test_ExportSimulationAsWireMock_RequestsWireMockFormat(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				v2.RequestMatcherResponsePairViewV6{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "GET",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/simulation",
+							},
+						},
+						Query: &v2.QueryMatcherViewV5{
+							"format": []v2.MatcherViewV5{
+								{
+									Matcher: matchers.Exact,
+									Value:   "wiremock",
+								},
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV6{
+						Status: 200,
+						Body:   `{"mappings": []}`,
+					},
+				},
+			},
+		},
+		v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	mappings, err := ExportSimulationAsWireMock(target, "")
+	Expect(err).To(BeNil())
+
+	Expect(string(mappings)).To(Equal("{\n\t\"mappings\": []\n}"))
+}
+
+func Test_ExportSimulationAsCurl_RequestsCurlFormatThroughTargetProxy(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				v2.RequestMatcherResponsePairViewV6{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "GET",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/simulation",
+							},
+						},
+						Query: &v2.QueryMatcherViewV5{
+							"format": []v2.MatcherViewV5{
+								{
+									Matcher: matchers.Exact,
+									Value:   "curl",
+								},
+							},
+							"proxy": []v2.MatcherViewV5{
+								{
+									Matcher: matchers.Exact,
+									Value:   "http://localhost:0",
+								},
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV6{
+						Status: 200,
+						Body:   `#!/bin/sh\ncurl`,
+					},
+				},
+			},
+		},
+		v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	script, err := ExportSimulationAsCurl(target, "")
+	Expect(err).To(BeNil())
+
+	Expect(string(script)).To(Equal(`#!/bin/sh\ncurl`))
+}
+
+func Test_ExportSimulationAsGoHttpTest_RequestsGoHttpTestFormatWithPackage(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				v2.RequestMatcherResponsePairViewV6{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "GET",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/simulation",
+							},
+						},
+						Query: &v2.QueryMatcherViewV5{
+							"format": []v2.MatcherViewV5{
+								{
+									Matcher: matchers.Exact,
+									Value:   "go-httptest",
+								},
+							},
+							"package": []v2.MatcherViewV5{
+								{
+									Matcher: matchers.Exact,
+									Value:   "fixtures",
+								},
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV6{
+						Status: 200,
+						Body:   `package fixtures`,
+					},
+				},
+			},
+		},
+		v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	source, err := ExportSimulationAsGoHttpTest(target, "", "fixtures")
+	Expect(err).To(BeNil())
+
+	Expect(string(source)).To(Equal("package fixtures"))
+}
+
+func Test_ExportSimulationAsGoHttpTest_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				v2.RequestMatcherResponsePairViewV6{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "GET",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/simulation",
+							},
+						},
+						Query: &v2.QueryMatcherViewV5{
+							"format": []v2.MatcherViewV5{
+								{
+									Matcher: matchers.Exact,
+									Value:   "go-httptest",
+								},
+							},
+							"package": []v2.MatcherViewV5{
+								{
+									Matcher: matchers.Exact,
+									Value:   "not-valid",
+								},
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV6{
+						Status: 400,
+						Body:   `{"error": "not-valid is not a valid Go package name"}`,
+					},
+				},
+			},
+		},
+		v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	_, err := ExportSimulationAsGoHttpTest(target, "", "not-valid")
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not retrieve simulation\n\nnot-valid is not a valid Go package name"))
+}
+
+func Test_LintSimulation_GetsLintOfCurrentSimulation(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				v2.RequestMatcherResponsePairViewV6{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "GET",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/simulation/lint",
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV6{
+						Status: 200,
+						Body:   `{"issues": [{"type": "duplicate", "pair": 1, "message": "data.pairs[1] is a duplicate"}]}`,
+					},
+				},
+			},
+		},
+		v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	lintView, err := LintSimulation(target, "", false)
+	Expect(err).To(BeNil())
+
+	Expect(lintView.Issues).To(HaveLen(1))
+	Expect(lintView.Issues[0].Type).To(Equal("duplicate"))
+	Expect(lintView.Issues[0].Pair).To(Equal(1))
+	Expect(lintView.Issues[0].Message).To(Equal("data.pairs[1] is a duplicate"))
+}
+
+func Test_LintSimulation_PostsYamlSimulationToLint(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				v2.RequestMatcherResponsePairViewV6{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "POST",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/simulation/lint",
+							},
+						},
+						Headers: map[string][]v2.MatcherViewV5{
+							"Content-Type": []v2.MatcherViewV5{
+								{
+									Matcher: matchers.Exact,
+									Value:   "application/x-yaml",
+								},
+							},
+						},
+						Body: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "simulation: true",
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV6{
+						Status: 200,
+						Body:   `{"issues": []}`,
+					},
+				},
+			},
+		},
+		v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	lintView, err := LintSimulation(target, "simulation: true", true)
+	Expect(err).To(BeNil())
+
+	Expect(lintView.Issues).To(BeEmpty())
+}
+
+func Test_LintSimulation_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				v2.RequestMatcherResponsePairViewV6{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "POST",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/simulation/lint",
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV6{
+						Status: 400,
+						Body:   `{"error": "Invalid JSON"}`,
+					},
+				},
+			},
+		},
+		v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	_, err := LintSimulation(target, "not a simulation", false)
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not lint simulation\n\nInvalid JSON"))
+}
+
+func Test_LintSimulation_ErrorsWhen_HoverflyNotAccessible(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := LintSimulation(inaccessibleTarget, "", false)
+
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not connect to Hoverfly at something:1234"))
+}
+
+func Test_VerifySimulation_PostsDiffRulesAndGetsVerification(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				v2.RequestMatcherResponsePairViewV6{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "POST",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/simulation/verify",
+							},
+						},
+						Body: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Json,
+								Value:   `{"diffRules": {"ignoreHeaders": ["Date"]}}`,
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV6{
+						Status: 200,
+						Body:   `{"passed": 1, "failed": 0, "skipped": 1, "pairs": [{"pair": 0, "result": "passed"}, {"pair": 1, "result": "skipped", "message": "The response is templated"}]}`,
+					},
+				},
+			},
+		},
+		v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	verificationView, err := VerifySimulation(target, &v2.DiffRulesView{IgnoreHeaders: []string{"Date"}})
+	Expect(err).To(BeNil())
+
+	Expect(verificationView.Passed).To(Equal(1))
+	Expect(verificationView.Skipped).To(Equal(1))
+	Expect(verificationView.Pairs).To(HaveLen(2))
+	Expect(verificationView.Pairs[1].Result).To(Equal(v2.VerificationSkipped))
+	Expect(verificationView.Pairs[1].Message).To(Equal("The response is templated"))
+}
+
+func Test_VerifySimulation_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				v2.RequestMatcherResponsePairViewV6{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "POST",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/simulation/verify",
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV6{
+						Status: 400,
+						Body:   `{"error": "Diff tolerances cannot be negative"}`,
+					},
+				},
+			},
+		},
+		v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	_, err := VerifySimulation(target, &v2.DiffRulesView{Tolerance: -1})
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not verify simulation\n\nDiff tolerances cannot be negative"))
+}
+
+func Test_VerifySimulation_ErrorsWhen_HoverflyNotAccessible(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := VerifySimulation(inaccessibleTarget, nil)
+
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not connect to Hoverfly at something:1234"))
+}
+
+func Test_GetCoverage_GetsCoverageOfSimulation(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				v2.RequestMatcherResponsePairViewV6{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "GET",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/simulation/coverage",
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV6{
+						Status: 200,
+						Body:   `{"since": "2018-01-01T00:00:00Z", "pairs": [{"hits": 2, "id": "get-payment"}], "delays": [{"hits": 1, "urlPattern": "test.com", "delay": 100}]}`,
+					},
+				},
+			},
+		},
+		v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	coverageView, err := GetCoverage(target)
+	Expect(err).To(BeNil())
+
+	Expect(coverageView.Since).To(Equal("2018-01-01T00:00:00Z"))
+	Expect(coverageView.Pairs).To(HaveLen(1))
+	Expect(coverageView.Pairs[0].Hits).To(Equal(2))
+	Expect(coverageView.Pairs[0].Id).To(Equal("get-payment"))
+	Expect(coverageView.Delays).To(HaveLen(1))
+	Expect(coverageView.Delays[0].Hits).To(Equal(1))
+	Expect(coverageView.Delays[0].UrlPattern).To(Equal("test.com"))
+}
+
+func Test_GetCoverage_ErrorsWhen_HoverflyNotAccessible(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := GetCoverage(inaccessibleTarget)
+
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not connect to Hoverfly at something:1234"))
+}
+
+func Test_ResetCoverage_SendsDeleteToHoverfly(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				v2.RequestMatcherResponsePairViewV6{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "DELETE",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/simulation/coverage",
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV6{
+						Status: 200,
+						Body:   `{"since": "2018-01-01T00:00:00Z", "pairs": [], "delays": []}`,
+					},
+				},
+			},
+		},
+		v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	err := ResetCoverage(target)
+	Expect(err).To(BeNil())
+}
+
+func Test_ResetCoverage_ErrorsWhen_HoverflyReturnsNon200(t *testing.T) {
+	RegisterTestingT(t)
+
+	hoverfly.DeleteSimulation()
+	hoverfly.PutSimulation(v2.SimulationViewV6{
+		v2.DataViewV6{
+			RequestResponsePairs: []v2.RequestMatcherResponsePairViewV6{
+				v2.RequestMatcherResponsePairViewV6{
+					RequestMatcher: v2.RequestMatcherViewV5{
+						Method: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "DELETE",
+							},
+						},
+						Path: []v2.MatcherViewV5{
+							{
+								Matcher: matchers.Exact,
+								Value:   "/api/v2/simulation/coverage",
+							},
+						},
+					},
+					Response: v2.ResponseDetailsViewV6{
+						Status: 500,
+						Body:   `{"error": "Coverage is unavailable"}`,
+					},
+				},
+			},
+		},
+		v2.MetaView{
+			SchemaVersion: "v2",
+		},
+	})
+
+	err := ResetCoverage(target)
+	Expect(err).ToNot(BeNil())
+	Expect(err.Error()).To(Equal("Could not reset simulation coverage\n\nCoverage is unavailable"))
+}
@@ -0,0 +1,51 @@
+package wrapper
+
+import (
+	"encoding/json"
+
+	"github.com/SpectoLabs/hoverfly/core/handlers/v2"
+	"github.com/SpectoLabs/hoverfly/hoverctl/configuration"
+)
+
+// GetSimulations will return the names of the simulations stored in Hoverfly and which of them are active
+func GetSimulations(target configuration.Target) (*v2.SimulationsView, error) {
+	response, err := doRequest(target, "GET", v2ApiSimulations, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	err = handleResponseError(response, "Could not retrieve simulations")
+	if err != nil {
+		return nil, err
+	}
+
+	var simulationsView v2.SimulationsView
+
+	err = UnmarshalToInterface(response, &simulationsView)
+	if err != nil {
+		return nil, err
+	}
+
+	return &simulationsView, nil
+}
+
+// UseSimulations will make the named simulations active in Hoverfly, layered in the order given
+func UseSimulations(target configuration.Target, names []string) error {
+	bytes, err := json.Marshal(v2.SimulationsView{
+		Active: names,
+	})
+	if err != nil {
+		return err
+	}
+
+	response, err := doRequest(target, "PUT", v2ApiSimulations, string(bytes), nil)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	return handleResponseError(response, "Could not use simulations")
+}